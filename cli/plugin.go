@@ -0,0 +1,44 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/33cn/chain33/pluginmgr"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	wcom "github.com/33cn/chain33/wallet/common"
+	raftcmd "github.com/33cn/plugin/plugin/consensus/raft/commands"
+	"github.com/33cn/plugin/plugin/store/mpt"
+	snapshotcmd "github.com/33cn/plugin/plugin/store/snapshot/commands"
+	storecmd "github.com/33cn/plugin/plugin/store/tools/commands"
+	"github.com/spf13/cobra"
+)
+
+// cliCmds 非执行器模块(共识, 存储等)的命令行
+// chain33-cli的命令树只能通过pluginmgr.AddCmd扩展, 这里只在chain33-cli中注册, 节点不会加载, 不会出现在执行器列表中
+var cliCmds = []func() *cobra.Command{
+	raftcmd.RaftCmd,
+	mpt.Cmd,
+	snapshotcmd.SnapshotCmd,
+	storecmd.StoreCmd,
+}
+
+func init() {
+	pluginmgr.Register(cliPlugin{})
+}
+
+type cliPlugin struct{}
+
+func (cliPlugin) GetName() string                                             { return "chain33-cli" }
+func (cliPlugin) GetExecutorName() string                                     { return "" }
+func (cliPlugin) InitExec(cfg *types.Chain33Config)                           {}
+func (cliPlugin) InitWallet(wallet wcom.WalletOperate, sub map[string][]byte) {}
+func (cliPlugin) AddRPC(s rpctypes.RPCServer)                                 {}
+
+func (cliPlugin) AddCmd(rootCmd *cobra.Command) {
+	for _, cmd := range cliCmds {
+		rootCmd.AddCommand(cmd())
+	}
+}
@@ -64,6 +64,7 @@ type client struct {
 	subCfg          *subConfig
 	dldCfg          *downloadClient
 	blsSignCli      *blsClient
+	endpoints       *mainEndpoints
 	isClosed        int32
 	quit            chan struct{}
 }
@@ -90,6 +91,11 @@ type subConfig struct {
 	RmCommitParamMainHeight int64      `json:"rmCommitParamMainHeight,omitempty"`
 	JumpDownloadClose       bool       `json:"jumpDownloadClose,omitempty"`
 	Bls                     *blsConfig `json:"bls,omitempty"`
+	//多个主链节点grpc地址, 配置后交叉校验主链区块并自动切换节点
+	MainEndpoints    []string `json:"mainEndpoints,omitempty"`
+	EndpointCheckSec int64    `json:"endpointCheckSec,omitempty"`
+	EndpointMaxLag   int64    `json:"endpointMaxLag,omitempty"`
+	EndpointBanSec   int64    `json:"endpointBanSec,omitempty"`
 }
 
 // New function to init paracross env
@@ -168,6 +174,8 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 
 	para.blsSignCli = newBlsClient(para, &subcfg)
 
+	para.endpoints = newMainEndpoints(para, &subcfg)
+
 	c.SetChild(para)
 	return para
 }
@@ -214,6 +222,10 @@ func (client *client) SetQueueClient(c queue.Client) {
 	go client.blsSignCli.procAggregateTxs()
 	go client.blsSignCli.procLeaderSync()
 
+	if client.endpoints != nil {
+		client.wg.Add(1)
+		go client.endpoints.healthCheck()
+	}
 }
 
 func (client *client) InitBlock() {
@@ -226,6 +238,11 @@ func (client *client) InitBlock() {
 		panic(err)
 	}
 	client.grpcClient = grpcCli
+	if client.endpoints != nil {
+		if err = client.endpoints.connect(cfg); err != nil {
+			panic(err)
+		}
+	}
 
 	err = client.commitMsgClient.setSelfConsEnable()
 	if err != nil {
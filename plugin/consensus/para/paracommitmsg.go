@@ -592,7 +592,7 @@ func (client *commitMsgClient) sendCommitTxOut(tx *types.Transaction) error {
 	if tx == nil {
 		return nil
 	}
-	resp, err := client.paraClient.grpcClient.SendTransaction(context.Background(), tx)
+	resp, err := client.paraClient.mainClient().SendTransaction(context.Background(), tx)
 	if err != nil {
 		plog.Error("sendCommitTxOut send tx", "tx", common.ToHex(tx.Hash()), "err", err.Error())
 		return err
@@ -767,7 +767,7 @@ func (client *commitMsgClient) getGenesisNodeStatus() (*pt.ParacrossNodeStatus,
 // only sync once, as main usually sync, here just need the first sync status after start up
 func (client *commitMsgClient) mainSync() error {
 	req := &types.ReqNil{}
-	reply, err := client.paraClient.grpcClient.IsSync(context.Background(), req)
+	reply, err := client.paraClient.mainClient().IsSync(context.Background(), req)
 	if err != nil {
 		plog.Error("Paracross main is syncing", "err", err.Error())
 		return err
@@ -838,7 +838,7 @@ out:
 }
 
 func (client *commitMsgClient) GetProperFeeRate() error {
-	feeRate, err := client.paraClient.grpcClient.GetProperFee(context.Background(), &types.ReqProperFee{})
+	feeRate, err := client.paraClient.mainClient().GetProperFee(context.Background(), &types.ReqProperFee{})
 	if err != nil {
 		plog.Error("para commit.GetProperFee", "err", err.Error())
 		return err
@@ -914,7 +914,7 @@ func (client *commitMsgClient) getMainConsensusStatus() (*pt.ParacrossStatus, er
 	}
 	cfg := client.paraClient.GetAPI().GetConfig()
 	//去主链获取共识高度
-	reply, err := client.paraClient.grpcClient.QueryChain(context.Background(), &types.ChainExecutor{
+	reply, err := client.paraClient.mainClient().QueryChain(context.Background(), &types.ChainExecutor{
 		Driver:   "paracross",
 		FuncName: "GetTitleByHash",
 		Param:    types.Encode(&pt.ReqParacrossTitleHash{Title: cfg.GetTitle(), BlockHash: block.MainHash}),
@@ -373,7 +373,12 @@ func (client *client) requestFilterParaTxs(currSeq int64, count int64, preMainBl
 }
 
 func (client *client) RequestTx(currSeq int64, count int64, preMainBlockHash []byte) (*types.ParaTxDetails, error) {
-	return client.requestFilterParaTxs(currSeq, count, preMainBlockHash)
+	details, err := client.requestFilterParaTxs(currSeq, count, preMainBlockHash)
+	if err != nil || client.endpoints == nil {
+		return details, err
+	}
+	cfg := client.GetAPI().GetConfig()
+	return client.endpoints.verifyMainBlocks(cfg.GetTitle(), client.subCfg.MainBlockHashForkHeight, details)
 }
 
 func (client *client) processHashNotMatchError(currSeq int64, lastSeqMainHash []byte, err error) (int64, []byte, error) {
@@ -524,6 +529,10 @@ func (client *client) CreateBlock() {
 		return
 	}
 	currSeq := lastSeq + 1
+	var endpointVer int64
+	if client.endpoints != nil {
+		endpointVer = client.endpoints.getVersion()
+	}
 
 out:
 	for {
@@ -531,6 +540,16 @@ out:
 		case <-client.quit:
 			break out
 		default:
+			//切换主链节点后seq需要重新匹配
+			if client.endpoints != nil && client.endpoints.getVersion() != endpointVer {
+				endpointVer = client.endpoints.getVersion()
+				currSeq, lastSeqMainHash, err = client.rematchMainSeq(currSeq, lastSeqMainHash)
+				if err != nil {
+					plog.Error("Parachain CreateBlock rematch seq", "err", err)
+					time.Sleep(time.Millisecond * time.Duration(client.subCfg.WriteBlockMsec))
+					continue
+				}
+			}
 			count, err := client.getBatchSeqCount(currSeq)
 			if err != nil {
 				currSeq, lastSeqMainHash, err = client.processHashNotMatchError(currSeq, lastSeqMainHash, err)
@@ -544,6 +563,10 @@ out:
 			plog.Debug("Parachain CreateBlock", "curSeq", currSeq, "count", count, "lastSeqMainHash", common.ToHex(lastSeqMainHash))
 			paraTxs, err := client.RequestTx(currSeq, count, lastSeqMainHash)
 			if err != nil {
+				if isMainDataUnverified(err) {
+					time.Sleep(time.Millisecond * time.Duration(client.subCfg.WriteBlockMsec))
+					continue
+				}
 				currSeq, lastSeqMainHash, err = client.processHashNotMatchError(currSeq, lastSeqMainHash, err)
 				continue
 			}
@@ -0,0 +1,546 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package para
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/merkle"
+	"github.com/33cn/chain33/rpc/grpcclient"
+	"github.com/33cn/chain33/types"
+	pt "github.com/33cn/plugin/plugin/dapp/paracross/types"
+)
+
+const (
+	defaultEndpointCheckSec int64 = 10
+	defaultEndpointMaxLag   int64 = 10
+	defaultEndpointBanSec   int64 = 600
+	endpointMaxFailCount          = 3
+	endpointRspTimeout            = 10 * time.Second
+)
+
+type mainEndpoint struct {
+	addr          string
+	conn          types.Chain33Client
+	height        int64
+	latency       time.Duration
+	failCount     int32
+	errCount      int64
+	mismatchCount int64
+	bannedUntil   time.Time
+	lastErr       string
+}
+
+// 连续请求失败或数据校验失败被屏蔽的节点不可用
+func (e *mainEndpoint) isHealthy(now time.Time) bool {
+	return e.failCount < endpointMaxFailCount && !now.Before(e.bannedUntil)
+}
+
+// mainEndpoints 跟踪多个主链节点, 选择高度最高的节点同步, 并用其他节点交叉校验获取的主链区块
+type mainEndpoints struct {
+	paraClient    *client
+	addrs         []string
+	list          []*mainEndpoint
+	primary       int
+	version       int64
+	maxLag        int64
+	checkInterval time.Duration
+	banDuration   time.Duration
+
+	switchCount     int64
+	crossCheckCount int64
+	uncheckedCount  int64
+	mtx             sync.RWMutex
+}
+
+func newMainEndpoints(para *client, cfg *subConfig) *mainEndpoints {
+	if len(cfg.MainEndpoints) == 0 {
+		return nil
+	}
+	m := &mainEndpoints{
+		paraClient:    para,
+		addrs:         cfg.MainEndpoints,
+		maxLag:        defaultEndpointMaxLag,
+		checkInterval: time.Duration(defaultEndpointCheckSec) * time.Second,
+		banDuration:   time.Duration(defaultEndpointBanSec) * time.Second,
+	}
+	if cfg.EndpointMaxLag > 0 {
+		m.maxLag = cfg.EndpointMaxLag
+	}
+	if cfg.EndpointCheckSec > 0 {
+		m.checkInterval = time.Duration(cfg.EndpointCheckSec) * time.Second
+	}
+	if cfg.EndpointBanSec > 0 {
+		m.banDuration = time.Duration(cfg.EndpointBanSec) * time.Second
+	}
+	if len(m.addrs) < 3 {
+		plog.Warn("mainEndpoints less than 3, sync stops when switched to a backup node without other node to cross check",
+			"addrs", m.addrs)
+	}
+	return m
+}
+
+// 连接所有节点并选择初始的同步节点
+func (m *mainEndpoints) connect(cfg *types.Chain33Config) error {
+	list := make([]*mainEndpoint, 0, len(m.addrs))
+	for _, addr := range m.addrs {
+		conn, err := grpcclient.NewMainChainClient(cfg, addr)
+		if err != nil {
+			plog.Error("mainEndpoints connect", "addr", addr, "err", err)
+			return err
+		}
+		list = append(list, &mainEndpoint{addr: addr, conn: conn})
+	}
+	m.mtx.Lock()
+	m.list = list
+	m.mtx.Unlock()
+	m.checkHealth()
+	return nil
+}
+
+func (m *mainEndpoints) current() types.Chain33Client {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	if len(m.list) == 0 {
+		return nil
+	}
+	return m.list[m.primary].conn
+}
+
+// getVersion 切换同步节点后版本增加, 不同节点的seq不一致, 需要重新匹配
+func (m *mainEndpoints) getVersion() int64 {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.version
+}
+
+func (m *mainEndpoints) healthCheck() {
+	defer m.paraClient.wg.Done()
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.paraClient.quit:
+			return
+		case <-ticker.C:
+			m.checkHealth()
+		}
+	}
+}
+
+// checkHealth 并发获取各节点最新高度和响应时间
+func (m *mainEndpoints) checkHealth() {
+	m.mtx.RLock()
+	list := m.list
+	m.mtx.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, e := range list {
+		wg.Add(1)
+		go func(e *mainEndpoint) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), endpointRspTimeout)
+			defer cancel()
+			start := time.Now()
+			header, err := e.conn.GetLastHeader(ctx, &types.ReqNil{})
+			m.mtx.Lock()
+			defer m.mtx.Unlock()
+			if err != nil {
+				e.failCount++
+				e.errCount++
+				e.lastErr = err.Error()
+				plog.Info("mainEndpoints checkHealth", "addr", e.addr, "failCount", e.failCount, "err", err)
+				return
+			}
+			e.failCount = 0
+			e.height = header.GetHeight()
+			e.latency = time.Since(start)
+		}(e)
+	}
+	wg.Wait()
+
+	m.mtx.Lock()
+	m.selectPrimary(time.Now())
+	m.mtx.Unlock()
+}
+
+// selectPrimary 当前节点不可用或落后最高节点超过maxLag时, 切换到高度最高的可用节点
+func (m *mainEndpoints) selectPrimary(now time.Time) {
+	best := -1
+	for i, e := range m.list {
+		if !e.isHealthy(now) {
+			continue
+		}
+		if best < 0 || e.height > m.list[best].height ||
+			(e.height == m.list[best].height && e.latency < m.list[best].latency) {
+			best = i
+		}
+	}
+	if best < 0 || best == m.primary {
+		return
+	}
+	cur := m.list[m.primary]
+	if cur.isHealthy(now) && cur.height+m.maxLag >= m.list[best].height {
+		return
+	}
+	plog.Info("mainEndpoints switch", "from", cur.addr, "height", cur.height, "healthy", cur.isHealthy(now),
+		"to", m.list[best].addr, "height", m.list[best].height)
+	m.primary = best
+	m.version++
+	m.switchCount++
+}
+
+// ban 屏蔽数据校验失败的节点, 如果是当前节点则切换
+func (m *mainEndpoints) ban(e *mainEndpoint, reason string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	now := time.Now()
+	e.bannedUntil = now.Add(m.banDuration)
+	e.mismatchCount++
+	e.lastErr = reason
+	plog.Error("mainEndpoints ban", "addr", e.addr, "reason", reason, "until", e.bannedUntil)
+	if m.list[m.primary] == e {
+		m.selectPrimary(now)
+	}
+}
+
+// getVerifier 选择当前节点以外, 高度不落后的最高节点用于交叉校验
+func (m *mainEndpoints) getVerifier() (*mainEndpoint, *mainEndpoint) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	now := time.Now()
+	primary := m.list[m.primary]
+	var verifier *mainEndpoint
+	for _, e := range m.list {
+		if e == primary || !e.isHealthy(now) || e.height+m.maxLag < primary.height {
+			continue
+		}
+		if verifier == nil || e.height > verifier.height {
+			verifier = e
+		}
+	}
+	return primary, verifier
+}
+
+// isBackup 是否不是配置的第一个节点
+func (m *mainEndpoints) isBackup(e *mainEndpoint) bool {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.list[0] != e
+}
+
+// getOthers 除当前节点和校验节点外的可用节点, 数据不一致时投票
+func (m *mainEndpoints) getOthers(exclude ...*mainEndpoint) []*mainEndpoint {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	now := time.Now()
+	var others []*mainEndpoint
+	for _, e := range m.list {
+		if !e.isHealthy(now) {
+			continue
+		}
+		excluded := false
+		for _, ex := range exclude {
+			if e == ex {
+				excluded = true
+			}
+		}
+		if !excluded {
+			others = append(others, e)
+		}
+	}
+	return others
+}
+
+func (m *mainEndpoints) addCount(crossChecked, unchecked int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.crossCheckCount += int64(crossChecked)
+	m.uncheckedCount += int64(unchecked)
+}
+
+func (m *mainEndpoints) getHeight(e *mainEndpoint) int64 {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return e.height
+}
+
+func (m *mainEndpoints) fail(e *mainEndpoint, err error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	e.failCount++
+	e.errCount++
+	e.lastErr = err.Error()
+}
+
+func (m *mainEndpoints) updateHeight(e *mainEndpoint) {
+	ctx, cancel := context.WithTimeout(context.Background(), endpointRspTimeout)
+	defer cancel()
+	header, err := e.conn.GetLastHeader(ctx, &types.ReqNil{})
+	if err != nil {
+		return
+	}
+	m.mtx.Lock()
+	e.height = header.GetHeight()
+	m.mtx.Unlock()
+}
+
+// verifyMainBlocks 校验当前节点返回的主链区块, 区块头哈希和平行链交易的merkle证明需自洽,
+// 并和其他节点相同高度的区块一致, 超过校验节点高度的区块暂不处理
+func (m *mainEndpoints) verifyMainBlocks(title string, forkHeight int64, details *types.ParaTxDetails) (*types.ParaTxDetails, error) {
+	primary, verifier := m.getVerifier()
+	for _, detail := range details.Items {
+		if err := verifyParaTxDetail(title, forkHeight, detail); err != nil {
+			m.ban(primary, err.Error())
+			return nil, pt.ErrParaMainDataVerify
+		}
+	}
+	if verifier == nil {
+		//切换到备用节点后不接受未经交叉校验的区块, 只有配置的第一个节点可以单独同步
+		if m.isBackup(primary) {
+			plog.Debug("verifyMainBlocks no verifier for backup node", "primary", primary.addr)
+			return nil, pt.ErrParaMainNoVerifier
+		}
+		plog.Debug("verifyMainBlocks no verifier", "primary", primary.addr)
+		m.addCount(0, len(details.Items))
+		return details, nil
+	}
+
+	items := truncateMainBlocks(details.Items, m.getHeight(verifier))
+	if len(items) == 0 {
+		m.updateHeight(verifier)
+		items = truncateMainBlocks(details.Items, m.getHeight(verifier))
+		if len(items) == 0 {
+			return nil, pt.ErrParaWaitingNewSeq
+		}
+	}
+	blocks := addedMainBlocks(items)
+	if len(blocks) == 0 {
+		return &types.ParaTxDetails{Items: items}, nil
+	}
+	heights := make([]int64, 0, len(blocks))
+	for h := range blocks {
+		heights = append(heights, h)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+
+	verified, err := requestMainDigests(verifier, title, heights)
+	if err != nil {
+		//校验节点连续失败后不再被选择, 避免阻塞同步
+		m.fail(verifier, err)
+		plog.Error("verifyMainBlocks request verifier", "addr", verifier.addr, "err", err)
+		return nil, err
+	}
+	checked := 0
+	for _, h := range heights {
+		//校验节点返回的数据量有限制, 未返回的区块下次再校验
+		if verified[h] == nil {
+			items = truncateMainBlocks(items, h-1)
+			break
+		}
+		digest := calcMainBlockDigest(blocks[h])
+		if !bytes.Equal(digest, verified[h]) {
+			m.resolveMismatch(primary, verifier, title, h, digest, verified[h])
+			return nil, pt.ErrParaMainDataMismatch
+		}
+		checked++
+	}
+	if len(items) == 0 {
+		return nil, pt.ErrParaWaitingNewSeq
+	}
+	m.addCount(checked, 0)
+	return &types.ParaTxDetails{Items: items}, nil
+}
+
+// resolveMismatch 由其他节点投票, 屏蔽少数一方, 无法判定时等待重试
+func (m *mainEndpoints) resolveMismatch(primary, verifier *mainEndpoint, title string, height int64, primaryDigest, verifierDigest []byte) {
+	votes := map[string]int{string(primaryDigest): 1}
+	votes[string(verifierDigest)]++
+	for _, e := range m.getOthers(primary, verifier) {
+		digests, err := requestMainDigests(e, title, []int64{height})
+		if err != nil {
+			continue
+		}
+		votes[string(digests[height])]++
+	}
+	plog.Error("verifyMainBlocks mismatch", "height", height, "primary", primary.addr, "verifier", verifier.addr,
+		"primaryVotes", votes[string(primaryDigest)], "verifierVotes", votes[string(verifierDigest)])
+	maxOther := 0
+	for digest, count := range votes {
+		if digest != string(primaryDigest) && count > maxOther {
+			maxOther = count
+		}
+	}
+	if votes[string(primaryDigest)] > maxOther {
+		m.ban(verifier, "main block mismatch with majority")
+		return
+	}
+	if maxOther > votes[string(primaryDigest)] {
+		m.ban(primary, "main block mismatch with majority")
+	}
+}
+
+func requestMainDigests(e *mainEndpoint, title string, heights []int64) (map[int64][]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), endpointRspTimeout)
+	defer cancel()
+	details, err := e.conn.GetParaTxByHeight(ctx, &types.ReqParaTxByHeight{Title: title, Items: heights})
+	if err != nil {
+		return nil, err
+	}
+	digests := make(map[int64][]byte)
+	for _, detail := range details.GetItems() {
+		if detail == nil || detail.GetHeader() == nil {
+			continue
+		}
+		digests[detail.Header.Height] = calcMainBlockDigest(detail)
+	}
+	return digests, nil
+}
+
+// truncateMainBlocks 截取不超过校验节点高度的区块
+func truncateMainBlocks(items []*types.ParaTxDetail, height int64) []*types.ParaTxDetail {
+	for i, item := range items {
+		if item.Header.Height > height {
+			return items[:i]
+		}
+	}
+	return items
+}
+
+// addedMainBlocks 处理后仍保留的区块, 同一批次中被回滚的区块不需要交叉校验
+func addedMainBlocks(items []*types.ParaTxDetail) map[int64]*types.ParaTxDetail {
+	blocks := make(map[int64]*types.ParaTxDetail)
+	for _, item := range items {
+		h := item.Header.Height
+		if item.Type == types.AddBlock {
+			blocks[h] = item
+			continue
+		}
+		if b, ok := blocks[h]; ok && bytes.Equal(b.Header.Hash, item.Header.Hash) {
+			delete(blocks, h)
+		}
+	}
+	return blocks
+}
+
+// calcMainBlockDigest 区块哈希, 平行链交易及执行结果的摘要
+func calcMainBlockDigest(detail *types.ParaTxDetail) []byte {
+	var buf bytes.Buffer
+	buf.Write(detail.GetHeader().GetHash())
+	var ty [4]byte
+	for _, tx := range detail.GetTxDetails() {
+		buf.Write(tx.GetTx().FullHash())
+		binary.BigEndian.PutUint32(ty[:], uint32(tx.GetReceipt().GetTy()))
+		buf.Write(ty[:])
+	}
+	return common.Sha256(buf.Bytes())
+}
+
+// calcMainHeaderHash 和主链区块哈希的计算方式一致, 区块头中记录了交易数量
+func calcMainHeaderHash(header *types.Header, forkHeight int64) []byte {
+	head := &types.Header{
+		Version:    header.Version,
+		ParentHash: header.ParentHash,
+		TxHash:     header.TxHash,
+		BlockTime:  header.BlockTime,
+		Height:     header.Height,
+	}
+	if header.Height >= forkHeight {
+		head.Difficulty = header.Difficulty
+		head.StateHash = header.StateHash
+		head.TxCount = header.TxCount
+	}
+	return common.Sha256(types.Encode(head))
+}
+
+// verifyParaTxDetail 校验区块头哈希, 以及平行链交易到区块交易根哈希的merkle证明
+func verifyParaTxDetail(title string, forkHeight int64, detail *types.ParaTxDetail) error {
+	header := detail.GetHeader()
+	if header == nil {
+		return types.ErrInvalidParam
+	}
+	if !bytes.Equal(calcMainHeaderHash(header, forkHeight), header.Hash) {
+		return types.ErrBlockHashNoMatch
+	}
+	//ForkRootHash之前没有子链证明, 交易只能通过交叉校验确认
+	if detail.GetChildHash() == nil {
+		return nil
+	}
+	hashes := make([][]byte, 0, len(detail.TxDetails))
+	for _, tx := range detail.TxDetails {
+		//子链哈希只包含本平行链的交易
+		if types.IsSpecificParaExecName(title, string(tx.GetTx().GetExecer())) {
+			hashes = append(hashes, tx.GetTx().FullHash())
+		}
+	}
+	if len(hashes) == 0 || !bytes.Equal(merkle.GetMerkleRoot(hashes), detail.ChildHash) {
+		return types.ErrCheckTxHash
+	}
+	if !bytes.Equal(merkle.GetMerkleRootFromBranch(detail.Proofs, detail.ChildHash, detail.Index), header.TxHash) {
+		return types.ErrCheckTxHash
+	}
+	return nil
+}
+
+func (m *mainEndpoints) getStatus() *pt.MainEndpointsStatus {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	now := time.Now()
+	status := &pt.MainEndpointsStatus{
+		SwitchCount:     m.switchCount,
+		CrossCheckCount: m.crossCheckCount,
+		UncheckedCount:  m.uncheckedCount,
+	}
+	for i, e := range m.list {
+		s := &pt.MainEndpointStatus{
+			Addr:          e.addr,
+			Height:        e.height,
+			LatencyMs:     e.latency.Milliseconds(),
+			Healthy:       e.isHealthy(now),
+			Primary:       i == m.primary,
+			ErrCount:      e.errCount,
+			MismatchCount: e.mismatchCount,
+			LastErr:       e.lastErr,
+		}
+		if now.Before(e.bannedUntil) {
+			s.BannedUntil = e.bannedUntil.Unix()
+		}
+		status.Endpoints = append(status.Endpoints, s)
+	}
+	return status
+}
+
+// mainClient 配置多个主链节点时使用当前选择的节点
+func (client *client) mainClient() types.Chain33Client {
+	if client.endpoints != nil {
+		if conn := client.endpoints.current(); conn != nil {
+			return conn
+		}
+	}
+	return client.grpcClient
+}
+
+// 主链数据未通过校验或没有可用的校验节点, 等待切换节点或新区块后重试
+func isMainDataUnverified(err error) bool {
+	return err == pt.ErrParaMainDataVerify || err == pt.ErrParaMainDataMismatch || err == pt.ErrParaWaitingNewSeq ||
+		err == pt.ErrParaMainNoVerifier
+}
+
+// rematchMainSeq 切换主链节点后, 按最后处理的主链区块哈希在新节点上重新定位seq
+func (client *client) rematchMainSeq(currSeq int64, lastSeqMainHash []byte) (int64, []byte, error) {
+	if lastSeqMainHash != nil {
+		seq, err := client.GetSeqByHashOnMainChain(lastSeqMainHash)
+		if err == nil {
+			return seq + 1, lastSeqMainHash, nil
+		}
+	}
+	return client.processHashNotMatchError(currSeq, lastSeqMainHash, pt.ErrParaCurHashNotMatch)
+}
@@ -0,0 +1,248 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package para
+
+import (
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/merkle"
+	"github.com/33cn/chain33/types"
+	typesmocks "github.com/33cn/chain33/types/mocks"
+	pt "github.com/33cn/plugin/plugin/dapp/paracross/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testEndpointTitle = "user.p.test."
+
+// 构造主链区块, 包含一笔主链交易和两笔平行链交易, 以及平行链交易的子链证明
+func newTestMainBlock(height int64, parent []byte) *types.ParaTxDetail {
+	mainTx := &types.Transaction{Execer: []byte("coins"), Nonce: height}
+	tx1 := &types.Transaction{Execer: []byte(testEndpointTitle + "coins"), Nonce: height}
+	tx2 := &types.Transaction{Execer: []byte(testEndpointTitle + "none"), Nonce: height}
+
+	mainHash := merkle.GetMerkleRoot([][]byte{mainTx.FullHash()})
+	childHash := merkle.GetMerkleRoot([][]byte{tx1.FullHash(), tx2.FullHash()})
+	header := &types.Header{
+		Height:     height,
+		ParentHash: parent,
+		TxHash:     merkle.GetMerkleRoot([][]byte{mainHash, childHash}),
+		BlockTime:  height,
+		TxCount:    3,
+	}
+	header.Hash = calcMainHeaderHash(header, 0)
+	return &types.ParaTxDetail{
+		Type:   types.AddBlock,
+		Header: header,
+		TxDetails: []*types.TxDetail{
+			{Tx: tx1, Receipt: &types.ReceiptData{Ty: types.ExecOk}, Index: 1},
+			{Tx: tx2, Receipt: &types.ReceiptData{Ty: types.ExecOk}, Index: 2},
+		},
+		ChildHash: childHash,
+		Index:     1,
+		Proofs:    [][]byte{mainHash},
+	}
+}
+
+func newTestMainBlocks(count int64) []*types.ParaTxDetail {
+	var blocks []*types.ParaTxDetail
+	var parent []byte
+	for i := int64(1); i <= count; i++ {
+		b := newTestMainBlock(i, parent)
+		parent = b.Header.Hash
+		blocks = append(blocks, b)
+	}
+	return blocks
+}
+
+func newTestEndpoints(heights ...int64) (*mainEndpoints, []*typesmocks.Chain33Client) {
+	m := newMainEndpoints(nil, &subConfig{MainEndpoints: []string{"a", "b", "c"}})
+	var conns []*typesmocks.Chain33Client
+	for i, h := range heights {
+		conn := &typesmocks.Chain33Client{}
+		conns = append(conns, conn)
+		m.list = append(m.list, &mainEndpoint{addr: m.addrs[i], conn: conn, height: h})
+	}
+	return m, conns
+}
+
+func TestVerifyParaTxDetail(t *testing.T) {
+	b := newTestMainBlock(1, nil)
+	assert.Nil(t, verifyParaTxDetail(testEndpointTitle, 0, b))
+
+	//ForkRootHash之前只校验区块头
+	old := newTestMainBlock(1, nil)
+	old.ChildHash = nil
+	old.Proofs = nil
+	old.TxDetails[0].Tx.Nonce = 100
+	assert.Nil(t, verifyParaTxDetail(testEndpointTitle, 0, old))
+
+	//新旧区块哈希计算方式不同
+	assert.Equal(t, types.ErrBlockHashNoMatch, verifyParaTxDetail(testEndpointTitle, 2, b))
+
+	b = newTestMainBlock(1, nil)
+	b.Header.StateHash = []byte("state")
+	assert.Equal(t, types.ErrBlockHashNoMatch, verifyParaTxDetail(testEndpointTitle, 0, b))
+
+	b = newTestMainBlock(1, nil)
+	b.TxDetails[0].Tx.Nonce = 100
+	assert.Equal(t, types.ErrCheckTxHash, verifyParaTxDetail(testEndpointTitle, 0, b))
+
+	b = newTestMainBlock(1, nil)
+	b.TxDetails = b.TxDetails[:1]
+	assert.Equal(t, types.ErrCheckTxHash, verifyParaTxDetail(testEndpointTitle, 0, b))
+
+	b = newTestMainBlock(1, nil)
+	b.Index = 0
+	assert.Equal(t, types.ErrCheckTxHash, verifyParaTxDetail(testEndpointTitle, 0, b))
+}
+
+func TestAddedMainBlocks(t *testing.T) {
+	blocks := newTestMainBlocks(3)
+	del := &types.ParaTxDetail{Type: types.DelBlock, Header: blocks[2].Header}
+	items := append(blocks, del)
+	added := addedMainBlocks(items)
+	assert.Equal(t, 2, len(added))
+	assert.Nil(t, added[3])
+
+	assert.Equal(t, blocks[:2], truncateMainBlocks(blocks, 2))
+	assert.Equal(t, 0, len(truncateMainBlocks(blocks, 0)))
+}
+
+func TestMainEndpointsCrossCheck(t *testing.T) {
+	blocks := newTestMainBlocks(3)
+	details := &types.ParaTxDetails{Items: blocks}
+
+	m, conns := newTestEndpoints(3, 3, 1)
+	conns[1].On("GetParaTxByHeight", mock.Anything, mock.Anything).Return(&types.ParaTxDetails{Items: blocks}, nil).Once()
+	rst, err := m.verifyMainBlocks(testEndpointTitle, 0, details)
+	assert.Nil(t, err)
+	assert.Equal(t, blocks, rst.Items)
+	assert.Equal(t, int64(3), m.getStatus().CrossCheckCount)
+
+	//校验节点只有2个区块, 超过的区块暂不处理
+	m, conns = newTestEndpoints(3, 2, 1)
+	conns[1].On("GetParaTxByHeight", mock.Anything, mock.Anything).Return(&types.ParaTxDetails{Items: blocks[:2]}, nil).Once()
+	rst, err = m.verifyMainBlocks(testEndpointTitle, 0, details)
+	assert.Nil(t, err)
+	assert.Equal(t, blocks[:2], rst.Items)
+
+	//校验节点高度不够, 重新获取高度后等待
+	m, conns = newTestEndpoints(5, 0, 0)
+	m.maxLag = 10
+	conns[1].On("GetLastHeader", mock.Anything, mock.Anything).Return(&types.Header{Height: 0}, nil).Once()
+	_, err = m.verifyMainBlocks(testEndpointTitle, 0, details)
+	assert.Equal(t, pt.ErrParaWaitingNewSeq, err)
+
+	//没有可用的校验节点不阻塞同步
+	m, _ = newTestEndpoints(3, 3, 3)
+	m.list[1].failCount = endpointMaxFailCount
+	m.list[2].bannedUntil = time.Now().Add(time.Minute)
+	rst, err = m.verifyMainBlocks(testEndpointTitle, 0, details)
+	assert.Nil(t, err)
+	assert.Equal(t, blocks, rst.Items)
+	assert.Equal(t, int64(3), m.getStatus().UncheckedCount)
+
+	//切换到备用节点后没有校验节点, 不接受未校验的区块
+	m, _ = newTestEndpoints(3, 3, 3)
+	m.primary = 1
+	m.list[0].failCount = endpointMaxFailCount
+	m.list[2].bannedUntil = time.Now().Add(time.Minute)
+	_, err = m.verifyMainBlocks(testEndpointTitle, 0, details)
+	assert.Equal(t, pt.ErrParaMainNoVerifier, err)
+	assert.True(t, isMainDataUnverified(err))
+
+	//校验节点请求失败, 连续失败后不再被选择
+	m, conns = newTestEndpoints(3, 3, 1)
+	m.list[2].failCount = endpointMaxFailCount
+	conns[1].On("GetParaTxByHeight", mock.Anything, mock.Anything).Return(nil, types.ErrTimeout)
+	for i := 0; i < endpointMaxFailCount; i++ {
+		_, err = m.verifyMainBlocks(testEndpointTitle, 0, details)
+		assert.Equal(t, types.ErrTimeout, err)
+	}
+	_, verifier := m.getVerifier()
+	assert.Nil(t, verifier)
+}
+
+func TestMainEndpointsMismatch(t *testing.T) {
+	blocks := newTestMainBlocks(2)
+	details := &types.ParaTxDetails{Items: blocks}
+	forged := newTestMainBlocks(2)
+	forged[1].TxDetails[0].Receipt.Ty = types.ExecPack
+
+	//当前节点返回的数据不能自洽, 直接屏蔽并切换
+	m, _ := newTestEndpoints(2, 2, 2)
+	bad := &types.ParaTxDetails{Items: newTestMainBlocks(2)}
+	bad.Items[1].TxDetails[1].Tx.Nonce = 100
+	_, err := m.verifyMainBlocks(testEndpointTitle, 0, bad)
+	assert.Equal(t, pt.ErrParaMainDataVerify, err)
+	assert.NotEqual(t, 0, m.primary)
+	assert.Equal(t, int64(1), m.getVersion())
+
+	//校验节点是少数方, 屏蔽校验节点
+	m, conns := newTestEndpoints(2, 2, 2)
+	conns[1].On("GetParaTxByHeight", mock.Anything, mock.Anything).Return(&types.ParaTxDetails{Items: forged}, nil).Once()
+	conns[2].On("GetParaTxByHeight", mock.Anything, mock.Anything).Return(&types.ParaTxDetails{Items: blocks[1:]}, nil).Once()
+	_, err = m.verifyMainBlocks(testEndpointTitle, 0, details)
+	assert.Equal(t, pt.ErrParaMainDataMismatch, err)
+	assert.Equal(t, 0, m.primary)
+	status := m.getStatus()
+	assert.False(t, status.Endpoints[1].Healthy)
+	assert.Equal(t, int64(1), status.Endpoints[1].MismatchCount)
+	assert.True(t, status.Endpoints[2].Healthy)
+
+	//当前节点是少数方, 屏蔽并切换节点
+	m, conns = newTestEndpoints(2, 2, 2)
+	conns[1].On("GetParaTxByHeight", mock.Anything, mock.Anything).Return(&types.ParaTxDetails{Items: blocks}, nil).Once()
+	conns[2].On("GetParaTxByHeight", mock.Anything, mock.Anything).Return(&types.ParaTxDetails{Items: blocks[1:]}, nil).Once()
+	_, err = m.verifyMainBlocks(testEndpointTitle, 0, &types.ParaTxDetails{Items: forged})
+	assert.Equal(t, pt.ErrParaMainDataMismatch, err)
+	assert.NotEqual(t, 0, m.primary)
+	status = m.getStatus()
+	assert.False(t, status.Endpoints[0].Healthy)
+	assert.Equal(t, int64(1), status.SwitchCount)
+
+	//无法判定时都不屏蔽
+	m, conns = newTestEndpoints(2, 2, 2)
+	m.list[2].failCount = endpointMaxFailCount
+	conns[1].On("GetParaTxByHeight", mock.Anything, mock.Anything).Return(&types.ParaTxDetails{Items: forged}, nil).Once()
+	_, err = m.verifyMainBlocks(testEndpointTitle, 0, details)
+	assert.Equal(t, pt.ErrParaMainDataMismatch, err)
+	status = m.getStatus()
+	assert.True(t, status.Endpoints[0].Healthy)
+	assert.True(t, status.Endpoints[1].Healthy)
+}
+
+func TestMainEndpointsSelectPrimary(t *testing.T) {
+	m, conns := newTestEndpoints(100, 105, 0)
+	conns[0].On("GetLastHeader", mock.Anything, mock.Anything).Return(&types.Header{Height: 100}, nil)
+	conns[1].On("GetLastHeader", mock.Anything, mock.Anything).Return(&types.Header{Height: 105}, nil)
+	conns[2].On("GetLastHeader", mock.Anything, mock.Anything).Return(nil, types.ErrTimeout)
+
+	//落后不超过maxLag不切换
+	m.checkHealth()
+	assert.Equal(t, 0, m.primary)
+
+	//落后超过maxLag切换到最高节点
+	m.list[2].failCount = 0
+	conns[2].ExpectedCalls = nil
+	conns[2].On("GetLastHeader", mock.Anything, mock.Anything).Return(&types.Header{Height: 120}, nil)
+	m.checkHealth()
+	assert.Equal(t, 2, m.primary)
+	assert.Equal(t, int64(1), m.getVersion())
+
+	//当前节点连续请求失败后切换
+	conns[2].ExpectedCalls = nil
+	conns[2].On("GetLastHeader", mock.Anything, mock.Anything).Return(nil, types.ErrTimeout)
+	for i := 0; i < endpointMaxFailCount; i++ {
+		m.checkHealth()
+	}
+	assert.Equal(t, 1, m.primary)
+	status := m.getStatus()
+	assert.True(t, status.Endpoints[1].Primary)
+	assert.False(t, status.Endpoints[2].Healthy)
+	assert.Equal(t, int64(2), status.SwitchCount)
+}
@@ -64,6 +64,16 @@ func (client *client) Query_LeaderInfo(req *types.ReqNil) (types.Message, error)
 	return &pt.ElectionStatus{IsLeader: isLeader, Leader: &pt.LeaderSyncInfo{ID: leader, BaseIdx: base, Offset: off}}, nil
 }
 
+func (client *client) Query_MainEndpoints(req *types.ReqNil) (types.Message, error) {
+	if client == nil {
+		return nil, fmt.Errorf("%s", "client not bind message queue.")
+	}
+	if client.endpoints == nil {
+		return nil, pt.ErrParaMainEndpointsNotSet
+	}
+	return client.endpoints.getStatus(), nil
+}
+
 func (client *client) Query_CommitTxInfo(req *types.ReqNil) (types.Message, error) {
 	if client == nil {
 		return nil, fmt.Errorf("%s", "client not bind message queue.")
@@ -29,7 +29,7 @@ func (client *client) GetBlockByHeight(height int64) (*types.Block, error) {
 
 func (client *client) GetBlockHeaders(req *types.ReqBlocks) (*types.Headers, error) {
 	//from blockchain db
-	headers, err := client.grpcClient.GetHeaders(context.Background(), req)
+	headers, err := client.mainClient().GetHeaders(context.Background(), req)
 	if err != nil {
 		plog.Error("GetBlockHeaders fail", "err", err)
 		return nil, err
@@ -70,7 +70,7 @@ func (client *client) getLastBlockInfo() (*types.Block, error) {
 }
 
 func (client *client) GetLastHeightOnMainChain() (int64, error) {
-	header, err := client.grpcClient.GetLastHeader(context.Background(), &types.ReqNil{})
+	header, err := client.mainClient().GetLastHeader(context.Background(), &types.ReqNil{})
 	if err != nil {
 		plog.Error("GetLastHeightOnMainChain", "Error", err.Error())
 		return -1, err
@@ -79,7 +79,7 @@ func (client *client) GetLastHeightOnMainChain() (int64, error) {
 }
 
 func (client *client) GetLastSeqOnMainChain() (int64, error) {
-	seq, err := client.grpcClient.GetLastBlockSequence(context.Background(), &types.ReqNil{})
+	seq, err := client.mainClient().GetLastBlockSequence(context.Background(), &types.ReqNil{})
 	if err != nil {
 		plog.Error("GetLastSeqOnMainChain", "Error", err.Error())
 		return -1, err
@@ -89,7 +89,7 @@ func (client *client) GetLastSeqOnMainChain() (int64, error) {
 }
 
 func (client *client) GetHashByHeightOnMainChain(height int64) ([]byte, error) {
-	reply, err := client.grpcClient.GetBlockHash(context.Background(), &types.ReqInt{Height: height})
+	reply, err := client.mainClient().GetBlockHash(context.Background(), &types.ReqInt{Height: height})
 	if err != nil {
 		plog.Error("GetHashByHeightOnMainChain", "Error", err.Error())
 		return nil, err
@@ -98,7 +98,7 @@ func (client *client) GetHashByHeightOnMainChain(height int64) ([]byte, error) {
 }
 
 func (client *client) GetSeqByHashOnMainChain(hash []byte) (int64, error) {
-	seq, err := client.grpcClient.GetSequenceByHash(context.Background(), &types.ReqHash{Hash: hash})
+	seq, err := client.mainClient().GetSequenceByHash(context.Background(), &types.ReqHash{Hash: hash})
 	if err != nil {
 		plog.Error("GetSeqByHashOnMainChain", "Error", err.Error(), "hash", hex.EncodeToString(hash))
 		return -1, err
@@ -108,7 +108,7 @@ func (client *client) GetSeqByHashOnMainChain(hash []byte) (int64, error) {
 }
 
 func (client *client) GetBlockOnMainBySeq(seq int64) (*types.BlockSeq, error) {
-	blockSeq, err := client.grpcClient.GetBlockBySeq(context.Background(), &types.Int64{Data: seq})
+	blockSeq, err := client.mainClient().GetBlockBySeq(context.Background(), &types.Int64{Data: seq})
 	if err != nil {
 		plog.Error("Not found block on main", "seq", seq)
 		return nil, err
@@ -125,7 +125,7 @@ func (client *client) GetBlockOnMainBySeq(seq int64) (*types.BlockSeq, error) {
 }
 
 func (client *client) GetParaTxByTitle(req *types.ReqParaTxByTitle) (*types.ParaTxDetails, error) {
-	txDetails, err := client.grpcClient.GetParaTxByTitle(context.Background(), req)
+	txDetails, err := client.mainClient().GetParaTxByTitle(context.Background(), req)
 	if err != nil {
 		plog.Error("GetParaTxByTitle wrong", "err", err.Error(), "start", req.Start, "end", req.End)
 		return nil, err
@@ -135,7 +135,7 @@ func (client *client) GetParaTxByTitle(req *types.ReqParaTxByTitle) (*types.Para
 }
 
 func (client *client) QueryTxOnMainByHash(hash []byte) (*types.TransactionDetail, error) {
-	detail, err := client.grpcClient.QueryTransaction(context.Background(), &types.ReqHash{Hash: hash})
+	detail, err := client.mainClient().QueryTransaction(context.Background(), &types.ReqHash{Hash: hash})
 	if err != nil {
 		plog.Error("QueryTxOnMainByHash Not found", "txhash", common.ToHex(hash))
 		return nil, err
@@ -146,7 +146,7 @@ func (client *client) QueryTxOnMainByHash(hash []byte) (*types.TransactionDetail
 
 func (client *client) GetParaHeightsByTitle(req *types.ReqHeightByTitle) (*types.ReplyHeightByTitle, error) {
 	//from blockchain db
-	heights, err := client.grpcClient.LoadParaTxByTitle(context.Background(), req)
+	heights, err := client.mainClient().LoadParaTxByTitle(context.Background(), req)
 	if err != nil {
 		plog.Error("GetParaHeightsByTitle fail", "err", err)
 		return nil, err
@@ -157,7 +157,7 @@ func (client *client) GetParaHeightsByTitle(req *types.ReqHeightByTitle) (*types
 
 func (client *client) GetParaTxByHeight(req *types.ReqParaTxByHeight) (*types.ParaTxDetails, error) {
 	//from blockchain db
-	blocks, err := client.grpcClient.GetParaTxByHeight(context.Background(), req)
+	blocks, err := client.mainClient().GetParaTxByHeight(context.Background(), req)
 	if err != nil {
 		plog.Error("GetParaTxByHeight get node status block count fail")
 		return nil, err
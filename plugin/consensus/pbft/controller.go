@@ -5,8 +5,13 @@
 package pbft
 
 import (
+	"fmt"
+	"os"
 	"strings"
 
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
 	pb "github.com/33cn/chain33/types"
@@ -17,6 +22,7 @@ var (
 	genesis          string
 	genesisBlockTime int64
 	clientAddr       string
+	dbPath           = fmt.Sprintf("datadir%spbft", string(os.PathSeparator))
 )
 
 type subConfig struct {
@@ -25,6 +31,12 @@ type subConfig struct {
 	NodeID           int64  `json:"nodeID"`
 	PeersURL         string `json:"peersURL"`
 	ClientAddr       string `json:"clientAddr"`
+	// PrivKey 本节点签名私钥(secp256k1, hex)
+	PrivKey string `json:"privKey"`
+	// ReplicaPubKeys 所有节点公钥(hex), 以逗号分隔, 第i个公钥对应nodeID为i+1的节点
+	ReplicaPubKeys string `json:"replicaPubKeys"`
+	// DbPath 节点消息日志存储路径
+	DbPath string `json:"dbPath"`
 }
 
 // NewPbft create pbft cluster
@@ -46,9 +58,48 @@ func NewPbft(cfg *pb.Consensus, sub []byte) queue.Module {
 		return nil
 	}
 	clientAddr = subcfg.ClientAddr
+	if subcfg.DbPath != "" {
+		dbPath = subcfg.DbPath
+	}
+
+	privKey, pubKeys, err := loadReplicaKeys(subcfg.PrivKey, subcfg.ReplicaPubKeys)
+	if err != nil {
+		plog.Error("load pbft replica keys", "err", err)
+		return nil
+	}
+	if pub, ok := pubKeys[uint32(subcfg.NodeID)]; !ok || !pub.Equals(privKey.PubKey()) {
+		plog.Error("The privKey does not match the configured public key of this node", "nodeID", subcfg.NodeID)
+		return nil
+	}
 
 	var c *Client
-	replyChan, requestChan, isPrimary := NewReplica(uint32(subcfg.NodeID), subcfg.PeersURL, subcfg.ClientAddr)
+	replyChan, requestChan, isPrimary := NewReplica(uint32(subcfg.NodeID), subcfg.PeersURL, subcfg.ClientAddr, privKey, pubKeys,
+		dbm.NewDB("pbft", "leveldb", dbPath, 0))
 	c = NewBlockstore(cfg, replyChan, requestChan, isPrimary)
 	return c
 }
+
+func loadReplicaKeys(priv, pubs string) (crypto.PrivKey, map[uint32]crypto.PubKey, error) {
+	cr := secpCrypto()
+	bpriv, err := common.FromHex(priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	privKey, err := cr.PrivKeyFromBytes(bpriv)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubKeys := make(map[uint32]crypto.PubKey)
+	for i, pub := range strings.Split(pubs, ",") {
+		bpub, err := common.FromHex(strings.TrimSpace(pub))
+		if err != nil {
+			return nil, nil, err
+		}
+		pubKey, err := cr.PubKeyFromBytes(bpub)
+		if err != nil {
+			return nil, nil, err
+		}
+		pubKeys[uint32(i+1)] = pubKey
+	}
+	return privKey, pubKeys, nil
+}
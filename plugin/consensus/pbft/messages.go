@@ -6,15 +6,24 @@ package pbft
 
 import (
 	"bytes"
-	"crypto/md5"
-	"fmt"
+	"errors"
 	"io"
 	"net"
 
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/crypto"
 	"github.com/33cn/chain33/types"
+	ptypes "github.com/33cn/plugin/plugin/consensus/pbft/types"
 	"github.com/golang/protobuf/proto"
 )
 
+var (
+	errNoSignature      = errors.New("ErrNoSignature")
+	errUnknownReplica   = errors.New("ErrUnknownReplica")
+	errReplicaMismatch  = errors.New("ErrReplicaMismatch")
+	errInvalidSignature = errors.New("ErrInvalidSignature")
+)
+
 // EQ Digest
 func EQ(d1 []byte, d2 []byte) bool {
 	if len(d1) != len(d2) {
@@ -112,13 +121,12 @@ func ToRequestNewView(view uint32, viewChanges []*types.ViewChange, summaries []
 	}
 }
 
-// ReqDigest method
+// ReqDigest sha256 of the canonical protobuf bytes of request
 func ReqDigest(req *types.Request) []byte {
 	if req == nil {
 		return nil
 	}
-	bytes := md5.Sum([]byte(req.String()))
-	return bytes[:]
+	return common.Sha256(types.Encode(req))
 }
 
 /*func (req *Request) LowWaterMark() uint32 {
@@ -135,22 +143,96 @@ func ToReply(view uint32, timestamp, client string, replica uint32, result *type
 	return &types.ClientReply{View: view, Timestamp: timestamp, Client: client, Replica: replica, Result: result}
 }
 
-// RepDigest method
-func RepDigest(reply fmt.Stringer) []byte {
+// RepDigest sha256 of the canonical protobuf bytes of reply
+func RepDigest(reply *types.ClientReply) []byte {
 	if reply == nil {
 		return nil
 	}
-	bytes := md5.Sum([]byte(reply.String()))
-	return bytes[:]
+	return common.Sha256(types.Encode(reply))
+}
+
+// requestReplica 消息体中声明的发送方节点, client请求不携带节点ID
+func requestReplica(req *types.Request) (uint32, bool) {
+	switch req.Value.(type) {
+	case *types.Request_Preprepare:
+		return req.GetPreprepare().Replica, true
+	case *types.Request_Prepare:
+		return req.GetPrepare().Replica, true
+	case *types.Request_Commit:
+		return req.GetCommit().Replica, true
+	case *types.Request_Checkpoint:
+		return req.GetCheckpoint().Replica, true
+	case *types.Request_Viewchange:
+		return req.GetViewchange().Replica, true
+	case *types.Request_Ack:
+		return req.GetAck().Replica, true
+	case *types.Request_Newview:
+		return req.GetNewview().Replica, true
+	default:
+		return 0, false
+	}
+}
+
+// SignRequest sign request with the node key of replica
+func SignRequest(req *types.Request, replica uint32, priv crypto.PrivKey) *ptypes.SignedRequest {
+	return signMessage(&ptypes.SignedRequest{Request: req, Replica: replica}, priv)
+}
+
+// signReplay 补发的历史消息, 接收方不会当作新请求处理
+func signReplay(req *types.Request, replica uint32, priv crypto.PrivKey) *ptypes.SignedRequest {
+	return signMessage(&ptypes.SignedRequest{Request: req, Replica: replica, Replay: true}, priv)
+}
+
+// signFetch 请求其他节点补发[from, to]之间的消息
+func signFetch(from, to, replica uint32, priv crypto.PrivKey) *ptypes.SignedRequest {
+	return signMessage(&ptypes.SignedRequest{Fetch: &ptypes.FetchRequest{From: from, To: to}, Replica: replica}, priv)
+}
+
+func signMessage(msg *ptypes.SignedRequest, priv crypto.PrivKey) *ptypes.SignedRequest {
+	msg.Signature = priv.Sign(types.Encode(msg)).Bytes()
+	return msg
+}
+
+// VerifyRequest check the signature of msg against the configured replica public keys
+func VerifyRequest(msg *ptypes.SignedRequest, pubkeys map[uint32]crypto.PubKey) (*types.Request, error) {
+	if (msg.GetRequest() == nil && msg.GetFetch() == nil) || len(msg.Signature) == 0 {
+		return nil, errNoSignature
+	}
+	pub, ok := pubkeys[msg.Replica]
+	if !ok {
+		return nil, errUnknownReplica
+	}
+	if msg.GetRequest() != nil {
+		if replica, ok := requestReplica(msg.Request); ok && replica != msg.Replica {
+			return nil, errReplicaMismatch
+		}
+	}
+	sig, err := secpCrypto().SignatureFromBytes(msg.Signature)
+	if err != nil {
+		return nil, errInvalidSignature
+	}
+	unsigned := &ptypes.SignedRequest{Request: msg.Request, Replica: msg.Replica, Fetch: msg.Fetch, Replay: msg.Replay}
+	if !pub.VerifyBytes(types.Encode(unsigned), sig) {
+		return nil, errInvalidSignature
+	}
+	return msg.Request, nil
+}
+
+func secpCrypto() crypto.Crypto {
+	cr, err := crypto.Load(types.GetSignName("", types.SECP256K1), -1)
+	if err != nil {
+		panic(err)
+	}
+	return cr
 }
 
 // WriteMessage write proto message
 func WriteMessage(addr string, msg proto.Message) error {
 	conn, err := net.Dial("tcp", addr)
-	defer conn.Close()
 	if err != nil {
 		return err
 	}
+	defer conn.Close()
 	bz, err := proto.Marshal(msg)
 	if err != nil {
 		return err
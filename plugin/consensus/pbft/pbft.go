@@ -8,9 +8,12 @@ import (
 	"errors"
 	"net"
 	"strings"
+	"sync"
 
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
 	pb "github.com/33cn/chain33/types"
-	"github.com/golang/protobuf/proto"
+	ptypes "github.com/33cn/plugin/plugin/consensus/pbft/types"
 )
 
 // constant
@@ -28,7 +31,6 @@ type Replica struct {
 	sequence    uint32
 	requestChan chan *pb.Request
 	replyChan   chan *pb.ClientReply
-	errChan     chan error
 	doneChan    chan string
 	requests    map[string][]*pb.Request
 	replies     map[string][]*pb.ClientReply
@@ -36,10 +38,25 @@ type Replica struct {
 	pendingVC   []*pb.Request
 	executed    []uint32
 	checkpoints []*pb.Checkpoint
+	privKey     crypto.PrivKey
+	pubKeys     map[uint32]crypto.PubKey
+	rejectLock  sync.Mutex
+	rejected    map[string]uint64
+	store       *replicaStore
+	fetchedTo   uint32
+	replyDone   chan struct{}
+	listener    net.Listener
+	quit        chan struct{}
 }
 
-// NewReplica create Replica instance
-func NewReplica(id uint32, PeersURL string, addr string) (chan *pb.ClientReply, chan *pb.Request, bool) {
+// NewReplica create Replica instance, pubKeys holds the node key of every replica indexed by replica ID
+func NewReplica(id uint32, PeersURL string, addr string, privKey crypto.PrivKey, pubKeys map[uint32]crypto.PubKey, db dbm.DB) (chan *pb.ClientReply, chan *pb.Request, bool) {
+	pn := newReplica(id, PeersURL, privKey, pubKeys, db)
+	pn.Startnode(addr)
+	return pn.replyChan, pn.requestChan, pn.isPrimary(pn.ID)
+}
+
+func newReplica(id uint32, PeersURL string, privKey crypto.PrivKey, pubKeys map[uint32]crypto.PubKey, db dbm.DB) *Replica {
 	replyChan := make(chan *pb.ClientReply)
 	requestChan := make(chan *pb.Request)
 	pn := &Replica{
@@ -50,22 +67,25 @@ func NewReplica(id uint32, PeersURL string, addr string) (chan *pb.ClientReply,
 		sequence:    0,
 		requestChan: requestChan,
 		replyChan:   replyChan,
-		errChan:     make(chan error),
 		requests:    make(map[string][]*pb.Request),
 		replies:     make(map[string][]*pb.ClientReply),
 		doneChan:    make(chan string),
 		lastReply:   nil,
 		pendingVC:   make([]*pb.Request, 10),
 		executed:    make([]uint32, 10),
+		privKey:     privKey,
+		pubKeys:     pubKeys,
+		rejected:    make(map[string]uint64),
+		store:       newReplicaStore(db),
+		quit:        make(chan struct{}),
 	}
 	peers := strings.Split(PeersURL, ",")
 	for num, peer := range peers {
 		pn.replicas[uint32(num)] = peer
 	}
 	pn.checkpoints = []*pb.Checkpoint{ToCheckpoint(0, []byte(""))}
-	pn.Startnode(addr)
-	return replyChan, requestChan, pn.isPrimary(pn.ID)
-
+	pn.restore()
+	return pn
 }
 
 // Startnode method
@@ -73,6 +93,127 @@ func (rep *Replica) Startnode(addr string) {
 	rep.acceptConnections(addr)
 }
 
+// Stop close the listener and stop sending requests
+func (rep *Replica) Stop() {
+	close(rep.quit)
+	if rep.listener != nil {
+		rep.listener.Close()
+	}
+}
+
+// restore 从持久化日志恢复视图、序号、稳定检查点以及尚未执行的prepared请求
+func (rep *Replica) restore() {
+	state := rep.store.loadState()
+	if state == nil {
+		return
+	}
+	rep.view = state.View
+	rep.sequence = state.Sequence
+	if state.StableCheckpoint != nil {
+		rep.addCheckpoint(state.StableCheckpoint)
+	}
+	if state.LastExecuted > 0 {
+		rep.executed = append(rep.executed, state.LastExecuted)
+	}
+	for _, cert := range rep.store.loadPrepared(rep.lowWaterMark()) {
+		if cert.Client != nil {
+			rep.logRequest(cert.Client)
+		}
+		rep.logRequest(cert.Preprepare)
+		for _, prepare := range cert.Prepares {
+			rep.logRequest(prepare)
+		}
+	}
+	plog.Info("restore pbft replica", "view", rep.view, "sequence", rep.sequence,
+		"lowWaterMark", rep.lowWaterMark(), "lastExecuted", rep.lastExecuted())
+}
+
+func (rep *Replica) saveState() {
+	state := &ptypes.ReplicaState{
+		View:             rep.view,
+		Sequence:         rep.sequence,
+		LowWaterMark:     rep.lowWaterMark(),
+		HighWaterMark:    rep.highWaterMark(),
+		LastExecuted:     rep.lastExecuted(),
+		StableCheckpoint: rep.lastStable(),
+	}
+	if err := rep.store.saveState(state); err != nil {
+		plog.Error("saveState", "err", err)
+	}
+}
+
+// savePrepared 持久化prepared证明, 重启后可继续完成commit
+func (rep *Replica) savePrepared(view, sequence uint32, digest []byte) {
+	cert := &ptypes.PreparedCert{View: view, Sequence: sequence, Digest: digest}
+	for _, req := range rep.requests["pre-prepare"] {
+		pp := req.GetPreprepare()
+		if pp.View == view && pp.Sequence == sequence && EQ(pp.Digest, digest) {
+			cert.Preprepare = req
+			break
+		}
+	}
+	for _, req := range rep.requests["prepare"] {
+		p := req.GetPrepare()
+		if p.View == view && p.Sequence == sequence && EQ(p.Digest, digest) {
+			cert.Prepares = append(cert.Prepares, req)
+		}
+	}
+	for _, req := range rep.requests["client"] {
+		if EQ(ReqDigest(req), digest) {
+			cert.Client = req
+			break
+		}
+	}
+	if cert.Preprepare == nil {
+		return
+	}
+	if err := rep.store.savePrepared(cert); err != nil {
+		plog.Error("savePrepared", "err", err)
+	}
+}
+
+func (rep *Replica) isExecuted(sequence uint32) bool {
+	return rep.store.isExecuted(sequence)
+}
+
+func (rep *Replica) markExecuted(sequence uint32) {
+	rep.executed = append(rep.executed, sequence)
+	if err := rep.store.setExecuted(sequence); err != nil {
+		plog.Error("markExecuted", "err", err)
+	}
+	rep.saveState()
+}
+
+func (rep *Replica) sendRequest(req *pb.Request) {
+	go func() {
+		select {
+		case rep.requestChan <- req:
+		case <-rep.quit:
+		}
+	}()
+}
+
+// sendReply 按执行顺序发送reply, 每个reply等待前一个发送完成
+func (rep *Replica) sendReply(reply *pb.ClientReply) {
+	prev := rep.replyDone
+	done := make(chan struct{})
+	rep.replyDone = done
+	go func() {
+		defer close(done)
+		if prev != nil {
+			select {
+			case <-prev:
+			case <-rep.quit:
+				return
+			}
+		}
+		select {
+		case rep.replyChan <- reply:
+		case <-rep.quit:
+		}
+	}()
+}
+
 // Basic operations
 
 func (rep *Replica) primary() uint32 {
@@ -153,22 +294,71 @@ func (rep *Replica) addCheckpoint(checkpoint *pb.Checkpoint) {
 	rep.checkpoints = append(rep.checkpoints, checkpoint)
 }
 
+// reject 记录来自peer的非法消息
+func (rep *Replica) reject(peer string, err error) {
+	rep.rejectLock.Lock()
+	rep.rejected[peer]++
+	count := rep.rejected[peer]
+	rep.rejectLock.Unlock()
+	plog.Error("reject pbft message", "peer", peer, "count", count, "err", err)
+}
+
+// RejectedCount number of messages rejected from peer
+func (rep *Replica) RejectedCount(peer string) uint64 {
+	rep.rejectLock.Lock()
+	defer rep.rejectLock.Unlock()
+	return rep.rejected[peer]
+}
+
+func peerHost(conn net.Conn) string {
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return conn.RemoteAddr().String()
+	}
+	return host
+}
+
 func (rep *Replica) acceptConnections(addr string) {
 	ln, err := net.Listen("tcp", addr)
 	if err != nil {
 		plog.Error("tcp connect error", "err", err)
+		return
 	}
+	rep.listener = ln
 	go rep.sendRoutine()
 	go func() {
 		for {
 			conn, err := ln.Accept()
 			if err != nil {
-				plog.Error("Accept error")
+				select {
+				case <-rep.quit:
+					return
+				default:
+				}
+				plog.Error("Accept error", "err", err)
+				continue
 			}
-			req := &pb.Request{}
-			err = ReadMessage(conn, req)
+			peer := peerHost(conn)
+			msg := &ptypes.SignedRequest{}
+			err = ReadMessage(conn, msg)
+			conn.Close()
 			if err != nil {
 				plog.Error("readmessage error", "err", err)
+				rep.reject(peer, err)
+				continue
+			}
+			req, err := VerifyRequest(msg, rep.pubKeys)
+			if err != nil {
+				rep.reject(peer, err)
+				continue
+			}
+			if msg.Fetch != nil {
+				rep.handleFetch(msg.Replica, msg.Fetch)
+				continue
+			}
+			if msg.Replay {
+				rep.handleReplay(req)
+				continue
 			}
 			rep.handleRequest(req)
 		}
@@ -177,18 +367,27 @@ func (rep *Replica) acceptConnections(addr string) {
 
 // Sends
 
-func (rep *Replica) multicast(REQ proto.Message) error {
+func (rep *Replica) multicast(REQ *pb.Request) error {
+	var lastErr error
+	msg := SignRequest(REQ, rep.ID, rep.privKey)
 	for _, replica := range rep.replicas {
-		err := WriteMessage(replica, REQ)
+		// 单个节点不可达不影响向其他节点广播
+		err := WriteMessage(replica, msg)
 		if err != nil {
-			return err
+			lastErr = err
 		}
 	}
-	return nil
+	return lastErr
 }
 
 func (rep *Replica) sendRoutine() {
-	for REQ := range rep.requestChan {
+	for {
+		var REQ *pb.Request
+		select {
+		case REQ = <-rep.requestChan:
+		case <-rep.quit:
+			return
+		}
 		switch REQ.Value.(type) {
 		case *pb.Request_Ack:
 			view := REQ.GetAck().View
@@ -198,19 +397,14 @@ func (rep *Replica) sendRoutine() {
 				plog.Error("primary not exeist")
 				continue
 			}
-			err := WriteMessage(primary, REQ)
+			err := WriteMessage(primary, SignRequest(REQ, rep.ID, rep.privKey))
 			if err != nil {
-				go func() {
-					rep.errChan <- err
-				}()
+				plog.Error("send ack", "err", err)
 			}
 		default:
 			err := rep.multicast(REQ)
-
 			if err != nil {
-				go func() {
-					rep.errChan <- err
-				}()
+				plog.Error("multicast", "err", err)
 			}
 		}
 	}
@@ -511,10 +705,10 @@ func (rep *Replica) handleRequest(REQ *pb.Request) {
 
 		rep.handleRequestCommit(REQ)
 
-	//case *pb.Request_Checkpoint:
-	//
-	//	rep.handleRequestCheckpoint(REQ)
-	//
+	case *pb.Request_Checkpoint:
+
+		rep.handleRequestCheckpoint(REQ)
+
 	//case *pb.Request_Viewchange:
 	//
 	//	rep.handleRequestViewChange(REQ)
@@ -530,6 +724,7 @@ func (rep *Replica) handleRequest(REQ *pb.Request) {
 
 func (rep *Replica) handleRequestClient(REQ *pb.Request) {
 	rep.sequence++
+	rep.saveState()
 	client := REQ.GetClient().Client
 	timestamp := REQ.GetClient().Timestamp
 	lastReplyToClient := rep.lastReplyToClient(client)
@@ -537,9 +732,7 @@ func (rep *Replica) handleRequestClient(REQ *pb.Request) {
 		if lastReplyToClient.Timestamp == timestamp {
 			reply := ToReply(rep.view, timestamp, client, rep.ID, lastReplyToClient.Result)
 			rep.logReply(client, reply)
-			go func() {
-				rep.replyChan <- reply
-			}()
+			rep.sendReply(reply)
 			return
 		}
 	}
@@ -550,9 +743,7 @@ func (rep *Replica) handleRequestClient(REQ *pb.Request) {
 	req := ToRequestPreprepare(rep.view, rep.sequence, ReqDigest(REQ), rep.ID)
 	rep.logRequest(req)
 	plog.Info("Client-request done")
-	go func() {
-		rep.requestChan <- req
-	}()
+	rep.sendRequest(req)
 }
 
 func (rep *Replica) handleRequestPreprepare(REQ *pb.Request) {
@@ -582,6 +773,11 @@ func (rep *Replica) handleRequestPreprepare(REQ *pb.Request) {
 	if !accept {
 		return
 	}
+	// 重启后错过的client请求, 以主节点分配的序号为准
+	if sequence > rep.sequence {
+		rep.sequence = sequence
+		rep.saveState()
+	}
 	rep.logRequest(REQ)
 	plog.Info("pre-prepare done")
 	req := ToRequestPrepare(view, sequence, digest, rep.ID)
@@ -589,15 +785,12 @@ func (rep *Replica) handleRequestPreprepare(REQ *pb.Request) {
 		return
 	}
 	rep.logRequest(req)
-	go func() {
-		rep.requestChan <- req
-	}()
+	rep.sendRequest(req)
 }
 
 func (rep *Replica) handleRequestPrepare(REQ *pb.Request) {
 
-	replica := REQ.GetPrepare().Replica
-
+	//replica := REQ.GetPrepare().Replica
 	//if rep.isPrimary(replica) {
 	//return
 	//}
@@ -627,6 +820,8 @@ func (rep *Replica) handleRequestPrepare(REQ *pb.Request) {
 
 	go func() {
 		count := 0
+		// 同一节点的多次prepare只计一次
+		voted := make(map[uint32]bool)
 		for _, req := range rep.requests["prepare"] {
 			v := req.GetPrepare().View
 			s := req.GetPrepare().Sequence
@@ -635,17 +830,18 @@ func (rep *Replica) handleRequestPrepare(REQ *pb.Request) {
 			if v != view || s != sequence || !EQ(d, digest) {
 				continue
 			}
-			if r == replica {
-				plog.Debug("multiple prepare requests", "Replica ", replica, " sent multiple prepare requests")
-				//continue
+			if voted[r] {
+				plog.Debug("multiple prepare requests", "Replica ", r, " sent multiple prepare requests")
+				continue
 			}
+			voted[r] = true
 			count++
 			if rep.overTwoThirds(count) {
 				twoThirds <- true
 				return
 			}
-			twoThirds <- false
 		}
+		twoThirds <- false
 	}()
 
 	if !<-twoThirds {
@@ -657,11 +853,10 @@ func (rep *Replica) handleRequestPrepare(REQ *pb.Request) {
 		return
 	}
 
+	rep.savePrepared(view, sequence, digest)
 	rep.logRequest(req)
 	plog.Info("prepare done")
-	go func() {
-		rep.requestChan <- req
-	}()
+	rep.sendRequest(req)
 }
 
 func (rep *Replica) handleRequestCommit(REQ *pb.Request) {
@@ -678,130 +873,223 @@ func (rep *Replica) handleRequestCommit(REQ *pb.Request) {
 		return
 	}
 
-	replica := REQ.GetCommit().Replica
-
 	rep.logRequest(REQ)
-	twoThirds := make(chan bool, 1)
-	go func() {
-		count := 0
-		for _, req := range rep.requests["commit"] {
-			v := req.GetCommit().View
-			s := req.GetCommit().Sequence
-			rr := req.GetCommit().Replica
-			if v != view || s != sequence {
-				continue
-			}
-			if rr == replica {
-				plog.Debug("multiple commit requests", "Replica ", replica, " sent multiple commit requests")
-				//continue
-			}
-			count++
-			if rep.overTwoThirds(count) {
-				twoThirds <- true
-				return
-			}
+	rep.executeCommitted()
+	if sequence > rep.lastExecuted()+1 && rep.commitQuorum(view, sequence) {
+		rep.fetchMissing(sequence - 1)
+	}
+}
+
+func (rep *Replica) commitQuorum(view, sequence uint32) bool {
+	count := 0
+	voted := make(map[uint32]bool)
+	for _, req := range rep.requests["commit"] {
+		v := req.GetCommit().View
+		s := req.GetCommit().Sequence
+		rr := req.GetCommit().Replica
+		if v != view || s != sequence {
+			continue
+		}
+		if voted[rr] {
+			plog.Debug("multiple commit requests", "Replica ", rr, " sent multiple commit requests")
+			continue
+		}
+		voted[rr] = true
+		count++
+		if rep.overTwoThirds(count) {
+			return true
 		}
-		twoThirds <- false
-	}()
-	if !<-twoThirds {
-		return
 	}
-	//log.Println(count)
-	//log.Println("overtwothirds done")
+	return false
+}
+
+// committedRequest 返回当前视图中已提交的sequence对应的client请求
+func (rep *Replica) committedRequest(sequence uint32) *pb.Request {
+	if !rep.commitQuorum(rep.view, sequence) {
+		return nil
+	}
 	var digest []byte
-	digests := make(map[string]struct{})
 	for _, req := range rep.requests["pre-prepare"] {
-		v := req.GetPreprepare().View
-		s := req.GetPreprepare().Sequence
-		if v == view && s <= sequence {
-			digests[string(req.GetPreprepare().Digest)] = struct{}{}
-			//log.Println(string(req.Digest()))
-			if s == sequence {
-				//log.Println("break done ")
-				digest = req.GetPreprepare().Digest
-				break
-			}
+		if req.GetPreprepare().View == rep.view && req.GetPreprepare().Sequence == sequence {
+			digest = req.GetPreprepare().Digest
+			break
 		}
 	}
+	if digest == nil {
+		return nil
+	}
 	for _, req := range rep.requests["client"] {
-		d := ReqDigest(req)
-
-		if _, exists := digests[string(d)]; !exists {
-
-			continue
+		if EQ(ReqDigest(req), digest) {
+			return req
 		}
-		if !EQ(d, digest) {
+	}
+	return nil
+}
 
+// executeCommitted 严格按序号顺序执行已提交的请求, 前一个序号没有执行之前后面的序号只能等待
+func (rep *Replica) executeCommitted() {
+	for {
+		sequence := rep.lastExecuted() + 1
+		// 已执行的序号不再重复执行, 包括重启之前执行过的
+		if rep.isExecuted(sequence) {
+			rep.executed = append(rep.executed, sequence)
 			continue
 		}
+		req := rep.committedRequest(sequence)
+		if req == nil {
+			return
+		}
+		rep.execute(sequence, req)
+	}
+}
+
+func (rep *Replica) execute(sequence uint32, req *pb.Request) {
+	op := req.GetClient().Op
+	timestamp := req.GetClient().Timestamp
+	client := req.GetClient().Client
+	result := &pb.Result{Value: op.Value}
 
-		op := req.GetClient().Op
-		timestamp := req.GetClient().Timestamp
-		client := req.GetClient().Client
-		result := &pb.Result{Value: op.Value}
+	reply := ToReply(rep.view, timestamp, client, rep.ID, result)
+	rep.markExecuted(sequence)
 
-		rep.executed = append(rep.executed, sequence)
-		reply := ToReply(view, timestamp, client, rep.ID, result)
+	rep.logReply(client, reply)
+	plog.Info("commit done", "sequence", sequence)
+	rep.lastReply = reply
 
-		rep.logReply(client, reply)
-		plog.Info("commit done")
-		rep.lastReply = reply
+	rep.sendReply(reply)
+
+	if !rep.isCheckpoint(sequence) {
+		return
+	}
+	stateDigest := rep.stateDigest()
+	checkpoint := ToRequestCheckpoint(sequence, stateDigest, rep.ID)
+	rep.logRequest(checkpoint)
 
-		go func() {
-			rep.replyChan <- reply
-		}()
+	rep.sendRequest(checkpoint)
+}
 
-		if !rep.isCheckpoint(sequence) {
+// fetchMissing 后面的序号已提交而中间的序号没有收到pre-prepare(如重启期间错过), 向其他节点请求补发
+func (rep *Replica) fetchMissing(to uint32) {
+	from := rep.lastExecuted() + 1
+	if to <= rep.fetchedTo {
+		return
+	}
+	for _, req := range rep.requests["pre-prepare"] {
+		if req.GetPreprepare().View == rep.view && req.GetPreprepare().Sequence == from {
+			// 消息仍在途中, 等待即可
 			return
 		}
-		stateDigest := rep.stateDigest()
-		req := ToRequestCheckpoint(sequence, stateDigest, rep.ID)
-		rep.logRequest(req)
+	}
+	rep.fetchedTo = to
+	plog.Info("fetch missing pbft requests", "from", from, "to", to)
+	msg := signFetch(from, to, rep.ID, rep.privKey)
+	go func() {
+		for id, replica := range rep.replicas {
+			if id+1 == rep.ID {
+				continue
+			}
+			if err := WriteMessage(replica, msg); err != nil {
+				plog.Error("fetch missing", "replica", replica, "err", err)
+			}
+		}
+	}()
+}
 
-		go func() {
-			rep.requestChan <- req
-		}()
+// handleFetch 向落后节点补发本节点在[from, to]之间发出的消息, 以及对应的client请求
+// 只补发本节点签名的消息, 落后节点仍需收集足够的prepare和commit才会执行
+func (rep *Replica) handleFetch(replica uint32, fetch *ptypes.FetchRequest) {
+	addr, ok := rep.replicas[replica-1]
+	if !ok || fetch.From > fetch.To || fetch.To-fetch.From >= CheckPointPeriod*ConstantFactor {
 		return
 	}
+	var msgs []*ptypes.SignedRequest
+	for sequence := fetch.From; sequence <= fetch.To; sequence++ {
+		cert := rep.store.loadPreparedCert(sequence)
+		if cert == nil {
+			continue
+		}
+		if cert.Client != nil {
+			msgs = append(msgs, signReplay(cert.Client, rep.ID, rep.privKey))
+		}
+		if cert.Preprepare.GetPreprepare().Replica == rep.ID {
+			msgs = append(msgs, signReplay(cert.Preprepare, rep.ID, rep.privKey))
+		}
+		for _, prepare := range cert.Prepares {
+			if prepare.GetPrepare().Replica == rep.ID {
+				msgs = append(msgs, signReplay(prepare, rep.ID, rep.privKey))
+			}
+		}
+		// 有prepared证明说明本节点已经发出过commit
+		msgs = append(msgs, signReplay(ToRequestCommit(cert.View, sequence, rep.ID), rep.ID, rep.privKey))
+	}
+	go func() {
+		for _, msg := range msgs {
+			if err := WriteMessage(addr, msg); err != nil {
+				plog.Error("replay pbft request", "addr", addr, "err", err)
+				return
+			}
+		}
+	}()
+}
 
+// handleReplay 处理其他节点补发的消息, client请求只记录不分配新序号
+func (rep *Replica) handleReplay(REQ *pb.Request) {
+	switch REQ.Value.(type) {
+	case *pb.Request_Client:
+		digest := ReqDigest(REQ)
+		for _, req := range rep.requests["client"] {
+			if EQ(ReqDigest(req), digest) {
+				return
+			}
+		}
+		rep.logRequest(REQ)
+		rep.executeCommitted()
+	case *pb.Request_Preprepare, *pb.Request_Prepare, *pb.Request_Commit:
+		if rep.hasRequest(REQ) {
+			return
+		}
+		rep.handleRequest(REQ)
+		rep.executeCommitted()
+	}
 }
 
-//func (rep *Replica) handleRequestCheckpoint(REQ *pb.Request) {
-//
-//	sequence := REQ.GetCheckpoint().Sequence
-//
-//	if !rep.sequenceInRange(sequence) {
-//		return
-//	}
-//
-//	digest := REQ.GetCheckpoint().Digest
-//	replica := REQ.GetCheckpoint().Replica
-//
-//	count := 0
-//	for _, req := range rep.requests["checkpoint"] {
-//		s := req.GetCheckpoint().Sequence
-//		d := req.GetCheckpoint().Digest
-//		r := req.GetCheckpoint().Replica
-//		if s != sequence || !EQ(d, digest) {
-//			continue
-//		}
-//		if r == replica {
-//			plog.Info("Replica %d sent multiple checkpoint requests\n", replica)
-//			//continue
-//		}
-//		count++
-//		if !rep.overTwoThirds(count) {
-//			continue
-//		}
-//		// rep.clearEntries(sequence)
-//		rep.clearRequestsBySeq(sequence)
-//		checkpoint := ToCheckpoint(sequence, digest)
-//		rep.addCheckpoint(checkpoint)
-//		plog.Info("checkpoint and clear request done")
-//		return
-//	}
-//
-//}
+func (rep *Replica) handleRequestCheckpoint(REQ *pb.Request) {
+
+	sequence := REQ.GetCheckpoint().Sequence
+
+	if !rep.sequenceInRange(sequence) {
+		return
+	}
+
+	digest := REQ.GetCheckpoint().Digest
+
+	rep.logRequest(REQ)
+
+	count := 0
+	voted := make(map[uint32]bool)
+	for _, req := range rep.requests["checkpoint"] {
+		s := req.GetCheckpoint().Sequence
+		d := req.GetCheckpoint().Digest
+		r := req.GetCheckpoint().Replica
+		if s != sequence || !EQ(d, digest) || voted[r] {
+			continue
+		}
+		voted[r] = true
+		count++
+		if !rep.overTwoThirds(count) {
+			continue
+		}
+		checkpoint := ToCheckpoint(sequence, digest)
+		rep.addCheckpoint(checkpoint)
+		rep.saveState()
+		if err := rep.store.prune(sequence); err != nil {
+			plog.Error("prune pbft log", "err", err)
+		}
+		plog.Info("stable checkpoint done", "sequence", sequence)
+		return
+	}
+
+}
 
 //func (rep *Replica) handleRequestViewChange(REQ *pb.Request) {
 //
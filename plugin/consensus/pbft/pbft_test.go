@@ -25,6 +25,8 @@ import (
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/wallet"
+	ptypes "github.com/33cn/plugin/plugin/consensus/pbft/types"
+	"github.com/stretchr/testify/require"
 
 	_ "github.com/33cn/chain33/system"
 	_ "github.com/33cn/plugin/plugin/dapp/init"
@@ -154,3 +156,40 @@ func clearTestData() {
 	}
 	fmt.Println("test data clear successfully!")
 }
+
+func TestSignedRequest(t *testing.T) {
+	priv := getprivkey("CC38546E9E659D15E6B4893F0AB32A06D103931A8230B0BDE71459D2B27D6944")
+	other := getprivkey("4eaac29629819c1b20985b8c3acccfac5749f946993ca4a791f6b87b11454008")
+	pubKeys := map[uint32]crypto.PubKey{1: priv.PubKey(), 2: other.PubKey()}
+
+	req := ToRequestPrepare(1, 1, []byte("digest"), 1)
+	msg := SignRequest(req, 1, priv)
+	got, err := VerifyRequest(msg, pubKeys)
+	require.Nil(t, err)
+	require.Equal(t, ReqDigest(req), ReqDigest(got))
+	require.Len(t, ReqDigest(req), 32)
+
+	// 冒充其他节点签名
+	forged := SignRequest(req, 2, priv)
+	_, err = VerifyRequest(forged, pubKeys)
+	require.Equal(t, errReplicaMismatch, err)
+	forged = SignRequest(ToRequestPrepare(1, 1, []byte("digest"), 2), 2, priv)
+	_, err = VerifyRequest(forged, pubKeys)
+	require.Equal(t, errInvalidSignature, err)
+
+	// 篡改消息内容
+	msg.Request = ToRequestPrepare(1, 2, []byte("digest"), 1)
+	_, err = VerifyRequest(msg, pubKeys)
+	require.Equal(t, errInvalidSignature, err)
+
+	_, err = VerifyRequest(SignRequest(ToRequestCommit(1, 1, 3), 3, priv), pubKeys)
+	require.Equal(t, errUnknownReplica, err)
+	_, err = VerifyRequest(&ptypes.SignedRequest{Request: req, Replica: 1}, pubKeys)
+	require.Equal(t, errNoSignature, err)
+
+	rep := &Replica{rejected: make(map[string]uint64)}
+	rep.reject("127.0.0.1", err)
+	rep.reject("127.0.0.1", err)
+	require.Equal(t, uint64(2), rep.RejectedCount("127.0.0.1"))
+	require.Equal(t, uint64(0), rep.RejectedCount("127.0.0.2"))
+}
@@ -0,0 +1,119 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pbft
+
+import (
+	"fmt"
+
+	dbm "github.com/33cn/chain33/common/db"
+	pb "github.com/33cn/chain33/types"
+	ptypes "github.com/33cn/plugin/plugin/consensus/pbft/types"
+)
+
+var (
+	stateKey         = []byte("pbft-state")
+	preparedPrefix   = []byte("PC:")
+	executedPrefix   = []byte("EX:")
+	executedFlagByte = []byte{1}
+)
+
+// replicaStore pbft节点的持久化日志, 保存视图、水位、prepared证明和稳定检查点
+type replicaStore struct {
+	db dbm.DB
+}
+
+func newReplicaStore(db dbm.DB) *replicaStore {
+	return &replicaStore{db: db}
+}
+
+func calcPreparedKey(sequence uint32) []byte {
+	return []byte(fmt.Sprintf("%s%010d", preparedPrefix, sequence))
+}
+
+func calcExecutedKey(sequence uint32) []byte {
+	return []byte(fmt.Sprintf("%s%010d", executedPrefix, sequence))
+}
+
+func (s *replicaStore) loadState() *ptypes.ReplicaState {
+	buf, err := s.db.Get(stateKey)
+	if err != nil {
+		if err != dbm.ErrNotFoundInDb {
+			plog.Error("loadState", "err", err)
+		}
+		return nil
+	}
+	state := &ptypes.ReplicaState{}
+	if err = pb.Decode(buf, state); err != nil {
+		panic(err)
+	}
+	return state
+}
+
+func (s *replicaStore) saveState(state *ptypes.ReplicaState) error {
+	return s.db.SetSync(stateKey, pb.Encode(state))
+}
+
+func (s *replicaStore) savePrepared(cert *ptypes.PreparedCert) error {
+	return s.db.SetSync(calcPreparedKey(cert.Sequence), pb.Encode(cert))
+}
+
+// loadPreparedCert 返回序号为sequence的prepared证明, 不存在或已被清理时返回nil
+func (s *replicaStore) loadPreparedCert(sequence uint32) *ptypes.PreparedCert {
+	buf, err := s.db.Get(calcPreparedKey(sequence))
+	if err != nil {
+		return nil
+	}
+	cert := &ptypes.PreparedCert{}
+	if err = pb.Decode(buf, cert); err != nil {
+		plog.Error("loadPreparedCert", "sequence", sequence, "err", err)
+		return nil
+	}
+	return cert
+}
+
+// loadPrepared 返回序号大于low的prepared证明
+func (s *replicaStore) loadPrepared(low uint32) []*ptypes.PreparedCert {
+	var certs []*ptypes.PreparedCert
+	values := dbm.NewListHelper(s.db).PrefixScan(preparedPrefix)
+	for _, value := range values {
+		cert := &ptypes.PreparedCert{}
+		if err := pb.Decode(value, cert); err != nil {
+			panic(err)
+		}
+		if cert.Sequence > low {
+			certs = append(certs, cert)
+		}
+	}
+	return certs
+}
+
+func (s *replicaStore) setExecuted(sequence uint32) error {
+	return s.db.SetSync(calcExecutedKey(sequence), executedFlagByte)
+}
+
+func (s *replicaStore) isExecuted(sequence uint32) bool {
+	_, err := s.db.Get(calcExecutedKey(sequence))
+	return err == nil
+}
+
+// prune 稳定检查点之前的日志不再需要
+func (s *replicaStore) prune(stable uint32) error {
+	batch := s.db.NewBatch(true)
+	for _, prefix := range [][]byte{preparedPrefix, executedPrefix} {
+		it := s.db.Iterator(prefix, nil, false)
+		for it.Rewind(); it.Valid(); it.Next() {
+			var sequence uint32
+			if _, err := fmt.Sscanf(string(it.Key()[len(prefix):]), "%d", &sequence); err != nil {
+				continue
+			}
+			if sequence >= stable {
+				break
+			}
+			batch.Delete(it.Key())
+		}
+		it.Close()
+	}
+	return batch.Write()
+}
@@ -0,0 +1,156 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pbft
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	ptypes "github.com/33cn/plugin/plugin/consensus/pbft/types"
+	"github.com/stretchr/testify/require"
+)
+
+var replicaKeys = []string{
+	"CC38546E9E659D15E6B4893F0AB32A06D103931A8230B0BDE71459D2B27D6944",
+	"1b3464811222a70d8ffa15b26a5b122a79f005b1628db206dffd25bf720fb899",
+	"4eaac29629819c1b20985b8c3acccfac5749f946993ca4a791f6b87b11454008",
+	"0a1def3f2cf0a4a50a07a83e04dedff0f5cd8608415e90145f86493882b54d01",
+}
+
+type testReplica struct {
+	*Replica
+	db dbm.DB
+}
+
+func startTestReplica(t *testing.T, dir string, id uint32, addrs []string, privs []crypto.PrivKey, pubKeys map[uint32]crypto.PubKey) *testReplica {
+	db := dbm.NewDB("pbft", "leveldb", filepath.Join(dir, fmt.Sprint(id)), 0)
+	rep := newReplica(id, strings.Join(addrs, ","), privs[id-1], pubKeys, db)
+	rep.Startnode(addrs[id-1])
+	require.NotNil(t, rep.listener)
+	return &testReplica{Replica: rep, db: db}
+}
+
+func (r *testReplica) kill() {
+	r.Stop()
+	time.Sleep(200 * time.Millisecond)
+	r.db.Close()
+}
+
+func waitReply(t *testing.T, rep *testReplica, height int64) {
+	select {
+	case reply := <-rep.replyChan:
+		block := reply.Result.Value
+		require.Equal(t, height, block.Height)
+	case <-time.After(10 * time.Second):
+		t.Fatalf("replica %d wait reply of height %d timeout", rep.ID, height)
+	}
+}
+
+func noReply(t *testing.T, rep *testReplica) {
+	select {
+	case reply := <-rep.replyChan:
+		t.Fatalf("replica %d unexpected reply of height %d", rep.ID, reply.Result.Value.Height)
+	case <-time.After(time.Second):
+	}
+}
+
+func propose(primary *testReplica, height int64) {
+	op := &types.Operation{Value: &types.Block{Height: height}}
+	primary.requestChan <- ToRequestClient(op, fmt.Sprint(height), "client")
+}
+
+func TestReplicaRestart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pbft")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	var privs []crypto.PrivKey
+	pubKeys := make(map[uint32]crypto.PubKey)
+	var addrs []string
+	for i, key := range replicaKeys {
+		priv := getprivkey(key)
+		privs = append(privs, priv)
+		pubKeys[uint32(i+1)] = priv.PubKey()
+		addrs = append(addrs, fmt.Sprintf("127.0.0.1:%d", 18901+i))
+	}
+	reps := make([]*testReplica, len(replicaKeys))
+	for i := range reps {
+		reps[i] = startTestReplica(t, dir, uint32(i+1), addrs, privs, pubKeys)
+	}
+	defer func() {
+		for _, rep := range reps {
+			rep.kill()
+		}
+	}()
+	primary := reps[0]
+	require.True(t, primary.isPrimary(primary.ID))
+
+	propose(primary, 1)
+	for _, rep := range reps {
+		waitReply(t, rep, 1)
+	}
+
+	// 节点4宕机, 其余三个节点仍可达成共识
+	reps[3].kill()
+	propose(primary, 2)
+	for _, rep := range reps[:3] {
+		waitReply(t, rep, 2)
+	}
+
+	reps[3] = startTestReplica(t, dir, 4, addrs, privs, pubKeys)
+	restarted := reps[3]
+	require.Equal(t, uint32(1), restarted.view)
+	require.Equal(t, uint32(1), restarted.sequence)
+	require.Equal(t, uint32(1), restarted.lastExecuted())
+	require.True(t, restarted.isExecuted(1))
+	require.False(t, restarted.isExecuted(2))
+	// 重启前prepared的请求已从日志恢复
+	require.Len(t, restarted.requests["pre-prepare"], 1)
+	require.Len(t, restarted.requests["client"], 1)
+
+	// 重放序号1的commit不会导致重复执行
+	for i := 0; i < 3; i++ {
+		commit := ToRequestCommit(1, 1, uint32(i+1))
+		require.Nil(t, WriteMessage(addrs[3], SignRequest(commit, uint32(i+1), privs[i])))
+	}
+	noReply(t, restarted)
+
+	// 重启节点收到序号3的commit后, 先向其他节点补齐错过的序号2, 再按顺序执行
+	propose(primary, 3)
+	for _, rep := range reps[:3] {
+		waitReply(t, rep, 3)
+	}
+	waitReply(t, restarted, 2)
+	waitReply(t, restarted, 3)
+	require.Equal(t, uint32(3), restarted.sequence)
+	require.Equal(t, uint32(3), restarted.lastExecuted())
+	require.True(t, restarted.isExecuted(2))
+
+	state := restarted.store.loadState()
+	require.Equal(t, uint32(3), state.LastExecuted)
+	require.Equal(t, restarted.highWaterMark(), state.HighWaterMark)
+}
+
+func TestReplicaStorePrune(t *testing.T) {
+	store := newReplicaStore(dbm.NewDB("pbft", "memdb", "", 0))
+	for seq := uint32(1); seq <= 5; seq++ {
+		req := ToRequestPreprepare(1, seq, []byte("digest"), 1)
+		require.Nil(t, store.savePrepared(&ptypes.PreparedCert{View: 1, Sequence: seq, Preprepare: req}))
+		require.Nil(t, store.setExecuted(seq))
+	}
+	require.Len(t, store.loadPrepared(2), 3)
+	require.Nil(t, store.prune(4))
+	require.Len(t, store.loadPrepared(0), 2)
+	require.False(t, store.isExecuted(3))
+	require.True(t, store.isExecuted(4))
+}
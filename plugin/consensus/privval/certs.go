@@ -0,0 +1,107 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package privval
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"time"
+)
+
+// 生成的证书文件名
+const (
+	CAFile         = "ca.crt"
+	SignerCertFile = "signer.crt"
+	SignerKeyFile  = "signer.key"
+	NodeCertFile   = "node.crt"
+	NodeKeyFile    = "node.key"
+)
+
+const certValidity = 10 * 365 * 24 * time.Hour
+
+// GenerateCerts 在dir目录下生成ca以及签名服务和共识节点的证书,
+// 签名服务证书包含DefaultServerName和hosts中的域名或ip
+func GenerateCerts(dir string, hosts []string) error {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	caTmpl := certTemplate("remote-signer-ca")
+	caTmpl.IsCA = true
+	caTmpl.BasicConstraintsValid = true
+	caTmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return err
+	}
+	if err = writePEM(filepath.Join(dir, CAFile), "CERTIFICATE", caDER); err != nil {
+		return err
+	}
+
+	signerTmpl := certTemplate(DefaultServerName)
+	signerTmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	signerTmpl.DNSNames = []string{DefaultServerName}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			signerTmpl.IPAddresses = append(signerTmpl.IPAddresses, ip)
+		} else {
+			signerTmpl.DNSNames = append(signerTmpl.DNSNames, host)
+		}
+	}
+	if err = issueCert(dir, SignerCertFile, SignerKeyFile, signerTmpl, ca, caKey); err != nil {
+		return err
+	}
+
+	nodeTmpl := certTemplate("consensus-node")
+	nodeTmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	return issueCert(dir, NodeCertFile, NodeKeyFile, nodeTmpl, ca, caKey)
+}
+
+func certTemplate(cn string) *x509.Certificate {
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(certValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+}
+
+func issueCert(dir, certFile, keyFile string, tmpl, ca *x509.Certificate, caKey *ecdsa.PrivateKey) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err = writePEM(filepath.Join(dir, certFile), "CERTIFICATE", der); err != nil {
+		return err
+	}
+	return writePEM(filepath.Join(dir, keyFile), "EC PRIVATE KEY", keyDER)
+}
+
+func writePEM(file, typ string, der []byte) error {
+	return ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0600)
+}
@@ -0,0 +1,117 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package privval
+
+import (
+	"crypto/tls"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+)
+
+// Client 连接远程签名服务, 实现共识types包中的Signer接口
+type Client struct {
+	network string
+	addr    string
+	timeout time.Duration
+	tlsCfg  *tls.Config
+	cr      crypto.Crypto
+
+	mtx    sync.Mutex
+	client *rpc.Client
+}
+
+// NewClient 连接在第一次请求时建立, 断开后自动重连
+func NewClient(cfg *Config, cr crypto.Crypto) (*Client, error) {
+	network, addr, err := ParseAddr(cfg.SignerAddr)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg, err := LoadTLSConfig(cfg.SignerCAFile, cfg.SignerCertFile, cfg.SignerKeyFile, false)
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg.ServerName = cfg.SignerServerName
+	if tlsCfg.ServerName == "" {
+		tlsCfg.ServerName = DefaultServerName
+	}
+	timeout := cfg.SignerTimeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{
+		network: network,
+		addr:    addr,
+		timeout: time.Duration(timeout) * time.Millisecond,
+		tlsCfg:  tlsCfg,
+		cr:      cr,
+	}, nil
+}
+
+func (c *Client) connect() (*rpc.Client, error) {
+	if c.client != nil {
+		return c.client, nil
+	}
+	dialer := &net.Dialer{Timeout: c.timeout}
+	conn, err := tls.DialWithDialer(dialer, c.network, c.addr, c.tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	c.client = rpc.NewClientWithCodec(jsonrpc.NewClientCodec(conn))
+	return c.client, nil
+}
+
+// call 签名服务返回的错误保持连接, 网络错误和超时断开连接等待重连
+func (c *Client) call(method string, args interface{}, reply interface{}) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	client, err := c.connect()
+	if err != nil {
+		return err
+	}
+	select {
+	case call := <-client.Go(method, args, reply, make(chan *rpc.Call, 1)).Done:
+		err = call.Error
+	case <-time.After(c.timeout):
+		err = ErrSignTimeout
+	}
+	if _, ok := err.(rpc.ServerError); err != nil && !ok {
+		client.Close()
+		c.client = nil
+	}
+	return err
+}
+
+// GetPubKey 查询签名服务的验证者公钥
+func (c *Client) GetPubKey() (crypto.PubKey, error) {
+	var reply ReplyPubKey
+	if err := c.call("Signer.PubKey", &ReqPubKey{}, &reply); err != nil {
+		return nil, err
+	}
+	return c.cr.PubKeyFromBytes(reply.PubKey)
+}
+
+// Sign implements Signer
+func (c *Client) Sign(msg []byte) (crypto.Signature, error) {
+	var reply ReplySign
+	if err := c.call("Signer.Sign", &ReqSign{SignBytes: msg}, &reply); err != nil {
+		return nil, err
+	}
+	return c.cr.SignatureFromBytes(reply.Signature)
+}
+
+// Close 断开与签名服务的连接
+func (c *Client) Close() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if c.client != nil {
+		c.client.Close()
+		c.client = nil
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// remote-signer 是qbft和tendermint验证者的参考签名服务,
+// 私钥只保存在本进程, 共识节点通过双向认证的tls连接请求签名
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/plugin/plugin/consensus/privval"
+	tml "github.com/BurntSushi/toml"
+
+	_ "github.com/33cn/chain33/system/crypto/init"
+	_ "github.com/33cn/plugin/plugin/crypto/init"
+)
+
+var (
+	log        = log15.New("module", "remote-signer")
+	configPath = flag.String("f", "remote-signer.toml", "config file")
+	genCert    = flag.String("gencert", "", "generate ca, signer and node certificates into the directory and exit")
+	hosts      = flag.String("hosts", "", "comma separated ip or domain names of the signer certificate")
+)
+
+// Config 签名服务配置
+type Config struct {
+	ListenAddr string `toml:"listenAddr"`
+	ChainID    string `toml:"chainID"`
+	PrivFile   string `toml:"privFile"`
+	StateFile  string `toml:"stateFile"`
+	CAFile     string `toml:"caFile"`
+	CertFile   string `toml:"certFile"`
+	KeyFile    string `toml:"keyFile"`
+}
+
+func main() {
+	flag.Parse()
+	if *genCert != "" {
+		var names []string
+		if *hosts != "" {
+			names = strings.Split(*hosts, ",")
+		}
+		if err := os.MkdirAll(*genCert, 0700); err != nil {
+			exit(err)
+		}
+		if err := privval.GenerateCerts(*genCert, names); err != nil {
+			exit(err)
+		}
+		fmt.Println("certificates generated in", *genCert)
+		return
+	}
+
+	var cfg Config
+	if _, err := tml.DecodeFile(*configPath, &cfg); err != nil {
+		exit(err)
+	}
+	privKey, err := privval.LoadPrivKey(cfg.PrivFile)
+	if err != nil {
+		exit(fmt.Errorf("load priv key: %v", err))
+	}
+	state, err := privval.LoadSignState(cfg.StateFile)
+	if err != nil {
+		exit(fmt.Errorf("load sign state: %v", err))
+	}
+	tlsCfg, err := privval.LoadTLSConfig(cfg.CAFile, cfg.CertFile, cfg.KeyFile, true)
+	if err != nil {
+		exit(err)
+	}
+	network, addr, err := privval.ParseAddr(cfg.ListenAddr)
+	if err != nil {
+		exit(err)
+	}
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		exit(err)
+	}
+	server := privval.NewServer(cfg.ChainID, privKey, state, tlsCfg)
+	log.Info("remote signer start", "listen", cfg.ListenAddr, "chainID", cfg.ChainID,
+		"pubkey", fmt.Sprintf("%X", privKey.PubKey().Bytes()), "height", state.Height, "round", state.Round, "step", state.Step)
+
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+		server.Close()
+	}()
+	err = server.Serve(listener)
+	log.Info("remote signer stop", "err", err)
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -0,0 +1,91 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package privval 实现qbft和tendermint验证者的远程签名,
+// 私钥只保存在独立的签名进程中, 共识节点通过双向认证的tls连接请求签名
+package privval
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	// DefaultServerName 签名服务证书的默认名称
+	DefaultServerName = "remote-signer"
+	// DefaultTimeout 默认的签名超时时间, 单位毫秒
+	DefaultTimeout = 3000
+)
+
+// error defines
+var (
+	ErrInvalidAddr     = errors.New("ErrInvalidSignerAddr")
+	ErrInvalidCA       = errors.New("ErrInvalidSignerCA")
+	ErrSignTimeout     = errors.New("ErrSignTimeout")
+	ErrUnknownSignMsg  = errors.New("ErrUnknownSignMsg")
+	ErrChainIDMismatch = errors.New("ErrChainIDMismatch")
+	ErrHeightRegress   = errors.New("ErrHeightRegression")
+	ErrRoundRegress    = errors.New("ErrRoundRegression")
+	ErrStepRegress     = errors.New("ErrStepRegression")
+	ErrConflictingData = errors.New("ErrConflictingData")
+)
+
+// Config 共识节点连接远程签名服务的配置
+type Config struct {
+	// 签名服务地址, tcp://host:port 或 unix:///path/to/socket, 为空时使用本地私钥文件
+	SignerAddr string `json:"signerAddr"`
+	// 签发双方证书的ca证书
+	SignerCAFile string `json:"signerCAFile"`
+	// 共识节点的证书和私钥
+	SignerCertFile string `json:"signerCertFile"`
+	SignerKeyFile  string `json:"signerKeyFile"`
+	// 签名服务证书中的名称, 默认为remote-signer
+	SignerServerName string `json:"signerServerName"`
+	// 单次签名请求的超时时间, 单位毫秒
+	SignerTimeout int32 `json:"signerTimeout"`
+}
+
+// ParseAddr 解析签名服务地址, 返回network和address
+func ParseAddr(addr string) (string, string, error) {
+	switch {
+	case strings.HasPrefix(addr, "unix://"):
+		return "unix", strings.TrimPrefix(addr, "unix://"), nil
+	case strings.HasPrefix(addr, "tcp://"):
+		return "tcp", strings.TrimPrefix(addr, "tcp://"), nil
+	case addr == "" || strings.Contains(addr, "://"):
+		return "", "", ErrInvalidAddr
+	}
+	return "tcp", addr, nil
+}
+
+// LoadTLSConfig 加载双向认证的tls配置, 对端证书必须由同一个ca签发
+func LoadTLSConfig(caFile, certFile, keyFile string, server bool) (*tls.Config, error) {
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, ErrInvalidCA
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load key pair %s: %v", certFile, err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if server {
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	} else {
+		cfg.RootCAs = pool
+	}
+	return cfg, nil
+}
@@ -0,0 +1,55 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package privval
+
+import (
+	"net"
+	"path/filepath"
+
+	"github.com/33cn/chain33/common/crypto"
+)
+
+// Harness 在进程内通过unix socket启动签名服务, 供共识测试使用
+type Harness struct {
+	Server *Server
+	// 共识节点连接签名服务的配置
+	Config *Config
+}
+
+// NewHarness 在dir目录下生成证书和签名状态文件并启动签名服务
+func NewHarness(dir, chainID string, privKey crypto.PrivKey) (*Harness, error) {
+	if err := GenerateCerts(dir, nil); err != nil {
+		return nil, err
+	}
+	tlsCfg, err := LoadTLSConfig(filepath.Join(dir, CAFile), filepath.Join(dir, SignerCertFile), filepath.Join(dir, SignerKeyFile), true)
+	if err != nil {
+		return nil, err
+	}
+	state, err := LoadSignState(filepath.Join(dir, "sign_state.json"))
+	if err != nil {
+		return nil, err
+	}
+	sock := filepath.Join(dir, "signer.sock")
+	listener, err := net.Listen("unix", sock)
+	if err != nil {
+		return nil, err
+	}
+	server := NewServer(chainID, privKey, state, tlsCfg)
+	go server.Serve(listener)
+	return &Harness{
+		Server: server,
+		Config: &Config{
+			SignerAddr:     "unix://" + sock,
+			SignerCAFile:   filepath.Join(dir, CAFile),
+			SignerCertFile: filepath.Join(dir, NodeCertFile),
+			SignerKeyFile:  filepath.Join(dir, NodeKeyFile),
+		},
+	}, nil
+}
+
+// Close 停止签名服务
+func (h *Harness) Close() error {
+	return h.Server.Close()
+}
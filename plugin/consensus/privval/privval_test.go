@@ -0,0 +1,130 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package privval_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/plugin/plugin/consensus/privval"
+	ttypes "github.com/33cn/plugin/plugin/consensus/qbft/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
+	"github.com/stretchr/testify/require"
+
+	_ "github.com/33cn/chain33/system/crypto/ed25519"
+)
+
+const testChainID = "chain33-privval"
+
+func newVote(height int64, round int32, typ byte, hash []byte, timestamp int64) *ttypes.Vote {
+	return &ttypes.Vote{QbftVote: &tmtypes.QbftVote{
+		Height:    height,
+		Round:     round,
+		Type:      uint32(typ),
+		Timestamp: timestamp,
+		BlockID:   &tmtypes.QbftBlockID{Hash: hash},
+	}}
+}
+
+func newHarness(t *testing.T, dir string, priv crypto.PrivKey) (*privval.Harness, *privval.Client) {
+	harness, err := privval.NewHarness(dir, testChainID, priv)
+	require.Nil(t, err)
+	cr, err := crypto.Load("ed25519", -1)
+	require.Nil(t, err)
+	client, err := privval.NewClient(harness.Config, cr)
+	require.Nil(t, err)
+	return harness, client
+}
+
+func TestRemoteSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "privval")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	cr, err := crypto.Load("ed25519", -1)
+	require.Nil(t, err)
+	priv, err := cr.GenKey()
+	require.Nil(t, err)
+
+	harness, client := newHarness(t, dir, priv)
+	pub, err := client.GetPubKey()
+	require.Nil(t, err)
+	require.Equal(t, priv.PubKey().Bytes(), pub.Bytes())
+	pv := ttypes.NewPrivValidatorWithSigner(pub, client)
+
+	vote := newVote(10, 0, ttypes.VoteTypePrevote, []byte("blockA"), 1)
+	require.Nil(t, pv.SignVote(testChainID, vote))
+	sig, err := cr.SignatureFromBytes(vote.Signature)
+	require.Nil(t, err)
+	require.True(t, pub.VerifyBytes(ttypes.SignBytes(testChainID, vote), sig))
+	// 重复请求和只有时间戳不同的投票可以签名, 其他区块的投票被拒绝
+	require.Nil(t, pv.SignVote(testChainID, newVote(10, 0, ttypes.VoteTypePrevote, []byte("blockA"), 1)))
+	require.Nil(t, pv.SignVote(testChainID, newVote(10, 0, ttypes.VoteTypePrevote, []byte("blockA"), 2)))
+	require.NotNil(t, pv.SignVote(testChainID, newVote(10, 0, ttypes.VoteTypePrevote, []byte("blockB"), 2)))
+
+	require.Nil(t, pv.SignVote(testChainID, newVote(10, 0, ttypes.VoteTypePrecommit, []byte("blockA"), 3)))
+	require.NotNil(t, pv.SignVote(testChainID, newVote(10, 0, ttypes.VoteTypePrevote, []byte("blockA"), 4)))
+	require.NotNil(t, pv.SignVote("chain33-other", newVote(10, 1, ttypes.VoteTypePrevote, []byte("blockA"), 4)))
+	_, err = client.Sign([]byte("transfer all coins"))
+	require.Equal(t, privval.ErrUnknownSignMsg.Error(), err.Error())
+
+	proposal := ttypes.NewProposal(11, 0, []byte("block"), -1, tmtypes.QbftBlockID{}, 0)
+	require.Nil(t, pv.SignProposal(testChainID, proposal))
+	proposal = ttypes.NewProposal(11, 0, []byte("block"), 0, tmtypes.QbftBlockID{}, 0)
+	require.NotNil(t, pv.SignProposal(testChainID, proposal))
+	require.Nil(t, pv.SignHeartbeat(testChainID, &ttypes.Heartbeat{QbftHeartbeat: &tmtypes.QbftHeartbeat{Height: 1}}))
+
+	// 签名服务重启后仍然拒绝回退的签名
+	client.Close()
+	require.Nil(t, harness.Close())
+	harness, client = newHarness(t, dir, priv)
+	defer harness.Close()
+	defer client.Close()
+	pv = ttypes.NewPrivValidatorWithSigner(pub, client)
+	require.NotNil(t, pv.SignVote(testChainID, newVote(10, 1, ttypes.VoteTypePrevote, []byte("blockB"), 5)))
+	require.Nil(t, pv.SignVote(testChainID, newVote(11, 0, ttypes.VoteTypePrevote, []byte("blockB"), 5)))
+}
+
+func TestSignerAuth(t *testing.T) {
+	dir, err := ioutil.TempDir("", "privval")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	cr, err := crypto.Load("ed25519", -1)
+	require.Nil(t, err)
+	priv, err := cr.GenKey()
+	require.Nil(t, err)
+	harness, err := privval.NewHarness(dir, testChainID, priv)
+	require.Nil(t, err)
+	defer harness.Close()
+
+	// 其他ca签发的节点证书不能连接签名服务
+	other := filepath.Join(dir, "other")
+	require.Nil(t, os.Mkdir(other, 0700))
+	require.Nil(t, privval.GenerateCerts(other, nil))
+	cfg := *harness.Config
+	cfg.SignerCertFile = filepath.Join(other, privval.NodeCertFile)
+	cfg.SignerKeyFile = filepath.Join(other, privval.NodeKeyFile)
+	client, err := privval.NewClient(&cfg, cr)
+	require.Nil(t, err)
+	_, err = client.GetPubKey()
+	require.NotNil(t, err)
+
+	// 节点也不信任其他ca签发的签名服务证书
+	cfg = *harness.Config
+	cfg.SignerCAFile = filepath.Join(other, privval.CAFile)
+	client, err = privval.NewClient(&cfg, cr)
+	require.Nil(t, err)
+	_, err = client.GetPubKey()
+	require.NotNil(t, err)
+
+	network, addr, err := privval.ParseAddr("127.0.0.1:8805")
+	require.Nil(t, err)
+	require.Equal(t, "tcp", network)
+	require.Equal(t, "127.0.0.1:8805", addr)
+	_, _, err = privval.ParseAddr("http://127.0.0.1:8805")
+	require.Equal(t, privval.ErrInvalidAddr, err)
+}
@@ -0,0 +1,200 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package privval
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/common/log/log15"
+)
+
+var plog = log15.New("module", "privval")
+
+const handshakeTimeout = 10 * time.Second
+
+// ReqPubKey 查询签名服务的公钥
+type ReqPubKey struct{}
+
+// ReplyPubKey 签名服务的公钥
+type ReplyPubKey struct {
+	PubKey []byte
+}
+
+// ReqSign 签名请求, SignBytes是投票、提案或心跳的canonical json
+type ReqSign struct {
+	SignBytes []byte
+}
+
+// ReplySign 签名结果
+type ReplySign struct {
+	Signature []byte
+}
+
+// keyText 与priv_validator.json的格式兼容
+type keyText struct {
+	Kind string `json:"type"`
+	Data string `json:"data"`
+}
+
+type keyFile struct {
+	Address string  `json:"address"`
+	PubKey  keyText `json:"pub_key"`
+	PrivKey keyText `json:"priv_key"`
+}
+
+// LoadPrivKey 从priv_validator.json格式的文件加载验证者私钥
+func LoadPrivKey(filePath string) (crypto.PrivKey, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var key keyFile
+	if err = json.Unmarshal(data, &key); err != nil {
+		return nil, err
+	}
+	if key.PrivKey.Kind == "" || key.PrivKey.Data == "" {
+		return nil, errors.New("ErrEmptyPrivKey")
+	}
+	cr, err := crypto.Load(key.PrivKey.Kind, -1)
+	if err != nil {
+		return nil, err
+	}
+	privBytes, err := hex.DecodeString(key.PrivKey.Data)
+	if err != nil {
+		return nil, err
+	}
+	return cr.PrivKeyFromBytes(privBytes)
+}
+
+// Server 远程签名服务, 只对高度、轮次和step不回退的共识消息签名
+type Server struct {
+	chainID string
+	privKey crypto.PrivKey
+	tlsCfg  *tls.Config
+	rpc     *rpc.Server
+
+	mtx      sync.Mutex
+	state    *SignState
+	listener net.Listener
+}
+
+// NewServer chainID为空时不检查签名内容中的chain_id
+func NewServer(chainID string, privKey crypto.PrivKey, state *SignState, tlsCfg *tls.Config) *Server {
+	s := &Server{
+		chainID: chainID,
+		privKey: privKey,
+		tlsCfg:  tlsCfg,
+		rpc:     rpc.NewServer(),
+		state:   state,
+	}
+	err := s.rpc.RegisterName("Signer", &signerService{s})
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Serve 在listener上接受共识节点的连接, 直到Close
+func (s *Server) Serve(listener net.Listener) error {
+	s.mtx.Lock()
+	s.listener = listener
+	s.mtx.Unlock()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	tlsConn := tls.Server(conn, s.tlsCfg)
+	tlsConn.SetDeadline(time.Now().Add(handshakeTimeout))
+	if err := tlsConn.Handshake(); err != nil {
+		plog.Error("serveConn handshake", "remote", conn.RemoteAddr(), "err", err)
+		conn.Close()
+		return
+	}
+	tlsConn.SetDeadline(time.Time{})
+	plog.Info("serveConn accept", "remote", conn.RemoteAddr(),
+		"cn", tlsConn.ConnectionState().PeerCertificates[0].Subject.CommonName)
+	s.rpc.ServeCodec(jsonrpc.NewServerCodec(tlsConn))
+}
+
+// Close 停止接受新连接
+func (s *Server) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Close()
+}
+
+// Sign 对共识消息签名, 签名前先持久化签名状态
+func (s *Server) Sign(signBytes []byte) ([]byte, error) {
+	msg, err := parseSignMsg(signBytes)
+	if err != nil {
+		return nil, err
+	}
+	if s.chainID != "" && msg.ChainID != s.chainID {
+		return nil, ErrChainIDMismatch
+	}
+	height, round, step, err := msg.hrs()
+	if err != nil {
+		return nil, err
+	}
+	if step == stepNone {
+		return s.privKey.Sign(signBytes).Bytes(), nil
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	last, err := s.state.check(height, round, step, msg, signBytes)
+	if err != nil {
+		plog.Error("Sign refused", "height", height, "round", round, "step", step, "err", err)
+		return nil, err
+	}
+	if last != nil {
+		return last, nil
+	}
+	sig := s.privKey.Sign(signBytes).Bytes()
+	if err = s.state.save(height, round, step, signBytes, sig); err != nil {
+		plog.Error("Sign save state", "err", err)
+		return nil, err
+	}
+	return sig, nil
+}
+
+type signerService struct {
+	s *Server
+}
+
+// PubKey rpc方法, 返回验证者公钥
+func (service *signerService) PubKey(req *ReqPubKey, reply *ReplyPubKey) error {
+	reply.PubKey = service.s.privKey.PubKey().Bytes()
+	return nil
+}
+
+// Sign rpc方法, 对共识消息签名
+func (service *signerService) Sign(req *ReqSign, reply *ReplySign) error {
+	sig, err := service.s.Sign(req.SignBytes)
+	if err != nil {
+		return err
+	}
+	reply.Signature = sig
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package privval
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// 与共识中的step一致
+const (
+	stepNone      = 0
+	stepPropose   = 1
+	stepPrevote   = 2
+	stepPrecommit = 3
+
+	voteTypePrevote   = 0x01
+	voteTypePrecommit = 0x02
+)
+
+// signMsg 共识签名内容的canonical json, 只解析签名服务需要检查的字段
+type signMsg struct {
+	ChainID string `json:"chain_id"`
+	Vote    *struct {
+		BlockID json.RawMessage `json:"block_id"`
+		Height  int64           `json:"height"`
+		Round   int             `json:"round"`
+		Type    byte            `json:"type"`
+	} `json:"vote"`
+	Proposal *struct {
+		Height int64 `json:"height"`
+		Round  int   `json:"round"`
+	} `json:"proposal"`
+	Heartbeat *struct {
+		Height int64 `json:"height"`
+		Round  int   `json:"round"`
+	} `json:"heartbeat"`
+}
+
+// hrs 解析签名内容的高度、轮次和step, 心跳不需要防止重复签名
+func (msg *signMsg) hrs() (height int64, round int, step int8, err error) {
+	switch {
+	case msg.Vote != nil && msg.Proposal == nil && msg.Heartbeat == nil:
+		switch msg.Vote.Type {
+		case voteTypePrevote:
+			step = stepPrevote
+		case voteTypePrecommit:
+			step = stepPrecommit
+		default:
+			return 0, 0, 0, ErrUnknownSignMsg
+		}
+		return msg.Vote.Height, msg.Vote.Round, step, nil
+	case msg.Proposal != nil && msg.Vote == nil && msg.Heartbeat == nil:
+		return msg.Proposal.Height, msg.Proposal.Round, stepPropose, nil
+	case msg.Heartbeat != nil && msg.Vote == nil && msg.Proposal == nil:
+		return msg.Heartbeat.Height, msg.Heartbeat.Round, stepNone, nil
+	}
+	return 0, 0, 0, ErrUnknownSignMsg
+}
+
+func parseSignMsg(signBytes []byte) (*signMsg, error) {
+	msg := &signMsg{}
+	if err := json.Unmarshal(signBytes, msg); err != nil {
+		return nil, ErrUnknownSignMsg
+	}
+	return msg, nil
+}
+
+// SignState 签名服务最后一次签名的高度、轮次和step, 每次签名前持久化
+type SignState struct {
+	Height    int64  `json:"height"`
+	Round     int    `json:"round"`
+	Step      int8   `json:"step"`
+	SignBytes []byte `json:"signbytes,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+
+	filePath string
+}
+
+// LoadSignState 加载签名状态, 文件不存在时从零开始
+func LoadSignState(filePath string) (*SignState, error) {
+	state := &SignState{filePath: filePath}
+	data, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// check 检查是否可以签名, 返回相同高度轮次和step下之前的签名
+func (state *SignState) check(height int64, round int, step int8, msg *signMsg, signBytes []byte) ([]byte, error) {
+	if state.Height > height {
+		return nil, ErrHeightRegress
+	}
+	if state.Height < height {
+		return nil, nil
+	}
+	if state.Round > round {
+		return nil, ErrRoundRegress
+	}
+	if state.Round < round {
+		return nil, nil
+	}
+	if state.Step > step {
+		return nil, ErrStepRegress
+	}
+	if state.Step < step {
+		return nil, nil
+	}
+	if bytes.Equal(state.SignBytes, signBytes) {
+		return state.Signature, nil
+	}
+	// 同一个区块的投票只有时间戳不同, 不构成作恶
+	if msg.Vote != nil {
+		last, err := parseSignMsg(state.SignBytes)
+		if err == nil && last.Vote != nil && bytes.Equal(last.Vote.BlockID, msg.Vote.BlockID) {
+			return nil, nil
+		}
+	}
+	return nil, ErrConflictingData
+}
+
+// save 先写临时文件再改名, 保证签名状态不会写坏
+func (state *SignState) save(height int64, round int, step int8, signBytes, signature []byte) error {
+	next := *state
+	next.Height, next.Round, next.Step = height, round, step
+	next.SignBytes, next.Signature = signBytes, signature
+	if state.filePath != "" {
+		data, err := json.Marshal(&next)
+		if err != nil {
+			return err
+		}
+		tmp, err := ioutil.TempFile(filepath.Dir(state.filePath), filepath.Base(state.filePath)+".tmp")
+		if err != nil {
+			return err
+		}
+		_, err = tmp.Write(data)
+		if err == nil {
+			err = tmp.Sync()
+		}
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err == nil {
+			err = os.Rename(tmp.Name(), state.filePath)
+		}
+		if err != nil {
+			os.Remove(tmp.Name())
+			return err
+		}
+	}
+	*state = next
+	return nil
+}
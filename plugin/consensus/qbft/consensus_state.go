@@ -33,6 +33,9 @@ var (
 	ErrAddingVote               = errors.New("Error adding vote")
 	ErrAddingAggVote            = errors.New("Error adding aggregate vote")
 	ErrVoteHeightMismatch       = errors.New("Error vote height mismatch")
+	ErrEvidenceHeight           = errors.New("Error evidence height")
+	ErrEvidenceExpired          = errors.New("Error evidence expired")
+	ErrEvidenceNotValidator     = errors.New("Error evidence not from validator")
 )
 
 //-----------------------------------------------------------------------------
@@ -94,6 +97,9 @@ type ConsensusState struct {
 	txsAvailable      chan int64
 	begCons           time.Time
 	ProposalBlockHash []byte
+
+	// 作恶证据, 由提议者打包到区块
+	evpool *EvidencePool
 }
 
 // NewConsensusState returns a new ConsensusState.
@@ -108,6 +114,7 @@ func NewConsensusState(client *Client, state State, blockExec *BlockExecutor) *C
 		quit:         make(chan struct{}),
 		txsAvailable: make(chan int64, 1),
 		begCons:      time.Time{},
+		evpool:       NewEvidencePool(),
 	}
 	atomic.CompareAndSwapUint32(&cs.status, 0, 0)
 	// set function defaults (may be overwritten before calling Start)
@@ -506,6 +513,11 @@ func (cs *ConsensusState) handleMsg(mi MsgInfo) {
 		// We could make note of this and help filter in broadcastHasVoteMessage().
 	case *tmtypes.QbftAggVote:
 		err = cs.tryAddAggVote(msg, peerID)
+	case *tmtypes.QbftEvidence:
+		err = cs.addEvidence(msg)
+		if err != nil {
+			qbftlog.Debug("handleMsg evidence failed", "peerip", peerIP, "err", err)
+		}
 	default:
 		qbftlog.Error("Unknown msg type", msg.String(), "peerid", peerID, "peerip", peerIP)
 	}
@@ -846,6 +858,7 @@ func (cs *ConsensusState) createProposalBlock() (block *ttypes.QbftBlock) {
 		qbftlog.Error("pblock.Height is not equal to cs.Height")
 		return nil
 	}
+	pblock.Txs = append(pblock.Txs, cs.createEvidenceTxs()...)
 
 	proposerAddr := cs.privValidator.GetAddress()
 	block = cs.state.MakeBlock(cs.Height, int64(cs.Round), pblock, commit, proposerAddr)
@@ -1268,6 +1281,7 @@ func (cs *ConsensusState) finalizeCommit(height int64) {
 	}
 	reqblock := cs.client.WaitBlock(height)
 	stateCopy.LastResultsHash = reqblock.Hash(cs.client.GetAPI().GetConfig())
+	cs.evpool.Update(reqblock)
 
 	//check whether need update validator nodes
 	qbftNodes, err := cs.client.QueryValidatorsByHeight(block.Header.Height)
@@ -1324,9 +1338,12 @@ func (cs *ConsensusState) defaultSetProposal(proposal *tmtypes.QbftProposal) err
 	qbftlog.Debug(fmt.Sprintf("Consensus receive proposal. Current: %v/%v/%v", cs.Height, cs.Round, cs.Step),
 		"proposal", fmt.Sprintf("%v/%v", proposal.Height, proposal.Round))
 	// Already have one
-	// TODO: possibly catch double proposals
 	if cs.Proposal != nil {
 		qbftlog.Debug("defaultSetProposal: already has proposal")
+		if proposal.Height == cs.Proposal.Height && proposal.Round == cs.Proposal.Round &&
+			!bytes.Equal(proposal.Signature, cs.Proposal.Signature) {
+			cs.addProposalEvidence(proposal)
+		}
 		return nil
 	}
 
@@ -1596,12 +1613,13 @@ func (cs *ConsensusState) tryAddVote(voteRaw *tmtypes.QbftVote, peerID string, p
 		// If it's otherwise invalid, punish peer.
 		if err == ErrVoteHeightMismatch {
 			return err
-		} else if err == ttypes.ErrVoteConflict {
+		} else if conflict, ok := err.(*ttypes.ErrVoteConflictingVotes); ok {
 			if bytes.Equal(vote.ValidatorAddress, cs.privValidator.GetAddress()) {
 				qbftlog.Error("Found conflicting vote from ourselves. Did you unsafe_reset a validator?",
 					"height", vote.Height, "round", vote.Round, "type", vote.Type)
 				return err
 			}
+			cs.addVoteEvidence(conflict)
 		} else {
 			// Probably an invalid signature / Bad peer.
 			// Seems this can also err sometimes with "Unexpected step" - perhaps not from a bad peer ?
@@ -1614,6 +1632,98 @@ func (cs *ConsensusState) tryAddVote(voteRaw *tmtypes.QbftVote, peerID string, p
 
 //-----------------------------------------------------------------------------
 
+// addVoteEvidence 把冲突投票作为证据加入证据池
+func (cs *ConsensusState) addVoteEvidence(conflict *ttypes.ErrVoteConflictingVotes) {
+	valSet := cs.Validators
+	if conflict.VoteA.Height+1 == cs.Height {
+		valSet = cs.LastValidators
+	}
+	_, val := valSet.GetByAddress(conflict.VoteA.ValidatorAddress)
+	if val == nil {
+		return
+	}
+	ev := ttypes.NewVoteEvidence(val.PubKey, conflict.VoteA, conflict.VoteB)
+	if err := cs.addEvidence(ev); err != nil {
+		qbftlog.Error("addVoteEvidence fail", "err", err)
+	}
+}
+
+// addProposalEvidence 提议者在同一高度和轮次签名了不同的提案
+func (cs *ConsensusState) addProposalEvidence(proposal *tmtypes.QbftProposal) {
+	ev := ttypes.NewProposalEvidence(cs.Validators.GetProposer().PubKey, cs.Proposal, proposal)
+	err := cs.addEvidence(ev)
+	if err != nil && err != ttypes.ErrEvidenceNotDouble {
+		qbftlog.Error("addProposalEvidence fail", "err", err)
+	}
+}
+
+// addEvidence 验证证据并加入证据池, 新的证据广播给其他节点
+func (cs *ConsensusState) addEvidence(ev *tmtypes.QbftEvidence) error {
+	height, _, _ := ttypes.EvidenceHRK(ev)
+	if height <= 0 || height > cs.Height {
+		return ErrEvidenceHeight
+	}
+	maxAge := cs.state.ConsensusParams.EvidenceParams.MaxAge
+	if maxAge > 0 && cs.Height-height > maxAge {
+		return ErrEvidenceExpired
+	}
+	addr, err := ttypes.VerifyEvidence(cs.state.ChainID, ttypes.ConsensusCrypto, ev)
+	if err != nil {
+		return err
+	}
+	valSet := cs.evidenceValidators(height)
+	if valSet == nil {
+		return ErrEvidenceHeight
+	}
+	if _, val := valSet.GetByAddress(addr); val == nil || val.VotingPower <= 0 {
+		return ErrEvidenceNotValidator
+	}
+	if !cs.evpool.Add(ev) {
+		return nil
+	}
+	qbftlog.Info("Add evidence", "evidence", ttypes.EvidenceKey(ev), "pool-size", cs.evpool.Size())
+	cs.broadcastChannel <- MsgInfo{TypeID: ttypes.EvidenceID, Msg: ev, PeerID: "", PeerIP: ""}
+	return nil
+}
+
+// evidenceValidators 返回证据高度的验证者集合
+func (cs *ConsensusState) evidenceValidators(height int64) *ttypes.ValidatorSet {
+	switch height {
+	case cs.Height:
+		return cs.Validators
+	case cs.Height - 1:
+		return cs.LastValidators
+	}
+	state := cs.client.LoadBlockState(height)
+	if state == nil {
+		return nil
+	}
+	return LoadState(state).Validators
+}
+
+// createEvidenceTxs 把还没有处罚的证据打包成交易, 证据交易分叉之前不打包
+func (cs *ConsensusState) createEvidenceTxs() []*types.Transaction {
+	cfg := cs.client.GetQueueClient().GetConfig()
+	if !cfg.IsDappFork(cs.Height, tmtypes.QbftNodeX, tmtypes.ForkQbftEvidence) {
+		return nil
+	}
+	var txs []*types.Transaction
+	maxAge := cs.state.ConsensusParams.EvidenceParams.MaxAge
+	for _, ev := range cs.evpool.Pending(cs.Height, maxAge, maxEvidencePerBlock) {
+		_, err := cs.client.QueryEvidence(ev)
+		if err == nil {
+			cs.evpool.Remove(ev)
+			continue
+		}
+		if err != types.ErrNotFound {
+			qbftlog.Error("createEvidenceTxs QueryEvidence fail", "err", err)
+			continue
+		}
+		txs = append(txs, CreateEvidenceTx(cs.client.privKey, ev))
+	}
+	return txs
+}
+
 func (cs *ConsensusState) addVote(vote *ttypes.Vote, peerID string, peerIP string) (added bool, err error) {
 	qbftlog.Debug(fmt.Sprintf("Consensus receive vote. Current: %v/%v/%v", cs.Height, cs.Round, cs.Step),
 		"vote", fmt.Sprintf("{%v:%X %v/%02d/%v}", vote.ValidatorIndex, ttypes.Fingerprint(vote.ValidatorAddress),
@@ -0,0 +1,106 @@
+package qbft
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/qbft/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
+	"github.com/stretchr/testify/require"
+)
+
+const evidenceChainID = "chain33-evidence"
+
+func signVote(t *testing.T, priv crypto.PrivKey, height int64, round int32, hash []byte) *ttypes.Vote {
+	vote := &ttypes.Vote{QbftVote: &tmtypes.QbftVote{
+		ValidatorAddress: ttypes.GenAddressByPubKey(priv.PubKey()),
+		Height:           height,
+		Round:            round,
+		Type:             uint32(ttypes.VoteTypePrevote),
+		BlockID:          &tmtypes.QbftBlockID{Hash: hash},
+	}}
+	vote.Signature = priv.Sign(ttypes.SignBytes(evidenceChainID, vote)).Bytes()
+	return vote
+}
+
+func signProposal(t *testing.T, priv crypto.PrivKey, height int64, round, polRound int32) *tmtypes.QbftProposal {
+	proposal := ttypes.NewProposal(height, int(round), []byte("block"), int(polRound), tmtypes.QbftBlockID{}, 0)
+	proposal.Signature = priv.Sign(ttypes.SignBytes(evidenceChainID, proposal)).Bytes()
+	return &proposal.QbftProposal
+}
+
+func TestVerifyEvidence(t *testing.T) {
+	cr, err := ttypes.LoadCrypto("ed25519")
+	require.Nil(t, err)
+	priv, err := cr.GenKey()
+	require.Nil(t, err)
+	other, err := cr.GenKey()
+	require.Nil(t, err)
+	pubKey := priv.PubKey().Bytes()
+
+	voteA := signVote(t, priv, 10, 1, []byte("blockA"))
+	voteB := signVote(t, priv, 10, 1, []byte("blockB"))
+	ev := ttypes.NewVoteEvidence(pubKey, voteB, voteA)
+	addr, err := ttypes.VerifyEvidence(evidenceChainID, cr, ev)
+	require.Nil(t, err)
+	require.Equal(t, ttypes.GenAddressByPubKey(priv.PubKey()), addr)
+	require.Equal(t, ev, ttypes.NewVoteEvidence(pubKey, voteA, voteB))
+	height, round, kind := ttypes.EvidenceHRK(ev)
+	require.Equal(t, int64(10), height)
+	require.Equal(t, int32(1), round)
+	require.Equal(t, ttypes.EvidenceKindPrevote, kind)
+
+	// 其他链、不同轮次、相同区块和其他人签名的投票都不是证据
+	_, err = ttypes.VerifyEvidence("chain33-other", cr, ev)
+	require.Equal(t, ttypes.ErrEvidenceSignature, err)
+	_, err = ttypes.VerifyEvidence(evidenceChainID, cr, ttypes.NewVoteEvidence(pubKey, voteA, signVote(t, priv, 10, 2, []byte("blockB"))))
+	require.Equal(t, ttypes.ErrEvidenceNotDouble, err)
+	_, err = ttypes.VerifyEvidence(evidenceChainID, cr, &tmtypes.QbftEvidence{PubKey: ev.PubKey, VoteA: voteA.QbftVote, VoteB: voteA.QbftVote})
+	require.Equal(t, ttypes.ErrEvidenceNotDouble, err)
+	_, err = ttypes.VerifyEvidence(evidenceChainID, cr, ttypes.NewVoteEvidence(pubKey, voteA, signVote(t, other, 10, 1, []byte("blockB"))))
+	require.Equal(t, ttypes.ErrVoteInvalidValidatorAddress, err)
+	_, err = ttypes.VerifyEvidence(evidenceChainID, cr, &tmtypes.QbftEvidence{PubKey: ev.PubKey, VoteA: voteA.QbftVote})
+	require.Equal(t, ttypes.ErrEvidenceInvalid, err)
+
+	// 同一轮次签名了不同的提案
+	proposalA := signProposal(t, priv, 10, 1, -1)
+	proposalB := signProposal(t, priv, 10, 1, 0)
+	ev = ttypes.NewProposalEvidence(pubKey, proposalA, proposalB)
+	_, err = ttypes.VerifyEvidence(evidenceChainID, cr, ev)
+	require.Nil(t, err)
+	_, _, kind = ttypes.EvidenceHRK(ev)
+	require.Equal(t, ttypes.EvidenceKindProposal, kind)
+	_, err = ttypes.VerifyEvidence(evidenceChainID, cr, ttypes.NewProposalEvidence(pubKey, proposalA, signProposal(t, priv, 10, 2, -1)))
+	require.Equal(t, ttypes.ErrEvidenceNotDouble, err)
+}
+
+func TestEvidencePool(t *testing.T) {
+	cr, err := ttypes.LoadCrypto("ed25519")
+	require.Nil(t, err)
+	priv, err := cr.GenKey()
+	require.Nil(t, err)
+	pubKey := priv.PubKey().Bytes()
+
+	pool := NewEvidencePool()
+	ev5 := ttypes.NewVoteEvidence(pubKey, signVote(t, priv, 5, 0, []byte("a")), signVote(t, priv, 5, 0, []byte("b")))
+	ev8 := ttypes.NewVoteEvidence(pubKey, signVote(t, priv, 8, 0, []byte("a")), signVote(t, priv, 8, 0, []byte("b")))
+	require.True(t, pool.Add(ev5))
+	require.True(t, pool.Add(ev8))
+	// 同一作恶的其他证据不重复加入
+	require.False(t, pool.Add(ttypes.NewVoteEvidence(pubKey, signVote(t, priv, 5, 0, []byte("a")), signVote(t, priv, 5, 0, []byte("c")))))
+	require.Equal(t, 2, pool.Size())
+
+	// 只打包已经上链高度的证据, 过期的证据被丢弃
+	require.Equal(t, []*tmtypes.QbftEvidence{ev5}, pool.Pending(8, 10, maxEvidencePerBlock))
+	require.Equal(t, []*tmtypes.QbftEvidence{ev5, ev8}, pool.Pending(9, 10, maxEvidencePerBlock))
+	require.Equal(t, []*tmtypes.QbftEvidence{ev5}, pool.Pending(9, 10, 1))
+	require.Equal(t, []*tmtypes.QbftEvidence{ev8}, pool.Pending(16, 10, maxEvidencePerBlock))
+	require.Equal(t, 1, pool.Size())
+
+	// 区块中打包的证据从证据池删除
+	signName.Store("ed25519")
+	tx := CreateEvidenceTx(priv, ev8)
+	pool.Update(&types.Block{Txs: []*types.Transaction{tx}})
+	require.Equal(t, 0, pool.Size())
+}
@@ -6,7 +6,6 @@ package qbft
 
 import (
 	"bytes"
-	"encoding/hex"
 	"errors"
 	"fmt"
 
@@ -106,97 +105,7 @@ func updateState(s State, blockID ttypes.BlockID, block *ttypes.QbftBlock) (Stat
 }
 
 func updateValidators(currentSet *ttypes.ValidatorSet, updates []*tmtypes.QbftNode) error {
-	// If more or equal than 1/3 of total voting power changed in one block, then
-	// a light client could never prove the transition externally. See
-	// ./lite/doc.go for details on how a light client tracks validators.
-	vp23, err := changeInVotingPowerMoreOrEqualToOneThird(currentSet, updates)
-	if err != nil {
-		return err
-	}
-	if vp23 {
-		return errors.New("the change in voting power must be strictly less than 1/3")
-	}
-
-	for _, v := range updates {
-		pubkeyBytes, err := hex.DecodeString(v.PubKey)
-		if err != nil {
-			return err
-		}
-		pubkey, err := ttypes.ConsensusCrypto.PubKeyFromBytes(pubkeyBytes)
-		if err != nil {
-			return err
-		}
-
-		address := ttypes.GenAddressByPubKey(pubkey)
-		power := v.Power
-		// mind the overflow from int64
-		if power < 0 {
-			return fmt.Errorf("Power (%d) overflows int64", v.Power)
-		}
-
-		_, val := currentSet.GetByAddress(address)
-		if val == nil {
-			// add val
-			added := currentSet.Add(ttypes.NewValidator(pubkey, power))
-			if !added {
-				return fmt.Errorf("Failed to add new validator %X with voting power %d", address, power)
-			}
-		} else if v.Power == 0 {
-			// remove val
-			_, removed := currentSet.Remove(address)
-			if !removed {
-				return fmt.Errorf("Failed to remove validator %X", address)
-			}
-		} else {
-			// update val
-			val.VotingPower = power
-			updated := currentSet.Update(val)
-			if !updated {
-				return fmt.Errorf("Failed to update validator %X with voting power %d", address, power)
-			}
-		}
-	}
-	return nil
-}
-
-func changeInVotingPowerMoreOrEqualToOneThird(currentSet *ttypes.ValidatorSet, updates []*tmtypes.QbftNode) (bool, error) {
-	threshold := currentSet.TotalVotingPower() * 1 / 3
-	acc := int64(0)
-
-	for _, v := range updates {
-		pubkeyBytes, err := hex.DecodeString(v.PubKey)
-		if err != nil {
-			return false, err
-		}
-		pubkey, err := ttypes.ConsensusCrypto.PubKeyFromBytes(pubkeyBytes)
-		if err != nil {
-			return false, err
-		}
-
-		address := ttypes.GenAddressByPubKey(pubkey)
-		power := v.Power
-		// mind the overflow from int64
-		if power < 0 {
-			return false, fmt.Errorf("Power (%d) overflows int64", v.Power)
-		}
-
-		_, val := currentSet.GetByAddress(address)
-		if val == nil {
-			acc += power
-		} else {
-			np := val.VotingPower - power
-			if np < 0 {
-				np = -np
-			}
-			acc += np
-		}
-
-		if acc >= threshold {
-			return true, nil
-		}
-	}
-
-	return false, nil
+	return currentSet.ApplyUpdates(ttypes.ConsensusCrypto, updates)
 }
 
 func validateBlock(stateDB *CSStateDB, s State, b *ttypes.QbftBlock) error {
@@ -0,0 +1,165 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package light 校验qbft共识区块的轻节点证明, 不依赖共识模块和区块数据,
+// 从一个可信的区块头和验证者集合出发, 按证明中记录的验证者变更依次验证到目标区块
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/33cn/chain33/common/crypto"
+	ttypes "github.com/33cn/plugin/plugin/consensus/qbft/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
+)
+
+var (
+	// ErrValidatorsHash 验证者集合与区块头不一致
+	ErrValidatorsHash = errors.New("ErrValidatorsHash")
+	// ErrChainID 链ID不一致
+	ErrChainID = errors.New("ErrChainID")
+	// ErrHeight 证明中的区块高度不是递增的
+	ErrHeight = errors.New("ErrHeight")
+	// ErrBlockID commit签名的不是该区块
+	ErrBlockID = errors.New("ErrBlockID")
+	// ErrNotTrusted 信任的验证者集合签名不足1/3
+	ErrNotTrusted = errors.New("ErrNotTrusted")
+	// ErrInvalidProof 证明格式错误
+	ErrInvalidProof = errors.New("ErrInvalidProof")
+)
+
+//TrustedState 轻节点信任的区块头和对该区块签名的验证者集合
+type TrustedState struct {
+	ChainID    string
+	Height     int64
+	Header     *tmtypes.QbftBlockHeader
+	Validators *ttypes.ValidatorSet
+}
+
+//NewTrustedState 由可信的区块头和验证者集合创建信任状态, 通常取自创世文件或者可信节点
+func NewTrustedState(header *tmtypes.QbftBlockHeader, vals []*ttypes.Validator) (*TrustedState, error) {
+	if header == nil {
+		return nil, ErrInvalidProof
+	}
+	valSet := ttypes.NewValidatorSet(vals)
+	if !bytes.Equal(valSet.Hash(), header.ValidatorsHash) {
+		return nil, ErrValidatorsHash
+	}
+	return &TrustedState{
+		ChainID:    header.ChainID,
+		Height:     header.Height,
+		Header:     header,
+		Validators: valSet,
+	}, nil
+}
+
+//Verify 校验证明, 成功时返回目标区块的信任状态
+//每一步先按变更记录计算新的验证者集合, 要求与区块头一致, 且commit有新集合2/3以上的签名,
+//同时其中的签名者在上一个信任集合中的权重超过1/3
+func Verify(cr crypto.Crypto, trusted *TrustedState, proof *tmtypes.QbftLightProof) (*TrustedState, error) {
+	if trusted == nil || proof == nil || proof.Target == nil {
+		return nil, ErrInvalidProof
+	}
+	if proof.ChainID != trusted.ChainID {
+		return nil, ErrChainID
+	}
+	if proof.TrustedHeight != trusted.Height {
+		return nil, ErrHeight
+	}
+	cur := trusted
+	steps := append(append([]*tmtypes.QbftSignedHeader{}, proof.Changes...), proof.Target)
+	for _, step := range steps {
+		next, err := verifyStep(cr, cur, step)
+		if err != nil {
+			return nil, err
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+func verifyStep(cr crypto.Crypto, cur *TrustedState, step *tmtypes.QbftSignedHeader) (*TrustedState, error) {
+	if step == nil || step.Header == nil || step.Commit == nil {
+		return nil, ErrInvalidProof
+	}
+	header := step.Header
+	if header.ChainID != cur.ChainID {
+		return nil, ErrChainID
+	}
+	if header.Height <= cur.Height {
+		return nil, ErrHeight
+	}
+
+	valSet := cur.Validators.Copy()
+	if len(step.Updates) > 0 {
+		if err := valSet.ApplyUpdates(cr, step.Updates); err != nil {
+			return nil, err
+		}
+	}
+	if !bytes.Equal(valSet.Hash(), header.ValidatorsHash) {
+		return nil, ErrValidatorsHash
+	}
+
+	hash := (&ttypes.Header{QbftBlockHeader: header}).Hash()
+	if !bytes.Equal(step.Commit.GetBlockID().GetHash(), hash) {
+		return nil, ErrBlockID
+	}
+	//聚合签名的commit只对聚合投票中的区块签名
+	if step.Commit.AggVote != nil && !bytes.Equal(step.Commit.AggVote.GetBlockID().GetHash(), hash) {
+		return nil, ErrBlockID
+	}
+	blockID := ttypes.BlockID{QbftBlockID: &tmtypes.QbftBlockID{Hash: hash}}
+	commit := &ttypes.Commit{QbftCommit: step.Commit}
+	err := valSet.VerifyCommitByCrypto(cr, header.ChainID, blockID, header.Height, commit)
+	if err != nil {
+		return nil, fmt.Errorf("verify commit at height %d: %v", header.Height, err)
+	}
+
+	//签名者在信任集合中的权重需超过1/3, 保证至少有一个诚实的验证者认可了这次变更
+	var power int64
+	for _, addr := range signers(valSet, step.Commit, hash) {
+		if _, val := cur.Validators.GetByAddress(addr); val != nil {
+			power += val.VotingPower
+		}
+	}
+	if power <= cur.Validators.TotalVotingPower()/3 {
+		return nil, ErrNotTrusted
+	}
+
+	return &TrustedState{
+		ChainID:    cur.ChainID,
+		Height:     header.Height,
+		Header:     header,
+		Validators: valSet,
+	}, nil
+}
+
+// 对区块hash签名的验证者地址
+func signers(valSet *ttypes.ValidatorSet, commit *tmtypes.QbftCommit, hash []byte) [][]byte {
+	var addrs [][]byte
+	if commit.AggVote != nil {
+		arr := &ttypes.BitArray{QbftBitArray: commit.AggVote.ValidatorArray}
+		for i, val := range valSet.Validators {
+			if arr.GetIndex(i) {
+				addrs = append(addrs, val.Address)
+			}
+		}
+		return addrs
+	}
+	votes := commit.Precommits
+	if commit.VoteType == uint32(ttypes.VoteTypePrevote) {
+		votes = commit.Prevotes
+	}
+	for i, vote := range votes {
+		if vote == nil || len(vote.Signature) == 0 || !bytes.Equal(vote.GetBlockID().GetHash(), hash) {
+			continue
+		}
+		if addr, _ := valSet.GetByIndex(i); addr != nil {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
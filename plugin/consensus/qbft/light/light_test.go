@@ -0,0 +1,132 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package light
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/33cn/chain33/common/crypto"
+	ttypes "github.com/33cn/plugin/plugin/consensus/qbft/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = "chain33-light"
+
+type testChain struct {
+	t     *testing.T
+	cr    crypto.Crypto
+	privs map[string]crypto.PrivKey
+}
+
+func (c *testChain) genKey() crypto.PrivKey {
+	priv, err := c.cr.GenKey()
+	require.Nil(c.t, err)
+	c.privs[string(ttypes.GenAddressByPubKey(priv.PubKey()))] = priv
+	return priv
+}
+
+func (c *testChain) header(height int64, valSet *ttypes.ValidatorSet) *tmtypes.QbftBlockHeader {
+	return &tmtypes.QbftBlockHeader{
+		ChainID:        testChainID,
+		Height:         height,
+		ValidatorsHash: valSet.Hash(),
+	}
+}
+
+// 由validators中前signed个验证者对区块签名
+func (c *testChain) sign(header *tmtypes.QbftBlockHeader, valSet *ttypes.ValidatorSet, signed int) *tmtypes.QbftSignedHeader {
+	hash := (&ttypes.Header{QbftBlockHeader: header}).Hash()
+	blockID := &tmtypes.QbftBlockID{Hash: hash}
+	commit := &tmtypes.QbftCommit{BlockID: blockID, VoteType: uint32(ttypes.VoteTypePrecommit)}
+	for i, val := range valSet.Validators {
+		if i >= signed {
+			commit.Precommits = append(commit.Precommits, nil)
+			continue
+		}
+		vote := &ttypes.Vote{QbftVote: &tmtypes.QbftVote{
+			ValidatorAddress: val.Address,
+			ValidatorIndex:   int32(i),
+			Height:           header.Height,
+			Type:             uint32(ttypes.VoteTypePrecommit),
+			BlockID:          blockID,
+		}}
+		vote.Signature = c.privs[string(val.Address)].Sign(ttypes.SignBytes(testChainID, vote)).Bytes()
+		commit.Precommits = append(commit.Precommits, vote.QbftVote)
+	}
+	return &tmtypes.QbftSignedHeader{Header: header, Commit: commit}
+}
+
+func TestVerify(t *testing.T) {
+	cr, err := ttypes.LoadCrypto("ed25519")
+	require.Nil(t, err)
+	c := &testChain{t: t, cr: cr, privs: make(map[string]crypto.PrivKey)}
+	var vals []*ttypes.Validator
+	for i := 0; i < 4; i++ {
+		vals = append(vals, ttypes.NewValidator(c.genKey().PubKey(), 10))
+	}
+	genesis := ttypes.NewValidatorSet(vals)
+	trusted, err := NewTrustedState(c.header(1, genesis), vals)
+	require.Nil(t, err)
+
+	//第4个区块执行了增加验证者的交易, 第5个区块开始生效
+	newPriv := c.genKey()
+	updates := []*tmtypes.QbftNode{{PubKey: hex.EncodeToString(newPriv.PubKey().Bytes()), Power: 5}}
+	changed := genesis.Copy()
+	require.Nil(t, changed.ApplyUpdates(cr, updates))
+	require.Equal(t, 5, changed.Size())
+	change := c.sign(c.header(5, changed), changed, 4)
+	change.Updates = updates
+	proof := &tmtypes.QbftLightProof{
+		ChainID:       testChainID,
+		TrustedHeight: 1,
+		Changes:       []*tmtypes.QbftSignedHeader{change},
+		Target:        c.sign(c.header(9, changed), changed, 5),
+	}
+	state, err := Verify(cr, trusted, proof)
+	require.Nil(t, err)
+	require.Equal(t, int64(9), state.Height)
+	require.Equal(t, changed.Hash(), state.Validators.Hash())
+
+	//没有验证者变更时直接验证目标区块
+	direct := &tmtypes.QbftLightProof{ChainID: testChainID, TrustedHeight: 1, Target: c.sign(c.header(3, genesis), genesis, 3)}
+	_, err = Verify(cr, trusted, direct)
+	require.Nil(t, err)
+
+	//签名不足2/3
+	direct.Target = c.sign(c.header(3, genesis), genesis, 2)
+	_, err = Verify(cr, trusted, direct)
+	require.NotNil(t, err)
+
+	//缺少验证者变更记录
+	proof.Changes = nil
+	_, err = Verify(cr, trusted, proof)
+	require.Equal(t, ErrValidatorsHash, err)
+
+	//commit不是该区块的签名
+	forged := c.sign(c.header(3, genesis), genesis, 4)
+	forged.Header = c.header(4, genesis)
+	_, err = Verify(cr, trusted, &tmtypes.QbftLightProof{ChainID: testChainID, TrustedHeight: 1, Target: forged})
+	require.Equal(t, ErrBlockID, err)
+
+	//全新的验证者集合不被信任
+	var others []*ttypes.Validator
+	for i := 0; i < 4; i++ {
+		others = append(others, ttypes.NewValidator(c.genKey().PubKey(), 10))
+	}
+	otherSet := ttypes.NewValidatorSet(others)
+	otherTrusted, err := NewTrustedState(c.header(1, otherSet), others)
+	require.Nil(t, err)
+	_, err = Verify(cr, otherTrusted, &tmtypes.QbftLightProof{ChainID: testChainID, TrustedHeight: 1, Target: c.sign(c.header(3, genesis), genesis, 4)})
+	require.Equal(t, ErrValidatorsHash, err)
+
+	_, err = Verify(cr, trusted, &tmtypes.QbftLightProof{ChainID: "other", TrustedHeight: 1, Target: direct.Target})
+	require.Equal(t, ErrChainID, err)
+	_, err = Verify(cr, trusted, &tmtypes.QbftLightProof{ChainID: testChainID, TrustedHeight: 1, Target: c.sign(c.header(1, genesis), genesis, 4)})
+	require.Equal(t, ErrHeight, err)
+	_, err = NewTrustedState(c.header(1, genesis), others)
+	require.Equal(t, ErrValidatorsHash, err)
+}
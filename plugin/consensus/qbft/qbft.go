@@ -20,6 +20,7 @@ import (
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/util"
+	"github.com/33cn/plugin/plugin/consensus/privval"
 	ttypes "github.com/33cn/plugin/plugin/consensus/qbft/types"
 	tmtypes "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
 	"github.com/golang/protobuf/proto"
@@ -50,6 +51,7 @@ var (
 	emptyBlockInterval    atomic.Value // 0  second
 	genesisFile                        = "genesis.json"
 	privFile                           = "priv_validator.json"
+	nodeKeyFile                        = "node_key.json"
 	dbPath                             = fmt.Sprintf("datadir%sqbft", string(os.PathSeparator))
 	port                  int32        = DefaultQbftPort
 	validatorNodes                     = []string{"127.0.0.1:33001"}
@@ -65,6 +67,7 @@ var (
 	zeroHash                    [32]byte
 	random                      *rand.Rand
 	peerGossipSleepDuration     atomic.Value
+	signerCfg                   privval.Config
 	peerQueryMaj23SleepDuration int32 = 2000
 )
 
@@ -116,6 +119,9 @@ type subConfig struct {
 	MessageInterval       int32    `json:"messageInterval"`
 	DetachExecution       bool     `json:"detachExecution"`
 	SameBlocktime         bool     `json:"sameBlocktime"`
+	// 使用远程签名服务时, 节点p2p连接和交易签名使用的私钥文件
+	NodeKeyFile string `json:"nodeKeyFile"`
+	privval.Config
 }
 
 func applyConfig(cfg *types.Consensus, sub []byte) {
@@ -146,6 +152,10 @@ func applyConfig(cfg *types.Consensus, sub []byte) {
 	if subcfg.PrivFile != "" {
 		privFile = subcfg.PrivFile
 	}
+	if subcfg.NodeKeyFile != "" {
+		nodeKeyFile = subcfg.NodeKeyFile
+	}
+	signerCfg = subcfg.Config
 	if subcfg.DbPath != "" {
 		dbPath = subcfg.DbPath
 	}
@@ -207,18 +217,12 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 	//init rand
 	ttypes.Init()
 
-	signType, ok := ttypes.SignMap[signName.Load().(string)]
-	if !ok {
-		qbftlog.Error("invalid sign name")
-		return nil
-	}
-
-	ttypes.CryptoName = types.GetSignName("", signType)
-	cr, err := crypto.Load(ttypes.CryptoName, -1)
+	cr, err := ttypes.LoadCrypto(signName.Load().(string))
 	if err != nil {
 		qbftlog.Error("load qbft crypto fail", "err", err)
 		return nil
 	}
+	ttypes.CryptoName = types.GetSignName("", ttypes.SignMap[signName.Load().(string)])
 	ttypes.ConsensusCrypto = cr
 
 	if UseAggSig() {
@@ -234,14 +238,25 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 		qbftlog.Error("load genesis file fail", "error", err)
 		return nil
 	}
-	privValidator := ttypes.LoadPrivValidatorFS(privFile)
-	if privValidator == nil {
-		qbftlog.Error("load priv_validator file fail")
-		return nil
+	var privValidator *ttypes.PrivValidatorImp
+	var privKey crypto.PrivKey
+	if signerCfg.SignerAddr != "" {
+		privValidator, privKey, err = newRemotePrivValidator()
+		if err != nil {
+			qbftlog.Error("connect remote signer fail", "addr", signerCfg.SignerAddr, "err", err)
+			return nil
+		}
+	} else {
+		privValidator = ttypes.LoadPrivValidatorFS(privFile)
+		if privValidator == nil {
+			qbftlog.Error("load priv_validator file fail")
+			return nil
+		}
+		privKey = privValidator.PrivKey
 	}
 
 	qbftlog.Info("show qbft info", "version", qbftVersion, "sign", ttypes.CryptoName, "useAggSig", UseAggSig(),
-		"detachExec", DetachExec(), "genesisFile", genesisFile, "privFile", privFile)
+		"detachExec", DetachExec(), "genesisFile", genesisFile, "privFile", privFile, "remoteSigner", signerCfg.SignerAddr)
 
 	ttypes.InitMessageMap()
 
@@ -253,7 +268,7 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 		BaseClient:    c,
 		genesisDoc:    genDoc,
 		privValidator: privValidator,
-		privKey:       privValidator.PrivKey,
+		privKey:       privKey,
 		csStore:       NewConsensusStore(),
 		txsAvailable:  make(chan int64, 1),
 		ctx:           ctx,
@@ -263,6 +278,20 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 	return client
 }
 
+// newRemotePrivValidator 验证者私钥保存在远程签名服务, 节点使用nodeKeyFile中的私钥进行p2p连接和交易签名
+func newRemotePrivValidator() (*ttypes.PrivValidatorImp, crypto.PrivKey, error) {
+	signer, err := privval.NewClient(&signerCfg, ttypes.ConsensusCrypto)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubKey, err := signer.GetPubKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	nodeKey := ttypes.LoadOrGenPrivValidatorFS(nodeKeyFile)
+	return ttypes.NewPrivValidatorWithSigner(pubKey, signer), nodeKey.PrivKey, nil
+}
+
 // GenesisState returns the Node's GenesisState.
 func (client *Client) GenesisState() *State {
 	state, err := MakeGenesisState(client.genesisDoc)
@@ -622,6 +651,22 @@ func (client *Client) QueryValidatorsByHeight(height int64) (*tmtypes.QbftNodes,
 	return msg.GetData().(types.Message).(*tmtypes.QbftNodes), nil
 }
 
+// QueryEvidence 查询证据对应的作恶是否已经处罚
+func (client *Client) QueryEvidence(ev *tmtypes.QbftEvidence) (*tmtypes.QbftEvidenceRecord, error) {
+	msg := client.GetQueueClient().NewMessage("execs", types.EventBlockChainQuery,
+		&types.ChainExecutor{Driver: "qbftNode", FuncName: "GetQbftEvidence", StateHash: zeroHash[:], Param: types.Encode(ev)})
+	err := client.GetQueueClient().Send(msg, true)
+	if err != nil {
+		qbftlog.Error("QueryEvidence send", "err", err)
+		return nil, err
+	}
+	msg, err = client.GetQueueClient().Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	return msg.GetData().(types.Message).(*tmtypes.QbftEvidenceRecord), nil
+}
+
 // QueryBlockInfoByHeight get blockInfo and block by height
 func (client *Client) QueryBlockInfoByHeight(height int64) (*tmtypes.QbftBlockInfo, *types.Block, error) {
 	if height < 1 {
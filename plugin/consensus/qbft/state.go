@@ -473,3 +473,15 @@ func CreateBlockInfoTx(priv crypto.PrivKey, state *tmtypes.QbftState, block *tmt
 
 	return tx
 }
+
+// CreateEvidenceTx make evidence of double signing to a qbftNode transaction
+func CreateEvidenceTx(priv crypto.PrivKey, ev *tmtypes.QbftEvidence) *types.Transaction {
+	nput := &tmtypes.QbftNodeAction_Evidence{Evidence: ev}
+	action := &tmtypes.QbftNodeAction{Value: nput, Ty: tmtypes.QbftNodeActionEvidence}
+	tx := &types.Transaction{Execer: []byte("qbftNode"), Payload: types.Encode(action), Fee: fee}
+	tx.To = address.ExecAddress("qbftNode")
+	tx.Nonce = random.Int63()
+	tx.Sign(int32(ttypes.SignMap[signName.Load().(string)]), priv)
+
+	return tx
+}
@@ -0,0 +1,165 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
+)
+
+// error defines
+var (
+	ErrEvidenceInvalid   = errors.New("Invalid evidence")
+	ErrEvidenceNotDouble = errors.New("Evidence is not double signing")
+	ErrEvidenceSignature = errors.New("Invalid evidence signature")
+)
+
+// evidence kinds
+const (
+	EvidenceKindPrevote   = "prevote"
+	EvidenceKindPrecommit = "precommit"
+	EvidenceKindProposal  = "proposal"
+)
+
+// ErrVoteConflictingVotes 同一验证者在相同高度、轮次和类型下的两个冲突投票
+type ErrVoteConflictingVotes struct {
+	VoteA *Vote
+	VoteB *Vote
+}
+
+func (err *ErrVoteConflictingVotes) Error() string {
+	return fmt.Sprintf("Conflicting vote: %v; New vote: %v", err.VoteA, err.VoteB)
+}
+
+// Cause 兼容errors.Cause(err) == ErrVoteConflict的判断
+func (err *ErrVoteConflictingVotes) Cause() error {
+	return ErrVoteConflict
+}
+
+// LoadCrypto 根据共识配置的签名名称加载加密算法
+func LoadCrypto(signName string) (crypto.Crypto, error) {
+	signType, ok := SignMap[signName]
+	if !ok {
+		return nil, fmt.Errorf("invalid sign name %s", signName)
+	}
+	return crypto.Load(types.GetSignName("", signType), -1)
+}
+
+// NewVoteEvidence 由两个冲突投票生成证据, 按区块hash排序使证据唯一
+func NewVoteEvidence(pubKey []byte, voteA, voteB *Vote) *tmtypes.QbftEvidence {
+	if bytes.Compare(voteA.BlockID.Hash, voteB.BlockID.Hash) > 0 {
+		voteA, voteB = voteB, voteA
+	}
+	return &tmtypes.QbftEvidence{
+		PubKey: fmt.Sprintf("%X", pubKey),
+		VoteA:  voteA.QbftVote,
+		VoteB:  voteB.QbftVote,
+	}
+}
+
+// NewProposalEvidence 由两个冲突提案生成证据, 按签名排序使证据唯一
+func NewProposalEvidence(pubKey []byte, proposalA, proposalB *tmtypes.QbftProposal) *tmtypes.QbftEvidence {
+	if bytes.Compare(proposalA.Signature, proposalB.Signature) > 0 {
+		proposalA, proposalB = proposalB, proposalA
+	}
+	return &tmtypes.QbftEvidence{
+		PubKey:    fmt.Sprintf("%X", pubKey),
+		ProposalA: proposalA,
+		ProposalB: proposalB,
+	}
+}
+
+// EvidenceHRK 返回证据的高度、轮次和类型
+func EvidenceHRK(ev *tmtypes.QbftEvidence) (height int64, round int32, kind string) {
+	if ev.GetVoteA() != nil {
+		kind = EvidenceKindPrevote
+		if byte(ev.VoteA.Type) == VoteTypePrecommit {
+			kind = EvidenceKindPrecommit
+		}
+		return ev.VoteA.Height, ev.VoteA.Round, kind
+	}
+	return ev.GetProposalA().GetHeight(), ev.GetProposalA().GetRound(), EvidenceKindProposal
+}
+
+// EvidenceKey 同一验证者在同一高度、轮次和类型下的作恶只记录一次
+func EvidenceKey(ev *tmtypes.QbftEvidence) string {
+	height, round, kind := EvidenceHRK(ev)
+	return fmt.Sprintf("%s-%d-%d-%s", strings.ToUpper(ev.GetPubKey()), height, round, kind)
+}
+
+// VerifyEvidence 检查证据中的两个投票或提案由pubKey在同一高度和轮次签名且互相冲突, 返回作恶验证者地址
+func VerifyEvidence(chainID string, cr crypto.Crypto, ev *tmtypes.QbftEvidence) ([]byte, error) {
+	pubKeyBytes, err := hex.DecodeString(ev.GetPubKey())
+	if err != nil {
+		return nil, ErrEvidenceInvalid
+	}
+	pubKey, err := cr.PubKeyFromBytes(pubKeyBytes)
+	if err != nil {
+		return nil, ErrEvidenceInvalid
+	}
+	address := GenAddressByPubKey(pubKey)
+
+	var signA, signB Signable
+	var sigA, sigB []byte
+	switch {
+	case ev.GetVoteA() != nil && ev.GetVoteB() != nil && ev.GetProposalA() == nil && ev.GetProposalB() == nil:
+		voteA, voteB := ev.VoteA, ev.VoteB
+		if voteA.BlockID == nil || voteB.BlockID == nil || !IsVoteTypeValid(byte(voteA.Type)) {
+			return nil, ErrEvidenceInvalid
+		}
+		if voteA.UseAggSig || voteB.UseAggSig {
+			return nil, ErrEvidenceInvalid
+		}
+		if !bytes.Equal(voteA.ValidatorAddress, address) || !bytes.Equal(voteB.ValidatorAddress, address) {
+			return nil, ErrVoteInvalidValidatorAddress
+		}
+		if voteA.Height != voteB.Height || voteA.Round != voteB.Round || voteA.Type != voteB.Type {
+			return nil, ErrEvidenceNotDouble
+		}
+		if bytes.Compare(voteA.BlockID.Hash, voteB.BlockID.Hash) >= 0 {
+			return nil, ErrEvidenceNotDouble
+		}
+		signA, signB = &Vote{QbftVote: voteA}, &Vote{QbftVote: voteB}
+		sigA, sigB = voteA.Signature, voteB.Signature
+	case ev.GetProposalA() != nil && ev.GetProposalB() != nil && ev.GetVoteA() == nil && ev.GetVoteB() == nil:
+		proposalA, proposalB := ev.ProposalA, ev.ProposalB
+		if proposalA.POLBlockID == nil || proposalB.POLBlockID == nil {
+			return nil, ErrEvidenceInvalid
+		}
+		if proposalA.Height != proposalB.Height || proposalA.Round != proposalB.Round {
+			return nil, ErrEvidenceNotDouble
+		}
+		if bytes.Compare(proposalA.Signature, proposalB.Signature) >= 0 {
+			return nil, ErrEvidenceNotDouble
+		}
+		signA, signB = &Proposal{QbftProposal: *proposalA}, &Proposal{QbftProposal: *proposalB}
+		sigA, sigB = proposalA.Signature, proposalB.Signature
+	default:
+		return nil, ErrEvidenceInvalid
+	}
+
+	// 提案的签名内容不包含区块hash, 只有签名内容不同才算冲突
+	bytesA, bytesB := SignBytes(chainID, signA), SignBytes(chainID, signB)
+	if bytes.Equal(bytesA, bytesB) {
+		return nil, ErrEvidenceNotDouble
+	}
+	for _, item := range []struct{ msg, sig []byte }{{bytesA, sigA}, {bytesB, sigB}} {
+		sig, err := cr.SignatureFromBytes(item.sig)
+		if err != nil {
+			return nil, ErrEvidenceSignature
+		}
+		if !pubKey.VerifyBytes(item.msg, sig) {
+			return nil, ErrEvidenceSignature
+		}
+	}
+	return address, nil
+}
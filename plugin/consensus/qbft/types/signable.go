@@ -252,7 +252,12 @@ func (aggVote *AggVote) WriteSignBytes(chainID string, w io.Writer, n *int, err
 
 // Verify ...
 func (aggVote *AggVote) Verify(chainID string, valSet *ValidatorSet) error {
-	aggSig, err := ConsensusCrypto.SignatureFromBytes(aggVote.Signature)
+	return aggVote.VerifyByCrypto(ConsensusCrypto, chainID, valSet)
+}
+
+// VerifyByCrypto 使用指定的加密算法验证聚合签名
+func (aggVote *AggVote) VerifyByCrypto(cr crypto.Crypto, chainID string, valSet *ValidatorSet) error {
+	aggSig, err := cr.SignatureFromBytes(aggVote.Signature)
 	if err != nil {
 		return errors.New("invalid aggregate signature")
 	}
@@ -260,7 +265,7 @@ func (aggVote *AggVote) Verify(chainID string, valSet *ValidatorSet) error {
 	arr := &BitArray{QbftBitArray: aggVote.ValidatorArray}
 	for i, val := range valSet.Validators {
 		if arr.GetIndex(i) {
-			pub, _ := ConsensusCrypto.PubKeyFromBytes(val.PubKey)
+			pub, _ := cr.PubKeyFromBytes(val.PubKey)
 			pubs = append(pubs, pub)
 		}
 	}
@@ -272,7 +277,7 @@ func (aggVote *AggVote) Verify(chainID string, valSet *ValidatorSet) error {
 		Type:      aggVote.Type,
 		UseAggSig: true,
 	}}
-	aggr, err := crypto.ToAggregate(ConsensusCrypto)
+	aggr, err := crypto.ToAggregate(cr)
 	if err != nil {
 		return err
 	}
@@ -7,11 +7,13 @@ package types
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"sort"
 
 	"github.com/33cn/chain33/common/crypto"
 	"github.com/33cn/chain33/common/merkle"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
 	"github.com/pkg/errors"
 )
 
@@ -307,8 +309,110 @@ func (valSet *ValidatorSet) Iterate(fn func(index int, val *Validator) bool) {
 	}
 }
 
+// ApplyUpdates 按执行器记录的验证者变更更新验证者集合, power为0表示移除
+func (valSet *ValidatorSet) ApplyUpdates(cr crypto.Crypto, updates []*tmtypes.QbftNode) error {
+	// If more or equal than 1/3 of total voting power changed in one block, then
+	// a light client could never prove the transition externally.
+	vp23, err := changeInVotingPowerMoreOrEqualToOneThird(cr, valSet, updates)
+	if err != nil {
+		return err
+	}
+	if vp23 {
+		return errors.New("the change in voting power must be strictly less than 1/3")
+	}
+
+	for _, v := range updates {
+		pubkeyBytes, err := hex.DecodeString(v.PubKey)
+		if err != nil {
+			return err
+		}
+		pubkey, err := cr.PubKeyFromBytes(pubkeyBytes)
+		if err != nil {
+			return err
+		}
+
+		address := GenAddressByPubKey(pubkey)
+		power := v.Power
+		// mind the overflow from int64
+		if power < 0 {
+			return fmt.Errorf("Power (%d) overflows int64", v.Power)
+		}
+
+		_, val := valSet.GetByAddress(address)
+		if val == nil && power == 0 {
+			// 已经不是验证者, 比如被处罚前已经移除
+			ttlog.Info("ApplyUpdates ignore removing non-validator", "address", fmt.Sprintf("%X", address))
+		} else if val == nil {
+			// add val
+			added := valSet.Add(NewValidator(pubkey, power))
+			if !added {
+				return fmt.Errorf("Failed to add new validator %X with voting power %d", address, power)
+			}
+		} else if v.Power == 0 {
+			// remove val
+			_, removed := valSet.Remove(address)
+			if !removed {
+				return fmt.Errorf("Failed to remove validator %X", address)
+			}
+		} else {
+			// update val
+			val.VotingPower = power
+			updated := valSet.Update(val)
+			if !updated {
+				return fmt.Errorf("Failed to update validator %X with voting power %d", address, power)
+			}
+		}
+	}
+	return nil
+}
+
+func changeInVotingPowerMoreOrEqualToOneThird(cr crypto.Crypto, currentSet *ValidatorSet, updates []*tmtypes.QbftNode) (bool, error) {
+	threshold := currentSet.TotalVotingPower() * 1 / 3
+	acc := int64(0)
+
+	for _, v := range updates {
+		pubkeyBytes, err := hex.DecodeString(v.PubKey)
+		if err != nil {
+			return false, err
+		}
+		pubkey, err := cr.PubKeyFromBytes(pubkeyBytes)
+		if err != nil {
+			return false, err
+		}
+
+		address := GenAddressByPubKey(pubkey)
+		power := v.Power
+		// mind the overflow from int64
+		if power < 0 {
+			return false, fmt.Errorf("Power (%d) overflows int64", v.Power)
+		}
+
+		_, val := currentSet.GetByAddress(address)
+		if val == nil {
+			acc += power
+		} else {
+			np := val.VotingPower - power
+			if np < 0 {
+				np = -np
+			}
+			acc += np
+		}
+
+		if acc >= threshold {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // VerifyCommit Verify that +2/3 of the set had signed the given signBytes
 func (valSet *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, height int64, commit *Commit) error {
+	return valSet.VerifyCommitByCrypto(ConsensusCrypto, chainID, blockID, height, commit)
+}
+
+// VerifyCommitByCrypto 使用指定的加密算法验证commit, 供不加载共识模块的轻节点使用
+func (valSet *ValidatorSet) VerifyCommitByCrypto(cr crypto.Crypto, chainID string, blockID BlockID, height int64, commit *Commit) error {
 	if valSet.Size() != commit.Size() {
 		return fmt.Errorf("Invalid commit -- wrong set size: %v vs %v", valSet.Size(), commit.Size())
 	}
@@ -330,7 +434,7 @@ func (valSet *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, height
 				aggVote.Height, aggVote.Round, aggVote.Type)
 		}
 		// Check signature
-		err := aggVote.Verify(chainID, valSet)
+		err := aggVote.VerifyByCrypto(cr, chainID, valSet)
 		if err != nil {
 			return err
 		}
@@ -361,11 +465,11 @@ func (valSet *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, height
 
 			// Validate signature
 			precommitSignBytes := SignBytes(chainID, precommit)
-			sig, err := ConsensusCrypto.SignatureFromBytes(precommit.Signature)
+			sig, err := cr.SignatureFromBytes(precommit.Signature)
 			if err != nil {
 				return fmt.Errorf("VerifyCommit precommit SignatureFromBytes [%X] fail:%v", precommit.Signature, err)
 			}
-			pubkey, err := ConsensusCrypto.PubKeyFromBytes(val.PubKey)
+			pubkey, err := cr.PubKeyFromBytes(val.PubKey)
 			if err != nil {
 				return fmt.Errorf("VerifyCommit precommit PubKeyFromBytes [%X] fail:%v", val.PubKey, err)
 			}
@@ -398,11 +502,11 @@ func (valSet *ValidatorSet) VerifyCommit(chainID string, blockID BlockID, height
 
 			// Validate signature
 			prevoteSignBytes := SignBytes(chainID, prevote)
-			sig, err := ConsensusCrypto.SignatureFromBytes(prevote.Signature)
+			sig, err := cr.SignatureFromBytes(prevote.Signature)
 			if err != nil {
 				return fmt.Errorf("VerifyCommit prevote SignatureFromBytes [%X] fail:%v", prevote.Signature, err)
 			}
-			pubkey, err := ConsensusCrypto.PubKeyFromBytes(val.PubKey)
+			pubkey, err := cr.PubKeyFromBytes(val.PubKey)
 			if err != nil {
 				return fmt.Errorf("VerifyCommit prevote PubKeyFromBytes [%X] fail:%v", val.PubKey, err)
 			}
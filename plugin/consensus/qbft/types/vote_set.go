@@ -206,7 +206,7 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 	// Add vote and get conflicting vote if any
 	added, conflicting := voteSet.addVerifiedVote(vote, blockKey, val.VotingPower)
 	if conflicting != nil {
-		return added, errors.Wrapf(ErrVoteConflict, "Conflicting vote: %v; New vote: %v", conflicting, vote)
+		return added, &ErrVoteConflictingVotes{VoteA: conflicting, VoteB: vote}
 	}
 	if !added {
 		PanicSanity("Expected to add non-conflicting vote")
@@ -0,0 +1,206 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// RaftCmd raft cluster membership cmd register
+func RaftCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "raft",
+		Short: "Manage raft cluster membership",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.PersistentFlags().String("api", "http://localhost:9121", "raft api address")
+	cmd.PersistentFlags().String("token", "", "raft api bearer token")
+	cmd.PersistentFlags().String("cert", "", "client certificate file for mutual tls")
+	cmd.PersistentFlags().String("key", "", "client private key file for mutual tls")
+	cmd.PersistentFlags().String("cacert", "", "ca certificate file to verify raft api server")
+	cmd.AddCommand(
+		StatusCmd(),
+		AddNodeCmd(),
+		PromoteNodeCmd(),
+		RemoveNodeCmd(),
+		TransferLeaderCmd(),
+	)
+	return cmd
+}
+
+// StatusCmd query raft node status
+func StatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Get raft node status, members and replication progress",
+		Run:   status,
+	}
+	return cmd
+}
+
+func status(cmd *cobra.Command, args []string) {
+	doRequest(cmd, http.MethodGet, "/status", nil)
+}
+
+// AddNodeCmd add raft node
+func AddNodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add a voting member or learner to raft cluster",
+		Run:   addNode,
+	}
+	addNodeIDFlag(cmd)
+	cmd.Flags().StringP("url", "u", "", "peer url of the new node, e.g. http://127.0.0.1:9021")
+	cmd.MarkFlagRequired("url")
+	cmd.Flags().BoolP("learner", "l", false, "add as learner which only replicates log")
+	return cmd
+}
+
+func addNodeIDFlag(cmd *cobra.Command) {
+	cmd.Flags().Uint64P("id", "i", 0, "raft node id")
+	cmd.MarkFlagRequired("id")
+}
+
+func addNode(cmd *cobra.Command, args []string) {
+	id, _ := cmd.Flags().GetUint64("id")
+	url, _ := cmd.Flags().GetString("url")
+	learner, _ := cmd.Flags().GetBool("learner")
+	path := fmt.Sprintf("/members/%d", id)
+	if learner {
+		path = fmt.Sprintf("/learners/%d", id)
+	}
+	doRequest(cmd, http.MethodPost, path, strings.NewReader(url))
+}
+
+// PromoteNodeCmd promote learner to voting member
+func PromoteNodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "promote",
+		Short: "Promote a learner to voting member",
+		Run:   promoteNode,
+	}
+	addNodeIDFlag(cmd)
+	return cmd
+}
+
+func promoteNode(cmd *cobra.Command, args []string) {
+	id, _ := cmd.Flags().GetUint64("id")
+	doRequest(cmd, http.MethodPost, fmt.Sprintf("/members/%d/promote", id), nil)
+}
+
+// RemoveNodeCmd remove raft node
+func RemoveNodeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove",
+		Short: "Remove a member or learner from raft cluster",
+		Run:   removeNode,
+	}
+	addNodeIDFlag(cmd)
+	return cmd
+}
+
+func removeNode(cmd *cobra.Command, args []string) {
+	id, _ := cmd.Flags().GetUint64("id")
+	doRequest(cmd, http.MethodDelete, fmt.Sprintf("/members/%d", id), nil)
+}
+
+// TransferLeaderCmd transfer raft leadership
+func TransferLeaderCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "transfer",
+		Short: "Transfer raft leadership to another voting member",
+		Run:   transferLeader,
+	}
+	addNodeIDFlag(cmd)
+	return cmd
+}
+
+func transferLeader(cmd *cobra.Command, args []string) {
+	id, _ := cmd.Flags().GetUint64("id")
+	doRequest(cmd, http.MethodPost, fmt.Sprintf("/leader/%d", id), nil)
+}
+
+func newClient(cmd *cobra.Command) (*http.Client, error) {
+	certFile, _ := cmd.Flags().GetString("cert")
+	keyFile, _ := cmd.Flags().GetString("key")
+	caFile, _ := cmd.Flags().GetString("cacert")
+	client := &http.Client{Timeout: 2 * time.Minute}
+	if certFile == "" && caFile == "" {
+		return client, nil
+	}
+	tlsCfg := &tls.Config{}
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsCfg.Certificates = []tls.Certificate{cert}
+	}
+	if caFile != "" {
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("invalid ca certificate")
+		}
+		tlsCfg.RootCAs = pool
+	}
+	client.Transport = &http.Transport{TLSClientConfig: tlsCfg}
+	return client, nil
+}
+
+func doRequest(cmd *cobra.Command, method, path string, body io.Reader) {
+	api, _ := cmd.Flags().GetString("api")
+	token, _ := cmd.Flags().GetString("token")
+	client, err := newClient(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	req, err := http.NewRequest(method, strings.TrimRight(api, "/")+path, body)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	if resp.StatusCode/100 != 2 {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", resp.Status, strings.TrimSpace(string(data)))
+		return
+	}
+	var buf bytes.Buffer
+	if err = json.Indent(&buf, data, "", "    "); err != nil {
+		fmt.Println(string(data))
+		return
+	}
+	fmt.Println(buf.String())
+}
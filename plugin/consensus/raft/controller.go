@@ -8,6 +8,7 @@ import (
 	"context"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
@@ -43,12 +44,41 @@ type subConfig struct {
 	WriteBlockSeconds  int64  `json:"writeBlockSeconds"`
 	HeartbeatTick      int32  `json:"heartbeatTick"`
 	EmptyBlockInterval int64  `json:"emptyBlockInterval"`
+	// 成员管理接口监听地址, 默认只监听本机
+	RaftAPIHost string `json:"raftAPIHost"`
+	// 成员管理接口的Bearer token
+	RaftAPIToken string `json:"raftAPIToken"`
+	// 成员管理接口的TLS证书, 配置raftAPIClientCAFile后要求客户端证书认证
+	RaftAPICertFile     string `json:"raftAPICertFile"`
+	RaftAPIKeyFile      string `json:"raftAPIKeyFile"`
+	RaftAPIClientCAFile string `json:"raftAPIClientCAFile"`
+	// 等待成员变更生效的超时时间, 单位秒, 默认30秒
+	ConfChangeTimeout int64 `json:"confChangeTimeout"`
 }
 
 func init() {
 	mux.Store(isLeader)
 }
 
+func newAPIConfig(subcfg *subConfig) *apiConfig {
+	cfg := &apiConfig{
+		host:         subcfg.RaftAPIHost,
+		port:         int(subcfg.RaftAPIPort),
+		token:        subcfg.RaftAPIToken,
+		certFile:     subcfg.RaftAPICertFile,
+		keyFile:      subcfg.RaftAPIKeyFile,
+		clientCAFile: subcfg.RaftAPIClientCAFile,
+		timeout:      30 * time.Second,
+	}
+	if cfg.host == "" {
+		cfg.host = "localhost"
+	}
+	if subcfg.ConfChangeTimeout > 0 {
+		cfg.timeout = time.Duration(subcfg.ConfChangeTimeout) * time.Second
+	}
+	return cfg
+}
+
 // NewRaftCluster create raft cluster
 func NewRaftCluster(cfg *types.Consensus, sub []byte) queue.Module {
 	genesis = cfg.Genesis
@@ -115,9 +145,9 @@ func NewRaftCluster(cfg *types.Consensus, sub []byte) queue.Module {
 	// propose channel
 	proposeC := make(chan *types.Block)
 	confChangeC = make(chan raftpb.ConfChange)
-	commitC, errorC, snapshotterReady, validatorC := NewRaftNode(ctx, int(subcfg.NodeID), subcfg.IsNewJoinNode, peers, readOnlyPeers, addPeers, getSnapshot, proposeC, confChangeC)
-	//启动raft删除节点操作监听
-	go serveHTTPRaftAPI(ctx, int(subcfg.RaftAPIPort), confChangeC, errorC)
+	node, commitC, errorC, snapshotterReady, validatorC := NewRaftNode(ctx, int(subcfg.NodeID), subcfg.IsNewJoinNode, peers, readOnlyPeers, addPeers, getSnapshot, proposeC, confChangeC)
+	//启动raft成员管理接口
+	go serveHTTPRaftAPI(ctx, newAPIConfig(&subcfg), node, confChangeC, errorC)
 	// 监听commit channel,取block
 	b = NewBlockstore(ctx, cfg, <-snapshotterReady, proposeC, commitC, errorC, validatorC, stop)
 	return b
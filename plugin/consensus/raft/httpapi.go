@@ -6,76 +6,350 @@ package raft
 
 import (
 	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
-	"fmt"
-
+	"github.com/coreos/etcd/raft"
 	"github.com/coreos/etcd/raft/raftpb"
 )
 
+var (
+	errUnauthorized     = errors.New("unauthorized")
+	errAPIDisabled      = errors.New("membership api disabled, please config raftAPIToken or raftAPIClientCAFile")
+	errMemberExist      = errors.New("member already exists")
+	errMemberNotExist   = errors.New("member not exists")
+	errNotLearner       = errors.New("member is not a learner")
+	errConfChangeExpire = errors.New("wait conf change applied timeout")
+)
+
+// membership raft节点提供给管理接口的能力
+type membership interface {
+	Status() raft.Status
+	ConfState() raftpb.ConfState
+	WaitConfState(ctx context.Context, applied func(raftpb.ConfState) bool) error
+	TransferLeadership(ctx context.Context, transferee uint64) error
+}
+
+// apiConfig raft管理接口配置
+type apiConfig struct {
+	host         string
+	port         int
+	token        string
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	timeout      time.Duration
+}
+
+func (c *apiConfig) mtls() bool {
+	return c.certFile != "" && c.keyFile != "" && c.clientCAFile != ""
+}
+
+// PeerStatus raft节点复制进度
+type PeerStatus struct {
+	ID        uint64 `json:"id"`
+	Match     uint64 `json:"match"`
+	Next      uint64 `json:"next"`
+	State     string `json:"state"`
+	IsLearner bool   `json:"isLearner"`
+	Active    bool   `json:"active"`
+}
+
+// Status raft节点状态
+type Status struct {
+	ID             uint64        `json:"id"`
+	Term           uint64        `json:"term"`
+	Vote           uint64        `json:"vote"`
+	Commit         uint64        `json:"commit"`
+	Applied        uint64        `json:"applied"`
+	Lead           uint64        `json:"lead"`
+	RaftState      string        `json:"raftState"`
+	LeadTransferee uint64        `json:"leadTransferee"`
+	Nodes          []uint64      `json:"nodes"`
+	Learners       []uint64      `json:"learners"`
+	Progress       []*PeerStatus `json:"progress,omitempty"`
+}
+
+func newStatus(st raft.Status, cs raftpb.ConfState) *Status {
+	status := &Status{
+		ID:             st.ID,
+		Term:           st.Term,
+		Vote:           st.Vote,
+		Commit:         st.Commit,
+		Applied:        st.Applied,
+		Lead:           st.Lead,
+		RaftState:      st.RaftState.String(),
+		LeadTransferee: st.LeadTransferee,
+		Nodes:          cs.Nodes,
+		Learners:       cs.Learners,
+	}
+	for id, pr := range st.Progress {
+		status.Progress = append(status.Progress, &PeerStatus{
+			ID:        id,
+			Match:     pr.Match,
+			Next:      pr.Next,
+			State:     pr.State.String(),
+			IsLearner: pr.IsLearner,
+			Active:    pr.RecentActive,
+		})
+	}
+	return status
+}
+
+func containsID(ids []uint64, id uint64) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
 // Handler for a http based httpRaftAPI backed by raft
 type httpRaftAPI struct {
 	confChangeC chan<- raftpb.ConfChange
+	node        membership
+	cfg         *apiConfig
 }
 
-func (h *httpRaftAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	key := r.RequestURI
-	switch {
-	case r.Method == "POST":
-		url, err := ioutil.ReadAll(r.Body)
-		if err != nil {
-			rlog.Error(fmt.Sprintf("Failed to convert ID for conf change (%v)", err.Error()))
-			http.Error(w, "Failed on POST", http.StatusBadRequest)
-			return
+// authorize 成员变更必须通过token或客户端证书认证, 未配置认证时只开放状态查询
+func (h *httpRaftAPI) authorize(r *http.Request, write bool) error {
+	if h.cfg.token == "" && !h.cfg.mtls() {
+		if write {
+			return errAPIDisabled
 		}
-
-		nodeID, err := strconv.ParseUint(key[1:], 0, 64)
-		if err != nil {
-			rlog.Error(fmt.Sprintf("Failed to convert ID for conf change (%v)", err.Error()))
-			http.Error(w, "Failed on POST", http.StatusBadRequest)
-			return
+		return nil
+	}
+	if h.cfg.token != "" {
+		auth := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(auth, []byte("Bearer "+h.cfg.token)) == 1 {
+			return nil
 		}
+	}
+	if h.cfg.mtls() && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		return nil
+	}
+	return errUnauthorized
+}
 
-		cc := raftpb.ConfChange{
-			Type:    raftpb.ConfChangeAddNode,
-			NodeID:  nodeID,
-			Context: url,
-		}
-		h.confChangeC <- cc
-		// As above, optimistic that raft will apply the conf change
-		w.WriteHeader(http.StatusCreated)
-	case r.Method == "DELETE":
-		nodeID, err := strconv.ParseUint(key[1:], 0, 64)
-		if err != nil {
-			rlog.Error(fmt.Sprintf("Failed to convert ID for conf change (%v)", err.Error()))
-			http.Error(w, "Failed on DELETE", http.StatusBadRequest)
-			return
-		}
-		cc := raftpb.ConfChange{
-			Type:   raftpb.ConfChangeRemoveNode,
-			NodeID: nodeID,
+func (h *httpRaftAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	paths := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	write := r.Method != http.MethodGet
+	if err := h.authorize(r, write); err != nil {
+		rlog.Error("raft api authorize", "remote", r.RemoteAddr, "path", r.URL.Path, "err", err)
+		code := http.StatusUnauthorized
+		if err == errAPIDisabled {
+			code = http.StatusForbidden
 		}
-		h.confChangeC <- cc
-		// As above, optimistic that raft will apply the conf change
-		w.WriteHeader(http.StatusAccepted)
+		http.Error(w, err.Error(), code)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), h.cfg.timeout)
+	defer cancel()
+
+	switch {
+	case r.Method == http.MethodGet && len(paths) == 1 && paths[0] == "status":
+		h.writeJSON(w, http.StatusOK, newStatus(h.node.Status(), h.node.ConfState()))
+	// 兼容旧接口: POST /{id}, DELETE /{id}
+	case r.Method == http.MethodPost && len(paths) == 1:
+		h.addNode(ctx, w, r, paths[0], raftpb.ConfChangeAddNode)
+	case r.Method == http.MethodDelete && len(paths) == 1:
+		h.removeNode(ctx, w, paths[0])
+	case r.Method == http.MethodPost && len(paths) == 2 && paths[0] == "members":
+		h.addNode(ctx, w, r, paths[1], raftpb.ConfChangeAddNode)
+	case r.Method == http.MethodPost && len(paths) == 2 && paths[0] == "learners":
+		h.addNode(ctx, w, r, paths[1], raftpb.ConfChangeAddLearnerNode)
+	case r.Method == http.MethodPost && len(paths) == 3 && paths[0] == "members" && paths[2] == "promote":
+		h.promoteNode(ctx, w, paths[1])
+	case r.Method == http.MethodDelete && len(paths) == 2 && paths[0] == "members":
+		h.removeNode(ctx, w, paths[1])
+	case r.Method == http.MethodPost && len(paths) == 2 && paths[0] == "leader":
+		h.transferLeader(ctx, w, paths[1])
 	default:
+		w.Header().Add("Allow", "GET")
 		w.Header().Add("Allow", "POST")
 		w.Header().Add("Allow", "DELETE")
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
 
-func serveHTTPRaftAPI(ctx context.Context, port int, confChangeC chan<- raftpb.ConfChange, errorC <-chan error) {
+func (h *httpRaftAPI) writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		rlog.Error("raft api write response", "err", err)
+	}
+}
+
+func parseNodeID(w http.ResponseWriter, key string) (uint64, bool) {
+	nodeID, err := strconv.ParseUint(key, 0, 64)
+	if err != nil || nodeID == 0 {
+		rlog.Error(fmt.Sprintf("Failed to convert ID for conf change (%v)", key))
+		http.Error(w, "Invalid node id", http.StatusBadRequest)
+		return 0, false
+	}
+	return nodeID, true
+}
+
+// proposeConfChange 提交成员变更并等待raft应用后才返回
+func (h *httpRaftAPI) proposeConfChange(ctx context.Context, w http.ResponseWriter, cc raftpb.ConfChange, code int, applied func(raftpb.ConfState) bool) {
+	select {
+	case h.confChangeC <- cc:
+	case <-ctx.Done():
+		http.Error(w, errConfChangeExpire.Error(), http.StatusGatewayTimeout)
+		return
+	}
+	if err := h.node.WaitConfState(ctx, applied); err != nil {
+		rlog.Error("wait conf change", "type", cc.Type, "nodeID", cc.NodeID, "err", err)
+		http.Error(w, errConfChangeExpire.Error(), http.StatusGatewayTimeout)
+		return
+	}
+	rlog.Info("conf change applied", "type", cc.Type, "nodeID", cc.NodeID)
+	h.writeJSON(w, code, h.node.ConfState())
+}
+
+func (h *httpRaftAPI) addNode(ctx context.Context, w http.ResponseWriter, r *http.Request, key string, ty raftpb.ConfChangeType) {
+	nodeID, ok := parseNodeID(w, key)
+	if !ok {
+		return
+	}
+	url, err := ioutil.ReadAll(r.Body)
+	if err != nil || len(url) == 0 {
+		http.Error(w, "Failed on POST, peer url is required", http.StatusBadRequest)
+		return
+	}
+	cs := h.node.ConfState()
+	if containsID(cs.Nodes, nodeID) || containsID(cs.Learners, nodeID) {
+		http.Error(w, errMemberExist.Error(), http.StatusConflict)
+		return
+	}
+	cc := raftpb.ConfChange{
+		Type:    ty,
+		NodeID:  nodeID,
+		Context: url,
+	}
+	h.proposeConfChange(ctx, w, cc, http.StatusCreated, func(cs raftpb.ConfState) bool {
+		if ty == raftpb.ConfChangeAddLearnerNode {
+			return containsID(cs.Learners, nodeID)
+		}
+		return containsID(cs.Nodes, nodeID)
+	})
+}
+
+// promoteNode learner节点提升为投票节点
+func (h *httpRaftAPI) promoteNode(ctx context.Context, w http.ResponseWriter, key string) {
+	nodeID, ok := parseNodeID(w, key)
+	if !ok {
+		return
+	}
+	if !containsID(h.node.ConfState().Learners, nodeID) {
+		http.Error(w, errNotLearner.Error(), http.StatusConflict)
+		return
+	}
+	cc := raftpb.ConfChange{
+		Type:   raftpb.ConfChangeAddNode,
+		NodeID: nodeID,
+	}
+	h.proposeConfChange(ctx, w, cc, http.StatusOK, func(cs raftpb.ConfState) bool {
+		return containsID(cs.Nodes, nodeID) && !containsID(cs.Learners, nodeID)
+	})
+}
+
+func (h *httpRaftAPI) removeNode(ctx context.Context, w http.ResponseWriter, key string) {
+	nodeID, ok := parseNodeID(w, key)
+	if !ok {
+		return
+	}
+	cs := h.node.ConfState()
+	if !containsID(cs.Nodes, nodeID) && !containsID(cs.Learners, nodeID) {
+		http.Error(w, errMemberNotExist.Error(), http.StatusNotFound)
+		return
+	}
+	cc := raftpb.ConfChange{
+		Type:   raftpb.ConfChangeRemoveNode,
+		NodeID: nodeID,
+	}
+	h.proposeConfChange(ctx, w, cc, http.StatusOK, func(cs raftpb.ConfState) bool {
+		return !containsID(cs.Nodes, nodeID) && !containsID(cs.Learners, nodeID)
+	})
+}
+
+func (h *httpRaftAPI) transferLeader(ctx context.Context, w http.ResponseWriter, key string) {
+	nodeID, ok := parseNodeID(w, key)
+	if !ok {
+		return
+	}
+	if !containsID(h.node.ConfState().Nodes, nodeID) {
+		http.Error(w, errMemberNotExist.Error(), http.StatusNotFound)
+		return
+	}
+	if err := h.node.TransferLeadership(ctx, nodeID); err != nil {
+		rlog.Error("transfer leadership", "transferee", nodeID, "err", err)
+		http.Error(w, err.Error(), http.StatusGatewayTimeout)
+		return
+	}
+	h.writeJSON(w, http.StatusOK, newStatus(h.node.Status(), h.node.ConfState()))
+}
+
+func newAPIServer(cfg *apiConfig, handler http.Handler) (*http.Server, error) {
 	srv := &http.Server{
-		Addr: "localhost:" + strconv.Itoa(port),
-		Handler: &httpRaftAPI{
-			confChangeC: confChangeC,
-		},
+		Addr:    cfg.host + ":" + strconv.Itoa(cfg.port),
+		Handler: handler,
+	}
+	if !cfg.mtls() {
+		return srv, nil
+	}
+	caPem, err := ioutil.ReadFile(cfg.clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPem) {
+		return nil, fmt.Errorf("invalid client ca file %s", cfg.clientCAFile)
+	}
+	clientAuth := tls.RequireAndVerifyClientCert
+	if cfg.token != "" {
+		// 同时配置token时, 允许不带证书的客户端使用token认证
+		clientAuth = tls.VerifyClientCertIfGiven
+	}
+	srv.TLSConfig = &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: clientAuth,
+		MinVersion: tls.VersionTLS12,
+	}
+	return srv, nil
+}
+
+func serveHTTPRaftAPI(ctx context.Context, cfg *apiConfig, node membership, confChangeC chan<- raftpb.ConfChange, errorC <-chan error) {
+	if cfg.token == "" && !cfg.mtls() {
+		rlog.Warn("raft membership api has no authentication configured, only status query is allowed")
+	}
+	srv, err := newAPIServer(cfg, &httpRaftAPI{
+		confChangeC: confChangeC,
+		node:        node,
+		cfg:         cfg,
+	})
+	if err != nil {
+		rlog.Error(fmt.Sprintf("create raft api server have a err: (%v)", err.Error()))
+		return
 	}
 	go func() {
-		if err := srv.ListenAndServe(); err != nil {
+		var err error
+		if cfg.mtls() {
+			err = srv.ListenAndServeTLS(cfg.certFile, cfg.keyFile)
+		} else {
+			err = srv.ListenAndServe()
+		}
+		if err != nil {
 			rlog.Error(fmt.Sprintf("ListenAndServe have a err: (%v)", err.Error()))
 		}
 	}()
@@ -0,0 +1,188 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package raft
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMembership 模拟raft节点应用成员变更
+type fakeMembership struct {
+	mu      sync.Mutex
+	cs      raftpb.ConfState
+	lead    uint64
+	applied chan struct{}
+	ignore  bool
+}
+
+func newFakeMembership(confChangeC <-chan raftpb.ConfChange) *fakeMembership {
+	m := &fakeMembership{
+		cs:      raftpb.ConfState{Nodes: []uint64{1}},
+		lead:    1,
+		applied: make(chan struct{}),
+	}
+	go func() {
+		for cc := range confChangeC {
+			m.apply(cc)
+		}
+	}()
+	return m
+}
+
+func removeID(ids []uint64, id uint64) []uint64 {
+	var res []uint64
+	for _, i := range ids {
+		if i != id {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+func (m *fakeMembership) apply(cc raftpb.ConfChange) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ignore {
+		return
+	}
+	cs := raftpb.ConfState{Nodes: removeID(m.cs.Nodes, cc.NodeID), Learners: removeID(m.cs.Learners, cc.NodeID)}
+	switch cc.Type {
+	case raftpb.ConfChangeAddNode:
+		cs.Nodes = append(cs.Nodes, cc.NodeID)
+	case raftpb.ConfChangeAddLearnerNode:
+		cs.Learners = append(cs.Learners, cc.NodeID)
+	}
+	m.cs = cs
+	close(m.applied)
+	m.applied = make(chan struct{})
+}
+
+func (m *fakeMembership) Status() raft.Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	st := raft.Status{ID: 1}
+	st.Lead = m.lead
+	return st
+}
+
+func (m *fakeMembership) ConfState() raftpb.ConfState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cs
+}
+
+func (m *fakeMembership) WaitConfState(ctx context.Context, applied func(raftpb.ConfState) bool) error {
+	for {
+		m.mu.Lock()
+		cs, ch := m.cs, m.applied
+		m.mu.Unlock()
+		if applied(cs) {
+			return nil
+		}
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (m *fakeMembership) TransferLeadership(ctx context.Context, transferee uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lead = transferee
+	return nil
+}
+
+func newTestAPI(cfg *apiConfig) (*httpRaftAPI, *fakeMembership) {
+	confChangeC := make(chan raftpb.ConfChange)
+	node := newFakeMembership(confChangeC)
+	return &httpRaftAPI{confChangeC: confChangeC, node: node, cfg: cfg}, node
+}
+
+func doAPI(h http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestRaftAPIAuthorize(t *testing.T) {
+	h, _ := newTestAPI(&apiConfig{timeout: time.Second})
+	require.Equal(t, http.StatusOK, doAPI(h, http.MethodGet, "/status", "", "").Code)
+	require.Equal(t, http.StatusForbidden, doAPI(h, http.MethodPost, "/members/2", "", "http://127.0.0.1:9022").Code)
+	require.Equal(t, http.StatusForbidden, doAPI(h, http.MethodDelete, "/1", "", "").Code)
+
+	h, _ = newTestAPI(&apiConfig{token: "secret", timeout: time.Second})
+	require.Equal(t, http.StatusUnauthorized, doAPI(h, http.MethodGet, "/status", "", "").Code)
+	require.Equal(t, http.StatusUnauthorized, doAPI(h, http.MethodPost, "/members/2", "wrong", "http://127.0.0.1:9022").Code)
+	require.Equal(t, http.StatusOK, doAPI(h, http.MethodGet, "/status", "secret", "").Code)
+}
+
+func TestRaftAPIMembership(t *testing.T) {
+	h, node := newTestAPI(&apiConfig{token: "secret", timeout: time.Second})
+
+	w := doAPI(h, http.MethodPost, "/learners/2", "secret", "http://127.0.0.1:9022")
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, []uint64{2}, node.ConfState().Learners)
+	require.Equal(t, http.StatusConflict, doAPI(h, http.MethodPost, "/members/2", "secret", "http://127.0.0.1:9022").Code)
+	require.Equal(t, http.StatusBadRequest, doAPI(h, http.MethodPost, "/members/3", "secret", "").Code)
+	require.Equal(t, http.StatusBadRequest, doAPI(h, http.MethodPost, "/members/abc", "secret", "http://127.0.0.1:9023").Code)
+
+	// learner提升为投票成员
+	require.Equal(t, http.StatusConflict, doAPI(h, http.MethodPost, "/members/1/promote", "secret", "").Code)
+	require.Equal(t, http.StatusOK, doAPI(h, http.MethodPost, "/members/2/promote", "secret", "").Code)
+	require.Equal(t, []uint64{1, 2}, node.ConfState().Nodes)
+	require.Empty(t, node.ConfState().Learners)
+
+	// 兼容旧接口
+	require.Equal(t, http.StatusCreated, doAPI(h, http.MethodPost, "/3", "secret", "http://127.0.0.1:9023").Code)
+	require.Equal(t, []uint64{1, 2, 3}, node.ConfState().Nodes)
+
+	w = doAPI(h, http.MethodPost, "/leader/2", "secret", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var status Status
+	require.Nil(t, json.Unmarshal(w.Body.Bytes(), &status))
+	require.Equal(t, uint64(2), status.Lead)
+	require.Equal(t, []uint64{1, 2, 3}, status.Nodes)
+	require.Equal(t, http.StatusNotFound, doAPI(h, http.MethodPost, "/leader/4", "secret", "").Code)
+
+	require.Equal(t, http.StatusOK, doAPI(h, http.MethodDelete, "/members/3", "secret", "").Code)
+	require.Equal(t, []uint64{1, 2}, node.ConfState().Nodes)
+	require.Equal(t, http.StatusNotFound, doAPI(h, http.MethodDelete, "/members/3", "secret", "").Code)
+	require.Equal(t, http.StatusMethodNotAllowed, doAPI(h, http.MethodPut, "/members/3", "secret", "").Code)
+}
+
+func TestRaftAPIConfChangeTimeout(t *testing.T) {
+	h, node := newTestAPI(&apiConfig{token: "secret", timeout: 200 * time.Millisecond})
+	node.ignore = true
+	w := doAPI(h, http.MethodPost, "/members/2", "secret", "http://127.0.0.1:9022")
+	require.Equal(t, http.StatusGatewayTimeout, w.Code)
+	require.Equal(t, []uint64{1}, node.ConfState().Nodes)
+}
+
+func TestNewAPIConfig(t *testing.T) {
+	cfg := newAPIConfig(&subConfig{RaftAPIPort: 9121})
+	require.Equal(t, "localhost", cfg.host)
+	require.Equal(t, 30*time.Second, cfg.timeout)
+	require.False(t, cfg.mtls())
+	cfg = newAPIConfig(&subConfig{RaftAPIHost: "0.0.0.0", ConfChangeTimeout: 5, RaftAPICertFile: "c", RaftAPIKeyFile: "k", RaftAPIClientCAFile: "ca"})
+	require.Equal(t, 5*time.Second, cfg.timeout)
+	require.True(t, cfg.mtls())
+}
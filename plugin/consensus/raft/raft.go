@@ -48,7 +48,9 @@ type raftNode struct {
 	snapdir          string
 	getSnapshot      func() ([]byte, error)
 	lastIndex        uint64
+	confMu           sync.RWMutex
 	confState        raftpb.ConfState
+	confApplied      chan struct{}
 	snapshotIndex    uint64
 	appliedIndex     uint64
 	node             raft.Node
@@ -75,7 +77,7 @@ type Node struct {
 
 // NewRaftNode create raft node
 func NewRaftNode(ctx context.Context, id int, join bool, peers []string, readOnlyPeers []string, addPeers []string, getSnapshot func() ([]byte, error), proposeC <-chan *types.Block,
-	confChangeC <-chan raftpb.ConfChange) (<-chan *types.Block, <-chan error, <-chan *snap.Snapshotter, <-chan bool) {
+	confChangeC <-chan raftpb.ConfChange) (*Node, <-chan *types.Block, <-chan error, <-chan *snap.Snapshotter, <-chan bool) {
 
 	rlog.Info("Enter consensus raft")
 	// commit channel
@@ -98,11 +100,12 @@ func NewRaftNode(ctx context.Context, id int, join bool, peers []string, readOnl
 		validatorC:       make(chan bool),
 		snapshotterReady: make(chan *snap.Snapshotter, 1),
 		restartC:         make(chan struct{}, 1),
+		confApplied:      make(chan struct{}),
 		ctx:              ctx,
 	}
 	go rc.startRaft()
 
-	return commitC, errorC, rc.snapshotterReady, rc.validatorC
+	return &Node{rc}, commitC, errorC, rc.snapshotterReady, rc.validatorC
 }
 
 //  启动raft节点
@@ -139,6 +142,7 @@ func (rc *raftNode) startRaft() {
 	if len(rc.readOnlyPeers) > 0 && rc.id > len(rc.bootstrapPeers) {
 		rc.join = true
 	}
+	rc.stopMu.Lock()
 	if oldwal {
 		rc.restartC <- struct{}{}
 		rc.node = raft.RestartNode(c)
@@ -149,6 +153,7 @@ func (rc *raftNode) startRaft() {
 		}
 		rc.node = raft.StartNode(c, startPeers)
 	}
+	rc.stopMu.Unlock()
 
 	rc.transport = &rafthttp.Transport{
 		ID:          typec.ID(rc.id),
@@ -216,7 +221,7 @@ func (rc *raftNode) serveChannels() {
 	if err != nil {
 		panic(err)
 	}
-	rc.confState = snapShot.Metadata.ConfState
+	rc.setConfState(snapShot.Metadata.ConfState)
 	rc.snapshotIndex = snapShot.Metadata.Index
 	rc.appliedIndex = snapShot.Metadata.Index
 
@@ -339,9 +344,71 @@ func (rc *raftNode) updateValidator() {
 func (rc *raftNode) Status() raft.Status {
 	rc.stopMu.RLock()
 	defer rc.stopMu.RUnlock()
+	if rc.node == nil {
+		return raft.Status{}
+	}
 	return rc.node.Status()
 }
 
+// ConfState 当前已应用的集群成员配置
+func (rc *raftNode) ConfState() raftpb.ConfState {
+	rc.confMu.RLock()
+	defer rc.confMu.RUnlock()
+	return rc.confState
+}
+
+// setConfState 更新成员配置并唤醒等待成员变更的调用者
+func (rc *raftNode) setConfState(cs raftpb.ConfState) {
+	rc.confMu.Lock()
+	rc.confState = cs
+	close(rc.confApplied)
+	rc.confApplied = make(chan struct{})
+	rc.confMu.Unlock()
+}
+
+// WaitConfState 等待成员变更被应用
+func (rc *raftNode) WaitConfState(ctx context.Context, applied func(raftpb.ConfState) bool) error {
+	for {
+		rc.confMu.RLock()
+		cs, ch := rc.confState, rc.confApplied
+		rc.confMu.RUnlock()
+		if applied(cs) {
+			return nil
+		}
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// TransferLeadership 转移leader并等待新leader当选
+func (rc *raftNode) TransferLeadership(ctx context.Context, transferee uint64) error {
+	status := rc.Status()
+	if status.Lead == raft.None {
+		return errors.New("no leader")
+	}
+	if status.Lead == transferee {
+		return nil
+	}
+	rc.stopMu.RLock()
+	rc.node.TransferLeadership(ctx, status.Lead, transferee)
+	rc.stopMu.RUnlock()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if rc.Status().Lead == transferee {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
 func (rc *raftNode) replayWAL() *wal.WAL {
 	rlog.Info(fmt.Sprintf("replaying WAL of member %v", rc.id))
 	snapshot := rc.loadSnapshot()
@@ -433,7 +500,7 @@ func (rc *raftNode) publishSnapshot(snapshotToSave raftpb.Snapshot) {
 	}
 	rc.commitC <- nil // trigger kvstore to load snapshot
 
-	rc.confState = snapshotToSave.Metadata.ConfState
+	rc.setConfState(snapshotToSave.Metadata.ConfState)
 	rc.snapshotIndex = snapshotToSave.Metadata.Index
 	rc.appliedIndex = snapshotToSave.Metadata.Index
 }
@@ -510,7 +577,7 @@ func (rc *raftNode) publishEntries(ents []raftpb.Entry) bool {
 		case raftpb.EntryConfChange:
 			var cc raftpb.ConfChange
 			cc.Unmarshal(ents[i].Data)
-			rc.confState = *rc.node.ApplyConfChange(cc)
+			rc.setConfState(*rc.node.ApplyConfChange(cc))
 			switch cc.Type {
 			case raftpb.ConfChangeAddNode:
 				if len(cc.Context) > 0 {
@@ -63,6 +63,20 @@ func (c *commitCache) addValidatorSign(isSelf bool, sign *rtypes.ValidatorSignMs
 	set.others = append(set.others, sign)
 }
 
+// 获取本节点在指定轮次之后的签名
+func (c *commitCache) getSelfSigns(startRound int64) []*rtypes.ValidatorSignMsg {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	signs := make([]*rtypes.ValidatorSignMsg, 0, len(c.signList))
+	for round, set := range c.signList {
+		if round >= startRound && set.self != nil {
+			signs = append(signs, set.self)
+		}
+	}
+	return signs
+}
+
 // clean already commit batch and sign
 func (c *commitCache) cleanHistory(currRound int64) {
 	c.lock.Lock()
@@ -0,0 +1,102 @@
+package rollup
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/system/crypto/secp256k1"
+	"github.com/33cn/chain33/types"
+	pt "github.com/33cn/plugin/plugin/dapp/paracross/types"
+)
+
+// 主链启用挑战期时, 提交轮次在挑战期内可能被回滚, 跨链交易等待挑战期结束后单独提交
+type pendingCrossTx struct {
+	lock sync.Mutex
+	// commit round => cross tx
+	list map[int64]*pt.RollupCrossTx
+}
+
+func newPendingCrossTx() *pendingCrossTx {
+	return &pendingCrossTx{list: make(map[int64]*pt.RollupCrossTx, 8)}
+}
+
+func (p *pendingCrossTx) add(crossTx *pt.RollupCrossTx) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.list[crossTx.GetCommitRound()] = crossTx
+}
+
+func (p *pendingCrossTx) remove(round int64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	delete(p.list, round)
+}
+
+// 按轮次顺序返回不超过maxRound的跨链交易
+func (p *pendingCrossTx) getRounds(maxRound int64) []*pt.RollupCrossTx {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	list := make([]*pt.RollupCrossTx, 0, len(p.list))
+	for round, crossTx := range p.list {
+		if round <= maxRound {
+			list = append(list, crossTx)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].GetCommitRound() < list[j].GetCommitRound()
+	})
+	return list
+}
+
+func (r *RollUp) handlePendingCrossTx() {
+
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// 被回滚的轮次重新提交后才能执行跨链交易
+		status := r.val.getRollupStatus()
+		list := r.pending.getRounds(status.GetCommitRound())
+		if len(list) == 0 {
+			continue
+		}
+		mainHeader, err := r.mainChainGrpc.GetLastHeader(r.ctx, &types.ReqNil{})
+		if err != nil {
+			rlog.Error("handlePendingCrossTx", "getLastHeader err", err)
+			continue
+		}
+
+		for _, crossTx := range list {
+			round := crossTx.GetCommitRound()
+			info := r.getCommitRoundInfo(round)
+			if info == nil || mainHeader.GetHeight() < info.GetChallengeEndHeight() {
+				break
+			}
+			if err := r.sendCrossTx(crossTx); err != nil {
+				rlog.Error("handlePendingCrossTx", "round", round, "err", err)
+				break
+			}
+			rlog.Info("handlePendingCrossTx", "round", round, "crossTx", len(crossTx.GetTxIndices()))
+			r.pending.remove(round)
+		}
+	}
+}
+
+func (r *RollUp) sendCrossTx(crossTx *pt.RollupCrossTx) error {
+
+	tx, err := r.createTx(pt.ParaX, pt.NameRollupCrossTxAction, types.Encode(crossTx))
+	if err != nil {
+		return err
+	}
+	tx.Fee, _ = tx.GetRealFee(r.getProperFeeRate())
+	tx.Sign(types.EncodeSignID(secp256k1.ID, r.cfg.AddressID), r.val.signTxKey)
+	return r.sendTx2MainChain(tx)
+}
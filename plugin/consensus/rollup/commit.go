@@ -113,10 +113,9 @@ func (r *RollUp) buildFullData(details []*types.BlockDetail, commitRound int64,
 		return nil
 	}
 	// 区块分割后接续, 从下标位置读取交易, 只有首次启动构建存在断点拼接情况
-	if *fragIndex > 0 {
-		details[0].Block.Txs = details[0].Block.Txs[*fragIndex:]
-		*fragIndex = 0
-	}
+	// 分割位置为交易在区块中的下标, 和区块回执及链上记录的分割位置一致
+	startIndex := int(*fragIndex)
+	*fragIndex = 0
 
 	for _, detail := range details {
 
@@ -125,6 +124,9 @@ func (r *RollUp) buildFullData(details []*types.BlockDetail, commitRound int64,
 		batch.BlockHeaders = append(batch.BlockHeaders, header)
 		for i, tx := range detail.Block.Txs {
 
+			if i < startIndex {
+				continue
+			}
 			ctx := types.CloneTx(tx)
 			ctx.Signature = nil
 			txData := types.Encode(ctx)
@@ -132,6 +134,10 @@ func (r *RollUp) buildFullData(details []*types.BlockDetail, commitRound int64,
 			// 超过最大容量, 区块分割
 			if commitSize+len(txData) > maxCommitDataSize {
 
+				// 区块首个交易即超过容量, 区块整体在下一批次提交, 无需分割
+				if i == 0 {
+					batch.BlockHeaders = batch.BlockHeaders[:len(batch.BlockHeaders)-1]
+				}
 				if sealBlockBatch(i) != nil {
 					return nil, nil
 				}
@@ -160,6 +166,7 @@ func (r *RollUp) buildFullData(details []*types.BlockDetail, commitRound int64,
 			batch.TxAddrIDList = append(batch.TxAddrIDList, byte(types.ExtractAddressID(ctx.Signature.Ty)))
 			batch.TxList = append(batch.TxList, txData)
 		}
+		startIndex = 0
 	}
 
 	if sealBlockBatch(0) != nil {
@@ -236,6 +243,11 @@ func (r *RollUp) handleCommit() {
 		}
 
 		nextCommitRound, ok := r.val.isMyCommitTurn(r.cfg.MaxCommitInterval)
+		// 已提交的轮次被挑战回滚, 需要重新提交
+		if ok && nextCommitRound < alreadyCommitRound {
+			rlog.Info("handleCommit round reverted", "round", nextCommitRound, "alreadyCommit", alreadyCommitRound)
+			alreadyCommitRound = nextCommitRound - 1
+		}
 		if !ok || nextCommitRound <= alreadyCommitRound {
 			rlog.Debug("handleCommit not ok", "round", nextCommitRound, "alreadyCommit", alreadyCommitRound)
 			time.Sleep(2 * time.Second)
@@ -270,8 +282,10 @@ func (r *RollUp) commit2MainChain(info *commitInfo) error {
 	}
 	tx.Fee, _ = tx.GetRealFee(r.getProperFeeRate())
 	tx.Sign(types.EncodeSignID(secp256k1.ID, r.cfg.AddressID), r.val.signTxKey)
+	// 启用挑战期, 跨链交易在挑战期结束后单独提交
+	challengeMode := r.val.getRollupStatus().GetChallengePeriod() > 0
 	// 提交跨链交易, 构建交易组
-	if len(info.crossTx.TxIndices) > 0 {
+	if len(info.crossTx.TxIndices) > 0 && !challengeMode {
 		tx2, err := r.createTx(pt.ParaX, pt.NameRollupCrossTxAction, types.Encode(info.crossTx))
 		if err != nil {
 			return errors.New("ErrCreateCommitCrossTx")
@@ -292,6 +306,9 @@ func (r *RollUp) commit2MainChain(info *commitInfo) error {
 	if err != nil {
 		return errors.Wrap(err, "sendTx2MainChain")
 	}
+	if len(info.crossTx.TxIndices) > 0 && challengeMode {
+		r.pending.add(info.crossTx)
+	}
 
 	return nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/33cn/chain33/rpc/grpcclient"
 	"github.com/33cn/chain33/system/consensus"
 	"github.com/33cn/chain33/types"
+	rtypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
 )
 
 const (
@@ -49,6 +50,7 @@ type RollUp struct {
 	val                  *validator
 	cache                *commitCache
 	cross                *crossTxHandler
+	pending              *pendingCrossTx
 	lastFeeRate          int64
 }
 
@@ -81,6 +83,7 @@ func (r *RollUp) Init(base *consensus.BaseClient, subCfg []byte) {
 	r.subChan = make(chan *types.TopicData, 32)
 	r.lastFeeRate = 100000
 	r.cross = &crossTxHandler{}
+	r.pending = newPendingCrossTx()
 	r.base = base
 	r.client = base.GetQueueClient()
 	r.val = &validator{}
@@ -174,12 +177,25 @@ func (r *RollUp) startRollupRoutine() {
 
 	<-r.initDone
 
-	if r.val.enable {
+	// 非验证节点等待治理变更加入验证者集合
+	for !r.val.isEnable() {
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-time.After(5 * time.Second):
+		}
+		if status := r.getRollupStatus(); status != nil {
+			r.refreshValidators(status)
+		}
+	}
+
+	if r.val.isEnable() {
 		go r.handleExit()
 		go r.handleBuildBatch()
 		go r.handleCommit()
 		go r.syncRollupState()
 		go r.cross.pullCrossTx()
+		go r.handlePendingCrossTx()
 
 		n := runtime.NumCPU()
 
@@ -214,16 +230,17 @@ func (r *RollUp) syncRollupState() {
 
 		select {
 		case <-ticker.C:
-			valPubs := r.getValidatorPubKeys()
 			status := r.getRollupStatus()
 
-			if len(valPubs.GetBlsPubs()) > 0 {
-				r.val.updateValidators(valPubs)
-			}
-
 			if status != nil {
+				r.refreshValidators(status)
 				r.val.updateRollupStatus(status)
-				r.cache.cleanHistory(status.CommitRound)
+				// 挑战期内的轮次可能被回滚重新提交, 只清理已确认的轮次
+				cleanRound := status.CommitRound
+				if status.ChallengePeriod > 0 {
+					cleanRound = status.FinalizedRound
+				}
+				r.cache.cleanHistory(cleanRound)
 			}
 
 		case <-r.ctx.Done():
@@ -234,3 +251,30 @@ func (r *RollUp) syncRollupState() {
 	}
 
 }
+
+// 同步验证者集合, 集合变更时重新广播本节点已有的签名, 避免之前被其他节点拒绝
+func (r *RollUp) refreshValidators(status *rtypes.RollupStatus) {
+
+	valPubs := r.getValidatorPubKeys()
+	if len(valPubs.GetBlsPubs()) == 0 {
+		return
+	}
+	changed := r.val.updateValidators(valPubs)
+	sets := r.getValidatorSets()
+	pending := &rtypes.ValidatorPubs{}
+	if len(sets.GetSets()) > 0 {
+		last := sets.GetSets()[len(sets.GetSets())-1]
+		if last.GetEffectiveRound() > status.GetCommitRound()+1 {
+			pending = last
+		}
+	}
+	if sets != nil && r.val.updatePendingValidators(pending) {
+		changed = true
+	}
+	if !changed || !r.val.isEnable() {
+		return
+	}
+	for _, sign := range r.cache.getSelfSigns(status.GetCommitRound() + 1) {
+		r.tryPubMsg(psValidatorSignTopic, types.Encode(sign), sign.CommitRound)
+	}
+}
@@ -33,6 +33,30 @@ func (r *RollUp) getValidatorPubKeys() *rtypes.ValidatorPubs {
 	return res
 }
 
+func (r *RollUp) getValidatorSets() *rtypes.ValidatorSets {
+
+	req := &rtypes.ChainTitle{Value: r.chainCfg.GetTitle()}
+
+	reply, err := r.mainChainGrpc.QueryChain(r.ctx, &types.ChainExecutor{
+		Driver:   rtypes.RollupX,
+		FuncName: "GetValidatorSets",
+		Param:    types.Encode(req),
+	})
+
+	if err != nil || !reply.GetIsOk() {
+		rlog.Error("getValidatorSets", "msg", string(reply.GetMsg()), "query err", err)
+		return nil
+	}
+
+	res := &rtypes.ValidatorSets{}
+	err = types.Decode(reply.GetMsg(), res)
+	if err != nil {
+		rlog.Error("getValidatorSets", "decode err", err)
+		return nil
+	}
+	return res
+}
+
 func (r *RollUp) getRollupStatus() *rtypes.RollupStatus {
 
 	req := &rtypes.ChainTitle{Value: r.chainCfg.GetTitle()}
@@ -105,3 +129,27 @@ func (r *RollUp) fetchCrossTx(start, end int64) (*types.ParaTxDetails, error) {
 	}
 	return r.mainChainGrpc.GetParaTxByTitle(r.ctx, req)
 }
+
+func (r *RollUp) getCommitRoundInfo(round int64) *rtypes.CommitRoundInfo {
+
+	req := &rtypes.ReqGetCommitRound{ChainTitle: r.chainCfg.GetTitle(), CommitRound: round}
+
+	reply, err := r.mainChainGrpc.QueryChain(r.ctx, &types.ChainExecutor{
+		Driver:   rtypes.RollupX,
+		FuncName: "GetCommitRoundInfo",
+		Param:    types.Encode(req),
+	})
+
+	if err != nil || !reply.GetIsOk() {
+		rlog.Error("getCommitRoundInfo", "round", round, "msg", string(reply.GetMsg()), "query err", err)
+		return nil
+	}
+
+	res := &rtypes.CommitRoundInfo{}
+	err = types.Decode(reply.GetMsg(), res)
+	if err != nil {
+		rlog.Error("getCommitRoundInfo", "decode err", err)
+		return nil
+	}
+	return res
+}
@@ -3,6 +3,7 @@ package rollup
 import (
 	"bytes"
 	"encoding/hex"
+	"strings"
 	"sync"
 
 	"github.com/33cn/chain33/common/address"
@@ -19,15 +20,20 @@ import (
 type validator struct {
 	lock             sync.RWMutex
 	enable           bool
+	isValidator      bool
 	commitRoundIndex int32
 	blsKey           crypto.PrivKey
 	signTxKey        crypto.PrivKey
 	commitAddr       string
 	validators       map[string]int
 	valPubHash       []byte
-	blsDriver        crypto.Crypto
-	status           *rtypes.RollupStatus
-	exit             chan struct{}
+	threshold        int
+	// 治理变更后待生效的验证者集合
+	pendingValidators map[string]struct{}
+	pendingHash       []byte
+	blsDriver         crypto.Crypto
+	status            *rtypes.RollupStatus
+	exit              chan struct{}
 }
 
 func getPrivKey(cryptoName, privKey string) (crypto.Crypto, crypto.PrivKey) {
@@ -67,6 +73,10 @@ func (v *validator) isMyCommitTurn(maxCommitInterval int64) (int64, bool) {
 	v.lock.RLock()
 	defer v.lock.RUnlock()
 
+	// 待生效的验证者不参与提交
+	if !v.isValidator {
+		return -1, false
+	}
 	nextCommitRound := v.status.GetCommitRound() + 1
 	roundIdx := int32(nextCommitRound % int64(len(v.validators)))
 
@@ -101,38 +111,84 @@ func (v *validator) updateRollupStatus(status *rtypes.RollupStatus) {
 	v.status = status
 }
 
+func (v *validator) getRollupStatus() *rtypes.RollupStatus {
+
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return v.status
+}
+
 func (v *validator) getValidatorCount() int {
 	v.lock.RLock()
 	defer v.lock.RUnlock()
 	return len(v.validators)
 }
 
-func (v *validator) updateValidators(valPubs *rtypes.ValidatorPubs) {
+func (v *validator) isEnable() bool {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return v.enable
+}
+
+// 更新下一提交轮次的验证者集合, 返回是否有变更
+func (v *validator) updateValidators(valPubs *rtypes.ValidatorPubs) bool {
 	v.lock.Lock()
 	defer v.lock.Unlock()
 
 	hash := common.Sha256(types.Encode(valPubs))
 	// 数据没有变更, 直接返回
 	if bytes.Equal(v.valPubHash, hash) {
-		return
+		return false
 	}
 	// 更新验证节点
 	v.valPubHash = hash
 	v.validators = make(map[string]int, len(valPubs.GetBlsPubs()))
 
 	for i, pub := range valPubs.GetBlsPubs() {
-		pub = rtypes.FormatHexPubKey(pub)
+		pub = strings.ToLower(rtypes.FormatHexPubKey(pub))
 		v.validators[pub] = i
 	}
+	v.threshold = int(valPubs.GetThreshold())
 
 	blsPub := hex.EncodeToString(v.blsKey.PubKey().Bytes())
 	idx, ok := v.validators[blsPub]
-	v.enable = ok
+	v.isValidator = ok
 	v.commitRoundIndex = int32(idx)
-	rlog.Info("updateValidators", "blsPub", blsPub, "isValidator", ok, "idx", idx)
-	if !v.enable {
+	rlog.Info("updateValidators", "blsPub", blsPub, "isValidator", ok, "idx", idx,
+		"effectiveRound", valPubs.GetEffectiveRound(), "threshold", v.threshold)
+	v.checkEnable()
+	return true
+}
+
+// 更新待生效的验证者集合, 返回是否有变更
+func (v *validator) updatePendingValidators(valPubs *rtypes.ValidatorPubs) bool {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	hash := common.Sha256(types.Encode(valPubs))
+	if bytes.Equal(v.pendingHash, hash) {
+		return false
+	}
+	v.pendingHash = hash
+	v.pendingValidators = make(map[string]struct{}, len(valPubs.GetBlsPubs()))
+	for _, pub := range valPubs.GetBlsPubs() {
+		v.pendingValidators[strings.ToLower(rtypes.FormatHexPubKey(pub))] = struct{}{}
+	}
+	rlog.Info("updatePendingValidators", "effectiveRound", valPubs.GetEffectiveRound(),
+		"validators", len(valPubs.GetBlsPubs()))
+	v.checkEnable()
+	return true
+}
+
+// 属于当前或待生效的验证者集合, 即需要参与签名, 被移除后退出
+func (v *validator) checkEnable() {
+
+	_, isPending := v.pendingValidators[hex.EncodeToString(v.blsKey.PubKey().Bytes())]
+	enable := v.isValidator || isPending
+	if v.enable && !enable {
 		close(v.exit)
 	}
+	v.enable = enable
 }
 
 func (v *validator) validateSignMsg(sign *rtypes.ValidatorSignMsg) bool {
@@ -145,6 +201,10 @@ func (v *validator) validateSignMsg(sign *rtypes.ValidatorSignMsg) bool {
 	pub := hex.EncodeToString(sign.PubKey)
 
 	_, ok := v.validators[pub]
+	if !ok {
+		// 待生效的验证者可预先对之后的轮次签名
+		_, ok = v.pendingValidators[pub]
+	}
 	if !ok {
 		rlog.Error("validateSignMsg invalid node", "round", sign.CommitRound, "pub", pub)
 		return false
@@ -177,9 +237,14 @@ func (v *validator) aggregateSign(set *validatorSignMsgSet) (pubs [][]byte, aggr
 	if set == nil || set.self == nil {
 		return nil, nil
 	}
-	valCount := v.getValidatorCount()
-	// 2/3 共识
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	valCount := len(v.validators)
+	// 默认2/3 共识, 或治理设置的门限
 	minSignCount := valCount*2/3 + 1
+	if v.threshold > 0 {
+		minSignCount = v.threshold
+	}
 	if len(set.others)+1 < minSignCount {
 		rlog.Debug("aggregateSign", "commitRound", set.self.CommitRound,
 			"valCount", valCount, "signCount", len(set.others)+1)
@@ -204,6 +269,13 @@ func (v *validator) aggregateSign(set *validatorSignMsgSet) (pubs [][]byte, aggr
 				"otherHash", hex.EncodeToString(sign.MsgHash))
 			continue
 		}
+		// 验证者集合变更, 已移除的验证者签名
+		if _, ok := v.validators[hex.EncodeToString(sign.PubKey)]; !ok {
+			set.others = append(set.others[:i], set.others[i+1:]...)
+			rlog.Debug("aggregateSign not validator", "commitRound", set.self.CommitRound,
+				"pub", hex.EncodeToString(sign.PubKey))
+			continue
+		}
 		s, _ = v.blsDriver.SignatureFromBytes(sign.GetSignature())
 		signs = append(signs, s)
 		pubs = append(pubs, sign.PubKey)
@@ -159,6 +159,8 @@ func TestAggreSign(t *testing.T) {
 	// 包非法签名, 但正确签名数量满足要求, 正确流程
 	blsKey, _ := val.blsDriver.GenKey()
 	pubKeys = append(pubKeys, blsKey.PubKey())
+	delete(val.validators, "other-node")
+	val.validators[hex.EncodeToString(blsKey.PubKey().Bytes())] = 1
 	val.blsKey = blsKey
 	otherSign := val.sign(1, &rtypes.BlockBatch{})
 	signSet.others = append(signSet.others, errSign, otherSign)
@@ -170,3 +172,40 @@ func TestAggreSign(t *testing.T) {
 	err = blsAggre.VerifyAggregatedOne(pubKeys, signSet.self.MsgHash, sig)
 	require.Nil(t, err)
 }
+
+func TestUpdatePendingValidators(t *testing.T) {
+
+	val, valPubs, _ := newTestVal()
+	selfPub := valPubs.BlsPubs[3]
+	pending := &rtypes.ValidatorPubs{BlsPubs: []string{selfPub, valPubs.BlsPubs[0]}, Threshold: 2, EffectiveRound: 5}
+	require.True(t, val.updatePendingValidators(pending))
+	require.False(t, val.updatePendingValidators(pending))
+
+	// 移出当前集合, 但属于待生效集合, 继续签名但不提交
+	valPubs.BlsPubs = valPubs.BlsPubs[:3]
+	require.True(t, val.updateValidators(valPubs))
+	require.True(t, val.isEnable())
+	_, isTurn := val.isMyCommitTurn(10)
+	require.False(t, isTurn)
+	sign := val.sign(5, &rtypes.BlockBatch{})
+	require.True(t, val.validateSignMsg(sign))
+
+	// 新集合生效
+	require.True(t, val.updateValidators(pending))
+	require.Equal(t, 2, val.threshold)
+	require.True(t, val.updatePendingValidators(&rtypes.ValidatorPubs{}))
+	require.True(t, val.isEnable())
+	val.status.CommitRound = 5
+	_, isTurn = val.isMyCommitTurn(10)
+	require.True(t, isTurn)
+
+	// 被移除后退出
+	require.True(t, val.updateValidators(&rtypes.ValidatorPubs{BlsPubs: valPubs.BlsPubs[:1]}))
+	require.False(t, val.isEnable())
+	select {
+	case <-val.exit:
+	default:
+		t.Error("validator exit chan not closed")
+	}
+	require.False(t, val.updatePendingValidators(&rtypes.ValidatorPubs{}))
+}
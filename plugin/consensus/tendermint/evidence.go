@@ -0,0 +1,119 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tendermint
+
+import (
+	"sync"
+
+	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
+	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
+)
+
+const (
+	// 证据池最多缓存的证据数量
+	maxPendingEvidence = 1000
+	// 每个区块最多打包的证据数量
+	maxEvidencePerBlock = 10
+)
+
+// EvidencePool 缓存发现的作恶证据, 等待打包到区块
+type EvidencePool struct {
+	mtx      sync.Mutex
+	evidence map[string]*tmtypes.ValNodeEvidence
+	// 按发现的先后顺序打包
+	keys []string
+}
+
+// NewEvidencePool ...
+func NewEvidencePool() *EvidencePool {
+	return &EvidencePool{evidence: make(map[string]*tmtypes.ValNodeEvidence)}
+}
+
+// Add 加入证据池, 同一验证者在同一高度、轮次和类型下的作恶只保留一个证据, 返回是否是新的证据
+func (pool *EvidencePool) Add(ev *tmtypes.ValNodeEvidence) bool {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	key := ttypes.EvidenceKey(ev)
+	if _, ok := pool.evidence[key]; ok {
+		return false
+	}
+	if len(pool.keys) >= maxPendingEvidence {
+		tendermintlog.Warn("EvidencePool is full", "size", len(pool.keys))
+		return false
+	}
+	pool.evidence[key] = ev
+	pool.keys = append(pool.keys, key)
+	return true
+}
+
+// Remove 删除已经上链的证据
+func (pool *EvidencePool) Remove(ev *tmtypes.ValNodeEvidence) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	pool.remove(ttypes.EvidenceKey(ev))
+}
+
+func (pool *EvidencePool) remove(key string) {
+	if _, ok := pool.evidence[key]; !ok {
+		return
+	}
+	delete(pool.evidence, key)
+	for i, k := range pool.keys {
+		if k == key {
+			pool.keys = append(pool.keys[:i], pool.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Pending 返回最多max个高度小于height的证据, 超过maxAge的证据被丢弃
+func (pool *EvidencePool) Pending(height, maxAge int64, max int) []*tmtypes.ValNodeEvidence {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	var pending []*tmtypes.ValNodeEvidence
+	var expired []string
+	for _, key := range pool.keys {
+		ev := pool.evidence[key]
+		evHeight, _, _ := ttypes.EvidenceHRK(ev)
+		if maxAge > 0 && height-evHeight > maxAge {
+			expired = append(expired, key)
+			continue
+		}
+		// 证据高度的区块信息上链后才能验证
+		if evHeight >= height || len(pending) >= max {
+			continue
+		}
+		pending = append(pending, ev)
+	}
+	for _, key := range expired {
+		tendermintlog.Info("EvidencePool drop expired evidence", "key", key, "height", height)
+		pool.remove(key)
+	}
+	return pending
+}
+
+// Update 删除区块中已经打包的证据
+func (pool *EvidencePool) Update(block *types.Block) {
+	for _, tx := range block.GetTxs() {
+		if string(tx.Execer) != tmtypes.ValNodeX {
+			continue
+		}
+		var action tmtypes.ValNodeAction
+		if err := types.Decode(tx.GetPayload(), &action); err != nil {
+			continue
+		}
+		if ev := action.GetEvidence(); ev != nil {
+			pool.Remove(ev)
+		}
+	}
+}
+
+// Size 证据池中的证据数量
+func (pool *EvidencePool) Size() int {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	return len(pool.keys)
+}
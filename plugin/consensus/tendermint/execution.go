@@ -122,7 +122,10 @@ func updateValidators(currentSet *ttypes.ValidatorSet, updates []*tmtypes.ValNod
 		}
 
 		_, val := currentSet.GetByAddress(address)
-		if val == nil {
+		if val == nil && power == 0 {
+			// 已经不是验证者, 比如被处罚前已经移除
+			tendermintlog.Info("updateValidators ignore removing non-validator", "address", fmt.Sprintf("%X", address))
+		} else if val == nil {
 			// add val
 			added := currentSet.Add(ttypes.NewValidator(pubkey, power))
 			if !added {
@@ -584,7 +584,7 @@ FOR_LOOP:
 					continue
 				}
 				if pc.transferChannel != nil && (pkt.TypeID == ttypes.ProposalID || pkt.TypeID == ttypes.VoteID ||
-					pkt.TypeID == ttypes.ProposalBlockID || pkt.TypeID == ttypes.AggVoteID) {
+					pkt.TypeID == ttypes.ProposalBlockID || pkt.TypeID == ttypes.AggVoteID || pkt.TypeID == ttypes.EvidenceID) {
 					pc.transferChannel <- MsgInfo{pkt.TypeID, realMsg.(proto.Message), pc.ID(), pc.ip.String()}
 					if pkt.TypeID == ttypes.ProposalID {
 						proposal := realMsg.(*tmtypes.Proposal)
@@ -458,3 +458,15 @@ func CreateBlockInfoTx(pubkey string, state *tmtypes.State, block *tmtypes.Tende
 
 	return tx
 }
+
+// CreateEvidenceTx make evidence of double signing to a valnode transaction
+func CreateEvidenceTx(pubkey string, ev *tmtypes.ValNodeEvidence) *types.Transaction {
+	nput := &tmtypes.ValNodeAction_Evidence{Evidence: ev}
+	action := &tmtypes.ValNodeAction{Value: nput, Ty: tmtypes.ValNodeActionEvidence}
+	tx := &types.Transaction{Execer: []byte("valnode"), Payload: types.Encode(action), Fee: fee}
+	tx.To = address.ExecAddress("valnode")
+	tx.Nonce = random.Int63()
+	tx.Sign(types.SECP256K1, getprivkey(pubkey))
+
+	return tx
+}
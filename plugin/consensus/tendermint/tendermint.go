@@ -20,6 +20,7 @@ import (
 	cty "github.com/33cn/chain33/system/dapp/coins/types"
 	"github.com/33cn/chain33/types"
 	"github.com/33cn/chain33/util"
+	"github.com/33cn/plugin/plugin/consensus/privval"
 	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 	tmtypes "github.com/33cn/plugin/plugin/dapp/valnode/types"
 	"github.com/golang/protobuf/proto"
@@ -55,6 +56,8 @@ var (
 	signName                    = "ed25519"
 	useAggSig                   = false
 	gossipVotes                 atomic.Value
+	nodeKeyFile                 = "node_key.json"
+	signerCfg                   privval.Config
 )
 
 func init() {
@@ -98,6 +101,9 @@ type subConfig struct {
 	PreExec                   bool     `json:"preExec"`
 	SignName                  string   `json:"signName"`
 	UseAggregateSignature     bool     `json:"useAggregateSignature"`
+	// 使用远程签名服务时, 节点p2p连接使用的私钥文件
+	NodeKeyFile string `json:"nodeKeyFile"`
+	privval.Config
 }
 
 func applyConfig(sub []byte) {
@@ -152,6 +158,10 @@ func applyConfig(sub []byte) {
 		signName = subcfg.SignName
 	}
 	useAggSig = subcfg.UseAggregateSignature
+	if subcfg.NodeKeyFile != "" {
+		nodeKeyFile = subcfg.NodeKeyFile
+	}
+	signerCfg = subcfg.Config
 	gossipVotes.Store(true)
 }
 
@@ -168,18 +178,12 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 	//init rand
 	ttypes.Init()
 
-	signType, ok := ttypes.SignMap[signName]
-	if !ok {
-		tendermintlog.Error("Invalid sign name")
-		return nil
-	}
-
-	ttypes.CryptoName = types.GetSignName("", signType)
-	cr, err := crypto.Load(ttypes.CryptoName, -1)
+	cr, err := ttypes.LoadCrypto(signName)
 	if err != nil {
 		tendermintlog.Error("NewTendermintClient", "err", err)
 		return nil
 	}
+	ttypes.CryptoName = types.GetSignName("", ttypes.SignMap[signName])
 	ttypes.ConsensusCrypto = cr
 
 	if useAggSig {
@@ -196,15 +200,25 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 		return nil
 	}
 
-	privValidator := ttypes.LoadOrGenPrivValidatorFS("priv_validator.json")
-	if privValidator == nil {
-		tendermintlog.Error("NewTendermintClient create priv_validator file fail")
-		return nil
+	var privValidator *ttypes.PrivValidatorImp
+	var priv crypto.PrivKey
+	if signerCfg.SignerAddr != "" {
+		privValidator, priv, err = newRemotePrivValidator()
+		if err != nil {
+			tendermintlog.Error("NewTendermintClient connect remote signer fail", "addr", signerCfg.SignerAddr, "err", err)
+			return nil
+		}
+	} else {
+		privValidator = ttypes.LoadOrGenPrivValidatorFS("priv_validator.json")
+		if privValidator == nil {
+			tendermintlog.Error("NewTendermintClient create priv_validator file fail")
+			return nil
+		}
+		priv = privValidator.PrivKey
 	}
 
 	ttypes.InitMessageMap()
 
-	priv := privValidator.PrivKey
 	pubkey := privValidator.GetPubKey().KeyString()
 	c := drivers.NewBaseClient(cfg)
 	client := &Client{
@@ -221,6 +235,20 @@ func New(cfg *types.Consensus, sub []byte) queue.Module {
 	return client
 }
 
+// newRemotePrivValidator 验证者私钥保存在远程签名服务, 节点使用nodeKeyFile中的私钥进行p2p连接
+func newRemotePrivValidator() (*ttypes.PrivValidatorImp, crypto.PrivKey, error) {
+	signer, err := privval.NewClient(&signerCfg, ttypes.ConsensusCrypto)
+	if err != nil {
+		return nil, nil, err
+	}
+	pubKey, err := signer.GetPubKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	nodeKey := ttypes.LoadOrGenPrivValidatorFS(nodeKeyFile)
+	return ttypes.NewPrivValidatorWithSigner(pubKey, signer), nodeKey.PrivKey, nil
+}
+
 // GenesisDoc returns the Node's GenesisDoc.
 func (client *Client) GenesisDoc() *ttypes.GenesisDoc {
 	return client.genesisDoc
@@ -591,6 +619,22 @@ func (client *Client) QueryValidatorsByHeight(height int64) (*tmtypes.ValNodes,
 	return msg.GetData().(types.Message).(*tmtypes.ValNodes), nil
 }
 
+// QueryEvidence 查询证据对应的作恶是否已经处罚
+func (client *Client) QueryEvidence(ev *tmtypes.ValNodeEvidence) (*tmtypes.ValNodeEvidenceRecord, error) {
+	msg := client.GetQueueClient().NewMessage("execs", types.EventBlockChainQuery,
+		&types.ChainExecutor{Driver: "valnode", FuncName: "GetValNodeEvidence", StateHash: zeroHash[:], Param: types.Encode(ev)})
+	err := client.GetQueueClient().Send(msg, true)
+	if err != nil {
+		tendermintlog.Error("QueryEvidence send", "err", err)
+		return nil, err
+	}
+	msg, err = client.GetQueueClient().Wait(msg)
+	if err != nil {
+		return nil, err
+	}
+	return msg.GetData().(types.Message).(*tmtypes.ValNodeEvidenceRecord), nil
+}
+
 // QueryBlockInfoByHeight get blockInfo and block by height
 func (client *Client) QueryBlockInfoByHeight(height int64) (*tmtypes.TendermintBlockInfo, *types.Block, error) {
 	if height < 1 {
@@ -187,6 +187,17 @@ func GenPrivValidatorImp(filePath string) *PrivValidatorImp {
 	}
 }
 
+// NewPrivValidatorWithSigner creates a validator whose private key is kept by
+// the signer, eg. a remote signer process. It has no filePath and can not Save().
+func NewPrivValidatorWithSigner(pubKey crypto.PubKey, signer Signer) *PrivValidatorImp {
+	return &PrivValidatorImp{
+		Address:  GenAddressByPubKey(pubKey),
+		PubKey:   pubKey,
+		LastStep: stepNone,
+		Signer:   signer,
+	}
+}
+
 // LoadPrivValidatorFS loads a PrivValidatorImp from the filePath.
 func LoadPrivValidatorFS(filePath string) *PrivValidatorImp {
 	return LoadPrivValidatorFSWithSigner(filePath, func(privVal PrivValidator) Signer {
@@ -433,8 +444,11 @@ func (pv *PrivValidatorImp) SignHeartbeat(chainID string, heartbeat *Heartbeat)
 	pv.mtx.Lock()
 	defer pv.mtx.Unlock()
 	sig, err := pv.Sign(SignBytes(chainID, heartbeat))
+	if err != nil {
+		return err
+	}
 	heartbeat.Signature = sig.Bytes()
-	return err
+	return nil
 }
 
 // String returns a string representation of the PrivValidatorImp.
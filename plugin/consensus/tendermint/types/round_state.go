@@ -45,6 +45,7 @@ const (
 	ProposalBlockID     = byte(0x09)
 	ValidBlockID        = byte(0x0a)
 	AggVoteID           = byte(0x0b)
+	EvidenceID          = byte(0x0c)
 
 	PacketTypePing = byte(0xff)
 	PacketTypePong = byte(0xfe)
@@ -64,6 +65,7 @@ func InitMessageMap() {
 		ProposalBlockID:     reflect.TypeOf(tmtypes.TendermintBlock{}),
 		ValidBlockID:        reflect.TypeOf(tmtypes.ValidBlockMsg{}),
 		AggVoteID:           reflect.TypeOf(tmtypes.AggVote{}),
+		EvidenceID:          reflect.TypeOf(tmtypes.ValNodeEvidence{}),
 	}
 }
 
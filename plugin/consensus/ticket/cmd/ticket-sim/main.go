@@ -0,0 +1,81 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// ticket-sim 按chain33配置文件的共识参数离线模拟ticket出块,
+// 用于在分叉前评估难度调整周期, ticket价格, 冻结时间等参数对出块时间和孤块率的影响
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/consensus/ticket"
+
+	_ "github.com/33cn/chain33/system/crypto/init"
+	_ "github.com/33cn/chain33/system/dapp/init"
+	_ "github.com/33cn/plugin/plugin/crypto/init"
+	_ "github.com/33cn/plugin/plugin/dapp/init"
+)
+
+var (
+	configPath = flag.String("f", "chain33.toml", "chain33 config file")
+	miners     = flag.Int64("miners", 10, "number of miners")
+	balances   = flag.String("balances", "100000", "comma separated coins of each miner used to buy tickets, reused in turn")
+	blocks     = flag.Int64("blocks", 2000, "number of blocks to simulate")
+	height     = flag.Int64("height", 0, "start height, consensus params are read at the simulated heights")
+	startTime  = flag.Int64("time", 0, "start block time, genesisBlockTime of the config file if 0")
+	delay      = flag.Int64("delay", 200, "base block propagation delay in milliseconds")
+	jitter     = flag.Int64("jitter", 300, "random extra propagation delay in milliseconds")
+	maxWait    = flag.Int64("maxwait", 3600, "max seconds to search a block before giving up")
+	noReopen   = flag.Bool("noreopen", false, "do not buy a new ticket after mining")
+	bucket     = flag.Int64("bucket", 2, "bucket width of block time histogram in seconds")
+	seed       = flag.Int64("seed", 1, "random seed")
+)
+
+func main() {
+	flag.Parse()
+	cfg := types.NewChain33Config(types.ReadFile(*configPath))
+	sc := &ticket.SimConfig{
+		Miners:      *miners,
+		Blocks:      *blocks,
+		StartHeight: *height,
+		StartTime:   *startTime,
+		DelayMs:     *delay,
+		JitterMs:    *jitter,
+		MaxWait:     *maxWait,
+		NoReopen:    *noReopen,
+		BucketSec:   *bucket,
+		Seed:        *seed,
+	}
+	if sc.StartTime == 0 {
+		sc.StartTime = cfg.GetModuleConfig().Consensus.GenesisBlockTime
+	}
+	for _, b := range strings.Split(*balances, ",") {
+		balance, err := strconv.ParseInt(strings.TrimSpace(b), 10, 64)
+		if err != nil {
+			exit(fmt.Errorf("invalid balances %s: %v", *balances, err))
+		}
+		sc.Balances = append(sc.Balances, balance)
+	}
+
+	report, err := ticket.Simulate(cfg, sc)
+	if err != nil {
+		exit(err)
+	}
+	data, err := json.MarshalIndent(report, "", "    ")
+	if err != nil {
+		exit(err)
+	}
+	fmt.Println(string(data))
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
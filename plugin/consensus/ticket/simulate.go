@@ -0,0 +1,398 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ticket
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/common/difficulty"
+	"github.com/33cn/chain33/types"
+	ty "github.com/33cn/plugin/plugin/dapp/ticket/types"
+)
+
+//离线模拟ticket挖矿:
+//1. 按配置文件的共识参数, 用和节点相同的难度调整, modify计算, ticket难度值和成熟期判断逻辑出块
+//2. 矿工按余额和ticket价格购买ticket, 挖到区块后关闭ticket并重新购买, 新ticket需要经过冻结期才能挖矿
+//3. 区块广播有网络延时, 矿工收到前一个区块之前挖到的同高度区块按CmpBestBlock的规则竞争, 失败的成为孤块
+
+var (
+	errSimConfig = errors.New("ErrSimConfig")
+	errSimStall  = errors.New("ErrSimStall")
+)
+
+// SimConfig 挖矿模拟参数
+type SimConfig struct {
+	// Miners 矿工数量
+	Miners int64
+	// Balances 每个矿工用于购买ticket的币数, 少于矿工数量时循环使用
+	Balances []int64
+	// Blocks 模拟出块数量
+	Blocks int64
+	// StartHeight 起始高度, 共识参数按模拟的区块高度读取
+	StartHeight int64
+	// StartTime 起始区块时间
+	StartTime int64
+	// DelayMs 区块广播的基础网络延时, 单位毫秒
+	DelayMs int64
+	// JitterMs 网络延时的随机抖动, 单位毫秒
+	JitterMs int64
+	// MaxWait 单个区块的最长搜索时间, 单位秒
+	MaxWait int64
+	// NoReopen 挖矿后不再重新购买ticket
+	NoReopen bool
+	// BucketSec 出块时间分布的区间宽度, 单位秒
+	BucketSec int64
+	// Seed 随机数种子, 相同的种子和参数得到相同的结果
+	Seed int64
+}
+
+// SimBucket 出块时间分布区间
+type SimBucket struct {
+	From  int64 `json:"from"`
+	To    int64 `json:"to"`
+	Count int64 `json:"count"`
+}
+
+// SimRetarget 难度调整记录
+type SimRetarget struct {
+	Height         int64  `json:"height"`
+	Bits           string `json:"bits"`
+	ActualTimespan int64  `json:"actualTimespan"`
+}
+
+// SimMiner 矿工出块统计
+type SimMiner struct {
+	Address string `json:"address"`
+	Tickets int64  `json:"tickets"`
+	Blocks  int64  `json:"blocks"`
+	Orphans int64  `json:"orphans"`
+}
+
+// SimReport 模拟结果
+type SimReport struct {
+	Blocks          int64          `json:"blocks"`
+	Orphans         int64          `json:"orphans"`
+	OrphanRate      float64        `json:"orphanRate"`
+	Tickets         int64          `json:"tickets"`
+	TicketPrice     int64          `json:"ticketPrice"`
+	TargetBlockTime int64          `json:"targetBlockTime"`
+	MeanBlockTime   float64        `json:"meanBlockTime"`
+	StdDevBlockTime float64        `json:"stdDevBlockTime"`
+	MinBlockTime    int64          `json:"minBlockTime"`
+	MaxBlockTime    int64          `json:"maxBlockTime"`
+	P50BlockTime    int64          `json:"p50BlockTime"`
+	P90BlockTime    int64          `json:"p90BlockTime"`
+	P99BlockTime    int64          `json:"p99BlockTime"`
+	FinalBits       string         `json:"finalBits"`
+	Histogram       []*SimBucket   `json:"histogram"`
+	Retargets       []*SimRetarget `json:"retargets"`
+	Miners          []*SimMiner    `json:"miners"`
+}
+
+type simTicket struct {
+	ticket   *ty.Ticket
+	privHash []byte
+}
+
+type simMiner struct {
+	addr    string
+	priv    crypto.PrivKey
+	opened  int64
+	tickets []*simTicket
+	stat    *SimMiner
+}
+
+type simBlock struct {
+	height    int64
+	blockTime int64
+	bits      uint32
+	modify    []byte
+}
+
+type simulator struct {
+	cfg    *types.Chain33Config
+	sc     *SimConfig
+	rnd    *rand.Rand
+	miners []*simMiner
+	chain  []*simBlock
+	report *SimReport
+}
+
+// Simulate 按chain33配置的共识参数离线模拟ticket出块
+func Simulate(cfg *types.Chain33Config, sc *SimConfig) (*SimReport, error) {
+	if sc.Miners <= 0 || sc.Blocks <= 0 || sc.MaxWait <= 0 || sc.BucketSec <= 0 || len(sc.Balances) == 0 {
+		return nil, errSimConfig
+	}
+	if sc.DelayMs < 0 || sc.JitterMs < 0 || sc.StartHeight < 0 {
+		return nil, errSimConfig
+	}
+	s := &simulator{
+		cfg:    cfg,
+		sc:     sc,
+		rnd:    rand.New(rand.NewSource(sc.Seed)),
+		report: &SimReport{},
+	}
+	if err := s.initMiners(); err != nil {
+		return nil, err
+	}
+	s.chain = append(s.chain, &simBlock{
+		height:    sc.StartHeight,
+		blockTime: sc.StartTime,
+		bits:      cfg.GetP(sc.StartHeight).PowLimitBits,
+		modify:    defaultModify,
+	})
+	arrivals := make([]int64, len(s.miners))
+	for i := int64(0); i < sc.Blocks; i++ {
+		var err error
+		arrivals, err = s.mineBlock(arrivals)
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.summarize()
+	return s.report, nil
+}
+
+func (s *simulator) initMiners() error {
+	param := ty.GetTicketMinerParam(s.cfg, s.sc.StartHeight+1)
+	if param.TicketPrice <= 0 {
+		return errSimConfig
+	}
+	c, err := crypto.Load(types.GetSignName("", types.SECP256K1), -1)
+	if err != nil {
+		return err
+	}
+	s.report.TicketPrice = param.TicketPrice
+	s.report.TargetBlockTime = int64(param.TargetTimePerBlock.Seconds())
+	for i := int64(0); i < s.sc.Miners; i++ {
+		//私钥由种子生成, 保证结果可以重现
+		priv, err := c.PrivKeyFromBytes(common.Sha256([]byte(fmt.Sprintf("%d:%d", s.sc.Seed, i))))
+		if err != nil {
+			return err
+		}
+		m := &simMiner{
+			addr: address.PubKeyToAddr(address.DefaultID, priv.PubKey().Bytes()),
+			priv: priv,
+		}
+		balance := s.sc.Balances[i%int64(len(s.sc.Balances))] * s.cfg.GetCoinPrecision()
+		count := balance / param.TicketPrice
+		m.stat = &SimMiner{Address: m.addr, Tickets: count}
+		for j := int64(0); j < count; j++ {
+			//初始ticket都已经过了冻结期
+			if err := s.openTicket(m, s.sc.StartTime-param.TicketFrozenTime-1); err != nil {
+				return err
+			}
+		}
+		s.report.Tickets += count
+		s.miners = append(s.miners, m)
+	}
+	if s.report.Tickets == 0 {
+		return errSimConfig
+	}
+	return nil
+}
+
+// openTicket 按TicketOpen的格式生成ticket id
+func (s *simulator) openTicket(m *simMiner, createTime int64) error {
+	m.opened++
+	txHash := common.Sha256([]byte(fmt.Sprintf("%s:%d", m.addr, m.opened)))
+	pubHash := common.Sha256(txHash)
+	id := fmt.Sprintf("%s:%s:%010d:%x:%d", m.addr, common.ToHex(txHash), 0, pubHash, s.rnd.Int63())
+	privHash, err := genPrivHash(m.priv, id)
+	if err != nil {
+		return err
+	}
+	t := &ty.Ticket{TicketId: id, MinerAddress: m.addr, ReturnAddress: m.addr, CreateTime: createTime, Status: ty.TicketOpened}
+	m.tickets = append(m.tickets, &simTicket{ticket: t, privHash: privHash})
+	return nil
+}
+
+func (s *simulator) sampleDelay() int64 {
+	return s.sc.DelayMs + s.rnd.Int63n(s.sc.JitterMs+1)
+}
+
+// nextTarget 和getNextRequiredDifficulty相同的难度和modify计算
+func (s *simulator) nextTarget(parent *simBlock) (uint32, []byte) {
+	if parent.height == 0 {
+		return s.cfg.GetP(0).PowLimitBits, defaultModify
+	}
+	param := ty.GetTicketMinerParam(s.cfg, parent.height)
+	blocksPerRetarget := getBlocksPerRetarget(param)
+	first := parent.height + 1 - blocksPerRetarget
+	//起始高度之前的区块不参与难度调整
+	if !isRetargetHeight(parent.height+1, blocksPerRetarget) || first < s.sc.StartHeight {
+		return parent.bits, parent.modify
+	}
+	var blockTimes []int64
+	for _, b := range s.chain[first-s.sc.StartHeight:] {
+		blockTimes = append(blockTimes, b.blockTime)
+	}
+	firstBlock := s.chain[first-s.sc.StartHeight]
+	actualTimespan := parent.blockTime - firstBlock.blockTime
+	bits, _ := calcRetargetBits(param, parent.bits, s.cfg.GetP(parent.height).PowLimitBits, actualTimespan)
+	s.report.Retargets = append(s.report.Retargets, &SimRetarget{
+		Height:         parent.height + 1,
+		Bits:           fmt.Sprintf("%08x", bits),
+		ActualTimespan: actualTimespan,
+	})
+	return bits, calcModify(blockTimes, parent.modify)
+}
+
+// searchTicket 和searchTargetTicket相同的ticket选择逻辑
+func (s *simulator) searchTicket(m *simMiner, param *ty.TicketMinerParam, blockTime int64, modify []byte, diff *big.Int) (int, *big.Int) {
+	for i, t := range m.tickets {
+		if !isTicketMatured(param, t.ticket, blockTime) {
+			continue
+		}
+		currentdiff := calcTicketTarget(blockTime, t.ticket.TicketId, modify, t.privHash)
+		if currentdiff.Cmp(diff) >= 0 {
+			continue
+		}
+		return i, currentdiff
+	}
+	return -1, nil
+}
+
+// mineBlock 模拟一个高度的出块, arrivals为各矿工收到父区块的时间(毫秒), 返回新区块的广播到达时间
+func (s *simulator) mineBlock(arrivals []int64) ([]int64, error) {
+	parent := s.chain[len(s.chain)-1]
+	height := parent.height + 1
+	param := ty.GetTicketMinerParam(s.cfg, height)
+	bits, modify := s.nextTarget(parent)
+	diff := difficulty.CompactToBig(bits)
+
+	starts := make([]int64, len(s.miners))
+	for i, arrival := range arrivals {
+		//收到父区块后以当前时间开始挖矿, 区块时间不小于父区块
+		starts[i] = arrival / 1000
+		if starts[i] < parent.blockTime {
+			starts[i] = parent.blockTime
+		}
+	}
+	found := make([]int64, len(s.miners))
+	tickets := make([]int, len(s.miners))
+	targets := make([]*big.Int, len(s.miners))
+	for i := range found {
+		found[i] = -1
+	}
+	maxDelay := s.sc.DelayMs + s.sc.JitterMs
+	earliest := int64(-1)
+	for t := parent.blockTime; ; t++ {
+		if earliest >= 0 && t*1000 >= earliest*1000+maxDelay {
+			break
+		}
+		if t-parent.blockTime > s.sc.MaxWait {
+			return nil, fmt.Errorf("%v: no ticket found at height %d within %d seconds", errSimStall, height, s.sc.MaxWait)
+		}
+		for i, m := range s.miners {
+			if found[i] >= 0 || starts[i] > t {
+				continue
+			}
+			index, target := s.searchTicket(m, param, t, modify, diff)
+			if index < 0 {
+				continue
+			}
+			found[i], tickets[i], targets[i] = t, index, target
+			if earliest < 0 {
+				earliest = t
+			}
+		}
+	}
+
+	//收到最早区块之前挖到的同高度区块参与竞争, 难度值最小的成为主链区块
+	winner := -1
+	var candidates []int
+	for i := range s.miners {
+		delay := s.sampleDelay()
+		if found[i] < 0 || (found[i] != earliest && found[i]*1000 >= earliest*1000+delay) {
+			continue
+		}
+		candidates = append(candidates, i)
+		if winner < 0 || targets[i].Cmp(targets[winner]) < 0 {
+			winner = i
+		}
+	}
+	for _, i := range candidates {
+		if i != winner {
+			s.miners[i].stat.Orphans++
+			s.report.Orphans++
+		}
+	}
+
+	m := s.miners[winner]
+	m.stat.Blocks++
+	m.tickets = append(m.tickets[:tickets[winner]], m.tickets[tickets[winner]+1:]...)
+	if !s.sc.NoReopen {
+		if err := s.openTicket(m, found[winner]); err != nil {
+			return nil, err
+		}
+	}
+	s.chain = append(s.chain, &simBlock{
+		height:    height,
+		blockTime: found[winner],
+		bits:      bits,
+		modify:    modify,
+	})
+
+	next := make([]int64, len(s.miners))
+	for i := range next {
+		next[i] = found[winner]*1000 + s.sampleDelay()
+	}
+	next[winner] = found[winner] * 1000
+	return next, nil
+}
+
+func (s *simulator) summarize() {
+	r := s.report
+	var intervals []int64
+	var sum float64
+	for i := 1; i < len(s.chain); i++ {
+		interval := s.chain[i].blockTime - s.chain[i-1].blockTime
+		intervals = append(intervals, interval)
+		sum += float64(interval)
+	}
+	r.Blocks = int64(len(intervals))
+	r.OrphanRate = float64(r.Orphans) / float64(r.Blocks+r.Orphans)
+	r.MeanBlockTime = sum / float64(r.Blocks)
+	var variance float64
+	for _, interval := range intervals {
+		variance += (float64(interval) - r.MeanBlockTime) * (float64(interval) - r.MeanBlockTime)
+	}
+	r.StdDevBlockTime = math.Sqrt(variance / float64(r.Blocks))
+
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i] < intervals[j] })
+	percentile := func(p int64) int64 {
+		index := (int64(len(intervals))*p+99)/100 - 1
+		if index < 0 {
+			index = 0
+		}
+		return intervals[index]
+	}
+	r.MinBlockTime = intervals[0]
+	r.MaxBlockTime = intervals[len(intervals)-1]
+	r.P50BlockTime = percentile(50)
+	r.P90BlockTime = percentile(90)
+	r.P99BlockTime = percentile(99)
+	r.FinalBits = fmt.Sprintf("%08x", s.chain[len(s.chain)-1].bits)
+
+	for _, interval := range intervals {
+		from := interval / s.sc.BucketSec * s.sc.BucketSec
+		if len(r.Histogram) == 0 || r.Histogram[len(r.Histogram)-1].From != from {
+			r.Histogram = append(r.Histogram, &SimBucket{From: from, To: from + s.sc.BucketSec})
+		}
+		r.Histogram[len(r.Histogram)-1].Count++
+	}
+	for _, m := range s.miners {
+		r.Miners = append(r.Miners, m.stat)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ticket
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/33cn/chain33/common/difficulty"
+	"github.com/33cn/chain33/types"
+	ty "github.com/33cn/plugin/plugin/dapp/ticket/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_calcRetargetBits(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	param := ty.GetTicketMinerParam(cfg, 0)
+	powLimitBits := cfg.GetP(0).PowLimitBits
+	bits := difficulty.BigToCompact(new(big.Int).Rsh(difficulty.CompactToBig(powLimitBits), 8))
+	targetTimespan := int64(param.TargetTimespan.Seconds())
+
+	//调整幅度不超过retargetAdjustmentFactor
+	newBits, adjusted := calcRetargetBits(param, bits, powLimitBits, 0)
+	require.Equal(t, targetTimespan/param.RetargetAdjustmentFactor, adjusted)
+	require.Equal(t, new(big.Int).Div(difficulty.CompactToBig(bits), big.NewInt(4)).Cmp(difficulty.CompactToBig(newBits)) >= 0, true)
+	newBits, adjusted = calcRetargetBits(param, bits, powLimitBits, targetTimespan*10)
+	require.Equal(t, targetTimespan*param.RetargetAdjustmentFactor, adjusted)
+	require.True(t, difficulty.CompactToBig(newBits).Cmp(difficulty.CompactToBig(bits)) > 0)
+	newBits, _ = calcRetargetBits(param, bits, powLimitBits, targetTimespan)
+	require.Equal(t, bits, newBits)
+	//不超过powLimit
+	newBits, _ = calcRetargetBits(param, powLimitBits, powLimitBits, targetTimespan*2)
+	require.Equal(t, powLimitBits, newBits)
+
+	require.False(t, isRetargetHeight(144, 144))
+	require.True(t, isRetargetHeight(288, 144))
+	require.False(t, isRetargetHeight(289, 144))
+}
+
+func TestSimulate(t *testing.T) {
+	cfgstring := strings.Replace(types.GetDefaultCfgstring(), "targetTimespan = 288 #only for test", "targetTimespan = 128", 1)
+	cfgstring = strings.Replace(cfgstring, "targetTimePerBlock = 2", "targetTimePerBlock = 8", 1)
+	cfg := types.NewChain33Config(cfgstring)
+	sc := &SimConfig{
+		Miners:    20,
+		Balances:  []int64{200000, 100000},
+		Blocks:    200,
+		StartTime: 1514533394,
+		MaxWait:   3600,
+		BucketSec: 2,
+		Seed:      1,
+	}
+	report, err := Simulate(cfg, sc)
+	require.Nil(t, err)
+	require.Equal(t, int64(200), report.Blocks)
+	require.Equal(t, int64(300), report.Tickets)
+	require.Equal(t, int64(8), report.TargetBlockTime)
+	//每16个区块调整一次难度, 第一个周期不调整
+	require.Equal(t, 11, len(report.Retargets))
+	require.NotEqual(t, report.Retargets[0].Bits, report.FinalBits)
+	var count, blocks int64
+	for _, b := range report.Histogram {
+		count += b.Count
+	}
+	for _, m := range report.Miners {
+		blocks += m.Blocks
+	}
+	require.Equal(t, report.Blocks, count)
+	require.Equal(t, report.Blocks, blocks)
+	require.True(t, report.P50BlockTime <= report.P90BlockTime && report.P90BlockTime <= report.P99BlockTime)
+
+	//相同的种子结果相同
+	again, err := Simulate(cfg, sc)
+	require.Nil(t, err)
+	require.Equal(t, report, again)
+
+	//网络延时增加孤块
+	sc.DelayMs = 3000
+	sc.JitterMs = 2000
+	delayed, err := Simulate(cfg, sc)
+	require.Nil(t, err)
+	require.True(t, delayed.Orphans > report.Orphans)
+	require.True(t, delayed.OrphanRate > report.OrphanRate)
+
+	//ticket用完后不能出块
+	_, err = Simulate(cfg, &SimConfig{Miners: 1, Balances: []int64{10000}, Blocks: 2, MaxWait: 60, BucketSec: 1, NoReopen: true})
+	require.NotNil(t, err)
+
+	_, err = Simulate(cfg, &SimConfig{Miners: 1, Balances: []int64{1}, Blocks: 2, MaxWait: 60, BucketSec: 1})
+	require.Equal(t, errSimConfig, err)
+}
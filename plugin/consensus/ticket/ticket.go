@@ -306,32 +306,41 @@ func (client *Client) getMinerModify(block *types.Block) ([]byte, error) {
 
 func (client *Client) getModify(beg, end int64) ([]byte, error) {
 	//通过某个区间计算modify
-	timeSource := int64(0)
-	total := int64(0)
-	newmodify := ""
+	var blockTimes []int64
+	var last []byte
 	for i := beg; i <= end; i++ {
 		block, err := client.RequestBlock(i)
 		if err != nil {
 			return defaultModify, err
 		}
-		timeSource += block.BlockTime
-		if total == 0 {
-			total = block.BlockTime
-		}
-		if timeSource%4 == 0 {
-			total += block.BlockTime
-		}
+		blockTimes = append(blockTimes, block.BlockTime)
 		if i == end {
 			ticketAction, err := client.getMinerTx(block)
 			if err != nil {
 				return defaultModify, err
 			}
-			last := ticketAction.GetMiner().GetModify()
-			newmodify = fmt.Sprintf("%s:%d", string(last), total)
+			last = ticketAction.GetMiner().GetModify()
+		}
+	}
+	return calcModify(blockTimes, last), nil
+}
+
+// calcModify 通过调整周期内的区块时间和最后一个区块的modify计算新的modify
+func calcModify(blockTimes []int64, last []byte) []byte {
+	timeSource := int64(0)
+	total := int64(0)
+	for _, blockTime := range blockTimes {
+		timeSource += blockTime
+		if total == 0 {
+			total = blockTime
+		}
+		if timeSource%4 == 0 {
+			total += blockTime
 		}
 	}
+	newmodify := fmt.Sprintf("%s:%d", string(last), total)
 	modify := common.ToHex(common.Sha256([]byte(newmodify)))
-	return []byte(modify), nil
+	return []byte(modify)
 }
 
 // CheckBlock ticket implete checkblock func
@@ -456,6 +465,11 @@ func (client *Client) getNextTarget(block *types.Block, bits uint32) (*big.Int,
 }
 
 func (client *Client) getCurrentTarget(blocktime int64, id string, modify []byte, privHash []byte) *big.Int {
+	return calcTicketTarget(blocktime, id, modify, privHash)
+}
+
+// calcTicketTarget 计算ticket在指定区块时间的难度值, 小于区块难度时可以挖矿
+func calcTicketTarget(blocktime int64, id string, modify []byte, privHash []byte) *big.Int {
 	s := fmt.Sprintf("%d:%s:%x", blocktime, id, modify)
 	if len(privHash) != 0 {
 		s = s + ":" + string(privHash)
@@ -478,10 +492,10 @@ func (client *Client) getNextRequiredDifficulty(block *types.Block, bits uint32)
 	}
 	powLimitBits := chain33Cfg.GetP(block.Height).PowLimitBits
 	cfg := ty.GetTicketMinerParam(chain33Cfg, block.Height)
-	blocksPerRetarget := int64(cfg.TargetTimespan / cfg.TargetTimePerBlock)
+	blocksPerRetarget := getBlocksPerRetarget(cfg)
 	// Return the previous block's difficulty requirements if this block
 	// is not at a difficulty retarget interval.
-	if (block.Height+1) <= blocksPerRetarget || (block.Height+1)%blocksPerRetarget != 0 {
+	if !isRetargetHeight(block.Height+1, blocksPerRetarget) {
 		// For the main network (or any unrecognized networks), simply
 		// return the previous block's difficulty requirements.
 		modify, err := client.getMinerModify(block)
@@ -505,9 +519,42 @@ func (client *Client) getNextRequiredDifficulty(block *types.Block, bits uint32)
 	if err != nil {
 		return powLimitBits, defaultModify, err
 	}
+	actualTimespan := block.BlockTime - firstBlock.BlockTime
+	newTargetBits, adjustedTimespan := calcRetargetBits(cfg, bits, powLimitBits, actualTimespan)
+
+	// Log new target difficulty and return it.  The new target logging is
+	// intentionally converting the bits back to a number instead of using
+	// newTarget since conversion to the compact representation loses
+	// precision.
+	oldTarget := difficulty.CompactToBig(bits)
+	tlog.Info(fmt.Sprintf("Difficulty retarget at block height %d", block.Height+1))
+	tlog.Info(fmt.Sprintf("Old target %08x, (%064x)", bits, oldTarget))
+	tlog.Info(fmt.Sprintf("New target %08x, (%064x)", newTargetBits, difficulty.CompactToBig(newTargetBits)))
+	tlog.Info("Timespan", "Actual timespan", time.Duration(actualTimespan)*time.Second,
+		"adjusted timespan", time.Duration(adjustedTimespan)*time.Second,
+		"target timespan", cfg.TargetTimespan)
+	prevmodify, err := client.getMinerModify(block)
+	if err != nil {
+		panic(err)
+	}
+	tlog.Info("UpdateModify", "prev", string(prevmodify), "current", string(modify))
+	return newTargetBits, modify, nil
+}
+
+// getBlocksPerRetarget 每个难度调整周期的区块数
+func getBlocksPerRetarget(cfg *ty.TicketMinerParam) int64 {
+	return int64(cfg.TargetTimespan / cfg.TargetTimePerBlock)
+}
+
+// isRetargetHeight 该高度的区块是否需要调整难度
+func isRetargetHeight(height, blocksPerRetarget int64) bool {
+	return height > blocksPerRetarget && height%blocksPerRetarget == 0
+}
+
+// calcRetargetBits 按调整周期的实际时间计算新的难度, 返回新难度和限制后的时间跨度
+func calcRetargetBits(cfg *ty.TicketMinerParam, bits, powLimitBits uint32, actualTimespan int64) (uint32, int64) {
 	// Limit the amount of adjustment that can occur to the previous
 	// difficulty.
-	actualTimespan := block.BlockTime - firstBlock.BlockTime
 	adjustedTimespan := actualTimespan
 	targetTimespan := int64(cfg.TargetTimespan / time.Second)
 
@@ -533,24 +580,7 @@ func (client *Client) getNextRequiredDifficulty(block *types.Block, bits uint32)
 	if newTarget.Cmp(powLimit) > 0 {
 		newTarget.Set(powLimit)
 	}
-
-	// Log new target difficulty and return it.  The new target logging is
-	// intentionally converting the bits back to a number instead of using
-	// newTarget since conversion to the compact representation loses
-	// precision.
-	newTargetBits := difficulty.BigToCompact(newTarget)
-	tlog.Info(fmt.Sprintf("Difficulty retarget at block height %d", block.Height+1))
-	tlog.Info(fmt.Sprintf("Old target %08x, (%064x)", bits, oldTarget))
-	tlog.Info(fmt.Sprintf("New target %08x, (%064x)", newTargetBits, difficulty.CompactToBig(newTargetBits)))
-	tlog.Info("Timespan", "Actual timespan", time.Duration(actualTimespan)*time.Second,
-		"adjusted timespan", time.Duration(adjustedTimespan)*time.Second,
-		"target timespan", cfg.TargetTimespan)
-	prevmodify, err := client.getMinerModify(block)
-	if err != nil {
-		panic(err)
-	}
-	tlog.Info("UpdateModify", "prev", string(prevmodify), "current", string(modify))
-	return newTargetBits, modify, nil
+	return difficulty.BigToCompact(newTarget), adjustedTimespan
 }
 
 func printBInt(data *big.Int) string {
@@ -576,7 +606,7 @@ func (client *Client) searchTargetTicket(parent, block *types.Block) (*ty.Ticket
 			continue
 		}
 		//已经到成熟期
-		if !ticket.GetIsGenesis() && (block.BlockTime-ticket.GetCreateTime() <= ty.GetTicketMinerParam(cfg, block.Height).TicketFrozenTime) {
+		if !isTicketMatured(ty.GetTicketMinerParam(cfg, block.Height), ticket, block.BlockTime) {
 			continue
 		}
 		// 查找私钥
@@ -602,6 +632,11 @@ func (client *Client) searchTargetTicket(parent, block *types.Block) (*ty.Ticket
 	return nil, nil, nil, nil, "", nil
 }
 
+// isTicketMatured 非创世ticket需要超过冻结时间才能挖矿
+func isTicketMatured(cfg *ty.TicketMinerParam, ticket *ty.Ticket, blockTime int64) bool {
+	return ticket.GetIsGenesis() || blockTime-ticket.GetCreateTime() > cfg.TicketFrozenTime
+}
+
 func (client *Client) delTicket(ticketID string) {
 	client.ticketmu.Lock()
 	defer client.ticketmu.Unlock()
@@ -0,0 +1,318 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/phoreproject/bls"
+)
+
+// DKG相关错误
+var (
+	ErrDKGPhase       = errors.New("ErrDKGPhase")
+	ErrDKGParticipant = errors.New("ErrDKGParticipant")
+	ErrDKGDeal        = errors.New("ErrDKGDeal")
+	ErrDKGQualified   = errors.New("ErrDKGQualified")
+)
+
+// DKG阶段
+const (
+	dkgPhaseDeal = iota
+	dkgPhaseComplaint
+	dkgPhaseFinal
+)
+
+// DKGDeal 分发者广播的多项式系数承诺
+type DKGDeal struct {
+	Dealer      int
+	Commitments []PubKeyBLS
+}
+
+// DKGShare 分发者通过私密信道发给接收者的私钥分片
+type DKGShare struct {
+	Dealer   int
+	Receiver int
+	Share    PrivKeyBLS
+}
+
+// DKGComplaint 接收者广播的投诉, 表示没有收到分发者的有效分片
+type DKGComplaint struct {
+	Dealer     int
+	Complainer int
+}
+
+// DKGJustification 分发者针对投诉公开对应的分片, 所有参与者都可以校验
+type DKGJustification struct {
+	Dealer   int
+	Receiver int
+	Share    PrivKeyBLS
+}
+
+// DKGResult DKG完成后本节点持有的分片以及组内公开信息
+type DKGResult struct {
+	Share       *KeyShare
+	Commitments []PubKeyBLS
+	Qualified   []int
+}
+
+// GroupPubKey 组公钥
+func (r *DKGResult) GroupPubKey() PubKeyBLS {
+	return r.Commitments[0]
+}
+
+// DKG 基于Feldman VSS的Pedersen分布式密钥生成, 每个参与者各自作为分发者共享一个随机秘密, 组私钥为所有合格分发者秘密之和, 任何一方都不持有组私钥
+// 流程:
+// 1. Deal生成承诺和分片, 承诺广播给所有参与者, 分片私密发送给对应接收者
+// 2. ProcessDeal, ProcessShare处理收到的承诺和分片
+// 3. Complaints结束分发阶段, 对未收到有效分片的分发者广播投诉
+// 4. ProcessComplaint处理投诉, 针对本节点的投诉返回需广播的公开分片, ProcessJustification校验公开分片
+// 5. Finalize剔除没有承诺或未能澄清投诉的分发者, 计算本节点的最终分片和组公钥
+// 消息的来源认证和广播一致性由传输层保证, DKG对象不是并发安全的
+type DKG struct {
+	index  int
+	t      int
+	n      int
+	random io.Reader
+	phase  int
+	poly   polynomial
+	deals  map[int]*DKGDeal
+	shares map[int]*big.Int
+	//dealer -> complainer -> 是否已澄清
+	complaints   map[int]map[int]bool
+	disqualified map[int]bool
+}
+
+// NewDKG 创建编号为index(从1开始)的参与者, t为恢复签名所需的分片数, n为参与者总数, random为nil时使用crypto/rand
+func NewDKG(index, t, n int, random io.Reader) (*DKG, error) {
+	if t <= 0 || t > n {
+		return nil, ErrThreshold
+	}
+	if index <= 0 || index > n {
+		return nil, ErrShareIndex
+	}
+	return &DKG{
+		index:        index,
+		t:            t,
+		n:            n,
+		random:       random,
+		deals:        make(map[int]*DKGDeal),
+		shares:       make(map[int]*big.Int),
+		complaints:   make(map[int]map[int]bool),
+		disqualified: make(map[int]bool),
+	}, nil
+}
+
+// Index 参与者编号
+func (d *DKG) Index() int {
+	return d.index
+}
+
+func (d *DKG) checkParticipant(i int) error {
+	if i <= 0 || i > d.n {
+		return ErrDKGParticipant
+	}
+	return nil
+}
+
+// Deal 生成随机多项式, 返回需广播的承诺和发给其他参与者的分片, 本节点的分片直接保留
+func (d *DKG) Deal() (*DKGDeal, []*DKGShare, error) {
+	if d.phase != dkgPhaseDeal || d.poly != nil {
+		return nil, nil, ErrDKGPhase
+	}
+	secret, err := randScalar(d.random)
+	if err != nil {
+		return nil, nil, err
+	}
+	poly, err := randomPolynomial(d.random, secret, d.t)
+	if err != nil {
+		return nil, nil, err
+	}
+	d.poly = poly
+	deal := &DKGDeal{Dealer: d.index, Commitments: poly.commit()}
+	d.deals[d.index] = deal
+	d.shares[d.index] = poly.eval(d.index)
+	shares := make([]*DKGShare, 0, d.n-1)
+	for i := 1; i <= d.n; i++ {
+		if i == d.index {
+			continue
+		}
+		shares = append(shares, &DKGShare{Dealer: d.index, Receiver: i, Share: privFromScalar(poly.eval(i))})
+	}
+	return deal, shares, nil
+}
+
+// ProcessDeal 处理其他分发者广播的承诺, 承诺个数必须为t
+func (d *DKG) ProcessDeal(deal *DKGDeal) error {
+	if d.phase != dkgPhaseDeal {
+		return ErrDKGPhase
+	}
+	if err := d.checkParticipant(deal.Dealer); err != nil {
+		return err
+	}
+	if len(deal.Commitments) != d.t {
+		return ErrDKGDeal
+	}
+	if _, ok := d.deals[deal.Dealer]; ok {
+		return ErrDKGDeal
+	}
+	for _, commitment := range deal.Commitments {
+		if _, err := pointFromPub(commitment); err != nil {
+			return err
+		}
+	}
+	d.deals[deal.Dealer] = deal
+	return nil
+}
+
+// ProcessShare 校验发给本节点的分片, 需要先处理对应分发者的承诺, 分片无效时保留待投诉
+func (d *DKG) ProcessShare(share *DKGShare) error {
+	if d.phase != dkgPhaseDeal {
+		return ErrDKGPhase
+	}
+	if share.Receiver != d.index {
+		return ErrShareIndex
+	}
+	if err := d.checkParticipant(share.Dealer); err != nil {
+		return err
+	}
+	deal, ok := d.deals[share.Dealer]
+	if !ok {
+		return ErrDKGDeal
+	}
+	if err := VerifyShare(deal.Commitments, &KeyShare{Index: d.index, PrivKey: share.Share}); err != nil {
+		return err
+	}
+	d.shares[share.Dealer] = scalarFromPriv(share.Share)
+	return nil
+}
+
+// Complaints 结束分发阶段, 返回对未收到有效分片的分发者的投诉, 需广播给所有参与者
+func (d *DKG) Complaints() ([]*DKGComplaint, error) {
+	if d.phase != dkgPhaseDeal {
+		return nil, ErrDKGPhase
+	}
+	d.phase = dkgPhaseComplaint
+	var complaints []*DKGComplaint
+	for i := 1; i <= d.n; i++ {
+		if _, ok := d.deals[i]; !ok {
+			//没有承诺的分发者直接剔除, 不需要投诉
+			d.disqualified[i] = true
+			continue
+		}
+		if _, ok := d.shares[i]; !ok {
+			complaint := &DKGComplaint{Dealer: i, Complainer: d.index}
+			d.addComplaint(complaint)
+			complaints = append(complaints, complaint)
+		}
+	}
+	return complaints, nil
+}
+
+func (d *DKG) addComplaint(c *DKGComplaint) {
+	if d.complaints[c.Dealer] == nil {
+		d.complaints[c.Dealer] = make(map[int]bool)
+	}
+	if _, ok := d.complaints[c.Dealer][c.Complainer]; !ok {
+		d.complaints[c.Dealer][c.Complainer] = false
+	}
+}
+
+// ProcessComplaint 处理其他参与者的投诉, 针对本节点的投诉返回需广播的公开分片, 否则返回nil
+func (d *DKG) ProcessComplaint(c *DKGComplaint) (*DKGJustification, error) {
+	if d.phase != dkgPhaseComplaint {
+		return nil, ErrDKGPhase
+	}
+	if err := d.checkParticipant(c.Dealer); err != nil {
+		return nil, err
+	}
+	if err := d.checkParticipant(c.Complainer); err != nil {
+		return nil, err
+	}
+	d.addComplaint(c)
+	if c.Dealer != d.index || d.poly == nil {
+		return nil, nil
+	}
+	return &DKGJustification{Dealer: d.index, Receiver: c.Complainer, Share: privFromScalar(d.poly.eval(c.Complainer))}, nil
+}
+
+// ProcessJustification 校验分发者公开的分片, 有效则澄清对应投诉, 否则剔除该分发者
+func (d *DKG) ProcessJustification(j *DKGJustification) error {
+	if d.phase != dkgPhaseComplaint {
+		return ErrDKGPhase
+	}
+	if err := d.checkParticipant(j.Dealer); err != nil {
+		return err
+	}
+	if _, ok := d.complaints[j.Dealer][j.Receiver]; !ok {
+		return ErrDKGDeal
+	}
+	deal, ok := d.deals[j.Dealer]
+	if !ok {
+		return ErrDKGDeal
+	}
+	if err := VerifyShare(deal.Commitments, &KeyShare{Index: j.Receiver, PrivKey: j.Share}); err != nil {
+		d.disqualified[j.Dealer] = true
+		return err
+	}
+	d.complaints[j.Dealer][j.Receiver] = true
+	if j.Receiver == d.index {
+		d.shares[j.Dealer] = scalarFromPriv(j.Share)
+	}
+	return nil
+}
+
+// Finalize 结束DKG, 合格分发者少于t个时失败
+func (d *DKG) Finalize() (*DKGResult, error) {
+	if d.phase != dkgPhaseComplaint {
+		return nil, ErrDKGPhase
+	}
+	var qual []int
+	for i := 1; i <= d.n; i++ {
+		if d.disqualified[i] {
+			continue
+		}
+		justified := true
+		for _, ok := range d.complaints[i] {
+			justified = justified && ok
+		}
+		if !justified {
+			d.disqualified[i] = true
+			continue
+		}
+		qual = append(qual, i)
+	}
+	if len(qual) < d.t {
+		return nil, ErrDKGQualified
+	}
+	share := new(big.Int)
+	//组承诺为各合格分发者承诺之和
+	points := make([]*bls.G1Projective, d.t)
+	for k := range points {
+		points[k] = bls.G1ProjectiveZero.Copy()
+	}
+	for _, i := range qual {
+		share.Add(share, d.shares[i]).Mod(share, curveOrder)
+		for k, commitment := range d.deals[i].Commitments {
+			point, err := pointFromPub(commitment)
+			if err != nil {
+				return nil, err
+			}
+			points[k] = points[k].Add(point)
+		}
+	}
+	commitments := make([]PubKeyBLS, d.t)
+	for k, point := range points {
+		commitments[k] = pubFromPoint(point)
+	}
+	d.phase = dkgPhaseFinal
+	return &DKGResult{
+		Share:       &KeyShare{Index: d.index, PrivKey: privFromScalar(share)},
+		Commitments: commitments,
+		Qualified:   qual,
+	}, nil
+}
@@ -0,0 +1,247 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/phoreproject/bls"
+	"github.com/phoreproject/bls/g1pubs"
+)
+
+// 门限签名相关错误
+var (
+	ErrThreshold        = errors.New("ErrThreshold")
+	ErrShareIndex       = errors.New("ErrShareIndex")
+	ErrInvalidShare     = errors.New("ErrInvalidShare")
+	ErrNotEnoughPartial = errors.New("ErrNotEnoughPartial")
+	ErrDuplicateIndex   = errors.New("ErrDuplicateIndex")
+)
+
+// curveOrder 私钥所在有限域的阶
+var curveOrder = bls.RFieldModulus.ToBig()
+
+// KeyShare t-of-n门限密钥中编号为Index(从1开始)的私钥分片
+type KeyShare struct {
+	Index   int
+	PrivKey PrivKeyBLS
+}
+
+// PartialSignature 私钥分片对消息的签名
+type PartialSignature struct {
+	Index int
+	Sig   SignatureBLS
+}
+
+// 多项式系数, 常数项为秘密
+type polynomial []*big.Int
+
+func randomPolynomial(random io.Reader, secret *big.Int, t int) (polynomial, error) {
+	poly := make(polynomial, t)
+	poly[0] = new(big.Int).Set(secret)
+	for i := 1; i < t; i++ {
+		coeff, err := randScalar(random)
+		if err != nil {
+			return nil, err
+		}
+		poly[i] = coeff
+	}
+	return poly, nil
+}
+
+func (p polynomial) eval(x int) *big.Int {
+	xb := big.NewInt(int64(x))
+	res := new(big.Int)
+	for i := len(p) - 1; i >= 0; i-- {
+		res.Mul(res, xb).Add(res, p[i]).Mod(res, curveOrder)
+	}
+	return res
+}
+
+// Feldman承诺, 每个系数对应的公钥
+func (p polynomial) commit() []PubKeyBLS {
+	commitments := make([]PubKeyBLS, len(p))
+	for i, coeff := range p {
+		commitments[i] = pubFromPoint(bls.G1ProjectiveOne.MulFR(scalarRepr(coeff)))
+	}
+	return commitments
+}
+
+func randScalar(random io.Reader) (*big.Int, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+	for {
+		x, err := rand.Int(random, curveOrder)
+		if err != nil {
+			return nil, err
+		}
+		if x.Sign() > 0 {
+			return x, nil
+		}
+	}
+}
+
+func scalarRepr(x *big.Int) *bls.FRRepr {
+	repr, _ := bls.FRReprFromBigInt(new(big.Int).Mod(x, curveOrder))
+	return repr
+}
+
+func privFromScalar(x *big.Int) PrivKeyBLS {
+	return PrivKeyBLS(scalarRepr(x).Bytes())
+}
+
+func scalarFromPriv(priv PrivKeyBLS) *big.Int {
+	return new(big.Int).SetBytes(priv[:])
+}
+
+func pubFromPoint(p *bls.G1Projective) PubKeyBLS {
+	return PubKeyBLS(g1pubs.NewPublicKeyFromG1(p.ToAffine()).Serialize())
+}
+
+func pointFromPub(pub PubKeyBLS) (*bls.G1Projective, error) {
+	g1pub, err := g1pubs.DeserializePublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return g1pub.GetPoint(), nil
+}
+
+// SplitKey 由可信的分发者将私钥拆分为n个分片, 任意t个分片可以恢复出组签名, 同时返回用于校验分片的Feldman承诺
+// 不希望任何一方持有完整私钥时使用DKG生成
+func SplitKey(priv crypto.PrivKey, t, n int) ([]*KeyShare, []PubKeyBLS, error) {
+	if t <= 0 || t > n {
+		return nil, nil, ErrThreshold
+	}
+	privBLS, ok := priv.(PrivKeyBLS)
+	if !ok {
+		return nil, nil, errors.New("invalid bls privkey")
+	}
+	poly, err := randomPolynomial(nil, scalarFromPriv(privBLS), t)
+	if err != nil {
+		return nil, nil, err
+	}
+	shares := make([]*KeyShare, n)
+	for i := 1; i <= n; i++ {
+		shares[i-1] = &KeyShare{Index: i, PrivKey: privFromScalar(poly.eval(i))}
+	}
+	return shares, poly.commit(), nil
+}
+
+// GroupPubKey 组公钥, 即Feldman承诺的常数项
+func GroupPubKey(commitments []PubKeyBLS) (crypto.PubKey, error) {
+	if len(commitments) == 0 {
+		return nil, ErrThreshold
+	}
+	return commitments[0], nil
+}
+
+// SharePubKey 由承诺计算编号为index的分片公钥, 用于校验部分签名
+func SharePubKey(commitments []PubKeyBLS, index int) (PubKeyBLS, error) {
+	if index <= 0 {
+		return PubKeyBLS{}, ErrShareIndex
+	}
+	if len(commitments) == 0 {
+		return PubKeyBLS{}, ErrThreshold
+	}
+	x := big.NewInt(int64(index))
+	xk := big.NewInt(1)
+	res := bls.G1ProjectiveZero.Copy()
+	for k, commitment := range commitments {
+		point, err := pointFromPub(commitment)
+		if err != nil {
+			return PubKeyBLS{}, fmt.Errorf("%v(commitment: %d)", err, k)
+		}
+		res = res.Add(point.MulFR(scalarRepr(xk)))
+		xk.Mul(xk, x).Mod(xk, curveOrder)
+	}
+	return pubFromPoint(res), nil
+}
+
+// VerifyShare 校验私钥分片与承诺是否一致
+func VerifyShare(commitments []PubKeyBLS, share *KeyShare) error {
+	if scalarFromPriv(share.PrivKey).Cmp(curveOrder) >= 0 {
+		return ErrInvalidShare
+	}
+	pub, err := SharePubKey(commitments, share.Index)
+	if err != nil {
+		return err
+	}
+	if !share.PrivKey.PubKey().Equals(pub) {
+		return ErrInvalidShare
+	}
+	return nil
+}
+
+// Sign 分片私钥签名
+func (share *KeyShare) Sign(msg []byte) *PartialSignature {
+	return &PartialSignature{Index: share.Index, Sig: share.PrivKey.Sign(msg).(SignatureBLS)}
+}
+
+// VerifyPartial 使用承诺校验部分签名
+func VerifyPartial(commitments []PubKeyBLS, msg []byte, partial *PartialSignature) error {
+	pub, err := SharePubKey(commitments, partial.Index)
+	if err != nil {
+		return err
+	}
+	if !pub.VerifyBytes(msg, partial.Sig) {
+		return errors.New("bls signature mismatch")
+	}
+	return nil
+}
+
+// 拉格朗日插值在0处的系数 λi = Π j/(j-i), j为其他分片编号
+func lagrangeCoeff(index int, indices []int) *big.Int {
+	num := big.NewInt(1)
+	den := big.NewInt(1)
+	for _, j := range indices {
+		if j == index {
+			continue
+		}
+		num.Mul(num, big.NewInt(int64(j))).Mod(num, curveOrder)
+		den.Mul(den, big.NewInt(int64(j-index))).Mod(den, curveOrder)
+	}
+	den.ModInverse(den, curveOrder)
+	return num.Mul(num, den).Mod(num, curveOrder)
+}
+
+// RecoverSignature 由至少t个不同分片的部分签名通过拉格朗日插值恢复组签名, 组签名可由组公钥直接验证
+// 调用方需先用VerifyPartial过滤无效的部分签名, 只使用前t个
+func RecoverSignature(partials []*PartialSignature, t int) (crypto.Signature, error) {
+	if t <= 0 {
+		return nil, ErrThreshold
+	}
+	if len(partials) < t {
+		return nil, ErrNotEnoughPartial
+	}
+	partials = partials[:t]
+	indices := make([]int, 0, t)
+	seen := make(map[int]bool)
+	for _, partial := range partials {
+		if partial.Index <= 0 {
+			return nil, ErrShareIndex
+		}
+		if seen[partial.Index] {
+			return nil, ErrDuplicateIndex
+		}
+		seen[partial.Index] = true
+		indices = append(indices, partial.Index)
+	}
+	res := bls.G2ProjectiveZero.Copy()
+	for i, partial := range partials {
+		sig, err := ConvertToSignature(partial.Sig)
+		if err != nil {
+			return nil, fmt.Errorf("%v(index: %d)", err, i)
+		}
+		coeff := lagrangeCoeff(partial.Index, indices)
+		res = res.Add(sig.GetPoint().MulFR(scalarRepr(coeff)))
+	}
+	return SignatureBLS(g1pubs.NewSignatureFromG2(res.ToAffine()).Serialize()), nil
+}
@@ -0,0 +1,200 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThresholdSign(t *testing.T) {
+	sk, err := blsDrv.GenKey()
+	assert.NoError(t, err)
+	shares, commitments, err := SplitKey(sk, 3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(shares))
+	assert.Equal(t, 3, len(commitments))
+	groupPub, err := GroupPubKey(commitments)
+	assert.NoError(t, err)
+	assert.True(t, groupPub.Equals(sk.PubKey()))
+
+	_, _, err = SplitKey(sk, 6, 5)
+	assert.Equal(t, ErrThreshold, err)
+
+	msg := []byte("threshold message")
+	var partials []*PartialSignature
+	for _, share := range shares {
+		assert.NoError(t, VerifyShare(commitments, share))
+		partial := share.Sign(msg)
+		assert.NoError(t, VerifyPartial(commitments, msg, partial))
+		partials = append(partials, partial)
+	}
+	//篡改的分片和部分签名无法通过校验
+	bad := &KeyShare{Index: 1, PrivKey: shares[1].PrivKey}
+	assert.Equal(t, ErrInvalidShare, VerifyShare(commitments, bad))
+	assert.Error(t, VerifyPartial(commitments, msg, &PartialSignature{Index: 1, Sig: partials[1].Sig}))
+
+	//任意t个部分签名恢复出相同的组签名
+	sig, err := RecoverSignature(partials, 3)
+	assert.NoError(t, err)
+	assert.True(t, groupPub.VerifyBytes(msg, sig))
+	assert.True(t, sig.Equals(sk.Sign(msg)))
+	sig2, err := RecoverSignature([]*PartialSignature{partials[4], partials[1], partials[3]}, 3)
+	assert.NoError(t, err)
+	assert.True(t, sig.Equals(sig2))
+
+	//少于t个部分签名
+	_, err = RecoverSignature(partials[:2], 3)
+	assert.Equal(t, ErrNotEnoughPartial, err)
+	sig3, err := RecoverSignature(partials[:2], 2)
+	assert.NoError(t, err)
+	assert.False(t, groupPub.VerifyBytes(msg, sig3))
+	_, err = RecoverSignature([]*PartialSignature{partials[0], partials[0], partials[1]}, 3)
+	assert.Equal(t, ErrDuplicateIndex, err)
+}
+
+type dkgNetwork struct {
+	nodes []*DKG
+}
+
+func newDKGNetwork(t *testing.T, threshold, n int) *dkgNetwork {
+	net := &dkgNetwork{}
+	for i := 1; i <= n; i++ {
+		node, err := NewDKG(i, threshold, n, nil)
+		assert.NoError(t, err)
+		net.nodes = append(net.nodes, node)
+	}
+	return net
+}
+
+func (net *dkgNetwork) node(i int) *DKG {
+	return net.nodes[i-1]
+}
+
+func TestDKG(t *testing.T) {
+	threshold, n := 3, 5
+	net := newDKGNetwork(t, threshold, n)
+
+	deals := make(map[int]*DKGDeal)
+	shares := make(map[int][]*DKGShare)
+	//节点5没有参与分发, 被剔除
+	for _, node := range net.nodes[:n-1] {
+		deal, s, err := node.Deal()
+		assert.NoError(t, err)
+		assert.Equal(t, n-1, len(s))
+		deals[node.Index()] = deal
+		shares[node.Index()] = s
+	}
+	_, _, err := net.node(1).Deal()
+	assert.Equal(t, ErrDKGPhase, err)
+
+	//节点2发给节点3错误的分片, 投诉后公开正确分片
+	//节点4发给节点1错误的分片, 且不澄清投诉, 被剔除
+	for _, s := range shares[2] {
+		if s.Receiver == 3 {
+			s.Share = shares[2][0].Share
+		}
+	}
+	for _, s := range shares[4] {
+		if s.Receiver == 1 {
+			s.Share = shares[4][1].Share
+		}
+	}
+	for _, node := range net.nodes {
+		for dealer, deal := range deals {
+			if dealer != node.Index() {
+				assert.NoError(t, node.ProcessDeal(deal))
+			}
+		}
+		for dealer := range deals {
+			for _, s := range shares[dealer] {
+				if s.Receiver != node.Index() {
+					continue
+				}
+				err := node.ProcessShare(s)
+				if (dealer == 2 && s.Receiver == 3) || (dealer == 4 && s.Receiver == 1) {
+					assert.Equal(t, ErrInvalidShare, err)
+				} else {
+					assert.NoError(t, err)
+				}
+			}
+		}
+	}
+
+	var complaints []*DKGComplaint
+	for _, node := range net.nodes {
+		c, err := node.Complaints()
+		assert.NoError(t, err)
+		complaints = append(complaints, c...)
+	}
+	assert.Equal(t, []*DKGComplaint{{Dealer: 4, Complainer: 1}, {Dealer: 2, Complainer: 3}}, complaints)
+
+	var justifications []*DKGJustification
+	for _, node := range net.nodes {
+		for _, c := range complaints {
+			j, err := node.ProcessComplaint(c)
+			assert.NoError(t, err)
+			if j != nil && j.Dealer == 2 {
+				justifications = append(justifications, j)
+			}
+		}
+	}
+	assert.Equal(t, 1, len(justifications))
+	for _, node := range net.nodes {
+		for _, j := range justifications {
+			assert.NoError(t, node.ProcessJustification(j))
+		}
+	}
+
+	var results []*DKGResult
+	for _, node := range net.nodes {
+		result, err := node.Finalize()
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, result.Qualified)
+		assert.NoError(t, VerifyShare(result.Commitments, result.Share))
+		if len(results) > 0 {
+			assert.Equal(t, results[0].Commitments, result.Commitments)
+		}
+		results = append(results, result)
+	}
+
+	msg := []byte("dkg message")
+	groupPub := results[0].GroupPubKey()
+	var partials []*PartialSignature
+	for _, result := range results {
+		partial := result.Share.Sign(msg)
+		assert.NoError(t, VerifyPartial(results[0].Commitments, msg, partial))
+		partials = append(partials, partial)
+	}
+	sig, err := RecoverSignature(partials[2:], threshold)
+	assert.NoError(t, err)
+	assert.True(t, groupPub.VerifyBytes(msg, sig))
+	sig2, err := RecoverSignature(partials, threshold)
+	assert.NoError(t, err)
+	assert.True(t, sig.Equals(sig2))
+}
+
+func TestDKGNotEnoughQualified(t *testing.T) {
+	net := newDKGNetwork(t, 2, 3)
+	deal, shares, err := net.node(1).Deal()
+	assert.NoError(t, err)
+	for _, node := range net.nodes[1:] {
+		assert.NoError(t, node.ProcessDeal(deal))
+		for _, s := range shares {
+			if s.Receiver == node.Index() {
+				assert.NoError(t, node.ProcessShare(s))
+			}
+		}
+	}
+	for _, node := range net.nodes {
+		_, err := node.Complaints()
+		assert.NoError(t, err)
+		_, err = node.Finalize()
+		assert.Equal(t, ErrDKGQualified, err)
+	}
+	_, err = NewDKG(4, 2, 3, nil)
+	assert.Equal(t, ErrShareIndex, err)
+}
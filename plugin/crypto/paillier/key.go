@@ -0,0 +1,269 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+var (
+	// ErrKeyBits 密钥长度错误
+	ErrKeyBits = errors.New("ErrKeyBits")
+	// ErrPrivateKey 私钥格式错误
+	ErrPrivateKey = errors.New("ErrPrivateKey")
+	// ErrPublicKey 公钥格式错误
+	ErrPublicKey = errors.New("ErrPublicKey")
+	// ErrCiphertext 密文格式错误或不是有效的密文
+	ErrCiphertext = errors.New("ErrCiphertext")
+	// ErrPlaintext 明文超出范围[0, n)
+	ErrPlaintext = errors.New("ErrPlaintext")
+)
+
+const (
+	// MinKeyBits 最小密钥长度
+	MinKeyBits = 1024
+	// 密文中n的长度用2字节表示
+	maxKeyBits = 0x7fff * 8
+)
+
+var one = big.NewInt(1)
+
+// PublicKey paillier公钥, 生成元g固定为n+1
+type PublicKey struct {
+	N       *big.Int
+	G       *big.Int
+	NSquare *big.Int
+}
+
+// PrivateKey paillier私钥
+type PrivateKey struct {
+	PublicKey
+	P      *big.Int
+	Q      *big.Int
+	Lambda *big.Int
+	Mu     *big.Int
+}
+
+// GenerateKey 生成bits位的密钥, random为nil时使用crypto/rand
+func GenerateKey(random io.Reader, bits int) (*PrivateKey, error) {
+	if bits < MinKeyBits || bits > maxKeyBits {
+		return nil, ErrKeyBits
+	}
+	if random == nil {
+		random = rand.Reader
+	}
+	for {
+		p, err := rand.Prime(random, bits/2)
+		if err != nil {
+			return nil, err
+		}
+		q, err := rand.Prime(random, bits-bits/2)
+		if err != nil {
+			return nil, err
+		}
+		if p.Cmp(q) == 0 {
+			continue
+		}
+		priv, err := NewPrivateKey(p, q)
+		if err != nil {
+			return nil, err
+		}
+		if priv.N.BitLen() == bits {
+			return priv, nil
+		}
+	}
+}
+
+// NewPrivateKey 由素数p, q构造私钥
+func NewPrivateKey(p, q *big.Int) (*PrivateKey, error) {
+	if p.Sign() <= 0 || q.Sign() <= 0 || p.Cmp(q) == 0 {
+		return nil, ErrPrivateKey
+	}
+	n := new(big.Int).Mul(p, q)
+	pm := new(big.Int).Sub(p, one)
+	qm := new(big.Int).Sub(q, one)
+	phi := new(big.Int).Mul(pm, qm)
+	//要求gcd(n, φ(n)) = 1
+	if new(big.Int).GCD(nil, nil, n, phi).Cmp(one) != 0 {
+		return nil, ErrPrivateKey
+	}
+	lambda := new(big.Int).Div(phi, new(big.Int).GCD(nil, nil, pm, qm))
+	//g = n+1时, μ = λ^-1 mod n
+	mu := new(big.Int).ModInverse(lambda, n)
+	if mu == nil {
+		return nil, ErrPrivateKey
+	}
+	return &PrivateKey{
+		PublicKey: *newPublicKey(n),
+		P:         new(big.Int).Set(p),
+		Q:         new(big.Int).Set(q),
+		Lambda:    lambda,
+		Mu:        mu,
+	}, nil
+}
+
+func newPublicKey(n *big.Int) *PublicKey {
+	return &PublicKey{
+		N:       n,
+		G:       new(big.Int).Add(n, one),
+		NSquare: new(big.Int).Mul(n, n),
+	}
+}
+
+// NewPublicKey 由n的大端字节构造公钥
+func NewPublicKey(nBytes []byte) (*PublicKey, error) {
+	n := new(big.Int).SetBytes(nBytes)
+	if n.BitLen() < MinKeyBits || n.BitLen() > maxKeyBits || n.Bit(0) == 0 {
+		return nil, ErrPublicKey
+	}
+	return newPublicKey(n), nil
+}
+
+// Bytes 公钥序列化为n的大端字节
+func (pub *PublicKey) Bytes() []byte {
+	return pub.N.Bytes()
+}
+
+// Bytes 私钥序列化为 2字节p的长度 + p + q
+func (priv *PrivateKey) Bytes() []byte {
+	p := priv.P.Bytes()
+	data := make([]byte, 2, 2+len(p)+len(priv.Q.Bytes()))
+	binary.BigEndian.PutUint16(data, uint16(len(p)))
+	data = append(data, p...)
+	return append(data, priv.Q.Bytes()...)
+}
+
+// ParsePrivateKey 解析PrivateKey.Bytes序列化的私钥
+func ParsePrivateKey(data []byte) (*PrivateKey, error) {
+	if len(data) < 2 {
+		return nil, ErrPrivateKey
+	}
+	plen := int(binary.BigEndian.Uint16(data))
+	if plen == 0 || plen >= len(data)-2 {
+		return nil, ErrPrivateKey
+	}
+	p := new(big.Int).SetBytes(data[2 : 2+plen])
+	q := new(big.Int).SetBytes(data[2+plen:])
+	return NewPrivateKey(p, q)
+}
+
+// 密文格式: 2字节n的长度 + n + c
+func encodeCiphertext(n, c *big.Int) []byte {
+	nBytes := n.Bytes()
+	data := make([]byte, 2, 2+len(nBytes)+len(n.Bytes())*2)
+	binary.BigEndian.PutUint16(data, uint16(len(nBytes)))
+	data = append(data, nBytes...)
+	return append(data, c.Bytes()...)
+}
+
+// 解析密文, 并检查c是Z*(n^2)中的元素
+func parseCiphertext(cipher []byte) (*PublicKey, *big.Int, error) {
+	if len(cipher) < 2 {
+		return nil, nil, ErrCiphertext
+	}
+	nlen := int(binary.BigEndian.Uint16(cipher))
+	if nlen == 0 || nlen >= len(cipher)-2 {
+		return nil, nil, ErrCiphertext
+	}
+	pub, err := NewPublicKey(cipher[2 : 2+nlen])
+	if err != nil {
+		return nil, nil, err
+	}
+	c := new(big.Int).SetBytes(cipher[2+nlen:])
+	if !pub.inGroup(c, pub.NSquare) {
+		return nil, nil, ErrCiphertext
+	}
+	return pub, c, nil
+}
+
+// x是否在Z*(mod)中
+func (pub *PublicKey) inGroup(x, mod *big.Int) bool {
+	return x.Sign() > 0 && x.Cmp(mod) < 0 && new(big.Int).GCD(nil, nil, x, pub.N).Cmp(one) == 0
+}
+
+// 随机选取Z*n中的元素
+func (pub *PublicKey) randomNonce(random io.Reader) (*big.Int, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+	for {
+		r, err := rand.Int(random, pub.N)
+		if err != nil {
+			return nil, err
+		}
+		if pub.inGroup(r, pub.N) {
+			return r, nil
+		}
+	}
+}
+
+// Encrypt 加密明文m, m需在[0, n)范围内
+func (pub *PublicKey) Encrypt(m *big.Int) ([]byte, error) {
+	r, err := pub.randomNonce(nil)
+	if err != nil {
+		return nil, err
+	}
+	return pub.EncryptWithNonce(m, r)
+}
+
+// EncryptWithNonce 使用指定的随机数r加密, c = g^m * r^n mod n^2, 生成范围证明时需要r
+func (pub *PublicKey) EncryptWithNonce(m, r *big.Int) ([]byte, error) {
+	if m.Sign() < 0 || m.Cmp(pub.N) >= 0 {
+		return nil, ErrPlaintext
+	}
+	if !pub.inGroup(r, pub.N) {
+		return nil, fmt.Errorf("EncryptWithNonce. invalid nonce")
+	}
+	return encodeCiphertext(pub.N, pub.encrypt(m, r)), nil
+}
+
+func (pub *PublicKey) encrypt(m, r *big.Int) *big.Int {
+	//g = n+1时, g^m = 1 + m*n mod n^2
+	gm := new(big.Int).Mul(m, pub.N)
+	gm.Add(gm, one).Mod(gm, pub.NSquare)
+	rn := new(big.Int).Exp(r, pub.N, pub.NSquare)
+	return gm.Mul(gm, rn).Mod(gm, pub.NSquare)
+}
+
+// Decrypt 解密, m = L(c^λ mod n^2) * μ mod n, L(x) = (x-1)/n
+func (priv *PrivateKey) Decrypt(cipher []byte) (*big.Int, error) {
+	pub, c, err := parseCiphertext(cipher)
+	if err != nil {
+		return nil, err
+	}
+	if pub.N.Cmp(priv.N) != 0 {
+		return nil, ErrPublicKey
+	}
+	x := new(big.Int).Exp(c, priv.Lambda, priv.NSquare)
+	x.Sub(x, one).Div(x, priv.N)
+	return x.Mul(x, priv.Mu).Mod(x, priv.N), nil
+}
+
+// CiphertextMulBytes 密文乘以明文k, 结果为m*k mod n的密文
+func CiphertextMulBytes(cipher []byte, k *big.Int) ([]byte, error) {
+	pub, c, err := parseCiphertext(cipher)
+	if err != nil {
+		return nil, err
+	}
+	if k.Sign() < 0 || k.Cmp(pub.N) >= 0 {
+		return nil, ErrPlaintext
+	}
+	return encodeCiphertext(pub.N, c.Exp(c, k, pub.NSquare)), nil
+}
+
+// RerandomizeBytes 重新随机化密文, 明文不变但与原密文不可关联
+func RerandomizeBytes(cipher []byte) ([]byte, error) {
+	pub, c, err := parseCiphertext(cipher)
+	if err != nil {
+		return nil, err
+	}
+	r, err := pub.randomNonce(nil)
+	if err != nil {
+		return nil, err
+	}
+	c.Mul(c, r.Exp(r, pub.N, pub.NSquare)).Mod(c, pub.NSquare)
+	return encodeCiphertext(pub.N, c), nil
+}
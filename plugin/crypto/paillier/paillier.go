@@ -30,6 +30,9 @@ func CiphertextAdd(ciphertext1, ciphertext2 string) (string, error) {
 }
 
 func CiphertextAddBytes(cipherbytes1, cipherbytes2 []byte) ([]byte, error) {
+	if len(cipherbytes1) < 2 || len(cipherbytes2) < 2 {
+		return nil, fmt.Errorf("CiphertextAddBytes. error param length")
+	}
 	nlen1 := bytesToInt(cipherbytes1[0:2])
 	if nlen1 >= len(cipherbytes1)-2 {
 		return nil, fmt.Errorf("CiphertextAddBytes. error param length")
@@ -1,6 +1,7 @@
 package paillier
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,3 +18,113 @@ func TestCiphertextAdd(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, c3, data)
 }
+
+func TestEncryptDecrypt(t *testing.T) {
+	priv, err := GenerateKey(nil, MinKeyBits)
+	assert.Nil(t, err)
+	assert.Equal(t, MinKeyBits, priv.N.BitLen())
+	_, err = GenerateKey(nil, 512)
+	assert.Equal(t, ErrKeyBits, err)
+
+	pub, err := NewPublicKey(priv.PublicKey.Bytes())
+	assert.Nil(t, err)
+	c1, err := pub.Encrypt(big.NewInt(100))
+	assert.Nil(t, err)
+	c2, err := pub.Encrypt(big.NewInt(23))
+	assert.Nil(t, err)
+	m, err := priv.Decrypt(c1)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), m.Int64())
+
+	sum, err := CiphertextAddBytes(c1, c2)
+	assert.Nil(t, err)
+	m, err = priv.Decrypt(sum)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(123), m.Int64())
+
+	prod, err := CiphertextMulBytes(c1, big.NewInt(7))
+	assert.Nil(t, err)
+	m, err = priv.Decrypt(prod)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(700), m.Int64())
+
+	c3, err := RerandomizeBytes(c1)
+	assert.Nil(t, err)
+	assert.NotEqual(t, c1, c3)
+	m, err = priv.Decrypt(c3)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(100), m.Int64())
+
+	priv2, err := ParsePrivateKey(priv.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, priv.Lambda, priv2.Lambda)
+	m, err = priv2.Decrypt(c2)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(23), m.Int64())
+
+	_, err = pub.Encrypt(priv.N)
+	assert.Equal(t, ErrPlaintext, err)
+	_, err = priv.Decrypt(c1[:len(c1)-len(priv.N.Bytes())*2])
+	assert.Equal(t, ErrCiphertext, err)
+	_, err = priv.Decrypt(append(append([]byte{}, c1[:2+len(priv.N.Bytes())]...), 0))
+	assert.Equal(t, ErrCiphertext, err)
+}
+
+func TestRangeProof(t *testing.T) {
+	priv, err := GenerateKey(nil, MinKeyBits)
+	assert.Nil(t, err)
+	pub := &priv.PublicKey
+	r, err := pub.randomNonce(nil)
+	assert.Nil(t, err)
+	m := big.NewInt(1000)
+	cipher, err := pub.EncryptWithNonce(m, r)
+	assert.Nil(t, err)
+
+	proof, err := ProveRange(cipher, m, r, 16)
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyRange(cipher, proof, 16))
+	assert.Nil(t, VerifyRange(cipher, proof, 32))
+	//证明的范围大于要求的范围
+	assert.Equal(t, ErrRangeBits, VerifyRange(cipher, proof, 8))
+
+	//序列化
+	parsed, err := ParseRangeProof(proof.Bytes())
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyRange(cipher, parsed, 16))
+	_, err = ParseRangeProof(proof.Bytes()[:100])
+	assert.Equal(t, ErrRangeProof, err)
+
+	//证明不能用于其他密文
+	other, err := pub.Encrypt(m)
+	assert.Nil(t, err)
+	assert.Equal(t, ErrRangeProof, VerifyRange(other, proof, 16))
+
+	//篡改
+	parsed.Bits[3].Z0.Add(parsed.Bits[3].Z0, one)
+	assert.Equal(t, ErrRangeProof, VerifyRange(cipher, parsed, 16))
+	parsed, _ = ParseRangeProof(proof.Bytes())
+	parsed.Bits = parsed.Bits[:15]
+	assert.Equal(t, ErrRangeProof, VerifyRange(cipher, parsed, 16))
+
+	//超出范围的明文无法生成证明
+	_, err = ProveRange(cipher, m, r, 8)
+	assert.Equal(t, ErrPlaintext, err)
+	_, err = ProveRange(cipher, big.NewInt(999), r, 16)
+	assert.Equal(t, ErrNonce, err)
+	//负数(n-1)的密文
+	neg, err := pub.EncryptWithNonce(new(big.Int).Sub(pub.N, one), r)
+	assert.Nil(t, err)
+	_, err = ProveRange(neg, new(big.Int).Sub(pub.N, one), r, MaxRangeBits)
+	assert.Equal(t, ErrPlaintext, err)
+
+	cipher8, proof8, err := pub.EncryptWithRangeProof(big.NewInt(255), 8)
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyRange(cipher8, proof8, 8))
+	_, _, err = pub.EncryptWithRangeProof(big.NewInt(256), 8)
+	assert.Equal(t, ErrPlaintext, err)
+
+	//最大位数的证明
+	proof2, err := ProveRange(cipher, m, r, MaxRangeBits)
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyRange(cipher, proof2, MaxRangeBits))
+}
@@ -0,0 +1,286 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+var (
+	// ErrRangeBits 范围证明位数错误
+	ErrRangeBits = errors.New("ErrRangeBits")
+	// ErrRangeProof 范围证明校验失败
+	ErrRangeProof = errors.New("ErrRangeProof")
+	// ErrNonce 随机数与密文不匹配
+	ErrNonce = errors.New("ErrNonce")
+)
+
+const (
+	// MaxRangeBits 范围证明支持的最大位数
+	MaxRangeBits = 64
+	//Fiat-Shamir挑战值位数, 需小于n的素因子位数
+	challengeBits = 256
+	rangeProofTag = "paillier-range-proof"
+)
+
+var challengeMod = new(big.Int).Lsh(one, challengeBits)
+
+// BitProof 证明密文C是0或1的加密, 两个分支的承诺值由验证方根据挑战和响应恢复
+type BitProof struct {
+	C  *big.Int
+	E0 *big.Int
+	E1 *big.Int
+	Z0 *big.Int
+	Z1 *big.Int
+}
+
+// RangeProof 非交互零知识范围证明, 证明密文的明文在[0, 2^len(Bits))范围内
+// 明文按二进制位分解为0或1的密文, 同时证明各位密文按2^i加权的乘积与原密文之商是0的加密
+type RangeProof struct {
+	Bits []*BitProof
+	E    *big.Int
+	Z    *big.Int
+}
+
+func challenge(vals ...*big.Int) *big.Int {
+	h := sha256.New()
+	h.Write([]byte(rangeProofTag))
+	var l [2]byte
+	for _, v := range vals {
+		b := v.Bytes()
+		binary.BigEndian.PutUint16(l[:], uint16(len(b)))
+		h.Write(l[:])
+		h.Write(b)
+	}
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+// 承诺值恢复 a = z^n * u^-e mod n^2
+func (pub *PublicKey) commitment(u, e, z *big.Int) *big.Int {
+	ue := new(big.Int).Exp(u, e, pub.NSquare)
+	if ue.ModInverse(ue, pub.NSquare) == nil {
+		return big.NewInt(0)
+	}
+	a := new(big.Int).Exp(z, pub.N, pub.NSquare)
+	return a.Mul(a, ue).Mod(a, pub.NSquare)
+}
+
+// 分支j的目标值 u_j = C * g^-j mod n^2, 证明其为n次剩余即C是j的加密
+func (pub *PublicKey) bitTargets(ci *big.Int) [2]*big.Int {
+	ginv := new(big.Int).ModInverse(pub.G, pub.NSquare)
+	u1 := ginv.Mul(ginv, ci).Mod(ginv, pub.NSquare)
+	return [2]*big.Int{ci, u1}
+}
+
+// 各位密文按2^i加权的乘积与原密文之商
+func (pub *PublicKey) zeroTarget(c *big.Int, bits []*BitProof) *big.Int {
+	prod := big.NewInt(1)
+	for i, bp := range bits {
+		w := new(big.Int).Exp(bp.C, new(big.Int).Lsh(one, uint(i)), pub.NSquare)
+		prod.Mul(prod, w).Mod(prod, pub.NSquare)
+	}
+	if prod.ModInverse(prod, pub.NSquare) == nil {
+		return big.NewInt(0)
+	}
+	return prod.Mul(prod, c).Mod(prod, pub.NSquare)
+}
+
+// 知道ri使得ci是bit的加密, 对另一分支模拟证明
+func (pub *PublicKey) proveBit(c *big.Int, index int, ci *big.Int, bit uint, ri *big.Int) (*BitProof, error) {
+	u := pub.bitTargets(ci)
+	var e, z, a [2]*big.Int
+	fake := 1 - bit
+	var err error
+	e[fake], err = rand.Int(rand.Reader, challengeMod)
+	if err != nil {
+		return nil, err
+	}
+	z[fake], err = pub.randomNonce(nil)
+	if err != nil {
+		return nil, err
+	}
+	a[fake] = pub.commitment(u[fake], e[fake], z[fake])
+	s, err := pub.randomNonce(nil)
+	if err != nil {
+		return nil, err
+	}
+	a[bit] = new(big.Int).Exp(s, pub.N, pub.NSquare)
+	sum := challenge(pub.N, c, big.NewInt(int64(index)), ci, a[0], a[1])
+	e[bit] = new(big.Int).Sub(sum, e[fake])
+	e[bit].Mod(e[bit], challengeMod)
+	z[bit] = new(big.Int).Exp(ri, e[bit], pub.N)
+	z[bit].Mul(z[bit], s).Mod(z[bit], pub.N)
+	return &BitProof{C: ci, E0: e[0], E1: e[1], Z0: z[0], Z1: z[1]}, nil
+}
+
+func (pub *PublicKey) verifyBit(c *big.Int, index int, bp *BitProof) bool {
+	if bp == nil || bp.C == nil || bp.E0 == nil || bp.E1 == nil || bp.Z0 == nil || bp.Z1 == nil {
+		return false
+	}
+	if !pub.inGroup(bp.C, pub.NSquare) || !pub.inGroup(bp.Z0, pub.N) || !pub.inGroup(bp.Z1, pub.N) {
+		return false
+	}
+	if bp.E0.Cmp(challengeMod) >= 0 || bp.E1.Cmp(challengeMod) >= 0 {
+		return false
+	}
+	u := pub.bitTargets(bp.C)
+	a0 := pub.commitment(u[0], bp.E0, bp.Z0)
+	a1 := pub.commitment(u[1], bp.E1, bp.Z1)
+	sum := new(big.Int).Add(bp.E0, bp.E1)
+	sum.Mod(sum, challengeMod)
+	return sum.Cmp(challenge(pub.N, c, big.NewInt(int64(index)), bp.C, a0, a1)) == 0
+}
+
+// ProveRange 对使用随机数r加密m得到的密文生成范围证明, 证明m在[0, 2^bits)范围内
+func ProveRange(cipher []byte, m, r *big.Int, bits int) (*RangeProof, error) {
+	if bits <= 0 || bits > MaxRangeBits {
+		return nil, ErrRangeBits
+	}
+	pub, c, err := parseCiphertext(cipher)
+	if err != nil {
+		return nil, err
+	}
+	if m.Sign() < 0 || m.BitLen() > bits {
+		return nil, ErrPlaintext
+	}
+	if !pub.inGroup(r, pub.N) || pub.encrypt(m, r).Cmp(c) != 0 {
+		return nil, ErrNonce
+	}
+	proof := &RangeProof{}
+	//商的n次根 ρ = r * Π ri^-(2^i) mod n
+	rho := new(big.Int).Set(r)
+	for i := 0; i < bits; i++ {
+		bit := m.Bit(i)
+		ri, err := pub.randomNonce(nil)
+		if err != nil {
+			return nil, err
+		}
+		ci := pub.encrypt(big.NewInt(int64(bit)), ri)
+		bp, err := pub.proveBit(c, i, ci, bit, ri)
+		if err != nil {
+			return nil, err
+		}
+		proof.Bits = append(proof.Bits, bp)
+		w := new(big.Int).Exp(ri, new(big.Int).Lsh(one, uint(i)), pub.N)
+		w.ModInverse(w, pub.N)
+		rho.Mul(rho, w).Mod(rho, pub.N)
+	}
+	u := pub.zeroTarget(c, proof.Bits)
+	s, err := pub.randomNonce(nil)
+	if err != nil {
+		return nil, err
+	}
+	a := new(big.Int).Exp(s, pub.N, pub.NSquare)
+	proof.E = challenge(pub.N, c, u, a)
+	proof.Z = new(big.Int).Exp(rho, proof.E, pub.N)
+	proof.Z.Mul(proof.Z, s).Mod(proof.Z, pub.N)
+	return proof, nil
+}
+
+// EncryptWithRangeProof 加密m并生成m在[0, 2^bits)范围内的证明
+func (pub *PublicKey) EncryptWithRangeProof(m *big.Int, bits int) ([]byte, *RangeProof, error) {
+	r, err := pub.randomNonce(nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	cipher, err := pub.EncryptWithNonce(m, r)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := ProveRange(cipher, m, r, bits)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cipher, proof, nil
+}
+
+// VerifyRange 校验范围证明, 要求证明的位数不超过bits
+func VerifyRange(cipher []byte, proof *RangeProof, bits int) error {
+	if proof == nil || len(proof.Bits) == 0 || len(proof.Bits) > bits || len(proof.Bits) > MaxRangeBits {
+		return ErrRangeBits
+	}
+	pub, c, err := parseCiphertext(cipher)
+	if err != nil {
+		return err
+	}
+	for i, bp := range proof.Bits {
+		if !pub.verifyBit(c, i, bp) {
+			return ErrRangeProof
+		}
+	}
+	if proof.E == nil || proof.Z == nil || proof.E.Cmp(challengeMod) >= 0 || !pub.inGroup(proof.Z, pub.N) {
+		return ErrRangeProof
+	}
+	u := pub.zeroTarget(c, proof.Bits)
+	if !pub.inGroup(u, pub.NSquare) {
+		return ErrRangeProof
+	}
+	a := pub.commitment(u, proof.E, proof.Z)
+	if challenge(pub.N, c, u, a).Cmp(proof.E) != 0 {
+		return ErrRangeProof
+	}
+	return nil
+}
+
+func appendInt(data []byte, x *big.Int) []byte {
+	b := x.Bytes()
+	var l [2]byte
+	binary.BigEndian.PutUint16(l[:], uint16(len(b)))
+	return append(append(data, l[:]...), b...)
+}
+
+func readInt(data []byte) (*big.Int, []byte, error) {
+	if len(data) < 2 {
+		return nil, nil, ErrRangeProof
+	}
+	l := int(binary.BigEndian.Uint16(data))
+	if len(data) < 2+l {
+		return nil, nil, ErrRangeProof
+	}
+	return new(big.Int).SetBytes(data[2 : 2+l]), data[2+l:], nil
+}
+
+// Bytes 序列化为 1字节位数 + 每一位的(C, E0, E1, Z0, Z1) + E + Z, 每个整数前为2字节长度
+func (proof *RangeProof) Bytes() []byte {
+	data := []byte{byte(len(proof.Bits))}
+	for _, bp := range proof.Bits {
+		for _, x := range []*big.Int{bp.C, bp.E0, bp.E1, bp.Z0, bp.Z1} {
+			data = appendInt(data, x)
+		}
+	}
+	data = appendInt(data, proof.E)
+	return appendInt(data, proof.Z)
+}
+
+// ParseRangeProof 解析RangeProof.Bytes序列化的证明
+func ParseRangeProof(data []byte) (*RangeProof, error) {
+	if len(data) == 0 || int(data[0]) > MaxRangeBits {
+		return nil, ErrRangeBits
+	}
+	bits := int(data[0])
+	data = data[1:]
+	proof := &RangeProof{Bits: make([]*BitProof, bits)}
+	var err error
+	for i := 0; i < bits; i++ {
+		vals := make([]*big.Int, 5)
+		for j := range vals {
+			vals[j], data, err = readInt(data)
+			if err != nil {
+				return nil, err
+			}
+		}
+		proof.Bits[i] = &BitProof{C: vals[0], E0: vals[1], E1: vals[2], Z0: vals[3], Z1: vals[4]}
+	}
+	if proof.E, data, err = readInt(data); err != nil {
+		return nil, err
+	}
+	if proof.Z, data, err = readInt(data); err != nil {
+		return nil, err
+	}
+	if len(data) != 0 {
+		return nil, ErrRangeProof
+	}
+	return proof, nil
+}
@@ -55,6 +55,7 @@ func DPosCmd() *cobra.Command {
 		DPosCreateCmd(),
 		DPosVrfVerifyCmd(),
 		DPosVrfEvaluateCmd(),
+		DPosVrfBeaconCmd(),
 		DPosCBRecordCmd(),
 		DPosCBQueryCmd(),
 		DPosTopNQueryCmd(),
@@ -979,3 +980,65 @@ func topNQuery(cmd *cobra.Command, args []string) {
 	ctx := jsonrpc.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
 	ctx.Run()
 }
+
+// DPosVrfBeaconCmd 查询cycle的随机数并用链上的VRF信息校验
+func DPosVrfBeaconCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vrfBeacon",
+		Short: "query random beacon of a cycle and verify it with vrf info on chain",
+		Run:   vrfBeacon,
+	}
+	addVrfBeaconFlags(cmd)
+	return cmd
+}
+
+func addVrfBeaconFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64P("cycle", "c", 0, "cycle")
+	cmd.Flags().Int64P("timestamp", "s", 0, "time stamp from 1970-1-1, query the latest finished cycle before it if cycle is not set")
+}
+
+func vrfBeacon(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	cycle, _ := cmd.Flags().GetInt64("cycle")
+	timestamp, _ := cmd.Flags().GetInt64("timestamp")
+	if cycle <= 0 && timestamp <= 0 {
+		fmt.Println("cycle or timestamp should be set")
+		return
+	}
+
+	var params rpctypes.Query4Jrpc
+	params.Execer = dty.DPosX
+	params.FuncName = dty.FuncNameQueryRandomBeacon
+	params.Payload = types.MustPBToJSON(&dty.DposVrfQuery{Cycle: cycle, Timestamp: timestamp})
+	var beacon dty.DposRandomBeacon
+	ctx := jsonrpc.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &beacon)
+	_, err := ctx.RunResult()
+	if err != nil {
+		fmt.Println("query random beacon failed:", err)
+		return
+	}
+
+	//重新查询该cycle链上记录的所有vrf信息, 在本地校验证明并计算随机数
+	params.FuncName = dty.FuncNameQueryVrfByCycle
+	params.Payload = types.MustPBToJSON(&dty.DposVrfQuery{Ty: dty.QueryVrfByCycle, Cycle: beacon.Cycle})
+	var vrfs dty.DposVrfReply
+	ctx = jsonrpc.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &vrfs)
+	_, err = ctx.RunResult()
+	if err != nil {
+		fmt.Println("query vrf info failed:", err)
+		return
+	}
+
+	fmt.Println("cycle:", beacon.Cycle)
+	fmt.Println("m:", beacon.M)
+	fmt.Println("random:", beacon.Random)
+	for _, info := range vrfs.Vrf {
+		fmt.Println(fmt.Sprintf("pubkey:%s, r:%s, vrf verify:%v", info.Pubkey, info.R, dty.VerifyVrfInfo(info)))
+	}
+
+	if err := dty.VerifyRandomBeacon(&beacon, vrfs.Vrf); err != nil {
+		fmt.Println("random beacon verify failed:", err)
+		return
+	}
+	fmt.Println("random beacon verify succeed")
+}
@@ -290,6 +290,59 @@ func queryVrfByCycle(kvdb db.KVDB, req *dty.DposVrfQuery) (types.Message, error)
 	return &dty.DposVrfReply{Vrf: getJSONVrfs(vrfs)}, nil
 }
 
+//queryRandomBeacon 根据cycle查询该cycle的可验证随机数, 按时间查询时取该时间之前最近一个已结束的cycle
+func queryRandomBeacon(kvdb db.KVDB, req *dty.DposVrfQuery) (types.Message, error) {
+	cycle := req.Cycle
+	if cycle <= 0 && req.Timestamp > 0 {
+		cycle = calcCycleByTime(req.Timestamp).cycle - 1
+	}
+	if cycle <= 0 {
+		return nil, types.ErrInvalidParam
+	}
+
+	return getRandomBeacon(kvdb, cycle)
+}
+
+//getRandomBeacon 根据cycle内受托节点注册的R/P信息计算随机数, 未通过vrf校验的记录不参与计算
+func getRandomBeacon(kvdb db.KVDB, cycle int64) (*dty.DposRandomBeacon, error) {
+	VrfRPTable := dty.NewDposVrfRPTable(kvdb)
+	query := VrfRPTable.GetQuery(kvdb)
+
+	rows, err := query.ListIndex("cycle", []byte(fmt.Sprintf("%018d", cycle)), nil, 0, 0)
+	if err != nil {
+		logger.Error("getRandomBeacon failed", "cycle", cycle, "err", err.Error())
+		return nil, dty.ErrNoValidVrf
+	}
+
+	var vrfs []*dty.VrfInfo
+	for i := 0; i < len(rows); i++ {
+		vrfs = append(vrfs, getVrfInfoFromVrfRP(rows[i].Data.(*dty.DposVrfRP)))
+	}
+
+	return dty.CalcRandomBeacon(cycle, getJSONVrfs(vrfs))
+}
+
+//getRandNum 取blockHash对应区块时间之前最近一个已结束cycle的随机数, 与blockHash混合后返回,
+//已结束的cycle不能再注册R/P, 保证同一个区块得到的随机数不变
+func (d *DPos) getRandNum(blockHash []byte) (types.Message, error) {
+	if len(blockHash) == 0 {
+		return nil, types.ErrBlockNotFound
+	}
+
+	block, err := d.GetAPI().GetBlockOverview(&types.ReqHash{Hash: blockHash})
+	if err != nil {
+		return nil, err
+	}
+
+	beacon, err := getRandomBeacon(d.GetLocalDB(), calcCycleByTime(block.Head.BlockTime).cycle-1)
+	if err != nil {
+		return nil, err
+	}
+
+	random, _ := hex.DecodeString(beacon.Random)
+	return &types.ReplyHash{Hash: common.Sha256(append(random, blockHash...))}, nil
+}
+
 //queryCands 根据候选节点的Pubkey下旬候选节点信息,得票数、状态等
 func queryCands(kvdb db.KVDB, req *dty.CandidatorQuery) (types.Message, error) {
 	var cands []*dty.JSONCandidator
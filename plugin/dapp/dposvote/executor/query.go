@@ -44,6 +44,16 @@ func (d *DPos) Query_QueryVrfByCycleForPubkeys(in *dty.DposVrfQuery) (types.Mess
 	return queryVrfByCycleForPubkeys(d.GetLocalDB(), in)
 }
 
+//Query_QueryRandomBeacon method
+func (d *DPos) Query_QueryRandomBeacon(in *dty.DposVrfQuery) (types.Message, error) {
+	return queryRandomBeacon(d.GetLocalDB(), in)
+}
+
+//Query_RandNumHash 其他执行器通过GetRandNum并指定ExecName为dpos获取随机数
+func (d *DPos) Query_RandNumHash(in *types.ReqRandHash) (types.Message, error) {
+	return d.getRandNum(in.Hash)
+}
+
 //Query_QueryCBInfoByCycle method
 func (d *DPos) Query_QueryCBInfoByCycle(in *dty.DposCBQuery) (types.Message, error) {
 	return queryCBInfoByCycle(d.GetLocalDB(), in)
@@ -0,0 +1,120 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+	"strings"
+
+	"github.com/33cn/chain33/common"
+	vrf "github.com/33cn/chain33/common/vrf/secp256k1"
+	secp256k1 "github.com/btcsuite/btcd/btcec"
+)
+
+//VerifyVrfInfo 校验受托节点注册的vrf信息, R必须是pubkey对应的私钥以M为输入计算出的vrf hash
+func VerifyVrfInfo(info *JSONVrfInfo) bool {
+	if info == nil || len(info.M) == 0 || len(info.R) == 0 || len(info.P) == 0 {
+		return false
+	}
+	//链上记录的M是cycle boundary的stopHash的hex字符串, 共识模块解码后作为vrf的输入
+	m, err := hex.DecodeString(info.M)
+	if err != nil {
+		return false
+	}
+	r, err := hex.DecodeString(info.R)
+	if err != nil {
+		return false
+	}
+	p, err := hex.DecodeString(info.P)
+	if err != nil {
+		return false
+	}
+	bPubkey, err := hex.DecodeString(info.Pubkey)
+	if err != nil {
+		return false
+	}
+	pubKey, err := secp256k1.ParsePubKey(bPubkey, secp256k1.S256())
+	if err != nil {
+		return false
+	}
+	vrfPub := &vrf.PublicKey{PublicKey: (*ecdsa.PublicKey)(pubKey)}
+	vrfHash, err := vrfPub.ProofToHash(m, p)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(vrfHash[:], r)
+}
+
+//CalcRandomBeacon 根据一个cycle内受托节点注册的vrf信息计算该cycle的随机数
+//只有vrf证明校验通过的记录参与计算, 同一pubkey只取第一条;
+//如果记录的M不一致, 取参与节点最多的M(相同时取较小的M), 保证结果只由链上数据决定
+//随机数 = sha256(cycle || M || R1 || R2 ...), R按pubkey排序
+func CalcRandomBeacon(cycle int64, vrfs []*JSONVrfInfo) (*DposRandomBeacon, error) {
+	groups := make(map[string][]*JSONVrfInfo)
+	seen := make(map[string]bool)
+	for _, info := range vrfs {
+		if info == nil || info.Cycle != cycle {
+			continue
+		}
+		pubkey := strings.ToUpper(info.Pubkey)
+		if seen[pubkey] || !VerifyVrfInfo(info) {
+			continue
+		}
+		seen[pubkey] = true
+		m := strings.ToUpper(info.M)
+		groups[m] = append(groups[m], info)
+	}
+	if len(groups) == 0 {
+		return nil, ErrNoValidVrf
+	}
+
+	var m string
+	for key, infos := range groups {
+		if m == "" || len(infos) > len(groups[m]) || (len(infos) == len(groups[m]) && key < m) {
+			m = key
+		}
+	}
+	infos := groups[m]
+	sort.Slice(infos, func(i, j int) bool {
+		return strings.ToUpper(infos[i].Pubkey) < strings.ToUpper(infos[j].Pubkey)
+	})
+
+	var buf bytes.Buffer
+	var bCycle [8]byte
+	binary.BigEndian.PutUint64(bCycle[:], uint64(cycle))
+	buf.Write(bCycle[:])
+	bM, _ := hex.DecodeString(m)
+	buf.Write(bM)
+	for _, info := range infos {
+		r, _ := hex.DecodeString(info.R)
+		buf.Write(r)
+	}
+
+	return &DposRandomBeacon{
+		Cycle:  cycle,
+		M:      m,
+		Random: hex.EncodeToString(common.Sha256(buf.Bytes())),
+		Vrf:    infos,
+	}, nil
+}
+
+//VerifyRandomBeacon 校验cycle随机数是否由给定的链上vrf信息计算得到
+func VerifyRandomBeacon(beacon *DposRandomBeacon, vrfs []*JSONVrfInfo) error {
+	if beacon == nil {
+		return ErrInvalidBeacon
+	}
+	expect, err := CalcRandomBeacon(beacon.Cycle, vrfs)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(expect.Random, beacon.Random) || !strings.EqualFold(expect.M, beacon.M) || len(expect.Vrf) != len(beacon.Vrf) {
+		return ErrInvalidBeacon
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package types
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	vrf "github.com/33cn/chain33/common/vrf/secp256k1"
+	secp256k1 "github.com/btcsuite/btcd/btcec"
+	"github.com/stretchr/testify/require"
+)
+
+func genVrfInfo(t *testing.T, seed string, cycle int64, m string) *JSONVrfInfo {
+	privKey, pubKey := secp256k1.PrivKeyFromBytes(secp256k1.S256(), common.Sha256([]byte(seed)))
+	bM, err := hex.DecodeString(m)
+	require.Nil(t, err)
+	vrfPriv := &vrf.PrivateKey{PrivateKey: (*ecdsa.PrivateKey)(privKey)}
+	hash, proof := vrfPriv.Evaluate(bM)
+	return &JSONVrfInfo{
+		Pubkey: hex.EncodeToString(pubKey.SerializeCompressed()),
+		Cycle:  cycle,
+		M:      m,
+		R:      hex.EncodeToString(hash[:]),
+		P:      hex.EncodeToString(proof),
+	}
+}
+
+func TestCalcRandomBeacon(t *testing.T) {
+	m := hex.EncodeToString(common.Sha256([]byte("stopHash")))
+	a := genVrfInfo(t, "a", 10, m)
+	b := genVrfInfo(t, "b", 10, m)
+	c := genVrfInfo(t, "c", 10, m)
+	require.True(t, VerifyVrfInfo(a))
+
+	beacon, err := CalcRandomBeacon(10, []*JSONVrfInfo{a, b, c})
+	require.Nil(t, err)
+	require.Equal(t, int64(10), beacon.Cycle)
+	require.Equal(t, 3, len(beacon.Vrf))
+	require.Nil(t, VerifyRandomBeacon(beacon, []*JSONVrfInfo{a, b, c}))
+
+	//与记录顺序无关
+	again, err := CalcRandomBeacon(10, []*JSONVrfInfo{c, a, b})
+	require.Nil(t, err)
+	require.Equal(t, beacon.Random, again.Random)
+
+	//伪造的R不参与计算
+	fake := genVrfInfo(t, "d", 10, m)
+	fake.R = a.R
+	require.False(t, VerifyVrfInfo(fake))
+	again, err = CalcRandomBeacon(10, []*JSONVrfInfo{a, b, c, fake})
+	require.Nil(t, err)
+	require.Equal(t, beacon.Random, again.Random)
+
+	//其他cycle和M不一致的少数记录不参与计算
+	other := genVrfInfo(t, "e", 10, hex.EncodeToString(common.Sha256([]byte("other"))))
+	require.True(t, VerifyVrfInfo(other))
+	again, err = CalcRandomBeacon(10, []*JSONVrfInfo{a, b, c, other, genVrfInfo(t, "f", 11, m)})
+	require.Nil(t, err)
+	require.Equal(t, beacon.Random, again.Random)
+
+	//缺少记录或随机数被修改时校验失败
+	require.Equal(t, ErrInvalidBeacon, VerifyRandomBeacon(beacon, []*JSONVrfInfo{a, b}))
+	beacon.Random = again.Vrf[0].R
+	require.Equal(t, ErrInvalidBeacon, VerifyRandomBeacon(beacon, []*JSONVrfInfo{a, b, c}))
+
+	_, err = CalcRandomBeacon(10, []*JSONVrfInfo{fake})
+	require.Equal(t, ErrNoValidVrf, err)
+	_, err = CalcRandomBeacon(12, []*JSONVrfInfo{a})
+	require.Equal(t, ErrNoValidVrf, err)
+}
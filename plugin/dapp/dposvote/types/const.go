@@ -98,6 +98,9 @@ const (
 	//FuncNameQueryVrfByCycleForPubkeys func name
 	FuncNameQueryVrfByCycleForPubkeys = "QueryVrfByCycleForPubkeys"
 
+	//FuncNameQueryRandomBeacon func name
+	FuncNameQueryRandomBeacon = "QueryRandomBeacon"
+
 	//FuncNameQueryVote func name
 	FuncNameQueryVote = "QueryVote"
 
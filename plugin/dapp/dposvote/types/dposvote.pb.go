@@ -2166,6 +2166,78 @@ func (x *DposVrfReply) GetVrf() []*JSONVrfInfo {
 	return nil
 }
 
+// DposRandomBeacon 根据一个cycle内受托节点注册的vrf信息生成的可验证随机数
+type DposRandomBeacon struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cycle  int64          `protobuf:"varint,1,opt,name=cycle,proto3" json:"cycle,omitempty"`
+	M      string         `protobuf:"bytes,2,opt,name=m,proto3" json:"m,omitempty"`           // 参与计算的vrf的共同输入
+	Random string         `protobuf:"bytes,3,opt,name=random,proto3" json:"random,omitempty"` // 随机数
+	Vrf    []*JSONVrfInfo `protobuf:"bytes,4,rep,name=vrf,proto3" json:"vrf,omitempty"`       // 参与计算的vrf信息,按pubkey排序
+}
+
+func (x *DposRandomBeacon) Reset() {
+	*x = DposRandomBeacon{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_dposvote_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DposRandomBeacon) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DposRandomBeacon) ProtoMessage() {}
+
+func (x *DposRandomBeacon) ProtoReflect() protoreflect.Message {
+	mi := &file_dposvote_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DposRandomBeacon.ProtoReflect.Descriptor instead.
+func (*DposRandomBeacon) Descriptor() ([]byte, []int) {
+	return file_dposvote_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DposRandomBeacon) GetCycle() int64 {
+	if x != nil {
+		return x.Cycle
+	}
+	return 0
+}
+
+func (x *DposRandomBeacon) GetM() string {
+	if x != nil {
+		return x.M
+	}
+	return ""
+}
+
+func (x *DposRandomBeacon) GetRandom() string {
+	if x != nil {
+		return x.Random
+	}
+	return ""
+}
+
+func (x *DposRandomBeacon) GetVrf() []*JSONVrfInfo {
+	if x != nil {
+		return x.Vrf
+	}
+	return nil
+}
+
 // DposCycleBoundaryInfo cycle边界信息
 type DposCycleBoundaryInfo struct {
 	state         protoimpl.MessageState
@@ -2182,7 +2254,7 @@ type DposCycleBoundaryInfo struct {
 func (x *DposCycleBoundaryInfo) Reset() {
 	*x = DposCycleBoundaryInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[24]
+		mi := &file_dposvote_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2195,7 +2267,7 @@ func (x *DposCycleBoundaryInfo) String() string {
 func (*DposCycleBoundaryInfo) ProtoMessage() {}
 
 func (x *DposCycleBoundaryInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[24]
+	mi := &file_dposvote_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2208,7 +2280,7 @@ func (x *DposCycleBoundaryInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DposCycleBoundaryInfo.ProtoReflect.Descriptor instead.
 func (*DposCycleBoundaryInfo) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{24}
+	return file_dposvote_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *DposCycleBoundaryInfo) GetCycle() int64 {
@@ -2262,7 +2334,7 @@ type DposCBInfo struct {
 func (x *DposCBInfo) Reset() {
 	*x = DposCBInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[25]
+		mi := &file_dposvote_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2275,7 +2347,7 @@ func (x *DposCBInfo) String() string {
 func (*DposCBInfo) ProtoMessage() {}
 
 func (x *DposCBInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[25]
+	mi := &file_dposvote_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2288,7 +2360,7 @@ func (x *DposCBInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DposCBInfo.ProtoReflect.Descriptor instead.
 func (*DposCBInfo) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{25}
+	return file_dposvote_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DposCBInfo) GetCycle() int64 {
@@ -2341,7 +2413,7 @@ type DposCBQuery struct {
 func (x *DposCBQuery) Reset() {
 	*x = DposCBQuery{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[26]
+		mi := &file_dposvote_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2354,7 +2426,7 @@ func (x *DposCBQuery) String() string {
 func (*DposCBQuery) ProtoMessage() {}
 
 func (x *DposCBQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[26]
+	mi := &file_dposvote_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2367,7 +2439,7 @@ func (x *DposCBQuery) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DposCBQuery.ProtoReflect.Descriptor instead.
 func (*DposCBQuery) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{26}
+	return file_dposvote_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *DposCBQuery) GetCycle() int64 {
@@ -2410,7 +2482,7 @@ type DposCBReply struct {
 func (x *DposCBReply) Reset() {
 	*x = DposCBReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[27]
+		mi := &file_dposvote_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2423,7 +2495,7 @@ func (x *DposCBReply) String() string {
 func (*DposCBReply) ProtoMessage() {}
 
 func (x *DposCBReply) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[27]
+	mi := &file_dposvote_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2436,7 +2508,7 @@ func (x *DposCBReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DposCBReply.ProtoReflect.Descriptor instead.
 func (*DposCBReply) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{27}
+	return file_dposvote_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *DposCBReply) GetCbInfo() *DposCBInfo {
@@ -2467,7 +2539,7 @@ type ReceiptCB struct {
 func (x *ReceiptCB) Reset() {
 	*x = ReceiptCB{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[28]
+		mi := &file_dposvote_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2480,7 +2552,7 @@ func (x *ReceiptCB) String() string {
 func (*ReceiptCB) ProtoMessage() {}
 
 func (x *ReceiptCB) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[28]
+	mi := &file_dposvote_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2493,7 +2565,7 @@ func (x *ReceiptCB) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReceiptCB.ProtoReflect.Descriptor instead.
 func (*ReceiptCB) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{28}
+	return file_dposvote_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ReceiptCB) GetIndex() int64 {
@@ -2582,7 +2654,7 @@ type TopNCandidator struct {
 func (x *TopNCandidator) Reset() {
 	*x = TopNCandidator{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[29]
+		mi := &file_dposvote_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2595,7 +2667,7 @@ func (x *TopNCandidator) String() string {
 func (*TopNCandidator) ProtoMessage() {}
 
 func (x *TopNCandidator) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[29]
+	mi := &file_dposvote_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2608,7 +2680,7 @@ func (x *TopNCandidator) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopNCandidator.ProtoReflect.Descriptor instead.
 func (*TopNCandidator) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{29}
+	return file_dposvote_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *TopNCandidator) GetCands() []*Candidator {
@@ -2661,7 +2733,7 @@ type TopNCandidators struct {
 func (x *TopNCandidators) Reset() {
 	*x = TopNCandidators{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[30]
+		mi := &file_dposvote_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2674,7 +2746,7 @@ func (x *TopNCandidators) String() string {
 func (*TopNCandidators) ProtoMessage() {}
 
 func (x *TopNCandidators) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[30]
+	mi := &file_dposvote_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2687,7 +2759,7 @@ func (x *TopNCandidators) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopNCandidators.ProtoReflect.Descriptor instead.
 func (*TopNCandidators) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{30}
+	return file_dposvote_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *TopNCandidators) GetCandsVotes() []*TopNCandidator {
@@ -2730,7 +2802,7 @@ type TopNCandidatorRegist struct {
 func (x *TopNCandidatorRegist) Reset() {
 	*x = TopNCandidatorRegist{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[31]
+		mi := &file_dposvote_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2743,7 +2815,7 @@ func (x *TopNCandidatorRegist) String() string {
 func (*TopNCandidatorRegist) ProtoMessage() {}
 
 func (x *TopNCandidatorRegist) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[31]
+	mi := &file_dposvote_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2756,7 +2828,7 @@ func (x *TopNCandidatorRegist) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopNCandidatorRegist.ProtoReflect.Descriptor instead.
 func (*TopNCandidatorRegist) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{31}
+	return file_dposvote_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *TopNCandidatorRegist) GetCand() *TopNCandidator {
@@ -2778,7 +2850,7 @@ type TopNCandidatorsQuery struct {
 func (x *TopNCandidatorsQuery) Reset() {
 	*x = TopNCandidatorsQuery{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[32]
+		mi := &file_dposvote_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2791,7 +2863,7 @@ func (x *TopNCandidatorsQuery) String() string {
 func (*TopNCandidatorsQuery) ProtoMessage() {}
 
 func (x *TopNCandidatorsQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[32]
+	mi := &file_dposvote_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2804,7 +2876,7 @@ func (x *TopNCandidatorsQuery) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopNCandidatorsQuery.ProtoReflect.Descriptor instead.
 func (*TopNCandidatorsQuery) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{32}
+	return file_dposvote_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *TopNCandidatorsQuery) GetVersion() int64 {
@@ -2826,7 +2898,7 @@ type TopNCandidatorsReply struct {
 func (x *TopNCandidatorsReply) Reset() {
 	*x = TopNCandidatorsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[33]
+		mi := &file_dposvote_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2839,7 +2911,7 @@ func (x *TopNCandidatorsReply) String() string {
 func (*TopNCandidatorsReply) ProtoMessage() {}
 
 func (x *TopNCandidatorsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[33]
+	mi := &file_dposvote_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2852,7 +2924,7 @@ func (x *TopNCandidatorsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopNCandidatorsReply.ProtoReflect.Descriptor instead.
 func (*TopNCandidatorsReply) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{33}
+	return file_dposvote_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *TopNCandidatorsReply) GetTopN() *TopNCandidators {
@@ -2880,7 +2952,7 @@ type ReceiptTopN struct {
 func (x *ReceiptTopN) Reset() {
 	*x = ReceiptTopN{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_dposvote_proto_msgTypes[34]
+		mi := &file_dposvote_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2893,7 +2965,7 @@ func (x *ReceiptTopN) String() string {
 func (*ReceiptTopN) ProtoMessage() {}
 
 func (x *ReceiptTopN) ProtoReflect() protoreflect.Message {
-	mi := &file_dposvote_proto_msgTypes[34]
+	mi := &file_dposvote_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2906,7 +2978,7 @@ func (x *ReceiptTopN) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReceiptTopN.ProtoReflect.Descriptor instead.
 func (*ReceiptTopN) Descriptor() ([]byte, []int) {
-	return file_dposvote_proto_rawDescGZIP(), []int{34}
+	return file_dposvote_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ReceiptTopN) GetIndex() int64 {
@@ -3221,103 +3293,111 @@ var file_dposvote_proto_rawDesc = []byte{
 	0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x34, 0x0a, 0x0c, 0x44, 0x70, 0x6f, 0x73, 0x56,
 	0x72, 0x66, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x24, 0x0a, 0x03, 0x76, 0x72, 0x66, 0x18, 0x01,
 	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4a, 0x53, 0x4f,
-	0x4e, 0x56, 0x72, 0x66, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x76, 0x72, 0x66, 0x22, 0x9f, 0x01,
-	0x0a, 0x15, 0x44, 0x70, 0x6f, 0x73, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x42, 0x6f, 0x75, 0x6e, 0x64,
-	0x61, 0x72, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x1e, 0x0a,
-	0x0a, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a,
-	0x08, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x08, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62,
-	0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65,
-	0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22,
-	0x94, 0x01, 0x0a, 0x0a, 0x44, 0x70, 0x6f, 0x73, 0x43, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14,
-	0x0a, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63,
-	0x79, 0x63, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x65,
-	0x69, 0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x61, 0x73, 0x68,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x61, 0x73, 0x68,
-	0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x6f, 0x0a, 0x0b, 0x44, 0x70, 0x6f, 0x73, 0x43, 0x42,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
-	0x74, 0x6f, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x0a, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73,
-	0x74, 0x6f, 0x70, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
-	0x74, 0x6f, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x79, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x02, 0x74, 0x79, 0x22, 0x38, 0x0a, 0x0b, 0x44, 0x70, 0x6f, 0x73, 0x43,
-	0x42, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x29, 0x0a, 0x06, 0x63, 0x62, 0x49, 0x6e, 0x66, 0x6f,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x44,
-	0x70, 0x6f, 0x73, 0x43, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x62, 0x49, 0x6e, 0x66,
-	0x6f, 0x22, 0xa9, 0x02, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x43, 0x42, 0x12,
-	0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68,
-	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69,
-	0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x79, 0x63, 0x6c, 0x65,
-	0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x79, 0x63,
-	0x6c, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x79, 0x63, 0x6c, 0x65,
-	0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x79,
-	0x63, 0x6c, 0x65, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x79, 0x63,
-	0x6c, 0x65, 0x53, 0x74, 0x6f, 0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x79,
-	0x63, 0x6c, 0x65, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x34, 0x0a, 0x06, 0x63, 0x62, 0x49, 0x6e, 0x66,
-	0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
-	0x44, 0x70, 0x6f, 0x73, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72,
-	0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0xa7, 0x01,
-	0x0a, 0x0e, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
-	0x12, 0x27, 0x0a, 0x05, 0x63, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
-	0x6f, 0x72, 0x52, 0x05, 0x63, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a,
-	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68,
-	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x50,
-	0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x69, 0x67,
-	0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67,
-	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69,
-	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xad, 0x01, 0x0a, 0x0f, 0x54, 0x6f, 0x70, 0x4e,
-	0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12, 0x35, 0x0a, 0x0a, 0x63,
-	0x61, 0x6e, 0x64, 0x73, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64,
-	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x73, 0x56, 0x6f, 0x74,
-	0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x31, 0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x43, 0x61, 0x6e,
-	0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0a, 0x66, 0x69, 0x6e,
-	0x61, 0x6c, 0x43, 0x61, 0x6e, 0x64, 0x73, 0x22, 0x41, 0x0a, 0x14, 0x54, 0x6f, 0x70, 0x4e, 0x43,
-	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x12,
-	0x29, 0x0a, 0x04, 0x63, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64,
-	0x61, 0x74, 0x6f, 0x72, 0x52, 0x04, 0x63, 0x61, 0x6e, 0x64, 0x22, 0x30, 0x0a, 0x14, 0x54, 0x6f,
-	0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x51, 0x75, 0x65,
-	0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x42, 0x0a, 0x14,
-	0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x70, 0x4e, 0x43,
-	0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e,
-	0x22, 0xc4, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x54, 0x6f, 0x70, 0x4e,
-	0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
-	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x04,
-	0x74, 0x6f, 0x70, 0x4e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x79, 0x70,
-	0x65, 0x73, 0x2e, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f,
-	0x72, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4e, 0x56, 0x72, 0x66, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03, 0x76, 0x72, 0x66, 0x22, 0x74, 0x0a,
+	0x10, 0x44, 0x70, 0x6f, 0x73, 0x52, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x42, 0x65, 0x61, 0x63, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x6d, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x01, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x12, 0x24, 0x0a,
+	0x03, 0x76, 0x72, 0x66, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x4a, 0x53, 0x4f, 0x4e, 0x56, 0x72, 0x66, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x03,
+	0x76, 0x72, 0x66, 0x22, 0x9f, 0x01, 0x0a, 0x15, 0x44, 0x70, 0x6f, 0x73, 0x43, 0x79, 0x63, 0x6c,
+	0x65, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x79,
+	0x63, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x61, 0x73, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x94, 0x01, 0x0a, 0x0a, 0x44, 0x70, 0x6f, 0x73, 0x43, 0x42,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74,
+	0x6f, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
+	0x73, 0x74, 0x6f, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74,
+	0x6f, 0x70, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74,
+	0x6f, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x6f, 0x0a, 0x0b,
+	0x44, 0x70, 0x6f, 0x73, 0x43, 0x42, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x6f, 0x70, 0x48, 0x61, 0x73, 0x68, 0x12, 0x0e, 0x0a,
+	0x02, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x74, 0x79, 0x22, 0x38, 0x0a,
+	0x0b, 0x44, 0x70, 0x6f, 0x73, 0x43, 0x42, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x29, 0x0a, 0x06,
+	0x63, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2e, 0x44, 0x70, 0x6f, 0x73, 0x43, 0x42, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x06, 0x63, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0xa9, 0x02, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x70, 0x74, 0x43, 0x42, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x70,
+	0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62,
+	0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x79, 0x63, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x79, 0x63, 0x6c,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x61, 0x72, 0x74, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x4d, 0x69, 0x64, 0x64, 0x6c, 0x65, 0x12,
+	0x1c, 0x0a, 0x09, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x6f, 0x70, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x53, 0x74, 0x6f, 0x70, 0x12, 0x34, 0x0a,
+	0x06, 0x63, 0x62, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x44, 0x70, 0x6f, 0x73, 0x43, 0x79, 0x63, 0x6c, 0x65, 0x42,
+	0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x62, 0x49,
+	0x6e, 0x66, 0x6f, 0x22, 0xa7, 0x01, 0x0a, 0x0e, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64,
+	0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x05, 0x63, 0x61, 0x6e, 0x64, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61,
+	0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x05, 0x63, 0x61, 0x6e, 0x64, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68,
+	0x61, 0x73, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x50, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xad, 0x01,
+	0x0a, 0x0f, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x12, 0x35, 0x0a, 0x0a, 0x63, 0x61, 0x6e, 0x64, 0x73, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f,
+	0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x0a, 0x63, 0x61,
+	0x6e, 0x64, 0x73, 0x56, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x31, 0x0a, 0x0a, 0x66, 0x69,
+	0x6e, 0x61, 0x6c, 0x43, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x43, 0x61, 0x6e, 0x64, 0x73, 0x22, 0x41, 0x0a,
+	0x14, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52,
+	0x65, 0x67, 0x69, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x63, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x70, 0x4e,
+	0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x04, 0x63, 0x61, 0x6e, 0x64,
+	0x22, 0x30, 0x0a, 0x14, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x42, 0x0a, 0x14, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64,
+	0x61, 0x74, 0x6f, 0x72, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x6f,
+	0x70, 0x4e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e, 0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x73,
+	0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x22, 0xc4, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x70, 0x74, 0x54, 0x6f, 0x70, 0x4e, 0x12, 0x14, 0x0a, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75,
+	0x62, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x6f, 0x70, 0x4e, 0x43, 0x61, 0x6e,
+	0x64, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x52, 0x04, 0x74, 0x6f, 0x70, 0x4e, 0x42, 0x0a, 0x5a,
+	0x08, 0x2e, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -3332,7 +3412,7 @@ func file_dposvote_proto_rawDescGZIP() []byte {
 	return file_dposvote_proto_rawDescData
 }
 
-var file_dposvote_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_dposvote_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_dposvote_proto_goTypes = []interface{}{
 	(*CandidatorInfo)(nil),             // 0: types.CandidatorInfo
 	(*DposVoter)(nil),                  // 1: types.DposVoter
@@ -3358,17 +3438,18 @@ var file_dposvote_proto_goTypes = []interface{}{
 	(*DposVrfQuery)(nil),               // 21: types.DposVrfQuery
 	(*JSONVrfInfo)(nil),                // 22: types.JSONVrfInfo
 	(*DposVrfReply)(nil),               // 23: types.DposVrfReply
-	(*DposCycleBoundaryInfo)(nil),      // 24: types.DposCycleBoundaryInfo
-	(*DposCBInfo)(nil),                 // 25: types.DposCBInfo
-	(*DposCBQuery)(nil),                // 26: types.DposCBQuery
-	(*DposCBReply)(nil),                // 27: types.DposCBReply
-	(*ReceiptCB)(nil),                  // 28: types.ReceiptCB
-	(*TopNCandidator)(nil),             // 29: types.TopNCandidator
-	(*TopNCandidators)(nil),            // 30: types.TopNCandidators
-	(*TopNCandidatorRegist)(nil),       // 31: types.TopNCandidatorRegist
-	(*TopNCandidatorsQuery)(nil),       // 32: types.TopNCandidatorsQuery
-	(*TopNCandidatorsReply)(nil),       // 33: types.TopNCandidatorsReply
-	(*ReceiptTopN)(nil),                // 34: types.ReceiptTopN
+	(*DposRandomBeacon)(nil),           // 24: types.DposRandomBeacon
+	(*DposCycleBoundaryInfo)(nil),      // 25: types.DposCycleBoundaryInfo
+	(*DposCBInfo)(nil),                 // 26: types.DposCBInfo
+	(*DposCBQuery)(nil),                // 27: types.DposCBQuery
+	(*DposCBReply)(nil),                // 28: types.DposCBReply
+	(*ReceiptCB)(nil),                  // 29: types.ReceiptCB
+	(*TopNCandidator)(nil),             // 30: types.TopNCandidator
+	(*TopNCandidators)(nil),            // 31: types.TopNCandidators
+	(*TopNCandidatorRegist)(nil),       // 32: types.TopNCandidatorRegist
+	(*TopNCandidatorsQuery)(nil),       // 33: types.TopNCandidatorsQuery
+	(*TopNCandidatorsReply)(nil),       // 34: types.TopNCandidatorsReply
+	(*ReceiptTopN)(nil),                // 35: types.ReceiptTopN
 }
 var file_dposvote_proto_depIdxs = []int32{
 	1,  // 0: types.CandidatorInfo.voters:type_name -> types.DposVoter
@@ -3382,28 +3463,29 @@ var file_dposvote_proto_depIdxs = []int32{
 	17, // 8: types.DposVoteAction.registVrfM:type_name -> types.DposVrfMRegist
 	18, // 9: types.DposVoteAction.registVrfRP:type_name -> types.DposVrfRPRegist
 	21, // 10: types.DposVoteAction.vrfQuery:type_name -> types.DposVrfQuery
-	25, // 11: types.DposVoteAction.recordCB:type_name -> types.DposCBInfo
-	26, // 12: types.DposVoteAction.cbQuery:type_name -> types.DposCBQuery
-	31, // 13: types.DposVoteAction.registTopN:type_name -> types.TopNCandidatorRegist
-	32, // 14: types.DposVoteAction.topNQuery:type_name -> types.TopNCandidatorsQuery
+	26, // 11: types.DposVoteAction.recordCB:type_name -> types.DposCBInfo
+	27, // 12: types.DposVoteAction.cbQuery:type_name -> types.DposCBQuery
+	32, // 13: types.DposVoteAction.registTopN:type_name -> types.TopNCandidatorRegist
+	33, // 14: types.DposVoteAction.topNQuery:type_name -> types.TopNCandidatorsQuery
 	9,  // 15: types.CandidatorReply.candidators:type_name -> types.JSONCandidator
 	12, // 16: types.DposVoteReply.votes:type_name -> types.JSONDposVoter
 	1,  // 17: types.ReceiptCandicator.vote:type_name -> types.DposVoter
 	0,  // 18: types.ReceiptCandicator.candInfo:type_name -> types.CandidatorInfo
 	22, // 19: types.DposVrfReply.vrf:type_name -> types.JSONVrfInfo
-	25, // 20: types.DposCBReply.cbInfo:type_name -> types.DposCBInfo
-	24, // 21: types.ReceiptCB.cbInfo:type_name -> types.DposCycleBoundaryInfo
-	2,  // 22: types.TopNCandidator.cands:type_name -> types.Candidator
-	29, // 23: types.TopNCandidators.candsVotes:type_name -> types.TopNCandidator
-	2,  // 24: types.TopNCandidators.finalCands:type_name -> types.Candidator
-	29, // 25: types.TopNCandidatorRegist.cand:type_name -> types.TopNCandidator
-	30, // 26: types.TopNCandidatorsReply.topN:type_name -> types.TopNCandidators
-	29, // 27: types.ReceiptTopN.topN:type_name -> types.TopNCandidator
-	28, // [28:28] is the sub-list for method output_type
-	28, // [28:28] is the sub-list for method input_type
-	28, // [28:28] is the sub-list for extension type_name
-	28, // [28:28] is the sub-list for extension extendee
-	0,  // [0:28] is the sub-list for field type_name
+	22, // 20: types.DposRandomBeacon.vrf:type_name -> types.JSONVrfInfo
+	26, // 21: types.DposCBReply.cbInfo:type_name -> types.DposCBInfo
+	25, // 22: types.ReceiptCB.cbInfo:type_name -> types.DposCycleBoundaryInfo
+	2,  // 23: types.TopNCandidator.cands:type_name -> types.Candidator
+	30, // 24: types.TopNCandidators.candsVotes:type_name -> types.TopNCandidator
+	2,  // 25: types.TopNCandidators.finalCands:type_name -> types.Candidator
+	30, // 26: types.TopNCandidatorRegist.cand:type_name -> types.TopNCandidator
+	31, // 27: types.TopNCandidatorsReply.topN:type_name -> types.TopNCandidators
+	30, // 28: types.ReceiptTopN.topN:type_name -> types.TopNCandidator
+	29, // [29:29] is the sub-list for method output_type
+	29, // [29:29] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_dposvote_proto_init() }
@@ -3701,7 +3783,7 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DposCycleBoundaryInfo); i {
+			switch v := v.(*DposRandomBeacon); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3713,7 +3795,7 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DposCBInfo); i {
+			switch v := v.(*DposCycleBoundaryInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3725,7 +3807,7 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DposCBQuery); i {
+			switch v := v.(*DposCBInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3737,7 +3819,7 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DposCBReply); i {
+			switch v := v.(*DposCBQuery); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3749,7 +3831,7 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReceiptCB); i {
+			switch v := v.(*DposCBReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3761,7 +3843,7 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopNCandidator); i {
+			switch v := v.(*ReceiptCB); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3773,7 +3855,7 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopNCandidators); i {
+			switch v := v.(*TopNCandidator); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3785,7 +3867,7 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopNCandidatorRegist); i {
+			switch v := v.(*TopNCandidators); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3797,7 +3879,7 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopNCandidatorsQuery); i {
+			switch v := v.(*TopNCandidatorRegist); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3809,7 +3891,7 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TopNCandidatorsReply); i {
+			switch v := v.(*TopNCandidatorsQuery); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3821,6 +3903,18 @@ func file_dposvote_proto_init() {
 			}
 		}
 		file_dposvote_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopNCandidatorsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_dposvote_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReceiptTopN); i {
 			case 0:
 				return &v.state
@@ -3855,7 +3949,7 @@ func file_dposvote_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_dposvote_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   35,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -26,4 +26,6 @@ var (
 	ErrCycleNotAllowed          = errors.New("ErrCycleNotAllowed")
 	ErrVersionTopNNotExist      = errors.New("ErrVersionTopNNotExist")
 	ErrNotLegalTopN             = errors.New("ErrNotLegalTopN")
+	ErrNoValidVrf               = errors.New("ErrNoValidVrf")
+	ErrInvalidBeacon            = errors.New("ErrInvalidBeacon")
 )
@@ -43,6 +43,7 @@ func ParcCmd() *cobra.Command {
 		GetParaListCmd(),
 		GetParaAssetTransCmd(),
 		IsSyncCmd(),
+		mainEndpointsCmd(),
 		GetHeightCmd(),
 		GetBlockInfoCmd(),
 		GetLocalBlockInfoCmd(),
@@ -1143,6 +1144,23 @@ func isSync(cmd *cobra.Command, args []string) {
 	ctx.Run()
 }
 
+// mainEndpointsCmd query para node main chain endpoints status
+func mainEndpointsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "main_endpoints",
+		Short: "query main chain endpoints status of para node",
+		Run:   mainEndpoints,
+	}
+	return cmd
+}
+
+func mainEndpoints(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	var res pt.MainEndpointsStatus
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "paracross.GetParaMainEndpoints", nil, &res)
+	ctx.Run()
+}
+
 func blsCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "bls",
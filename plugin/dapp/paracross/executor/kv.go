@@ -45,6 +45,9 @@ var (
 	paraSupervisionNodes            string
 	paraSupervisionNodeIDPrefix     string
 	localSupervisionNodeStatusTitle string
+
+	//rollup已执行跨链交易的提交轮次
+	paraRollupCrossTx string
 )
 
 func setPrefix() {
@@ -77,6 +80,8 @@ func setPrefix() {
 	paraSupervisionNodes = "mavl-paracross-supervision-nodes-title-"
 	paraSupervisionNodeIDPrefix = "mavl-paracross-title-nodeid-supervision-"
 	localSupervisionNodeStatusTitle = "LODB-paracross-supervision-nodeStatusTitle-"
+
+	paraRollupCrossTx = "mavl-paracross-rollup-crosstx-"
 }
 
 func calcTitleKey(t string) []byte {
@@ -242,3 +247,7 @@ func calcLocalSupervisionNodeStatusTitlePrefix(title string, status int32) []byt
 func calcLocalSupervisionNodeStatusTitleAllPrefix(title string) []byte {
 	return []byte(fmt.Sprintf(localSupervisionNodeStatusTitle+"%s-", title))
 }
+
+func calcRollupCrossTxKey(title string, round int64) []byte {
+	return []byte(fmt.Sprintf(paraRollupCrossTx+"%s-%018d", title, round))
+}
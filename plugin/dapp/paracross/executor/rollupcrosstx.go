@@ -80,11 +80,13 @@ func (p *Paracross) ExecDelLocal_RollupCrossTx(_ *pt.RollupCrossTx, tx *types.Tr
 }
 
 var (
-	ErrInvalidCommitRound   = errors.New("ErrInvalidCommitRound")
-	ErrInvalidChain         = errors.New("ErrInvalidChain")
-	ErrGetRollupCrossTx     = errors.New("ErrGetRollupCrossTx")
-	ErrGetRollupCommitRound = errors.New("ErrGetRollupCommitRound")
-	ErrCrossTxCheckHash     = errors.New("ErrCrossTxCheckHash")
+	ErrInvalidCommitRound      = errors.New("ErrInvalidCommitRound")
+	ErrInvalidChain            = errors.New("ErrInvalidChain")
+	ErrGetRollupCrossTx        = errors.New("ErrGetRollupCrossTx")
+	ErrGetRollupCommitRound    = errors.New("ErrGetRollupCommitRound")
+	ErrCrossTxCheckHash        = errors.New("ErrCrossTxCheckHash")
+	ErrRollupRoundNotFinalized = errors.New("ErrRollupRoundNotFinalized")
+	ErrRollupCrossTxExecuted   = errors.New("ErrRollupCrossTxExecuted")
 )
 
 func (a *action) rollupCrossTx(commit *pt.RollupCrossTx) (*types.Receipt, error) {
@@ -96,7 +98,7 @@ func (a *action) rollupCrossTx(commit *pt.RollupCrossTx) (*types.Receipt, error)
 	receipt := &types.Receipt{Ty: types.ExecOk}
 	status, err := rexec.GetRollupStatus(a.db, commit.GetChainTitle())
 
-	if err != nil || status.CommitRound != commit.GetCommitRound() {
+	if err != nil || status.CommitRound < commit.GetCommitRound() {
 
 		clog.Error("rollupCrossTx", "currRound", status.GetCommitRound(),
 			"commitRound", commit.GetCommitRound(), "getRollupStatus err", err)
@@ -109,6 +111,26 @@ func (a *action) rollupCrossTx(commit *pt.RollupCrossTx) (*types.Receipt, error)
 		return nil, ErrGetRollupCommitRound
 	}
 
+	// 未启用挑战期的轮次, 跨链交易和提交交易在同一交易组执行
+	if roundInfo.GetChallengeEndHeight() <= 0 && status.CommitRound != commit.GetCommitRound() {
+		clog.Error("rollupCrossTx", "currRound", status.GetCommitRound(), "commitRound", commit.GetCommitRound())
+		return nil, ErrInvalidCommitRound
+	}
+	// 挑战期内的轮次可能被回滚, 挑战期结束后才能执行跨链交易, 且只能执行一次
+	if roundInfo.GetChallengeEndHeight() > 0 {
+		if a.height < roundInfo.GetChallengeEndHeight() {
+			clog.Error("rollupCrossTx", "commitRound", commit.GetCommitRound(),
+				"height", a.height, "challengeEndHeight", roundInfo.GetChallengeEndHeight())
+			return nil, ErrRollupRoundNotFinalized
+		}
+		key := calcRollupCrossTxKey(commit.GetChainTitle(), commit.GetCommitRound())
+		if _, err = a.db.Get(key); err == nil {
+			clog.Error("rollupCrossTx", "commitRound", commit.GetCommitRound(), "err", "already executed")
+			return nil, ErrRollupCrossTxExecuted
+		}
+		receipt.KV = append(receipt.KV, &types.KeyValue{Key: key, Value: []byte(roundInfo.GetBatchHash())})
+	}
+
 	crossTxHashes, crossTxs, err := getRollupCrossTxs(a.api, commit.GetChainTitle(), commit.GetTxIndices())
 
 	if err != nil {
@@ -72,6 +72,25 @@ func (c *Jrpc) GetParaNodeLeaderInfo(in *types.ReqNil, result *interface{}) erro
 	return nil
 }
 
+// GetParaMainEndpoints query para node main chain endpoints status
+func (c *channelClient) GetParaMainEndpoints(ctx context.Context, in *types.ReqNil) (*pt.MainEndpointsStatus, error) {
+	data, err := c.QueryConsensusFunc("para", "MainEndpoints", in)
+	if err != nil {
+		return nil, err
+	}
+	return data.(*pt.MainEndpointsStatus), nil
+}
+
+// GetParaMainEndpoints query para node main chain endpoints status
+func (c *Jrpc) GetParaMainEndpoints(in *types.ReqNil, result *interface{}) error {
+	data, err := c.cli.GetParaMainEndpoints(context.Background(), in)
+	if err != nil {
+		return err
+	}
+	*result = data
+	return nil
+}
+
 // GetParaCmtTxInfo query para chain commit tx info to bls sign
 func (c *channelClient) GetParaCmtTxInfo(ctx context.Context, in *types.ReqNil) (*pt.ParaBlsSignSumInfo, error) {
 	data, err := c.QueryConsensusFunc("para", "CommitTxInfo", in)
@@ -27,6 +27,14 @@ var (
 	ErrParaWaitingNewSeq = errors.New("ErrParaWaitingNewSeq")
 	// ErrParaCurHashNotMatch para curr main hash not match with pre, main node may switched
 	ErrParaCurHashNotMatch = errors.New("ErrParaCurHashNotMatch")
+	// ErrParaMainDataVerify main node data verify fail, header hash or para tx merkle proof not match
+	ErrParaMainDataVerify = errors.New("ErrParaMainDataVerify")
+	// ErrParaMainDataMismatch main node data not match with other main nodes
+	ErrParaMainDataMismatch = errors.New("ErrParaMainDataMismatch")
+	// ErrParaMainNoVerifier switched to a backup main node but no other main node to cross check
+	ErrParaMainNoVerifier = errors.New("ErrParaMainNoVerifier")
+	// ErrParaMainEndpointsNotSet para main chain endpoints not configured
+	ErrParaMainEndpointsNotSet = errors.New("ErrParaMainEndpointsNotSet")
 	// ErrParaUnSupportNodeOper unsupport node operation
 	ErrParaUnSupportNodeOper = errors.New("ErrParaUnSupportNodeOper")
 	//ErrParaNodeAddrExisted node addr exist in group
@@ -196,6 +196,192 @@ func (x *ParaLocalDbBlockInfo) GetTxs() []string {
 	return nil
 }
 
+// 主链节点同步状态
+type MainEndpointStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addr          string `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Height        int64  `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	LatencyMs     int64  `protobuf:"varint,3,opt,name=latencyMs,proto3" json:"latencyMs,omitempty"`
+	Healthy       bool   `protobuf:"varint,4,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	Primary       bool   `protobuf:"varint,5,opt,name=primary,proto3" json:"primary,omitempty"`
+	ErrCount      int64  `protobuf:"varint,6,opt,name=errCount,proto3" json:"errCount,omitempty"`
+	MismatchCount int64  `protobuf:"varint,7,opt,name=mismatchCount,proto3" json:"mismatchCount,omitempty"`
+	// 数据校验失败后的屏蔽截止时间, unix秒
+	BannedUntil int64  `protobuf:"varint,8,opt,name=bannedUntil,proto3" json:"bannedUntil,omitempty"`
+	LastErr     string `protobuf:"bytes,9,opt,name=lastErr,proto3" json:"lastErr,omitempty"`
+}
+
+func (x *MainEndpointStatus) Reset() {
+	*x = MainEndpointStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_paraconsensus_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MainEndpointStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MainEndpointStatus) ProtoMessage() {}
+
+func (x *MainEndpointStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_paraconsensus_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MainEndpointStatus.ProtoReflect.Descriptor instead.
+func (*MainEndpointStatus) Descriptor() ([]byte, []int) {
+	return file_paraconsensus_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MainEndpointStatus) GetAddr() string {
+	if x != nil {
+		return x.Addr
+	}
+	return ""
+}
+
+func (x *MainEndpointStatus) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *MainEndpointStatus) GetLatencyMs() int64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *MainEndpointStatus) GetHealthy() bool {
+	if x != nil {
+		return x.Healthy
+	}
+	return false
+}
+
+func (x *MainEndpointStatus) GetPrimary() bool {
+	if x != nil {
+		return x.Primary
+	}
+	return false
+}
+
+func (x *MainEndpointStatus) GetErrCount() int64 {
+	if x != nil {
+		return x.ErrCount
+	}
+	return 0
+}
+
+func (x *MainEndpointStatus) GetMismatchCount() int64 {
+	if x != nil {
+		return x.MismatchCount
+	}
+	return 0
+}
+
+func (x *MainEndpointStatus) GetBannedUntil() int64 {
+	if x != nil {
+		return x.BannedUntil
+	}
+	return 0
+}
+
+func (x *MainEndpointStatus) GetLastErr() string {
+	if x != nil {
+		return x.LastErr
+	}
+	return ""
+}
+
+type MainEndpointsStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Endpoints   []*MainEndpointStatus `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
+	SwitchCount int64                 `protobuf:"varint,2,opt,name=switchCount,proto3" json:"switchCount,omitempty"`
+	// 和其他主链节点交叉校验通过的区块数
+	CrossCheckCount int64 `protobuf:"varint,3,opt,name=crossCheckCount,proto3" json:"crossCheckCount,omitempty"`
+	// 没有可用的校验节点, 未交叉校验的区块数
+	UncheckedCount int64 `protobuf:"varint,4,opt,name=uncheckedCount,proto3" json:"uncheckedCount,omitempty"`
+}
+
+func (x *MainEndpointsStatus) Reset() {
+	*x = MainEndpointsStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_paraconsensus_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MainEndpointsStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MainEndpointsStatus) ProtoMessage() {}
+
+func (x *MainEndpointsStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_paraconsensus_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MainEndpointsStatus.ProtoReflect.Descriptor instead.
+func (*MainEndpointsStatus) Descriptor() ([]byte, []int) {
+	return file_paraconsensus_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MainEndpointsStatus) GetEndpoints() []*MainEndpointStatus {
+	if x != nil {
+		return x.Endpoints
+	}
+	return nil
+}
+
+func (x *MainEndpointsStatus) GetSwitchCount() int64 {
+	if x != nil {
+		return x.SwitchCount
+	}
+	return 0
+}
+
+func (x *MainEndpointsStatus) GetCrossCheckCount() int64 {
+	if x != nil {
+		return x.CrossCheckCount
+	}
+	return 0
+}
+
+func (x *MainEndpointsStatus) GetUncheckedCount() int64 {
+	if x != nil {
+		return x.UncheckedCount
+	}
+	return 0
+}
+
 var File_paraconsensus_proto protoreflect.FileDescriptor
 
 var file_paraconsensus_proto_rawDesc = []byte{
@@ -227,8 +413,38 @@ var file_paraconsensus_proto_rawDesc = []byte{
 	0x74, 0x4d, 0x61, 0x69, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x6c, 0x6f,
 	0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x62, 0x6c,
 	0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x78, 0x73, 0x18, 0x06,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x74, 0x78, 0x73, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x74, 0x78, 0x73, 0x22, 0x90, 0x02, 0x0a, 0x12, 0x4d, 0x61,
+	0x69, 0x6e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x61, 0x64, 0x64, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x68, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x1a,
+	0x0a, 0x08, 0x65, 0x72, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x65, 0x72, 0x72, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x69,
+	0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x20, 0x0a, 0x0b, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x61, 0x6e, 0x6e, 0x65, 0x64, 0x55, 0x6e, 0x74,
+	0x69, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x22, 0xc2, 0x01, 0x0a,
+	0x13, 0x4d, 0x61, 0x69, 0x6e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x4d, 0x61, 0x69, 0x6e, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x73, 0x77, 0x69, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x73, 0x77, 0x69, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x28, 0x0a, 0x0f, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x75, 0x6e, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -243,19 +459,22 @@ func file_paraconsensus_proto_rawDescGZIP() []byte {
 	return file_paraconsensus_proto_rawDescData
 }
 
-var file_paraconsensus_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_paraconsensus_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_paraconsensus_proto_goTypes = []interface{}{
 	(*ParaLocalDbBlock)(nil),     // 0: types.ParaLocalDbBlock
 	(*ParaLocalDbBlockInfo)(nil), // 1: types.ParaLocalDbBlockInfo
-	(*types.Transaction)(nil),    // 2: types.Transaction
+	(*MainEndpointStatus)(nil),   // 2: types.MainEndpointStatus
+	(*MainEndpointsStatus)(nil),  // 3: types.MainEndpointsStatus
+	(*types.Transaction)(nil),    // 4: types.Transaction
 }
 var file_paraconsensus_proto_depIdxs = []int32{
-	2, // 0: types.ParaLocalDbBlock.txs:type_name -> types.Transaction
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	4, // 0: types.ParaLocalDbBlock.txs:type_name -> types.Transaction
+	2, // 1: types.MainEndpointsStatus.endpoints:type_name -> types.MainEndpointStatus
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_paraconsensus_proto_init() }
@@ -288,6 +507,30 @@ func file_paraconsensus_proto_init() {
 				return nil
 			}
 		}
+		file_paraconsensus_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MainEndpointStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_paraconsensus_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MainEndpointsStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -295,7 +538,7 @@ func file_paraconsensus_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_paraconsensus_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
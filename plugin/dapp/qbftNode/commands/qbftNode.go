@@ -46,6 +46,8 @@ func ValCmd() *cobra.Command {
 		GetNodeInfoCmd(),
 		GetCurrentStateCmd(),
 		GetPerfStatCmd(),
+		ListEvidenceCmd(),
+		GetLightProofCmd(),
 		AddNodeCmd(),
 		CreateCmd(),
 	)
@@ -202,6 +204,96 @@ func getPerfStat(cmd *cobra.Command, args []string) {
 	ctx.Run()
 }
 
+// ListEvidenceCmd list evidence of double signing
+func ListEvidenceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evidence",
+		Short: "List evidence of validators double signing",
+		Run:   listEvidence,
+	}
+	addListEvidenceFlags(cmd)
+	return cmd
+}
+
+func addListEvidenceFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64P("height", "t", 0, "start from the evidence packed at this height, 0 for the first or latest")
+	cmd.Flags().Int32P("index", "i", 0, "tx index of the start evidence in block")
+	cmd.Flags().Int32P("count", "c", 10, "evidence count")
+	cmd.Flags().Int32P("direction", "d", 0, "query direction, 0: desc, 1: asc")
+}
+
+func listEvidence(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	height, _ := cmd.Flags().GetInt64("height")
+	index, _ := cmd.Flags().GetInt32("index")
+	count, _ := cmd.Flags().GetInt32("count")
+	direction, _ := cmd.Flags().GetInt32("direction")
+	req := &vt.ReqQbftEvidences{
+		Height:    height,
+		Index:     index,
+		Count:     count,
+		Direction: direction,
+	}
+	params := rpctypes.Query4Jrpc{
+		Execer:   vt.QbftNodeX,
+		FuncName: "ListQbftEvidence",
+		Payload:  types.MustPBToJSON(req),
+	}
+
+	var res vt.QbftEvidenceRecords
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.SetResultCb(jsonOutput)
+	result, err := ctx.RunResult()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(result)
+}
+
+// GetLightProofCmd get light client proof
+func GetLightProofCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "light_proof",
+		Short: "Get light client proof of block from trusted height",
+		Run:   getLightProof,
+	}
+	addGetLightProofFlags(cmd)
+	return cmd
+}
+
+func addGetLightProofFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64P("trusted", "s", 0, "trusted block height")
+	cmd.MarkFlagRequired("trusted")
+	cmd.Flags().Int64P("height", "t", 0, "target block height, must be less than current height")
+	cmd.MarkFlagRequired("height")
+}
+
+func getLightProof(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	trusted, _ := cmd.Flags().GetInt64("trusted")
+	height, _ := cmd.Flags().GetInt64("height")
+	req := &vt.ReqQbftLightProof{
+		TrustedHeight: trusted,
+		Height:        height,
+	}
+	params := rpctypes.Query4Jrpc{
+		Execer:   vt.QbftNodeX,
+		FuncName: "GetLightProof",
+		Payload:  types.MustPBToJSON(req),
+	}
+
+	var res vt.QbftLightProof
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.SetResultCb(jsonOutput)
+	result, err := ctx.RunResult()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(result)
+}
+
 // AddNodeCmd add validator node
 func AddNodeCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -6,6 +6,7 @@ package executor
 
 import (
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/qbft/types"
 	pty "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
 )
 
@@ -24,3 +25,18 @@ func (val *QbftNode) ExecDelLocal_BlockInfo(blockInfo *pty.QbftBlockInfo, tx *ty
 	set.KV = append(set.KV, &types.KeyValue{Key: key, Value: nil})
 	return set, nil
 }
+
+// ExecDelLocal_Evidence method
+func (val *QbftNode) ExecDelLocal_Evidence(ev *pty.QbftEvidence, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	set := &types.LocalDBSet{}
+	for _, item := range receipt.Logs {
+		if item.Ty != pty.TyLogQbftEvidence {
+			continue
+		}
+		set.KV = append(set.KV,
+			&types.KeyValue{Key: CalcQbftNodeUpdateHeightIndexKey(val.GetHeight(), index), Value: nil},
+			&types.KeyValue{Key: CalcQbftEvidenceHeightIndexKey(val.GetHeight(), index), Value: nil},
+			&types.KeyValue{Key: CalcQbftEvidenceLocalKey(ttypes.EvidenceKey(ev)), Value: nil})
+	}
+	return set, nil
+}
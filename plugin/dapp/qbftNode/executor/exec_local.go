@@ -6,6 +6,7 @@ package executor
 
 import (
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/qbft/types"
 	pty "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
 )
 
@@ -25,3 +26,24 @@ func (val *QbftNode) ExecLocal_BlockInfo(blockInfo *pty.QbftBlockInfo, tx *types
 	set.KV = append(set.KV, &types.KeyValue{Key: key, Value: types.Encode(blockInfo)})
 	return set, nil
 }
+
+// ExecLocal_Evidence 作恶验证者的投票权变化和其他验证者更新一样由共识在提交区块时生效
+func (val *QbftNode) ExecLocal_Evidence(ev *pty.QbftEvidence, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	set := &types.LocalDBSet{}
+	for _, item := range receipt.Logs {
+		if item.Ty != pty.TyLogQbftEvidence {
+			continue
+		}
+		var record pty.QbftEvidenceRecord
+		err := types.Decode(item.Log, &record)
+		if err != nil {
+			return nil, err
+		}
+		node := &pty.QbftNode{PubKey: ev.GetPubKey(), Power: record.SlashedPower}
+		set.KV = append(set.KV,
+			&types.KeyValue{Key: CalcQbftNodeUpdateHeightIndexKey(val.GetHeight(), index), Value: types.Encode(node)},
+			&types.KeyValue{Key: CalcQbftEvidenceHeightIndexKey(val.GetHeight(), index), Value: item.Log},
+			&types.KeyValue{Key: CalcQbftEvidenceLocalKey(ttypes.EvidenceKey(ev)), Value: item.Log})
+	}
+	return set, nil
+}
@@ -0,0 +1,106 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/types"
+	pty "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitSlash(t *testing.T) {
+	valSet := &pty.QbftValidatorSet{Validators: []*pty.QbftValidator{
+		{Address: "A", VotingPower: 40},
+		{Address: "B", VotingPower: 30},
+		{Address: "C", VotingPower: 30},
+	}}
+	// 总投票权100, 一个区块内的变化必须小于33
+	slashed, err := limitSlash(valSet, 0, 30, 0)
+	require.Nil(t, err)
+	require.Equal(t, int64(0), slashed)
+	slashed, err = limitSlash(valSet, 0, 40, 0)
+	require.Nil(t, err)
+	require.Equal(t, int64(8), slashed)
+	slashed, err = limitSlash(valSet, 0, 40, 20)
+	require.Nil(t, err)
+	require.Equal(t, int64(20), slashed)
+	// 同一区块中已经有其他变更
+	slashed, err = limitSlash(valSet, 30, 40, 0)
+	require.Nil(t, err)
+	require.Equal(t, int64(38), slashed)
+	_, err = limitSlash(valSet, 32, 40, 0)
+	require.Equal(t, pty.ErrSlashLimit, err)
+	// 已经不是验证者时没有变化
+	slashed, err = limitSlash(valSet, 32, 0, 0)
+	require.Nil(t, err)
+	require.Equal(t, int64(0), slashed)
+}
+
+func TestSlashPower(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	require.Equal(t, int64(0), slashPower(cfg, 1, 40))
+	// 扣减比例使用链配置, 不受节点执行器配置影响
+	cfg = types.NewChain33Config(types.GetDefaultCfgstring() + "\n[mver.exec.sub.qbftNode]\nslashPercent=30\n")
+	require.Equal(t, int64(28), slashPower(cfg, 1, 40))
+}
+
+func TestExecEvidenceFork(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	cfg.RegisterDappFork(pty.QbftNodeX, pty.ForkQbftEvidence, 10)
+	api := &mocks.QueueProtocolAPI{}
+	api.On("GetConfig").Return(cfg)
+	initEvidenceCrypto(cfg)
+	val := newQbftNode().(*QbftNode)
+	val.SetAPI(api)
+	val.SetEnv(5, 0, 0)
+	_, err := val.Exec_Evidence(&pty.QbftEvidence{}, &types.Transaction{}, 0)
+	require.Equal(t, types.ErrActionNotSupport, err)
+	val.SetEnv(10, 0, 0)
+	_, err = val.Exec_Evidence(&pty.QbftEvidence{}, &types.Transaction{}, 0)
+	require.Equal(t, pty.ErrEvidenceHeight, err)
+}
+
+func TestGetTxsState(t *testing.T) {
+	state := &pty.QbftState{ChainID: "chain33"}
+	action := &pty.QbftNodeAction{
+		Value: &pty.QbftNodeAction_BlockInfo{BlockInfo: &pty.QbftBlockInfo{State: state}},
+		Ty:    pty.QbftNodeActionBlockInfo,
+	}
+	tx := &types.Transaction{Execer: []byte(pty.QbftNodeX), Payload: types.Encode(action)}
+	got, err := getTxsState([]*types.Transaction{tx})
+	require.Nil(t, err)
+	require.Equal(t, "chain33", got.GetChainID())
+
+	_, err = getTxsState(nil)
+	require.Equal(t, pty.ErrBlockState, err)
+	other := &types.Transaction{Execer: []byte("coins")}
+	_, err = getTxsState([]*types.Transaction{other, tx})
+	require.Equal(t, pty.ErrBlockState, err)
+}
+
+func TestDiffValidators(t *testing.T) {
+	from := &pty.QbftValidatorSet{Validators: []*pty.QbftValidator{
+		{PubKey: "A", VotingPower: 10},
+		{PubKey: "B", VotingPower: 10},
+		{PubKey: "C", VotingPower: 10},
+	}}
+	require.Nil(t, diffValidators(from, from))
+
+	to := &pty.QbftValidatorSet{Validators: []*pty.QbftValidator{
+		{PubKey: "A", VotingPower: 10},
+		{PubKey: "C", VotingPower: 5},
+		{PubKey: "D", VotingPower: 10},
+	}}
+	nodes := diffValidators(from, to)
+	require.Equal(t, []*pty.QbftNode{
+		{PubKey: "C", Power: 5},
+		{PubKey: "D", Power: 10},
+		{PubKey: "B", Power: 0},
+	}, nodes)
+}
@@ -7,17 +7,25 @@ package executor
 import (
 	"fmt"
 
+	"github.com/33cn/chain33/common/crypto"
 	log "github.com/33cn/chain33/common/log/log15"
 	drivers "github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/qbft/types"
 )
 
 var clog = log.New("module", "execs.qbftNode")
 var driverName = "qbftNode"
 
+var (
+	// 验证作恶证据的签名, 和qbft共识的signName一致
+	evidenceCrypto crypto.Crypto
+)
+
 // Init method
 func Init(name string, cfg *types.Chain33Config, sub []byte) {
 	clog.Debug("register qbftNode execer")
+	initEvidenceCrypto(cfg)
 	drivers.Register(cfg, GetName(), newQbftNode, cfg.GetDappFork(driverName, "Enable"))
 	InitExecType()
 }
@@ -28,6 +36,22 @@ func InitExecType() {
 	ety.InitFuncList(types.ListMethod(&QbftNode{}))
 }
 
+func initEvidenceCrypto(cfg *types.Chain33Config) {
+	var consensus struct {
+		SignName string `json:"signName"`
+	}
+	consensus.SignName = "ed25519"
+	if sub := cfg.GetSubConfig(); sub != nil && sub.Consensus["qbft"] != nil {
+		types.MustDecode(sub.Consensus["qbft"], &consensus)
+	}
+	cr, err := ttypes.LoadCrypto(consensus.SignName)
+	if err != nil {
+		clog.Error("initEvidenceCrypto", "signName", consensus.SignName, "err", err)
+		return
+	}
+	evidenceCrypto = cr
+}
+
 // GetName method
 func GetName() string {
 	return newQbftNode().GetName()
@@ -71,6 +95,21 @@ func CalcQbftNodeBlockInfoHeightKey(height int64) []byte {
 	return []byte(fmt.Sprintf("LODB-qbftNode-BlockInfo:%18d:", height))
 }
 
+// CalcQbftEvidenceKey 记录作恶证据的状态数据key
+func CalcQbftEvidenceKey(key string) []byte {
+	return []byte(fmt.Sprintf("mavl-qbftNode-evidence-%s", key))
+}
+
+// CalcQbftEvidenceLocalKey 按作恶查询证据的本地数据key
+func CalcQbftEvidenceLocalKey(key string) []byte {
+	return []byte(fmt.Sprintf("LODB-qbftNode-EvidenceKey:%s", key))
+}
+
+// CalcQbftEvidenceHeightIndexKey 按打包高度列出证据的本地数据key
+func CalcQbftEvidenceHeightIndexKey(height int64, index int) []byte {
+	return []byte(fmt.Sprintf("LODB-qbftNode-Evidence:%18d:%18d", height, int64(index)))
+}
+
 // CheckReceiptExecOk return true to check if receipt ty is ok
 func (qbft *QbftNode) CheckReceiptExecOk() bool {
 	return true
@@ -5,10 +5,18 @@
 package executor
 
 import (
+	dbm "github.com/33cn/chain33/common/db"
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/qbft/types"
 	pty "github.com/33cn/plugin/plugin/dapp/qbftNode/types"
 )
 
+// 每次最多列出的处罚记录
+const maxEvidenceQuery = 100
+
+// 每次最多查询验证者变更的区块范围, 轻节点落后更多时分段获取证明
+const maxUpdatesQueryRange = 100000
+
 // Query_GetQbftNodeByHeight method
 func (val *QbftNode) Query_GetQbftNodeByHeight(in *pty.ReqQbftNodes) (types.Message, error) {
 	height := in.GetHeight()
@@ -44,21 +52,7 @@ func (val *QbftNode) Query_GetBlockInfoByHeight(in *pty.ReqQbftBlockInfo) (types
 	if height <= 0 {
 		return nil, types.ErrInvalidParam
 	}
-	key := CalcQbftNodeBlockInfoHeightKey(height)
-	value, err := val.GetLocalDB().Get(key)
-	if err != nil {
-		return nil, err
-	}
-	if len(value) == 0 {
-		return nil, types.ErrNotFound
-	}
-
-	reply := &pty.QbftBlockInfo{}
-	err = types.Decode(value, reply)
-	if err != nil {
-		return nil, err
-	}
-	return reply, nil
+	return getBlockInfo(val.GetLocalDB(), height)
 }
 
 // Query_GetCurrentState method
@@ -122,3 +116,181 @@ func (val *QbftNode) Query_GetPerfStat(in *pty.ReqQbftPerfStat) (types.Message,
 		TxPerSecond: totalTx / totalSecond,
 	}, nil
 }
+
+// Query_GetQbftEvidence 查询证据对应的作恶是否已经处罚
+func (val *QbftNode) Query_GetQbftEvidence(in *pty.QbftEvidence) (types.Message, error) {
+	if in.GetVoteA() == nil && in.GetProposalA() == nil {
+		return nil, types.ErrInvalidParam
+	}
+	value, err := val.GetLocalDB().Get(CalcQbftEvidenceLocalKey(ttypes.EvidenceKey(in)))
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, types.ErrNotFound
+	}
+	reply := &pty.QbftEvidenceRecord{}
+	err = types.Decode(value, reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// Query_ListQbftEvidence 按打包高度列出处罚记录, height为0时从头或者从最新开始
+func (val *QbftNode) Query_ListQbftEvidence(in *pty.ReqQbftEvidences) (types.Message, error) {
+	if in.GetHeight() < 0 || in.GetCount() < 0 || in.GetCount() > maxEvidenceQuery {
+		return nil, types.ErrInvalidParam
+	}
+	count := in.GetCount()
+	if count == 0 {
+		count = maxEvidenceQuery
+	}
+	var key []byte
+	if in.GetHeight() > 0 {
+		key = CalcQbftEvidenceHeightIndexKey(in.GetHeight(), int(in.GetIndex()))
+	}
+	values, err := val.GetLocalDB().List([]byte("LODB-qbftNode-Evidence:"), key, count, in.GetDirection())
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, types.ErrNotFound
+	}
+	reply := &pty.QbftEvidenceRecords{}
+	for _, value := range values {
+		var record pty.QbftEvidenceRecord
+		err := types.Decode(value, &record)
+		if err != nil {
+			return nil, err
+		}
+		reply.Records = append(reply.Records, &record)
+	}
+	return reply, nil
+}
+
+// 共识在执行高度的下一个区块才应用验证者变更, 超过限制的变更会被拒绝,
+// 所以由相邻两个区块记录的验证者集合计算实际生效的变更, 没有变化的高度跳过
+// 从height开始按LastHeightValidatorsChanged向前回溯, 只读取发生变更的区块, 返回在(lower, height]生效的变更
+func getNodeUpdates(db dbm.KVDB, lower, height int64) ([]*pty.QbftNodeUpdate, error) {
+	var updates []*pty.QbftNodeUpdate
+	info, err := getBlockInfo(db, height)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		changed := info.GetState().GetLastHeightValidatorsChanged()
+		if changed <= lower || changed <= 1 {
+			break
+		}
+		if changed != info.GetState().GetLastBlockHeight()+1 {
+			info, err = getBlockInfo(db, changed)
+			if err != nil {
+				return nil, err
+			}
+		}
+		prev, err := getBlockInfo(db, changed-1)
+		if err != nil {
+			return nil, err
+		}
+		nodes := diffValidators(prev.GetState().GetValidators(), info.GetState().GetValidators())
+		if len(nodes) > 0 {
+			updates = append([]*pty.QbftNodeUpdate{{Height: changed - 1, Nodes: nodes}}, updates...)
+		}
+		info = prev
+	}
+	return updates, nil
+}
+
+// 从验证者集合from变为to的变更, 移除的验证者权重为0
+func diffValidators(from, to *pty.QbftValidatorSet) []*pty.QbftNode {
+	var nodes []*pty.QbftNode
+	old := make(map[string]int64)
+	for _, v := range from.GetValidators() {
+		old[v.PubKey] = v.VotingPower
+	}
+	for _, v := range to.GetValidators() {
+		power, ok := old[v.PubKey]
+		if !ok || power != v.VotingPower {
+			nodes = append(nodes, &pty.QbftNode{PubKey: v.PubKey, Power: v.VotingPower})
+		}
+		delete(old, v.PubKey)
+	}
+	for _, v := range from.GetValidators() {
+		if _, ok := old[v.PubKey]; ok {
+			nodes = append(nodes, &pty.QbftNode{PubKey: v.PubKey, Power: 0})
+		}
+	}
+	return nodes
+}
+
+// Query_GetQbftNodeUpdates 列出[start, end]高度执行并被共识应用的验证者变更
+// 变更在下一个区块生效, end需要小于当前高度
+func (val *QbftNode) Query_GetQbftNodeUpdates(in *pty.ReqQbftNodeUpdates) (types.Message, error) {
+	start := in.GetStart()
+	end := in.GetEnd()
+	if start <= 0 || start > end || end-start >= maxUpdatesQueryRange || end >= val.GetHeight() {
+		return nil, types.ErrInvalidParam
+	}
+	updates, err := getNodeUpdates(val.GetLocalDB(), start, end+1)
+	if err != nil {
+		return nil, err
+	}
+	return &pty.QbftNodeUpdates{Updates: updates}, nil
+}
+
+// 高度h的区块头及其commit, commit记录在下一个区块的LastCommit中
+func getSignedHeader(db dbm.KVDB, height int64) (*pty.QbftSignedHeader, error) {
+	info, err := getBlockInfo(db, height)
+	if err != nil {
+		return nil, err
+	}
+	next, err := getBlockInfo(db, height+1)
+	if err != nil {
+		return nil, err
+	}
+	if info.GetBlock().GetHeader() == nil || next.GetBlock().GetLastCommit() == nil {
+		return nil, types.ErrNotFound
+	}
+	return &pty.QbftSignedHeader{
+		Header: info.Block.Header,
+		Commit: next.Block.LastCommit,
+	}, nil
+}
+
+// Query_GetLightProof 生成从信任高度到height的轻节点证明
+// 验证者变更在执行高度的下一个区块生效, 每次变更生效的区块头都包含在证明中
+func (val *QbftNode) Query_GetLightProof(in *pty.ReqQbftLightProof) (types.Message, error) {
+	trusted := in.GetTrustedHeight()
+	height := in.GetHeight()
+	//height的commit在height+1的区块中
+	if trusted <= 0 || trusted >= height || height >= val.GetHeight() || height-trusted > maxUpdatesQueryRange {
+		return nil, types.ErrInvalidParam
+	}
+	db := val.GetLocalDB()
+	updates, err := getNodeUpdates(db, trusted, height)
+	if err != nil {
+		return nil, err
+	}
+	proof := &pty.QbftLightProof{TrustedHeight: trusted}
+	for _, update := range updates {
+		if update.Height+1 == height {
+			break
+		}
+		change, err := getSignedHeader(db, update.Height+1)
+		if err != nil {
+			return nil, err
+		}
+		change.Updates = update.Nodes
+		proof.Changes = append(proof.Changes, change)
+	}
+	proof.Target, err = getSignedHeader(db, height)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(updates); n > 0 && updates[n-1].Height+1 == height {
+		proof.Target.Updates = updates[n-1].Nodes
+	}
+	proof.ChainID = proof.Target.Header.ChainID
+	return proof, nil
+}
@@ -54,3 +54,25 @@ func (c *Jrpc) GetNodeInfo(req *types.ReqNil, result *interface{}) error {
 	*result = data
 	return nil
 }
+
+// GetLightProof query light client proof of qbft block
+func (c *channelClient) GetLightProof(ctx context.Context, req *vt.ReqQbftLightProof) (*vt.QbftLightProof, error) {
+	data, err := c.Query(vt.QbftNodeX, "GetLightProof", req)
+	if err != nil {
+		return nil, err
+	}
+	if resp, ok := data.(*vt.QbftLightProof); ok {
+		return resp, nil
+	}
+	return nil, types.ErrDecode
+}
+
+// GetLightProof query light client proof of qbft block
+func (c *Jrpc) GetLightProof(req *vt.ReqQbftLightProof, result *interface{}) error {
+	data, err := c.cli.GetLightProof(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	*result = data
+	return nil
+}
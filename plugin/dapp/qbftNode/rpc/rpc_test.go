@@ -101,3 +101,19 @@ func TestJrpc_GetNodeInfo(t *testing.T) {
 	assert.Nil(t, err)
 	assert.EqualValues(t, set, result)
 }
+
+func TestJrpc_GetLightProof(t *testing.T) {
+	api := new(mocks.QueueProtocolAPI)
+	J := newJrpc(api)
+	req := &vt.ReqQbftLightProof{TrustedHeight: 1, Height: 10}
+	var result interface{}
+	proof := &vt.QbftLightProof{
+		ChainID:       "chain33",
+		TrustedHeight: 1,
+		Target:        &vt.QbftSignedHeader{Header: &vt.QbftBlockHeader{ChainID: "chain33", Height: 10}},
+	}
+	api.On("Query", vt.QbftNodeX, "GetLightProof", req).Return(proof, nil)
+	err := J.GetLightProof(req, &result)
+	assert.Nil(t, err)
+	assert.EqualValues(t, proof, result)
+}
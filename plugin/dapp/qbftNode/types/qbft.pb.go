@@ -1941,6 +1941,86 @@ func (x *QbftAggVote) GetSignature() []byte {
 	return nil
 }
 
+// 验证者在同一高度和轮次签名了两个冲突的投票或提案
+type QbftEvidence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PubKey    string        `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	VoteA     *QbftVote     `protobuf:"bytes,2,opt,name=voteA,proto3" json:"voteA,omitempty"`
+	VoteB     *QbftVote     `protobuf:"bytes,3,opt,name=voteB,proto3" json:"voteB,omitempty"`
+	ProposalA *QbftProposal `protobuf:"bytes,4,opt,name=proposalA,proto3" json:"proposalA,omitempty"`
+	ProposalB *QbftProposal `protobuf:"bytes,5,opt,name=proposalB,proto3" json:"proposalB,omitempty"`
+}
+
+func (x *QbftEvidence) Reset() {
+	*x = QbftEvidence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_qbft_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QbftEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QbftEvidence) ProtoMessage() {}
+
+func (x *QbftEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_qbft_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QbftEvidence.ProtoReflect.Descriptor instead.
+func (*QbftEvidence) Descriptor() ([]byte, []int) {
+	return file_qbft_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *QbftEvidence) GetPubKey() string {
+	if x != nil {
+		return x.PubKey
+	}
+	return ""
+}
+
+func (x *QbftEvidence) GetVoteA() *QbftVote {
+	if x != nil {
+		return x.VoteA
+	}
+	return nil
+}
+
+func (x *QbftEvidence) GetVoteB() *QbftVote {
+	if x != nil {
+		return x.VoteB
+	}
+	return nil
+}
+
+func (x *QbftEvidence) GetProposalA() *QbftProposal {
+	if x != nil {
+		return x.ProposalA
+	}
+	return nil
+}
+
+func (x *QbftEvidence) GetProposalB() *QbftProposal {
+	if x != nil {
+		return x.ProposalB
+	}
+	return nil
+}
+
 var File_qbft_proto protoreflect.FileDescriptor
 
 var file_qbft_proto_rawDesc = []byte{
@@ -2233,8 +2313,22 @@ var file_qbft_proto_rawDesc = []byte{
 	0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x52, 0x07,
 	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x44, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
 	0x74, 0x75, 0x72, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e,
-	0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0xda, 0x01, 0x0a, 0x0c, 0x51, 0x62, 0x66, 0x74, 0x45, 0x76,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x25,
+	0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x41, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05,
+	0x76, 0x6f, 0x74, 0x65, 0x41, 0x12, 0x25, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x42, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66,
+	0x74, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x42, 0x12, 0x31, 0x0a, 0x09,
+	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x41, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x50, 0x72, 0x6f, 0x70,
+	0x6f, 0x73, 0x61, 0x6c, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x41, 0x12,
+	0x31, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x42, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x50,
+	0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x42, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2249,7 +2343,7 @@ func file_qbft_proto_rawDescGZIP() []byte {
 	return file_qbft_proto_rawDescData
 }
 
-var file_qbft_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_qbft_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_qbft_proto_goTypes = []interface{}{
 	(*QbftBlockID)(nil),         // 0: types.QbftBlockID
 	(*QbftBitArray)(nil),        // 1: types.QbftBitArray
@@ -2276,7 +2370,8 @@ var file_qbft_proto_goTypes = []interface{}{
 	(*QbftHeartbeat)(nil),       // 22: types.QbftHeartbeat
 	(*QbftIsHealthy)(nil),       // 23: types.QbftIsHealthy
 	(*QbftAggVote)(nil),         // 24: types.QbftAggVote
-	(*types.Block)(nil),         // 25: types.Block
+	(*QbftEvidence)(nil),        // 25: types.QbftEvidence
+	(*types.Block)(nil),         // 26: types.Block
 }
 var file_qbft_proto_depIdxs = []int32{
 	0,  // 0: types.QbftVote.blockID:type_name -> types.QbftBlockID
@@ -2299,7 +2394,7 @@ var file_qbft_proto_depIdxs = []int32{
 	9,  // 17: types.QbftState.consensusParams:type_name -> types.QbftConsensusParams
 	0,  // 18: types.QbftBlockHeader.lastBlockID:type_name -> types.QbftBlockID
 	13, // 19: types.QbftBlock.header:type_name -> types.QbftBlockHeader
-	25, // 20: types.QbftBlock.data:type_name -> types.Block
+	26, // 20: types.QbftBlock.data:type_name -> types.Block
 	3,  // 21: types.QbftBlock.lastCommit:type_name -> types.QbftCommit
 	0,  // 22: types.QbftProposal.POLBlockID:type_name -> types.QbftBlockID
 	1,  // 23: types.QbftProposalPOLMsg.proposalPOL:type_name -> types.QbftBitArray
@@ -2308,11 +2403,15 @@ var file_qbft_proto_depIdxs = []int32{
 	1,  // 26: types.QbftVoteSetBitsMsg.votes:type_name -> types.QbftBitArray
 	1,  // 27: types.QbftAggVote.validatorArray:type_name -> types.QbftBitArray
 	0,  // 28: types.QbftAggVote.blockID:type_name -> types.QbftBlockID
-	29, // [29:29] is the sub-list for method output_type
-	29, // [29:29] is the sub-list for method input_type
-	29, // [29:29] is the sub-list for extension type_name
-	29, // [29:29] is the sub-list for extension extendee
-	0,  // [0:29] is the sub-list for field type_name
+	2,  // 29: types.QbftEvidence.voteA:type_name -> types.QbftVote
+	2,  // 30: types.QbftEvidence.voteB:type_name -> types.QbftVote
+	15, // 31: types.QbftEvidence.proposalA:type_name -> types.QbftProposal
+	15, // 32: types.QbftEvidence.proposalB:type_name -> types.QbftProposal
+	33, // [33:33] is the sub-list for method output_type
+	33, // [33:33] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_qbft_proto_init() }
@@ -2621,6 +2720,18 @@ func file_qbft_proto_init() {
 				return nil
 			}
 		}
+		file_qbft_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QbftEvidence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2628,7 +2739,7 @@ func file_qbft_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_qbft_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -6,6 +6,7 @@ package types
 
 import (
 	"encoding/json"
+	"reflect"
 
 	"github.com/33cn/chain33/common/address"
 	log "github.com/33cn/chain33/common/log/log15"
@@ -23,6 +24,7 @@ func init() {
 //InitFork ...
 func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(QbftNodeX, "Enable", 0)
+	cfg.RegisterDappFork(QbftNodeX, ForkQbftEvidence, 0)
 }
 
 //InitExecutor ...
@@ -58,12 +60,15 @@ func (t *QbftNodeType) GetTypeMap() map[string]int32 {
 	return map[string]int32{
 		"Node":      QbftNodeActionUpdate,
 		"BlockInfo": QbftNodeActionBlockInfo,
+		"Evidence":  QbftNodeActionEvidence,
 	}
 }
 
 // GetLogMap method
 func (t *QbftNodeType) GetLogMap() map[int64]*types.LogInfo {
-	return map[int64]*types.LogInfo{}
+	return map[int64]*types.LogInfo{
+		TyLogQbftEvidence: {Ty: reflect.TypeOf(QbftEvidenceRecord{}), Name: "LogQbftEvidence"},
+	}
 }
 
 // CreateTx ...
@@ -78,6 +83,14 @@ func (t *QbftNodeType) CreateTx(action string, message json.RawMessage) (*types.
 			return nil, types.ErrInvalidParam
 		}
 		return CreateNodeUpdateTx(cfg, &param)
+	} else if action == ActionEvidence {
+		var param QbftEvidence
+		err := types.JSONToPB(message, &param)
+		if err != nil {
+			tlog.Error("qbftNode.CreateTx", "err", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateEvidenceTx(cfg, &param)
 	}
 	return nil, types.ErrNotSupport
 }
@@ -110,3 +123,23 @@ func CreateNodeUpdateTx(cfg *types.Chain33Config, parm *NodeUpdateTx) (*types.Tr
 	}
 	return tx, nil
 }
+
+// CreateEvidenceTx 提交作恶证据, 任何人都可以提交
+func CreateEvidenceTx(cfg *types.Chain33Config, ev *QbftEvidence) (*types.Transaction, error) {
+	if ev == nil || ev.PubKey == "" {
+		tlog.Error("CreateEvidenceTx", "evidence", ev)
+		return nil, types.ErrInvalidParam
+	}
+	action := &QbftNodeAction{
+		Ty:    QbftNodeActionEvidence,
+		Value: &QbftNodeAction_Evidence{ev},
+	}
+
+	execName := cfg.ExecName(QbftNodeX)
+	tx := &types.Transaction{
+		Execer:  []byte(execName),
+		Payload: types.Encode(action),
+		To:      address.ExecAddress(execName),
+	}
+	return types.FormatTx(cfg, execName, tx)
+}
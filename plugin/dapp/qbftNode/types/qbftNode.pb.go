@@ -136,6 +136,7 @@ type QbftNodeAction struct {
 	// Types that are assignable to Value:
 	//	*QbftNodeAction_Node
 	//	*QbftNodeAction_BlockInfo
+	//	*QbftNodeAction_Evidence
 	Value isQbftNodeAction_Value `protobuf_oneof:"value"`
 	Ty    int32                  `protobuf:"varint,3,opt,name=Ty,proto3" json:"Ty,omitempty"`
 }
@@ -193,6 +194,13 @@ func (x *QbftNodeAction) GetBlockInfo() *QbftBlockInfo {
 	return nil
 }
 
+func (x *QbftNodeAction) GetEvidence() *QbftEvidence {
+	if x, ok := x.GetValue().(*QbftNodeAction_Evidence); ok {
+		return x.Evidence
+	}
+	return nil
+}
+
 func (x *QbftNodeAction) GetTy() int32 {
 	if x != nil {
 		return x.Ty
@@ -212,10 +220,16 @@ type QbftNodeAction_BlockInfo struct {
 	BlockInfo *QbftBlockInfo `protobuf:"bytes,2,opt,name=blockInfo,proto3,oneof"`
 }
 
+type QbftNodeAction_Evidence struct {
+	Evidence *QbftEvidence `protobuf:"bytes,4,opt,name=evidence,proto3,oneof"`
+}
+
 func (*QbftNodeAction_Node) isQbftNodeAction_Value() {}
 
 func (*QbftNodeAction_BlockInfo) isQbftNodeAction_Value() {}
 
+func (*QbftNodeAction_Evidence) isQbftNodeAction_Value() {}
+
 type ReqQbftNodes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -578,6 +592,568 @@ func (x *ReqQbftPerfStat) GetEnd() int64 {
 	return 0
 }
 
+type QbftEvidenceRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Evidence     *QbftEvidence `protobuf:"bytes,1,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	Address      string        `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Power        int64         `protobuf:"varint,3,opt,name=power,proto3" json:"power,omitempty"`
+	SlashedPower int64         `protobuf:"varint,4,opt,name=slashedPower,proto3" json:"slashedPower,omitempty"`
+	Height       int64         `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Index        int32         `protobuf:"varint,6,opt,name=index,proto3" json:"index,omitempty"`
+	TxHash       string        `protobuf:"bytes,7,opt,name=txHash,proto3" json:"txHash,omitempty"`
+}
+
+func (x *QbftEvidenceRecord) Reset() {
+	*x = QbftEvidenceRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_qbftNode_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QbftEvidenceRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QbftEvidenceRecord) ProtoMessage() {}
+
+func (x *QbftEvidenceRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_qbftNode_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QbftEvidenceRecord.ProtoReflect.Descriptor instead.
+func (*QbftEvidenceRecord) Descriptor() ([]byte, []int) {
+	return file_qbftNode_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QbftEvidenceRecord) GetEvidence() *QbftEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *QbftEvidenceRecord) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *QbftEvidenceRecord) GetPower() int64 {
+	if x != nil {
+		return x.Power
+	}
+	return 0
+}
+
+func (x *QbftEvidenceRecord) GetSlashedPower() int64 {
+	if x != nil {
+		return x.SlashedPower
+	}
+	return 0
+}
+
+func (x *QbftEvidenceRecord) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *QbftEvidenceRecord) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *QbftEvidenceRecord) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+type QbftEvidenceRecords struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*QbftEvidenceRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (x *QbftEvidenceRecords) Reset() {
+	*x = QbftEvidenceRecords{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_qbftNode_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QbftEvidenceRecords) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QbftEvidenceRecords) ProtoMessage() {}
+
+func (x *QbftEvidenceRecords) ProtoReflect() protoreflect.Message {
+	mi := &file_qbftNode_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QbftEvidenceRecords.ProtoReflect.Descriptor instead.
+func (*QbftEvidenceRecords) Descriptor() ([]byte, []int) {
+	return file_qbftNode_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QbftEvidenceRecords) GetRecords() []*QbftEvidenceRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type ReqQbftEvidences struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height    int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Index     int32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Count     int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Direction int32 `protobuf:"varint,4,opt,name=direction,proto3" json:"direction,omitempty"`
+}
+
+func (x *ReqQbftEvidences) Reset() {
+	*x = ReqQbftEvidences{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_qbftNode_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReqQbftEvidences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqQbftEvidences) ProtoMessage() {}
+
+func (x *ReqQbftEvidences) ProtoReflect() protoreflect.Message {
+	mi := &file_qbftNode_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqQbftEvidences.ProtoReflect.Descriptor instead.
+func (*ReqQbftEvidences) Descriptor() ([]byte, []int) {
+	return file_qbftNode_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReqQbftEvidences) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ReqQbftEvidences) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ReqQbftEvidences) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ReqQbftEvidences) GetDirection() int32 {
+	if x != nil {
+		return x.Direction
+	}
+	return 0
+}
+
+type ReqQbftNodeUpdates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start int64 `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End   int64 `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *ReqQbftNodeUpdates) Reset() {
+	*x = ReqQbftNodeUpdates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_qbftNode_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReqQbftNodeUpdates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqQbftNodeUpdates) ProtoMessage() {}
+
+func (x *ReqQbftNodeUpdates) ProtoReflect() protoreflect.Message {
+	mi := &file_qbftNode_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqQbftNodeUpdates.ProtoReflect.Descriptor instead.
+func (*ReqQbftNodeUpdates) Descriptor() ([]byte, []int) {
+	return file_qbftNode_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReqQbftNodeUpdates) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ReqQbftNodeUpdates) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+// 在height高度执行的验证者变更, 从下一个高度开始生效
+type QbftNodeUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height int64       `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Nodes  []*QbftNode `protobuf:"bytes,2,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *QbftNodeUpdate) Reset() {
+	*x = QbftNodeUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_qbftNode_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QbftNodeUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QbftNodeUpdate) ProtoMessage() {}
+
+func (x *QbftNodeUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_qbftNode_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QbftNodeUpdate.ProtoReflect.Descriptor instead.
+func (*QbftNodeUpdate) Descriptor() ([]byte, []int) {
+	return file_qbftNode_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QbftNodeUpdate) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *QbftNodeUpdate) GetNodes() []*QbftNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type QbftNodeUpdates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Updates []*QbftNodeUpdate `protobuf:"bytes,1,rep,name=updates,proto3" json:"updates,omitempty"`
+}
+
+func (x *QbftNodeUpdates) Reset() {
+	*x = QbftNodeUpdates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_qbftNode_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QbftNodeUpdates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QbftNodeUpdates) ProtoMessage() {}
+
+func (x *QbftNodeUpdates) ProtoReflect() protoreflect.Message {
+	mi := &file_qbftNode_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QbftNodeUpdates.ProtoReflect.Descriptor instead.
+func (*QbftNodeUpdates) Descriptor() ([]byte, []int) {
+	return file_qbftNode_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *QbftNodeUpdates) GetUpdates() []*QbftNodeUpdate {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+type ReqQbftLightProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrustedHeight int64 `protobuf:"varint,1,opt,name=trustedHeight,proto3" json:"trustedHeight,omitempty"`
+	Height        int64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+}
+
+func (x *ReqQbftLightProof) Reset() {
+	*x = ReqQbftLightProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_qbftNode_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReqQbftLightProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqQbftLightProof) ProtoMessage() {}
+
+func (x *ReqQbftLightProof) ProtoReflect() protoreflect.Message {
+	mi := &file_qbftNode_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqQbftLightProof.ProtoReflect.Descriptor instead.
+func (*ReqQbftLightProof) Descriptor() ([]byte, []int) {
+	return file_qbftNode_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ReqQbftLightProof) GetTrustedHeight() int64 {
+	if x != nil {
+		return x.TrustedHeight
+	}
+	return 0
+}
+
+func (x *ReqQbftLightProof) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// 区块头及其commit, updates为相对上一个区块的验证者变更
+type QbftSignedHeader struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Header  *QbftBlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Commit  *QbftCommit      `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	Updates []*QbftNode      `protobuf:"bytes,3,rep,name=updates,proto3" json:"updates,omitempty"`
+}
+
+func (x *QbftSignedHeader) Reset() {
+	*x = QbftSignedHeader{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_qbftNode_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QbftSignedHeader) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QbftSignedHeader) ProtoMessage() {}
+
+func (x *QbftSignedHeader) ProtoReflect() protoreflect.Message {
+	mi := &file_qbftNode_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QbftSignedHeader.ProtoReflect.Descriptor instead.
+func (*QbftSignedHeader) Descriptor() ([]byte, []int) {
+	return file_qbftNode_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *QbftSignedHeader) GetHeader() *QbftBlockHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *QbftSignedHeader) GetCommit() *QbftCommit {
+	if x != nil {
+		return x.Commit
+	}
+	return nil
+}
+
+func (x *QbftSignedHeader) GetUpdates() []*QbftNode {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+// 轻节点证明, 从信任高度的验证者集合开始依次验证changes中验证者变化后的区块头, 最后验证target
+type QbftLightProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainID       string              `protobuf:"bytes,1,opt,name=chainID,proto3" json:"chainID,omitempty"`
+	TrustedHeight int64               `protobuf:"varint,2,opt,name=trustedHeight,proto3" json:"trustedHeight,omitempty"`
+	Changes       []*QbftSignedHeader `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	Target        *QbftSignedHeader   `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *QbftLightProof) Reset() {
+	*x = QbftLightProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_qbftNode_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QbftLightProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QbftLightProof) ProtoMessage() {}
+
+func (x *QbftLightProof) ProtoReflect() protoreflect.Message {
+	mi := &file_qbftNode_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QbftLightProof.ProtoReflect.Descriptor instead.
+func (*QbftLightProof) Descriptor() ([]byte, []int) {
+	return file_qbftNode_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *QbftLightProof) GetChainID() string {
+	if x != nil {
+		return x.ChainID
+	}
+	return ""
+}
+
+func (x *QbftLightProof) GetTrustedHeight() int64 {
+	if x != nil {
+		return x.TrustedHeight
+	}
+	return 0
+}
+
+func (x *QbftLightProof) GetChanges() []*QbftSignedHeader {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *QbftLightProof) GetTarget() *QbftSignedHeader {
+	if x != nil {
+		return x.Target
+	}
+	return nil
+}
+
 var File_qbftNode_proto protoreflect.FileDescriptor
 
 var file_qbftNode_proto_rawDesc = []byte{
@@ -591,58 +1167,131 @@ var file_qbftNode_proto_rawDesc = []byte{
 	0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65,
 	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
 	0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22,
-	0x86, 0x01, 0x0a, 0x0e, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x63, 0x74, 0x69,
+	0xb9, 0x01, 0x0a, 0x0e, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x63, 0x74, 0x69,
 	0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64,
 	0x65, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x34, 0x0a, 0x09, 0x62, 0x6c, 0x6f,
 	0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74,
 	0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x6e,
 	0x66, 0x6f, 0x48, 0x00, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12,
-	0x0e, 0x0a, 0x02, 0x54, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x54, 0x79, 0x42,
-	0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x26, 0x0a, 0x0c, 0x52, 0x65, 0x71, 0x51,
-	0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
-	0x22, 0x2a, 0x0a, 0x10, 0x52, 0x65, 0x71, 0x51, 0x62, 0x66, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xa8, 0x01, 0x0a,
-	0x0c, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a,
-	0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x50, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e,
-	0x6f, 0x64, 0x65, 0x49, 0x50, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x18, 0x0a,
-	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12,
-	0x20, 0x0a, 0x0b, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65,
-	0x72, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x63, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x05, 0x61, 0x63, 0x63, 0x75, 0x6d, 0x22, 0x3c, 0x0a, 0x0f, 0x51, 0x62, 0x66, 0x74, 0x4e,
-	0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x53, 0x65, 0x74, 0x12, 0x29, 0x0a, 0x05, 0x6e, 0x6f,
-	0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05,
-	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0xac, 0x01, 0x0a, 0x0c, 0x51, 0x62, 0x66, 0x74, 0x50, 0x65,
-	0x72, 0x66, 0x53, 0x74, 0x61, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54,
-	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x78,
-	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x78, 0x50, 0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x78, 0x50, 0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x12, 0x20, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f,
-	0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x78, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e,
-	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x78, 0x50, 0x65, 0x72, 0x53, 0x65,
-	0x63, 0x6f, 0x6e, 0x64, 0x22, 0x39, 0x0a, 0x0f, 0x52, 0x65, 0x71, 0x51, 0x62, 0x66, 0x74, 0x50,
-	0x65, 0x72, 0x66, 0x53, 0x74, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a,
-	0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x32,
-	0x73, 0x0a, 0x08, 0x71, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x49,
+	0x31, 0x0a, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x45, 0x76,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x48, 0x00, 0x52, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e,
+	0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
+	0x54, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x26, 0x0a, 0x0c, 0x52,
+	0x65, 0x71, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x22, 0x2a, 0x0a, 0x10, 0x52, 0x65, 0x71, 0x51, 0x62, 0x66, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22,
+	0xa8, 0x01, 0x0a, 0x0c, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x50, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x50, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65,
+	0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75,
+	0x62, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b,
+	0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x63, 0x75, 0x6d, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x61, 0x63, 0x63, 0x75, 0x6d, 0x22, 0x3c, 0x0a, 0x0f, 0x51, 0x62,
+	0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x53, 0x65, 0x74, 0x12, 0x29, 0x0a,
+	0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0xac, 0x01, 0x0a, 0x0c, 0x51, 0x62, 0x66,
+	0x74, 0x50, 0x65, 0x72, 0x66, 0x53, 0x74, 0x61, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x54, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x78, 0x50, 0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x78, 0x50, 0x65, 0x72, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x78, 0x50, 0x65, 0x72, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x78, 0x50, 0x65,
+	0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x22, 0x39, 0x0a, 0x0f, 0x52, 0x65, 0x71, 0x51, 0x62,
+	0x66, 0x74, 0x50, 0x65, 0x72, 0x66, 0x53, 0x74, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65,
+	0x6e, 0x64, 0x22, 0xdf, 0x01, 0x0a, 0x12, 0x51, 0x62, 0x66, 0x74, 0x45, 0x76, 0x69, 0x64, 0x65,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x2f, 0x0a, 0x08, 0x65, 0x76, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65,
+	0x52, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x6c,
+	0x61, 0x73, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78,
+	0x48, 0x61, 0x73, 0x68, 0x22, 0x4a, 0x0a, 0x13, 0x51, 0x62, 0x66, 0x74, 0x45, 0x76, 0x69, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x72,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x22, 0x74, 0x0a, 0x10, 0x52, 0x65, 0x71, 0x51, 0x62, 0x66, 0x74, 0x45, 0x76, 0x69, 0x64, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x3c, 0x0a, 0x12, 0x52, 0x65, 0x71, 0x51, 0x62, 0x66,
+	0x74, 0x4e, 0x6f, 0x64, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x22, 0x4f, 0x0a, 0x0e, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x25,
+	0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05,
+	0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0x42, 0x0a, 0x0f, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64,
+	0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x2f, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0x51, 0x0a, 0x11, 0x52, 0x65, 0x71,
+	0x51, 0x62, 0x66, 0x74, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x24,
+	0x0a, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x98, 0x01, 0x0a,
+	0x10, 0x51, 0x62, 0x66, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x12, 0x2e, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65,
+	0x72, 0x12, 0x29, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x29, 0x0a, 0x07,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x07,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x22, 0xb4, 0x01, 0x0a, 0x0e, 0x51, 0x62, 0x66, 0x74,
+	0x4c, 0x69, 0x67, 0x68, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x49, 0x44, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x72, 0x75,
+	0x73, 0x74, 0x65, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x12, 0x2f, 0x0a,
+	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x32, 0xb7,
+	0x01, 0x0a, 0x08, 0x71, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x49,
 	0x73, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x52, 0x65,
 	0x71, 0x4e, 0x69, 0x6c, 0x1a, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66,
 	0x74, 0x49, 0x73, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0b,
 	0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0d, 0x2e, 0x74, 0x79,
 	0x70, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x4e, 0x69, 0x6c, 0x1a, 0x16, 0x2e, 0x74, 0x79, 0x70,
 	0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x53,
-	0x65, 0x74, 0x22, 0x00, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x74, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x67, 0x68, 0x74,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x18, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x52, 0x65,
+	0x71, 0x51, 0x62, 0x66, 0x74, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x1a,
+	0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x51, 0x62, 0x66, 0x74, 0x4c, 0x69, 0x67, 0x68,
+	0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x00, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -657,35 +1306,59 @@ func file_qbftNode_proto_rawDescGZIP() []byte {
 	return file_qbftNode_proto_rawDescData
 }
 
-var file_qbftNode_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_qbftNode_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_qbftNode_proto_goTypes = []interface{}{
-	(*QbftNode)(nil),         // 0: types.QbftNode
-	(*QbftNodes)(nil),        // 1: types.QbftNodes
-	(*QbftNodeAction)(nil),   // 2: types.QbftNodeAction
-	(*ReqQbftNodes)(nil),     // 3: types.ReqQbftNodes
-	(*ReqQbftBlockInfo)(nil), // 4: types.ReqQbftBlockInfo
-	(*QbftNodeInfo)(nil),     // 5: types.QbftNodeInfo
-	(*QbftNodeInfoSet)(nil),  // 6: types.QbftNodeInfoSet
-	(*QbftPerfStat)(nil),     // 7: types.QbftPerfStat
-	(*ReqQbftPerfStat)(nil),  // 8: types.ReqQbftPerfStat
-	(*QbftBlockInfo)(nil),    // 9: types.QbftBlockInfo
-	(*types.ReqNil)(nil),     // 10: types.ReqNil
-	(*QbftIsHealthy)(nil),    // 11: types.QbftIsHealthy
+	(*QbftNode)(nil),            // 0: types.QbftNode
+	(*QbftNodes)(nil),           // 1: types.QbftNodes
+	(*QbftNodeAction)(nil),      // 2: types.QbftNodeAction
+	(*ReqQbftNodes)(nil),        // 3: types.ReqQbftNodes
+	(*ReqQbftBlockInfo)(nil),    // 4: types.ReqQbftBlockInfo
+	(*QbftNodeInfo)(nil),        // 5: types.QbftNodeInfo
+	(*QbftNodeInfoSet)(nil),     // 6: types.QbftNodeInfoSet
+	(*QbftPerfStat)(nil),        // 7: types.QbftPerfStat
+	(*ReqQbftPerfStat)(nil),     // 8: types.ReqQbftPerfStat
+	(*QbftEvidenceRecord)(nil),  // 9: types.QbftEvidenceRecord
+	(*QbftEvidenceRecords)(nil), // 10: types.QbftEvidenceRecords
+	(*ReqQbftEvidences)(nil),    // 11: types.ReqQbftEvidences
+	(*ReqQbftNodeUpdates)(nil),  // 12: types.ReqQbftNodeUpdates
+	(*QbftNodeUpdate)(nil),      // 13: types.QbftNodeUpdate
+	(*QbftNodeUpdates)(nil),     // 14: types.QbftNodeUpdates
+	(*ReqQbftLightProof)(nil),   // 15: types.ReqQbftLightProof
+	(*QbftSignedHeader)(nil),    // 16: types.QbftSignedHeader
+	(*QbftLightProof)(nil),      // 17: types.QbftLightProof
+	(*QbftBlockInfo)(nil),       // 18: types.QbftBlockInfo
+	(*QbftEvidence)(nil),        // 19: types.QbftEvidence
+	(*QbftBlockHeader)(nil),     // 20: types.QbftBlockHeader
+	(*QbftCommit)(nil),          // 21: types.QbftCommit
+	(*types.ReqNil)(nil),        // 22: types.ReqNil
+	(*QbftIsHealthy)(nil),       // 23: types.QbftIsHealthy
 }
 var file_qbftNode_proto_depIdxs = []int32{
 	0,  // 0: types.QbftNodes.nodes:type_name -> types.QbftNode
 	0,  // 1: types.QbftNodeAction.node:type_name -> types.QbftNode
-	9,  // 2: types.QbftNodeAction.blockInfo:type_name -> types.QbftBlockInfo
-	5,  // 3: types.QbftNodeInfoSet.nodes:type_name -> types.QbftNodeInfo
-	10, // 4: types.qbftNode.IsSync:input_type -> types.ReqNil
-	10, // 5: types.qbftNode.GetNodeInfo:input_type -> types.ReqNil
-	11, // 6: types.qbftNode.IsSync:output_type -> types.QbftIsHealthy
-	6,  // 7: types.qbftNode.GetNodeInfo:output_type -> types.QbftNodeInfoSet
-	6,  // [6:8] is the sub-list for method output_type
-	4,  // [4:6] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	18, // 2: types.QbftNodeAction.blockInfo:type_name -> types.QbftBlockInfo
+	19, // 3: types.QbftNodeAction.evidence:type_name -> types.QbftEvidence
+	5,  // 4: types.QbftNodeInfoSet.nodes:type_name -> types.QbftNodeInfo
+	19, // 5: types.QbftEvidenceRecord.evidence:type_name -> types.QbftEvidence
+	9,  // 6: types.QbftEvidenceRecords.records:type_name -> types.QbftEvidenceRecord
+	0,  // 7: types.QbftNodeUpdate.nodes:type_name -> types.QbftNode
+	13, // 8: types.QbftNodeUpdates.updates:type_name -> types.QbftNodeUpdate
+	20, // 9: types.QbftSignedHeader.header:type_name -> types.QbftBlockHeader
+	21, // 10: types.QbftSignedHeader.commit:type_name -> types.QbftCommit
+	0,  // 11: types.QbftSignedHeader.updates:type_name -> types.QbftNode
+	16, // 12: types.QbftLightProof.changes:type_name -> types.QbftSignedHeader
+	16, // 13: types.QbftLightProof.target:type_name -> types.QbftSignedHeader
+	22, // 14: types.qbftNode.IsSync:input_type -> types.ReqNil
+	22, // 15: types.qbftNode.GetNodeInfo:input_type -> types.ReqNil
+	15, // 16: types.qbftNode.GetLightProof:input_type -> types.ReqQbftLightProof
+	23, // 17: types.qbftNode.IsSync:output_type -> types.QbftIsHealthy
+	6,  // 18: types.qbftNode.GetNodeInfo:output_type -> types.QbftNodeInfoSet
+	17, // 19: types.qbftNode.GetLightProof:output_type -> types.QbftLightProof
+	17, // [17:20] is the sub-list for method output_type
+	14, // [14:17] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_qbftNode_proto_init() }
@@ -803,10 +1476,119 @@ func file_qbftNode_proto_init() {
 				return nil
 			}
 		}
+		file_qbftNode_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QbftEvidenceRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_qbftNode_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QbftEvidenceRecords); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_qbftNode_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReqQbftEvidences); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_qbftNode_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReqQbftNodeUpdates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_qbftNode_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QbftNodeUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_qbftNode_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QbftNodeUpdates); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_qbftNode_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReqQbftLightProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_qbftNode_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QbftSignedHeader); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_qbftNode_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QbftLightProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_qbftNode_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*QbftNodeAction_Node)(nil),
 		(*QbftNodeAction_BlockInfo)(nil),
+		(*QbftNodeAction_Evidence)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -814,7 +1596,7 @@ func file_qbftNode_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_qbftNode_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -842,6 +1624,7 @@ const _ = grpc.SupportPackageIsVersion6
 type QbftNodeClient interface {
 	IsSync(ctx context.Context, in *types.ReqNil, opts ...grpc.CallOption) (*QbftIsHealthy, error)
 	GetNodeInfo(ctx context.Context, in *types.ReqNil, opts ...grpc.CallOption) (*QbftNodeInfoSet, error)
+	GetLightProof(ctx context.Context, in *ReqQbftLightProof, opts ...grpc.CallOption) (*QbftLightProof, error)
 }
 
 type qbftNodeClient struct {
@@ -870,10 +1653,20 @@ func (c *qbftNodeClient) GetNodeInfo(ctx context.Context, in *types.ReqNil, opts
 	return out, nil
 }
 
+func (c *qbftNodeClient) GetLightProof(ctx context.Context, in *ReqQbftLightProof, opts ...grpc.CallOption) (*QbftLightProof, error) {
+	out := new(QbftLightProof)
+	err := c.cc.Invoke(ctx, "/types.qbftNode/GetLightProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QbftNodeServer is the server API for QbftNode service.
 type QbftNodeServer interface {
 	IsSync(context.Context, *types.ReqNil) (*QbftIsHealthy, error)
 	GetNodeInfo(context.Context, *types.ReqNil) (*QbftNodeInfoSet, error)
+	GetLightProof(context.Context, *ReqQbftLightProof) (*QbftLightProof, error)
 }
 
 // UnimplementedQbftNodeServer can be embedded to have forward compatible implementations.
@@ -886,6 +1679,9 @@ func (*UnimplementedQbftNodeServer) IsSync(context.Context, *types.ReqNil) (*Qbf
 func (*UnimplementedQbftNodeServer) GetNodeInfo(context.Context, *types.ReqNil) (*QbftNodeInfoSet, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNodeInfo not implemented")
 }
+func (*UnimplementedQbftNodeServer) GetLightProof(context.Context, *ReqQbftLightProof) (*QbftLightProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLightProof not implemented")
+}
 
 func RegisterQbftNodeServer(s *grpc.Server, srv QbftNodeServer) {
 	s.RegisterService(&_QbftNode_serviceDesc, srv)
@@ -927,6 +1723,24 @@ func _QbftNode_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QbftNode_GetLightProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqQbftLightProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QbftNodeServer).GetLightProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/types.qbftNode/GetLightProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QbftNodeServer).GetLightProof(ctx, req.(*ReqQbftLightProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _QbftNode_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "types.qbftNode",
 	HandlerType: (*QbftNodeServer)(nil),
@@ -939,6 +1753,10 @@ var _QbftNode_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetNodeInfo",
 			Handler:    _QbftNode_GetNodeInfo_Handler,
 		},
+		{
+			MethodName: "GetLightProof",
+			Handler:    _QbftNode_GetLightProof_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "qbftNode.proto",
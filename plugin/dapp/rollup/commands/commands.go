@@ -24,6 +24,11 @@ func Cmd() *cobra.Command {
 		validatorCMD(),
 		rollupStatusCMD(),
 		roundInfoCMD(),
+		challengeCMD(),
+		validatorSetsCMD(),
+		signUpdateValidatorCMD(),
+		updateValidatorCMD(),
+		daCMD(),
 	)
 	return cmd
 }
@@ -0,0 +1,181 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/rpc/grpcclient"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/dapp/rollup/da"
+	"github.com/spf13/cobra"
+)
+
+func daCMD() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "da",
+		Short: "export rollup commit data from main chain and rebuild para chain state independently",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		daExportCMD(),
+		daRebuildCMD(),
+	)
+	return cmd
+}
+
+func newGrpcClient(addr string) (types.Chain33Client, error) {
+	return grpcclient.NewMainChainClient(types.NewChain33Config(types.GetDefaultCfgstring()), addr)
+}
+
+func daExportCMD() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "export executed rollup commits of para chain from main chain blocks",
+		Run:   daExport,
+	}
+	addTitleFlags(cmd)
+	cmd.Flags().StringP("grpc", "g", "localhost:8802", "main chain grpc address")
+	cmd.Flags().Int64P("start", "s", 0, "start main chain height, default the height of the first commit")
+	cmd.Flags().Int64P("end", "e", -1, "end main chain height, default the last block")
+	cmd.Flags().StringP("file", "f", "", "export file")
+	markRequired(cmd, "file")
+	return cmd
+}
+
+func daExport(cmd *cobra.Command, args []string) {
+	title, _ := cmd.Flags().GetString("paratitle")
+	addr, _ := cmd.Flags().GetString("grpc")
+	start, _ := cmd.Flags().GetInt64("start")
+	end, _ := cmd.Flags().GetInt64("end")
+	file, _ := cmd.Flags().GetString("file")
+
+	client, err := newGrpcClient(addr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Err connect main chain:", err)
+		return
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer f.Close()
+	opt := &da.ExportOption{
+		Title: title,
+		Start: start,
+		End:   end,
+		Progress: func(height int64) {
+			fmt.Fprintf(os.Stderr, "scanned to height %d\n", height)
+		},
+	}
+	res, err := da.Export(da.NewGrpcMainChain(context.Background(), client), opt, f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Err export:", err)
+		return
+	}
+	fmt.Printf("exported %d commits from main chain [%d, %d] to %s, reverted %d, last round %d, finalized round %d\n",
+		res.Commits, res.Start, res.End, file, res.Reverted, res.LastRound, res.FinalizedRound)
+}
+
+func daRebuildCMD() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebuild",
+		Short: "verify exported commits, rebuild para chain blocks and re-execute them to check state hash",
+		Run:   daRebuild,
+	}
+	addTitleFlags(cmd)
+	cmd.Flags().StringP("file", "f", "", "exported file")
+	cmd.Flags().StringP("conf", "c", "", "para chain node config file")
+	cmd.Flags().StringP("datadir", "d", "", "empty data dir to execute blocks")
+	cmd.Flags().StringP("genesis_grpc", "g", "", "para node grpc address to get genesis block, checked by the first block parent hash")
+	cmd.Flags().BoolP("verify_only", "v", false, "only verify commits and rebuild blocks, not execute")
+	markRequired(cmd, "file")
+	return cmd
+}
+
+func daRebuild(cmd *cobra.Command, args []string) {
+	title, _ := cmd.Flags().GetString("paratitle")
+	file, _ := cmd.Flags().GetString("file")
+	conf, _ := cmd.Flags().GetString("conf")
+	dataDir, _ := cmd.Flags().GetString("datadir")
+	genesisAddr, _ := cmd.Flags().GetString("genesis_grpc")
+	verifyOnly, _ := cmd.Flags().GetBool("verify_only")
+
+	f, err := os.Open(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer f.Close()
+	reader, err := da.NewReader(f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Err read file:", err)
+		return
+	}
+
+	var exec func(block *types.Block) error
+	if !verifyOnly {
+		if conf == "" || dataDir == "" || genesisAddr == "" {
+			fmt.Fprintln(os.Stderr, "Err conf, datadir and genesis_grpc are required to execute blocks")
+			return
+		}
+		if _, err = os.Stat(conf); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		cfg := types.NewChain33Config(types.ReadFile(conf))
+		replayer, err := da.NewReplayer(cfg, dataDir)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		defer replayer.Close()
+		exec = func(block *types.Block) error {
+			if replayer.LastHeader() == nil {
+				if err := execGenesis(cfg, replayer, genesisAddr, block); err != nil {
+					return err
+				}
+			}
+			return replayer.ExecBlock(block)
+		}
+	}
+
+	res, err := da.Rebuild(reader, da.NewRebuilder(title), exec)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Err rebuild at height %d: %s\n", res.LastHeight+1, err)
+	}
+	fmt.Printf("rebuilt %d blocks from %d rounds, last height %d, pending fragment %v\n",
+		res.Blocks, res.Rounds, res.LastHeight, res.Pending)
+	if exec != nil && err == nil && res.Blocks > 0 {
+		fmt.Println("state hash verified")
+	}
+}
+
+// 创世区块不在提交数据中, 从平行链节点获取, 以第一个区块的父哈希校验
+func execGenesis(cfg *types.Chain33Config, replayer *da.Replayer, addr string, first *types.Block) error {
+	if first.GetHeight() != 1 {
+		return da.ErrGenesis
+	}
+	client, err := newGrpcClient(addr)
+	if err != nil {
+		return err
+	}
+	reply, err := client.GetBlocks(context.Background(), &types.ReqBlocks{Start: 0, End: 0, IsDetail: true})
+	if err != nil {
+		return err
+	}
+	details := &types.BlockDetails{}
+	if err = types.Decode(reply.GetMsg(), details); err != nil || len(details.GetItems()) == 0 {
+		return da.ErrGenesis
+	}
+	genesis := details.GetItems()[0].GetBlock()
+	if !bytes.Equal(genesis.Hash(cfg), first.GetParentHash()) {
+		fmt.Fprintf(os.Stderr, "genesis hash %s, block 1 parent hash %s\n", common.ToHex(genesis.Hash(cfg)),
+			common.ToHex(first.GetParentHash()))
+		return da.ErrGenesis
+	}
+	return replayer.ExecGenesis(genesis)
+}
@@ -89,3 +89,32 @@ func getRoundInfo(cmd *cobra.Command, args []string) {
 	info := &rtypes.CommitRoundInfo{}
 	sendQueryRPC(cmd, "GetCommitRoundInfo", params, info)
 }
+
+func challengeCMD() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "challenge",
+		Short: "show rollup commit round challenge record",
+		Run:   getChallengeRecord,
+	}
+	addTitleFlags(cmd)
+	cmd.Flags().Int64P("round", "r", 0, "commit round")
+	markRequired(cmd, "round")
+	return cmd
+}
+
+func getChallengeRecord(cmd *cobra.Command, args []string) {
+	title, _ := cmd.Flags().GetString("paratitle")
+	if title == "" {
+		fmt.Fprintf(os.Stderr, "Err empty parachain title")
+		return
+	}
+
+	round, _ := cmd.Flags().GetInt64("round")
+
+	params := &rtypes.ReqGetCommitRound{
+		ChainTitle:  title,
+		CommitRound: round,
+	}
+	info := &rtypes.ChallengeRecord{}
+	sendQueryRPC(cmd, "GetChallengeRecord", params, info)
+}
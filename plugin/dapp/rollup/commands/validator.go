@@ -0,0 +1,157 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/crypto"
+	jsonrpc "github.com/33cn/chain33/rpc/jsonclient"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/crypto/bls"
+	rtypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
+	"github.com/spf13/cobra"
+)
+
+func validatorSetsCMD() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validator_sets",
+		Short: "show validator sets updated by governance",
+		Run:   getValidatorSets,
+	}
+	addTitleFlags(cmd)
+	return cmd
+}
+
+func getValidatorSets(cmd *cobra.Command, args []string) {
+	title, _ := cmd.Flags().GetString("paratitle")
+	if title == "" {
+		fmt.Fprintf(os.Stderr, "Err empty parachain title")
+		return
+	}
+
+	params := &rtypes.ChainTitle{
+		Value: title,
+	}
+	info := &rtypes.ValidatorSets{}
+	sendQueryRPC(cmd, "GetValidatorSets", params, info)
+}
+
+func addUpdateValidatorFlags(cmd *cobra.Command) {
+	addTitleFlags(cmd)
+	cmd.Flags().StringP("add", "a", "", "add validator bls pubkeys, separated by ','")
+	cmd.Flags().StringP("remove", "d", "", "remove validator bls pubkeys, separated by ','")
+	cmd.Flags().Int32P("threshold", "m", 0, "min validator sign count, default 2/3+1 of validators")
+	cmd.Flags().Int64P("round", "r", 0, "commit round the update takes effect")
+	markRequired(cmd, "round")
+}
+
+func splitPubs(pubs string) []string {
+	if pubs == "" {
+		return nil
+	}
+	return strings.Split(pubs, ",")
+}
+
+func getUpdateValidator(cmd *cobra.Command) *rtypes.UpdateValidator {
+	title, _ := cmd.Flags().GetString("paratitle")
+	add, _ := cmd.Flags().GetString("add")
+	remove, _ := cmd.Flags().GetString("remove")
+	threshold, _ := cmd.Flags().GetInt32("threshold")
+	round, _ := cmd.Flags().GetInt64("round")
+
+	return &rtypes.UpdateValidator{
+		ChainTitle:     title,
+		AddPubs:        splitPubs(add),
+		RemovePubs:     splitPubs(remove),
+		Threshold:      threshold,
+		EffectiveRound: round,
+	}
+}
+
+func signUpdateValidatorCMD() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign_update",
+		Short: "validator sign the validator set update, output blsPub:sign",
+		Run:   signUpdateValidator,
+	}
+	addUpdateValidatorFlags(cmd)
+	cmd.Flags().StringP("key", "k", "", "validator node auth private key")
+	markRequired(cmd, "key")
+	return cmd
+}
+
+func signUpdateValidator(cmd *cobra.Command, args []string) {
+	key, _ := cmd.Flags().GetString("key")
+	keyBytes, err := common.FromHex(key)
+	if err != nil || len(keyBytes) == 0 {
+		fmt.Fprintf(os.Stderr, "Err invalid private key")
+		return
+	}
+	// 与共识模块一致, 由节点私钥生成bls私钥
+	_, blsKey := bls.MustPrivKeyFromBytes(keyBytes)
+	sign := blsKey.Sign(rtypes.CalcUpdateValidatorMsg(getUpdateValidator(cmd)))
+	fmt.Println(common.ToHex(blsKey.PubKey().Bytes()) + ":" + common.ToHex(sign.Bytes()))
+}
+
+func updateValidatorCMD() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "update_validator",
+		Short: "create validator set update tx with validator signs",
+		Run:   updateValidator,
+	}
+	addUpdateValidatorFlags(cmd)
+	cmd.Flags().StringP("signs", "s", "", "validator signs output by sign_update, separated by ','")
+	markRequired(cmd, "signs")
+	return cmd
+}
+
+func updateValidator(cmd *cobra.Command, args []string) {
+	rpcAddr, _ := cmd.Flags().GetString("rpc_laddr")
+	paraName, _ := cmd.Flags().GetString("paraName")
+	signs, _ := cmd.Flags().GetString("signs")
+
+	update := getUpdateValidator(cmd)
+	blsDriver := &bls.Driver{}
+	sigs := make([]crypto.Signature, 0, 8)
+	for _, pubSign := range strings.Split(signs, ",") {
+		pair := strings.Split(pubSign, ":")
+		if len(pair) != 2 {
+			fmt.Fprintf(os.Stderr, "Err invalid sign format:%s", pubSign)
+			return
+		}
+		pub, err := common.FromHex(pair[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Err invalid bls pub:%s", pair[0])
+			return
+		}
+		sigBytes, err := common.FromHex(pair[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Err invalid bls sign:%s", pair[1])
+			return
+		}
+		sig, err := blsDriver.SignatureFromBytes(sigBytes)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Err invalid bls sign:%s", pair[1])
+			return
+		}
+		update.ValidatorPubs = append(update.ValidatorPubs, pub)
+		sigs = append(sigs, sig)
+	}
+	aggSig, err := blsDriver.Aggregate(sigs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Err aggregate sign:%s", err)
+		return
+	}
+	update.AggregateSign = aggSig.Bytes()
+
+	params := &rpctypes.CreateTxIn{
+		Execer:     types.GetExecName(rtypes.RollupX, paraName),
+		ActionName: rtypes.NameUpdateValidatorAction,
+		Payload:    types.MustPBToJSON(update),
+	}
+	ctx := jsonrpc.NewRPCCtx(rpcAddr, "Chain33.CreateTransaction", params, nil)
+	ctx.RunWithoutMarshal()
+}
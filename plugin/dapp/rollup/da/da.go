@@ -0,0 +1,143 @@
+// Package da rollup数据可用性工具: 从主链导出平行链的rollup提交数据, 并仅基于导出数据重建平行链区块,
+// 校验提交轮次信息, 重新执行区块并校验状态哈希, 无需信任验证节点
+//
+// 导出文件格式:
+//
+//	magic(8) | { commitLen(4) | commit(types.DACommit) }* | 0(4)
+package da
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/types"
+	rtypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
+)
+
+var dlog = log.New("module", "rollup.da")
+
+// 单个提交的上限, 防止损坏的文件导致分配过大的内存
+const maxCommitSize = 256 * 1024 * 1024
+
+var magic = []byte("C33ROLDA")
+
+var (
+	ErrBadMagic       = errors.New("ErrDABadMagic")
+	ErrCommitTooLarge = errors.New("ErrDACommitTooLarge")
+	ErrChainTitle     = errors.New("ErrDAChainTitle")
+	ErrCommitRound    = errors.New("ErrDACommitRound")
+	ErrRoundInfo      = errors.New("ErrDARoundInfo")
+	ErrBlockRootHash  = errors.New("ErrDABlockRootHash")
+	ErrBatchHash      = errors.New("ErrDABatchHash")
+	ErrBatchData      = errors.New("ErrDABatchData")
+	ErrNoTxData       = errors.New("ErrDANoTxData")
+	ErrTxAggregateSig = errors.New("ErrDATxAggregateSign")
+	ErrBlockHash      = errors.New("ErrDABlockHash")
+	ErrBlockHeight    = errors.New("ErrDABlockHeight")
+	ErrGenesis        = errors.New("ErrDAGenesisBlock")
+	ErrExecTx         = errors.New("ErrDAExecTx")
+	ErrStateHash      = errors.New("ErrDAStateHash")
+)
+
+// Writer 写入导出文件
+type Writer struct {
+	w       *bufio.Writer
+	commits int64
+}
+
+// NewWriter 写入magic并返回Writer
+func NewWriter(w io.Writer) (*Writer, error) {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(magic); err != nil {
+		return nil, err
+	}
+	return &Writer{w: bw}, nil
+}
+
+// Add 写入一次提交
+func (dw *Writer) Add(commit *rtypes.DACommit) error {
+	data := types.Encode(commit)
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(len(data)))
+	if _, err := dw.w.Write(buf[:]); err != nil {
+		return err
+	}
+	if _, err := dw.w.Write(data); err != nil {
+		return err
+	}
+	dw.commits++
+	return nil
+}
+
+// Commits 已写入的提交数量
+func (dw *Writer) Commits() int64 {
+	return dw.commits
+}
+
+// Close 写入文件尾
+func (dw *Writer) Close() error {
+	var buf [4]byte
+	if _, err := dw.w.Write(buf[:]); err != nil {
+		return err
+	}
+	return dw.w.Flush()
+}
+
+// Reader 按顺序读取导出文件
+type Reader struct {
+	r    *bufio.Reader
+	done bool
+}
+
+// NewReader 读取并检查magic
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	buf := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, buf); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(buf, magic) {
+		return nil, ErrBadMagic
+	}
+	return &Reader{r: br}, nil
+}
+
+// Next 返回下一次提交, 读完时返回 io.EOF
+func (dr *Reader) Next() (*rtypes.DACommit, error) {
+	if dr.done {
+		return nil, io.EOF
+	}
+	var buf [4]byte
+	if _, err := io.ReadFull(dr.r, buf[:]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	size := binary.BigEndian.Uint32(buf[:])
+	if size == 0 {
+		dr.done = true
+		return nil, io.EOF
+	}
+	if size > maxCommitSize {
+		return nil, ErrCommitTooLarge
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(dr.r, data); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	commit := &rtypes.DACommit{}
+	if err := types.Decode(data, commit); err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
+
+// 文件尾之前的EOF说明文件被截断
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,339 @@
+package da
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/common/merkle"
+	_ "github.com/33cn/chain33/system/address"
+	_ "github.com/33cn/chain33/system/crypto/init"
+	cty "github.com/33cn/chain33/system/dapp/coins/types"
+	_ "github.com/33cn/chain33/system/dapp/init"
+	_ "github.com/33cn/chain33/system/store/init"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/33cn/plugin/plugin/crypto/bls"
+	rtypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testTitle = "user.p.test."
+
+func TestWriterReader(t *testing.T) {
+
+	buf := &bytes.Buffer{}
+	dw, err := NewWriter(buf)
+	require.Nil(t, err)
+	for i := 1; i <= 3; i++ {
+		cp := &rtypes.CheckPoint{ChainTitle: testTitle, CommitRound: int64(i)}
+		require.Nil(t, dw.Add(&rtypes.DACommit{MainHeight: int64(i * 10), CheckPoint: cp}))
+	}
+	require.Nil(t, dw.Close())
+	require.Equal(t, int64(3), dw.Commits())
+	data := buf.Bytes()
+
+	dr, err := NewReader(bytes.NewReader(data))
+	require.Nil(t, err)
+	for i := 1; i <= 3; i++ {
+		commit, err := dr.Next()
+		require.Nil(t, err)
+		require.Equal(t, int64(i), commit.GetCheckPoint().GetCommitRound())
+		require.Equal(t, int64(i*10), commit.GetMainHeight())
+	}
+	_, err = dr.Next()
+	require.Equal(t, io.EOF, err)
+
+	// 文件截断, 或格式错误
+	dr, err = NewReader(bytes.NewReader(data[:len(data)-6]))
+	require.Nil(t, err)
+	for err == nil {
+		_, err = dr.Next()
+	}
+	require.Equal(t, io.ErrUnexpectedEOF, err)
+	_, err = NewReader(bytes.NewReader([]byte("C33ROLDB")))
+	require.Equal(t, ErrBadMagic, err)
+}
+
+type testChain struct {
+	blocks []*types.BlockDetail
+	status *rtypes.RollupStatus
+	rounds map[int64]*rtypes.CommitRoundInfo
+}
+
+func (c *testChain) LastHeight() (int64, error) {
+	return int64(len(c.blocks) - 1), nil
+}
+
+func (c *testChain) GetBlocks(start, end int64) ([]*types.BlockDetail, error) {
+	return c.blocks[start : end+1], nil
+}
+
+func (c *testChain) GetRollupStatus(title string) (*rtypes.RollupStatus, error) {
+	return c.status, nil
+}
+
+func (c *testChain) GetRoundInfo(title string, round int64) (*rtypes.CommitRoundInfo, error) {
+	info, ok := c.rounds[round]
+	if !ok {
+		return nil, types.ErrNotFound
+	}
+	return info, nil
+}
+
+func createCommitTx(cp *rtypes.CheckPoint) *types.Transaction {
+	action := &rtypes.RollupAction{Ty: rtypes.TyCommitAction, Value: &rtypes.RollupAction_Commit{Commit: cp}}
+	return &types.Transaction{Execer: []byte(rtypes.RollupX), Payload: types.Encode(action)}
+}
+
+func TestExport(t *testing.T) {
+
+	chain := &testChain{status: &rtypes.RollupStatus{CommitRound: 2}, rounds: make(map[int64]*rtypes.CommitRoundInfo)}
+	for i := 0; i < 250; i++ {
+		chain.blocks = append(chain.blocks, &types.BlockDetail{Block: &types.Block{Height: int64(i)}})
+	}
+	addCommit := func(height int64, round int64, ty int32, title string) *rtypes.CheckPoint {
+		cp := &rtypes.CheckPoint{ChainTitle: title, CommitRound: round,
+			Batch: &rtypes.BlockBatch{BlockHeaders: []*types.Header{{Height: height}}}}
+		detail := chain.blocks[height]
+		detail.Block.Txs = append(detail.Block.Txs, createCommitTx(cp))
+		detail.Receipts = append(detail.Receipts, &types.ReceiptData{Ty: ty})
+		return cp
+	}
+	// 第一轮在高度5提交, 第二轮在高度120被挑战回滚, 在高度180重新提交, 第三轮被回滚未重新提交
+	cp := addCommit(5, 1, types.ExecOk, testTitle)
+	chain.rounds[1] = &rtypes.CommitRoundInfo{CommitRound: 1, CommitHeight: 5,
+		BatchHash: common.ToHex(common.Sha256(types.Encode(cp.GetBatch())))}
+	addCommit(6, 2, types.ExecPack, testTitle)
+	addCommit(120, 2, types.ExecOk, testTitle)
+	addCommit(150, 3, types.ExecOk, testTitle)
+	addCommit(180, 2, types.ExecOk, testTitle)
+	chain.rounds[2] = &rtypes.CommitRoundInfo{CommitRound: 2, CommitHeight: 180}
+	addCommit(200, 3, types.ExecOk, "user.p.other.")
+
+	buf := &bytes.Buffer{}
+	var progress []int64
+	opt := &ExportOption{Title: testTitle, End: -1, Progress: func(height int64) {
+		progress = append(progress, height)
+	}}
+	res, err := Export(chain, opt, buf)
+	require.Nil(t, err)
+	require.Equal(t, int64(5), res.Start)
+	require.Equal(t, int64(249), res.End)
+	require.Equal(t, int64(2), res.Commits)
+	require.Equal(t, int64(2), res.Reverted)
+	require.Equal(t, []int64{104, 204, 249}, progress)
+
+	dr, err := NewReader(buf)
+	require.Nil(t, err)
+	commit, err := dr.Next()
+	require.Nil(t, err)
+	require.Equal(t, int64(5), commit.GetMainHeight())
+	require.Equal(t, int64(1), commit.GetRoundInfo().GetCommitRound())
+	commit, err = dr.Next()
+	require.Nil(t, err)
+	require.Equal(t, int64(180), commit.GetMainHeight())
+	require.Equal(t, int64(180), commit.GetCheckPoint().GetBatch().GetBlockHeaders()[0].GetHeight())
+	_, err = dr.Next()
+	require.Equal(t, io.EOF, err)
+}
+
+type testRollup struct {
+	priv    crypto.PrivKey
+	headers []*types.Header
+	txs     [][]*types.Transaction
+}
+
+func newTestRollup(t *testing.T, txCounts ...int) *testRollup {
+
+	priv, err := blsDriver.GenKey()
+	require.Nil(t, err)
+	tr := &testRollup{priv: priv}
+	var parentHash []byte
+	for i, count := range txCounts {
+		var txs []*types.Transaction
+		for j := 0; j < count; j++ {
+			tx := &types.Transaction{Execer: []byte(testTitle + "none"), Payload: []byte{byte(i), byte(j)}, Nonce: int64(j)}
+			tx.Sign(types.EncodeSignID(bls.ID, 2), priv)
+			txs = append(txs, tx)
+		}
+		header := &types.Header{Height: int64(i + 1), ParentHash: parentHash, TxCount: int64(count), BlockTime: int64(i)}
+		parentHash = headerHash(header)
+		tr.headers = append(tr.headers, header)
+		tr.txs = append(tr.txs, txs)
+	}
+	return tr
+}
+
+// 提交区块下标[first, last]的区块, 首个区块从firstTx开始, 最后一个区块在fragIndex处分割
+func (tr *testRollup) commit(t *testing.T, round int64, first, last int, firstTx, fragIndex int) *rtypes.DACommit {
+
+	batch := &rtypes.BlockBatch{BlockFragIndex: int32(fragIndex)}
+	var sigs []crypto.Signature
+	var blkHashes [][]byte
+	for i := first; i <= last; i++ {
+		batch.BlockHeaders = append(batch.BlockHeaders, tr.headers[i])
+		blkHashes = append(blkHashes, headerHash(tr.headers[i]))
+		txs := tr.txs[i]
+		if i == last && fragIndex > 0 {
+			txs = txs[:fragIndex]
+		}
+		if i == first {
+			txs = txs[firstTx:]
+		}
+		for _, tx := range txs {
+			ctx := types.CloneTx(tx)
+			ctx.Signature = nil
+			sig, err := blsDriver.SignatureFromBytes(tx.GetSignature().GetSignature())
+			require.Nil(t, err)
+			sigs = append(sigs, sig)
+			batch.TxList = append(batch.TxList, types.Encode(ctx))
+			batch.PubKeyList = append(batch.PubKeyList, tx.GetSignature().GetPubkey())
+			batch.TxAddrIDList = append(batch.TxAddrIDList, byte(types.ExtractAddressID(tx.GetSignature().GetTy())))
+		}
+	}
+	aggSig, err := blsDriver.Aggregate(sigs)
+	require.Nil(t, err)
+	batch.AggregateTxSign = aggSig.Bytes()
+	info := &rtypes.CommitRoundInfo{
+		CommitRound:   round,
+		BlockRootHash: common.ToHex(merkle.GetMerkleRoot(blkHashes)),
+		BatchHash:     common.ToHex(common.Sha256(types.Encode(batch))),
+		FirstTxIndex:  int32(firstTx),
+	}
+	cp := &rtypes.CheckPoint{ChainTitle: testTitle, CommitRound: round, Batch: batch}
+	return &rtypes.DACommit{CheckPoint: cp, RoundInfo: info}
+}
+
+func TestRebuild(t *testing.T) {
+
+	tr := newTestRollup(t, 2, 3, 1)
+	commit1 := tr.commit(t, 1, 0, 1, 0, 1)
+	commit2 := tr.commit(t, 2, 1, 2, 1, 0)
+
+	rb := NewRebuilder(testTitle)
+	_, err := rb.Add(commit2)
+	require.Equal(t, ErrCommitRound, errorCause(err))
+	blocks, err := rb.Add(commit1)
+	require.Nil(t, err)
+	require.Equal(t, 1, len(blocks))
+	require.True(t, rb.Pending())
+	require.Equal(t, int64(2), rb.LastHeight())
+
+	// 交易数据被篡改
+	bad := types.Clone(commit2).(*rtypes.DACommit)
+	bad.CheckPoint.Batch.TxList[0] = types.Encode(&types.Transaction{Execer: []byte(testTitle + "none")})
+	bad.RoundInfo.BatchHash = ""
+	_, err = rb.Add(bad)
+	require.Equal(t, ErrTxAggregateSig, errorCause(err))
+	bad = types.Clone(commit2).(*rtypes.DACommit)
+	bad.RoundInfo.BlockRootHash = commit1.GetRoundInfo().GetBlockRootHash()
+	_, err = rb.Add(bad)
+	require.Equal(t, ErrBlockRootHash, errorCause(err))
+	bad = types.Clone(commit2).(*rtypes.DACommit)
+	bad.CheckPoint.Batch.BlockFragIndex = 1
+	_, err = rb.Add(bad)
+	require.Equal(t, ErrBatchHash, errorCause(err))
+
+	blocks, err = rb.Add(commit2)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(blocks))
+	require.False(t, rb.Pending())
+	require.Equal(t, int64(3), rb.NextRound())
+	for i, block := range blocks {
+		require.Equal(t, tr.headers[i+1].GetHeight(), block.GetHeight())
+		require.Equal(t, len(tr.txs[i+1]), len(block.GetTxs()))
+		for j, tx := range block.GetTxs() {
+			require.Equal(t, tr.txs[i+1][j].Hash(), tx.Hash())
+			require.Equal(t, tr.txs[i+1][j].GetSignature().GetTy(), tx.GetSignature().GetTy())
+		}
+	}
+
+	// 按文件顺序重建
+	buf := &bytes.Buffer{}
+	dw, err := NewWriter(buf)
+	require.Nil(t, err)
+	require.Nil(t, dw.Add(commit1))
+	require.Nil(t, dw.Add(commit2))
+	require.Nil(t, dw.Close())
+	dr, err := NewReader(buf)
+	require.Nil(t, err)
+	var heights []int64
+	res, err := Rebuild(dr, NewRebuilder(testTitle), func(block *types.Block) error {
+		heights = append(heights, block.GetHeight())
+		return nil
+	})
+	require.Nil(t, err)
+	require.Equal(t, []int64{1, 2, 3}, heights)
+	require.Equal(t, int64(2), res.Rounds)
+	require.Equal(t, int64(3), res.LastHeight)
+}
+
+func errorCause(err error) error {
+	type causer interface {
+		Cause() error
+	}
+	for err != nil {
+		c, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = c.Cause()
+	}
+	return err
+}
+
+// 计算区块执行后的状态哈希, 不提交
+func calcStateHash(t *testing.T, r *Replayer, prevStateHash []byte, block *types.Block) {
+	receipts, err := util.ExecTx(r.client, prevStateHash, block)
+	require.Nil(t, err)
+	var kvs []*types.KeyValue
+	for _, receipt := range receipts.GetReceipts() {
+		kvs = append(kvs, receipt.KV...)
+	}
+	hash, err := util.ExecKVMemSet(r.client, prevStateHash, block.GetHeight(), util.DelDupKey(kvs), false, false)
+	require.Nil(t, err)
+	require.Nil(t, util.ExecKVSetRollback(r.client, hash))
+	block.StateHash = hash
+}
+
+func TestReplayer(t *testing.T) {
+
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	r, err := NewReplayer(cfg, t.TempDir())
+	require.Nil(t, err)
+	defer r.Close()
+
+	addr, priv := util.Genaddress()
+	genesisTx := &types.Transaction{Execer: []byte(cfg.GetCoinExec()), To: addr}
+	genesisTx.Payload = types.Encode(&cty.CoinsAction{Ty: cty.CoinsActionGenesis,
+		Value: &cty.CoinsAction_Genesis{Genesis: &types.AssetsGenesis{Amount: 1e8 * cfg.GetCoinPrecision()}}})
+	genesis := &types.Block{Height: 0, ParentHash: zeroHash, Txs: []*types.Transaction{genesisTx}}
+	calcStateHash(t, r, zeroHash, genesis)
+	require.Equal(t, ErrGenesis, r.ExecBlock(genesis))
+	require.Nil(t, r.ExecGenesis(genesis))
+
+	to, _ := util.Genaddress()
+	block := &types.Block{Height: 1, ParentHash: genesis.Hash(cfg), BlockTime: 1,
+		Txs: []*types.Transaction{util.CreateCoinsTx(cfg, priv, to, cfg.GetCoinPrecision())}}
+	calcStateHash(t, r, genesis.GetStateHash(), block)
+	wrong := types.Clone(block).(*types.Block)
+	wrong.StateHash = genesis.GetStateHash()
+	require.Equal(t, ErrStateHash, errorCause(r.ExecBlock(wrong)))
+	wrong = types.Clone(block).(*types.Block)
+	wrong.ParentHash = zeroHash
+	require.Equal(t, ErrBlockHash, errorCause(r.ExecBlock(wrong)))
+	require.Nil(t, r.ExecBlock(block))
+	require.Equal(t, block.Hash(cfg), r.LastHeader().GetHash())
+
+	// 本地数据写入localdb
+	count := 0
+	it := r.localDB.Iterator(nil, nil, false)
+	for it.Rewind(); it.Valid(); it.Next() {
+		count++
+	}
+	it.Close()
+	require.True(t, count > 0)
+}
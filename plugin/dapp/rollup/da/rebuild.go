@@ -0,0 +1,243 @@
+package da
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/common/merkle"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/crypto/bls"
+	"github.com/33cn/plugin/plugin/dapp/rollup/executor"
+	rtypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
+	"github.com/pkg/errors"
+)
+
+var blsDriver = bls.Driver{}
+
+// Rebuilder 按提交轮次顺序校验提交数据, 并重建平行链区块
+// 提交的交易不包含签名, 只能通过聚合签名校验, 无法重新计算区块头的交易根哈希
+type Rebuilder struct {
+	title      string
+	nextRound  int64
+	lastHash   []byte
+	lastHeight int64
+	// 被分段提交的区块, 等待下一轮的剩余交易
+	partial   *types.Block
+	fragIndex int32
+}
+
+// NewRebuilder 从第一轮提交开始重建
+func NewRebuilder(title string) *Rebuilder {
+	return &Rebuilder{title: title, nextRound: 1}
+}
+
+// NextRound 下一个需要的提交轮次
+func (rb *Rebuilder) NextRound() int64 {
+	return rb.nextRound
+}
+
+// LastHeight 已重建的最大区块高度, 包括分段未完成的区块
+func (rb *Rebuilder) LastHeight() int64 {
+	return rb.lastHeight
+}
+
+// Pending 最后一个区块是否分段未完成
+func (rb *Rebuilder) Pending() bool {
+	return rb.partial != nil
+}
+
+// Add 校验一轮提交, 返回该轮完成重建的区块
+func (rb *Rebuilder) Add(commit *rtypes.DACommit) ([]*types.Block, error) {
+
+	cp := commit.GetCheckPoint()
+	round := cp.GetCommitRound()
+	if cp.GetChainTitle() != rb.title {
+		return nil, errors.Wrapf(ErrChainTitle, "round %d title %s", round, cp.GetChainTitle())
+	}
+	if round != rb.nextRound {
+		return nil, errors.Wrapf(ErrCommitRound, "expect round %d, got %d", rb.nextRound, round)
+	}
+	if err := rb.verifyCommit(commit); err != nil {
+		return nil, errors.Wrapf(err, "round %d", round)
+	}
+	blocks, err := rb.assemble(cp.GetBatch())
+	if err != nil {
+		return nil, errors.Wrapf(err, "round %d", round)
+	}
+	rb.nextRound++
+	return blocks, nil
+}
+
+// 校验提交数据和主链记录的轮次信息一致, 以及交易聚合签名
+func (rb *Rebuilder) verifyCommit(commit *rtypes.DACommit) error {
+
+	batch := commit.GetCheckPoint().GetBatch()
+	info := commit.GetRoundInfo()
+	if info.GetCommitRound() != commit.GetCheckPoint().GetCommitRound() {
+		return ErrRoundInfo
+	}
+	headers := batch.GetBlockHeaders()
+	if len(headers) == 0 {
+		return ErrBatchData
+	}
+	blkHashes := make([][]byte, len(headers))
+	for i, h := range headers {
+		blkHashes[i] = headerHash(h)
+	}
+	if common.ToHex(merkle.GetMerkleRoot(blkHashes)) != info.GetBlockRootHash() {
+		return ErrBlockRootHash
+	}
+	if info.GetBatchHash() != "" && info.GetBatchHash() != common.ToHex(common.Sha256(types.Encode(batch))) {
+		return ErrBatchHash
+	}
+
+	// 精简模式只提交区块头, 无法重建交易
+	if len(batch.GetTxList()) == 0 {
+		for _, h := range headers {
+			if h.GetTxCount() > 0 {
+				return ErrNoTxData
+			}
+		}
+		return nil
+	}
+	if cause := executor.VerifyBatchData(rb.title, batch, rb.fragIndex); cause != "" {
+		return errors.Wrap(ErrBatchData, cause)
+	}
+	pubs := make([]crypto.PubKey, 0, len(batch.GetPubKeyList()))
+	for _, data := range batch.GetPubKeyList() {
+		pub, err := blsDriver.PubKeyFromBytes(data)
+		if err != nil {
+			return ErrTxAggregateSig
+		}
+		pubs = append(pubs, pub)
+	}
+	sig, err := blsDriver.SignatureFromBytes(batch.GetAggregateTxSign())
+	if err != nil {
+		return ErrTxAggregateSig
+	}
+	if err = blsDriver.VerifyAggregatedN(pubs, batch.GetTxList(), sig); err != nil {
+		return ErrTxAggregateSig
+	}
+	return nil
+}
+
+// 按区块头的交易数量分配交易, 最后一个区块被分割时等待下一轮提交
+func (rb *Rebuilder) assemble(batch *rtypes.BlockBatch) ([]*types.Block, error) {
+
+	headers := batch.GetBlockHeaders()
+	blocks := make([]*types.Block, 0, len(headers))
+	txIndex := 0
+	for i, h := range headers {
+		hash := headerHash(h)
+		var block *types.Block
+		if i == 0 && rb.partial != nil {
+			// 分割的区块, 区块头会被重复提交
+			if !bytes.Equal(hash, rb.lastHash) {
+				return nil, ErrBlockHash
+			}
+			block = rb.partial
+		} else {
+			if rb.lastHash != nil && !bytes.Equal(h.GetParentHash(), rb.lastHash) {
+				return nil, ErrBlockHash
+			}
+			if h.GetHeight() != rb.lastHeight+1 {
+				return nil, ErrBlockHeight
+			}
+			block = &types.Block{}
+			block.SetHeader(h)
+		}
+
+		count := h.GetTxCount()
+		fragment := i == len(headers)-1 && batch.GetBlockFragIndex() > 0
+		if fragment {
+			count = int64(batch.GetBlockFragIndex())
+		}
+		for int64(len(block.Txs)) < count && len(batch.GetTxList()) > 0 {
+			if txIndex >= len(batch.GetTxList()) {
+				return nil, ErrBatchData
+			}
+			tx, err := restoreTx(batch, txIndex)
+			if err != nil {
+				return nil, err
+			}
+			block.Txs = append(block.Txs, tx)
+			txIndex++
+		}
+
+		rb.lastHash = hash
+		rb.lastHeight = h.GetHeight()
+		rb.partial = nil
+		rb.fragIndex = 0
+		if fragment {
+			rb.partial = block
+			rb.fragIndex = batch.GetBlockFragIndex()
+			continue
+		}
+		blocks = append(blocks, block)
+	}
+	if txIndex != len(batch.GetTxList()) {
+		return nil, ErrBatchData
+	}
+	return blocks, nil
+}
+
+// 恢复交易的签名类型和公钥, 交易哈希不包含签名, 不影响执行
+func restoreTx(batch *rtypes.BlockBatch, index int) (*types.Transaction, error) {
+
+	tx := &types.Transaction{}
+	if err := types.Decode(batch.GetTxList()[index], tx); err != nil {
+		return nil, ErrBatchData
+	}
+	tx.Signature = &types.Signature{
+		Ty:     types.EncodeSignID(bls.ID, int32(batch.GetTxAddrIDList()[index])),
+		Pubkey: batch.GetPubKeyList()[index],
+	}
+	return tx, nil
+}
+
+// 和rollup合约计算区块哈希的方式一致
+func headerHash(h *types.Header) []byte {
+	return common.Sha256(types.Encode(h))
+}
+
+// RebuildResult 重建统计
+type RebuildResult struct {
+	Rounds     int64
+	Blocks     int64
+	LastHeight int64
+	// 最后一个区块分段提交未完成, 不计入Blocks
+	Pending bool
+}
+
+// Rebuild 依次读取导出文件中的提交并重建区块, 完成重建的区块按高度顺序回调exec
+func Rebuild(dr *Reader, rb *Rebuilder, exec func(block *types.Block) error) (*RebuildResult, error) {
+
+	res := &RebuildResult{}
+	for {
+		commit, err := dr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return res, err
+		}
+		blocks, err := rb.Add(commit)
+		if err != nil {
+			return res, err
+		}
+		res.Rounds++
+		for _, block := range blocks {
+			if exec != nil {
+				if err = exec(block); err != nil {
+					return res, err
+				}
+			}
+			res.Blocks++
+			res.LastHeight = block.GetHeight()
+		}
+	}
+	res.Pending = rb.Pending()
+	return res, nil
+}
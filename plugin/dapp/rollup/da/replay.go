@@ -0,0 +1,289 @@
+package da
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/executor"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	"github.com/pkg/errors"
+)
+
+// 创世区块执行时的前一个状态哈希, 与blockchain一致
+var zeroHash = make([]byte, 32)
+
+// Replayer 在独立的数据目录中重新执行重建的区块, 并和区块头的状态哈希比较
+// 只启动执行器和存储模块, blockchain模块的localdb接口由Replayer实现
+type Replayer struct {
+	cfg     *types.Chain33Config
+	q       queue.Queue
+	client  queue.Client
+	chain   queue.Client
+	store   queue.Module
+	exec    *executor.Executor
+	localDB dbm.DB
+
+	mu         sync.RWMutex
+	lastHeader *types.Header
+	stateHash  []byte
+}
+
+// NewReplayer 数据目录需要为空, cfg为平行链节点的配置
+func NewReplayer(cfg *types.Chain33Config, dataDir string) (*Replayer, error) {
+
+	entries, err := os.ReadDir(dataDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		return nil, errors.Errorf("data dir %s not empty", dataDir)
+	}
+	mcfg := cfg.GetModuleConfig()
+	mcfg.Store.DbPath = filepath.Join(dataDir, "store")
+
+	r := &Replayer{cfg: cfg, q: queue.New("rollupda")}
+	r.q.SetConfig(cfg)
+	r.localDB = dbm.NewDB("localdb", mcfg.BlockChain.Driver, dataDir, 128)
+	r.chain = r.q.Client()
+	r.chain.Sub("blockchain")
+	go r.handleChain()
+
+	r.store = store.New(cfg)
+	r.store.SetQueueClient(r.q.Client())
+	r.exec = executor.New(cfg)
+	r.exec.SetQueueClient(r.q.Client())
+	r.client = r.q.Client()
+	return r, nil
+}
+
+// LastHeader 最后执行的区块头
+func (r *Replayer) LastHeader() *types.Header {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.lastHeader
+}
+
+// ExecGenesis 执行创世区块, 需要和第一个重建区块的父哈希一致
+func (r *Replayer) ExecGenesis(genesis *types.Block) error {
+	if genesis.GetHeight() != 0 || r.LastHeader() != nil {
+		return ErrGenesis
+	}
+	return r.execBlock(genesis, zeroHash)
+}
+
+// ExecBlock 执行区块, 校验父哈希和执行后的状态哈希
+func (r *Replayer) ExecBlock(block *types.Block) error {
+
+	last := r.LastHeader()
+	if last == nil {
+		return ErrGenesis
+	}
+	if block.GetHeight() != last.GetHeight()+1 {
+		return errors.Wrapf(ErrBlockHeight, "height %d", block.GetHeight())
+	}
+	if !bytes.Equal(block.GetParentHash(), last.GetHash()) {
+		return errors.Wrapf(ErrBlockHash, "height %d", block.GetHeight())
+	}
+	r.mu.RLock()
+	prev := r.stateHash
+	r.mu.RUnlock()
+	return r.execBlock(block, prev)
+}
+
+func (r *Replayer) execBlock(block *types.Block, prevStateHash []byte) error {
+
+	receipts, err := util.ExecTx(r.client, prevStateHash, block)
+	if err != nil {
+		return err
+	}
+	kvs := make([]*types.KeyValue, 0, len(receipts.GetReceipts()))
+	rdata := make([]*types.ReceiptData, 0, len(receipts.GetReceipts()))
+	for i, receipt := range receipts.GetReceipts() {
+		// 上链的交易都是执行成功或执行失败只扣手续费的
+		if receipt.GetTy() == types.ExecErr {
+			return errors.Wrapf(ErrExecTx, "height %d tx %s", block.GetHeight(), common.ToHex(block.Txs[i].Hash()))
+		}
+		rdata = append(rdata, &types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs})
+		kvs = append(kvs, receipt.KV...)
+	}
+	kvs = util.DelDupKey(kvs)
+	stateHash, err := util.ExecKVMemSet(r.client, prevStateHash, block.GetHeight(), kvs, false, false)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(stateHash, block.GetStateHash()) {
+		if err = util.ExecKVSetRollback(r.client, stateHash); err != nil {
+			dlog.Error("execBlock rollback", "height", block.GetHeight(), "err", err)
+		}
+		return errors.Wrapf(ErrStateHash, "height %d calc %s expect %s", block.GetHeight(),
+			common.ToHex(stateHash), common.ToHex(block.GetStateHash()))
+	}
+	if err = util.ExecKVSetCommit(r.client, stateHash, false); err != nil {
+		return err
+	}
+
+	detail := &types.BlockDetail{Block: block, Receipts: rdata, KV: kvs, PrevStatusHash: prevStateHash}
+	if err = r.execLocal(detail); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	r.lastHeader = block.GetHeader(r.cfg)
+	r.stateHash = stateHash
+	r.mu.Unlock()
+	return nil
+}
+
+// 执行本地数据, 部分合约的执行依赖本地数据
+func (r *Replayer) execLocal(detail *types.BlockDetail) error {
+
+	msg := r.client.NewMessage("execs", types.EventAddBlock, detail)
+	if err := r.client.Send(msg, true); err != nil {
+		return err
+	}
+	resp, err := r.client.Wait(msg)
+	if err != nil {
+		return err
+	}
+	kvset, ok := resp.GetData().(*types.LocalDBSet)
+	if !ok {
+		return types.ErrTypeAsset
+	}
+	batch := r.localDB.NewBatch(false)
+	for _, kv := range kvset.GetKV() {
+		if kv.GetValue() == nil {
+			batch.Delete(kv.GetKey())
+			continue
+		}
+		batch.Set(kv.GetKey(), kv.GetValue())
+	}
+	return batch.Write()
+}
+
+// Close 关闭模块和数据库
+func (r *Replayer) Close() {
+	r.exec.Close()
+	r.store.Close()
+	r.chain.Close()
+	r.q.Close()
+	r.localDB.Close()
+}
+
+// 实现执行器需要的blockchain接口, 其他请求返回不支持
+func (r *Replayer) handleChain() {
+	for msg := range r.chain.Recv() {
+		switch msg.Ty {
+		case types.EventLocalGet:
+			r.localGet(msg)
+		case types.EventLocalSet:
+			r.localSet(msg)
+		case types.EventLocalNew:
+			tx := dbm.NewLocalDB(r.localDB, msg.GetData().(bool))
+			msg.Reply(r.chain.NewMessage("", types.EventLocalNew, &types.Int64{Data: common.StorePointer(tx)}))
+		case types.EventLocalClose:
+			id := msg.GetData().(*types.Int64).Data
+			_, err := common.GetPointer(id)
+			common.RemovePointer(id)
+			msg.Reply(r.chain.NewMessage("", types.EventLocalClose, err))
+		case types.EventLocalBegin, types.EventLocalCommit, types.EventLocalRollback:
+			r.localTx(msg)
+		case types.EventLocalList:
+			r.localList(msg)
+		case types.EventLocalPrefixCount:
+			count := dbm.NewListHelper(r.localDB).PrefixCount(msg.GetData().(*types.ReqKey).Key)
+			msg.Reply(r.chain.NewMessage("", types.EventLocalReplyValue, &types.Int64{Data: count}))
+		case types.EventGetLastHeader:
+			header := r.LastHeader()
+			if header == nil {
+				msg.Reply(r.chain.NewMessage("", types.EventHeader, types.ErrBlockNotFound))
+				continue
+			}
+			msg.Reply(r.chain.NewMessage("", types.EventHeader, header))
+		default:
+			msg.Reply(r.chain.NewMessage("", msg.Ty, types.ErrActionNotSupport))
+		}
+	}
+}
+
+func (r *Replayer) localGet(msg *queue.Message) {
+	keys := msg.GetData().(*types.LocalDBGet)
+	var kvdb dbm.KVDB
+	if keys.Txid != 0 {
+		tx, err := common.GetPointer(keys.Txid)
+		if err != nil {
+			msg.Reply(r.chain.NewMessage("", types.EventLocalReplyValue, err))
+			return
+		}
+		kvdb = tx.(dbm.KVDB)
+	}
+	reply := &types.LocalReplyValue{}
+	for _, key := range keys.Keys {
+		var value []byte
+		if kvdb != nil {
+			value, _ = kvdb.Get(key)
+		} else {
+			value, _ = r.localDB.Get(key)
+		}
+		reply.Values = append(reply.Values, value)
+	}
+	msg.Reply(r.chain.NewMessage("", types.EventLocalReplyValue, reply))
+}
+
+func (r *Replayer) localSet(msg *queue.Message) {
+	kvs := msg.GetData().(*types.LocalDBSet)
+	// 只允许在事务中写入
+	if kvs.Txid == 0 {
+		msg.Reply(r.chain.NewMessage("", types.EventLocalSet, types.ErrNotSetInTransaction))
+		return
+	}
+	tx, err := common.GetPointer(kvs.Txid)
+	if err != nil {
+		msg.Reply(r.chain.NewMessage("", types.EventLocalSet, err))
+		return
+	}
+	for _, kv := range kvs.KV {
+		if err = tx.(dbm.KVDB).Set(kv.Key, kv.Value); err != nil {
+			dlog.Error("localSet", "key", string(kv.Key), "err", err)
+		}
+	}
+	msg.Reply(r.chain.NewMessage("", types.EventLocalSet, nil))
+}
+
+func (r *Replayer) localTx(msg *queue.Message) {
+	tx, err := common.GetPointer(msg.GetData().(*types.Int64).Data)
+	if err == nil {
+		switch msg.Ty {
+		case types.EventLocalBegin:
+			tx.(dbm.KVDB).Begin()
+		case types.EventLocalCommit:
+			err = tx.(dbm.KVDB).Commit()
+		case types.EventLocalRollback:
+			tx.(dbm.KVDB).Rollback()
+		}
+	}
+	msg.Reply(r.chain.NewMessage("", msg.Ty, err))
+}
+
+func (r *Replayer) localList(msg *queue.Message) {
+	q := msg.GetData().(*types.LocalDBList)
+	var values [][]byte
+	if q.Txid > 0 {
+		tx, err := common.GetPointer(q.Txid)
+		if err == nil {
+			values, err = tx.(dbm.KVDB).List(q.Prefix, q.Key, q.Count, q.Direction)
+		}
+		if err != nil {
+			msg.Reply(r.chain.NewMessage("", types.EventLocalReplyValue, err))
+			return
+		}
+	} else {
+		values = dbm.NewListHelper(r.localDB).List(q.Prefix, q.Key, q.Count, q.Direction)
+	}
+	msg.Reply(r.chain.NewMessage("", types.EventLocalReplyValue, &types.LocalReplyValue{Values: values}))
+}
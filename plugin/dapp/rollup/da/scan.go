@@ -0,0 +1,202 @@
+package da
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	rtypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
+)
+
+// 单次从主链获取的区块数
+const scanBlockCount = 100
+
+// MainChain 导出需要的主链接口
+type MainChain interface {
+	LastHeight() (int64, error)
+	GetBlocks(start, end int64) ([]*types.BlockDetail, error)
+	GetRollupStatus(title string) (*rtypes.RollupStatus, error)
+	GetRoundInfo(title string, round int64) (*rtypes.CommitRoundInfo, error)
+}
+
+type grpcMainChain struct {
+	ctx    context.Context
+	client types.Chain33Client
+}
+
+// NewGrpcMainChain 基于主链grpc接口
+func NewGrpcMainChain(ctx context.Context, client types.Chain33Client) MainChain {
+	return &grpcMainChain{ctx: ctx, client: client}
+}
+
+func (g *grpcMainChain) LastHeight() (int64, error) {
+	header, err := g.client.GetLastHeader(g.ctx, &types.ReqNil{})
+	if err != nil {
+		return 0, err
+	}
+	return header.GetHeight(), nil
+}
+
+func (g *grpcMainChain) GetBlocks(start, end int64) ([]*types.BlockDetail, error) {
+	reply, err := g.client.GetBlocks(g.ctx, &types.ReqBlocks{Start: start, End: end, IsDetail: true})
+	if err != nil {
+		return nil, err
+	}
+	details := &types.BlockDetails{}
+	if err = types.Decode(reply.GetMsg(), details); err != nil {
+		return nil, err
+	}
+	return details.GetItems(), nil
+}
+
+func (g *grpcMainChain) query(funcName string, req, reply types.Message) error {
+	resp, err := g.client.QueryChain(g.ctx, &types.ChainExecutor{
+		Driver:   rtypes.RollupX,
+		FuncName: funcName,
+		Param:    types.Encode(req),
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.GetIsOk() {
+		return errors.New(string(resp.GetMsg()))
+	}
+	return types.Decode(resp.GetMsg(), reply)
+}
+
+func (g *grpcMainChain) GetRollupStatus(title string) (*rtypes.RollupStatus, error) {
+	status := &rtypes.RollupStatus{}
+	err := g.query("GetRollupStatus", &rtypes.ChainTitle{Value: title}, status)
+	return status, err
+}
+
+func (g *grpcMainChain) GetRoundInfo(title string, round int64) (*rtypes.CommitRoundInfo, error) {
+	info := &rtypes.CommitRoundInfo{}
+	err := g.query("GetCommitRoundInfo", &rtypes.ReqGetCommitRound{ChainTitle: title, CommitRound: round}, info)
+	return info, err
+}
+
+// ExportOption 导出参数
+type ExportOption struct {
+	Title string
+	// 扫描的主链高度范围, Start为0时从第一轮提交的高度开始(挑战分叉后才有记录), End为负数时到最新区块
+	Start int64
+	End   int64
+	// 每扫描完一批区块回调, 用于显示进度
+	Progress func(height int64)
+}
+
+// ExportResult 导出统计
+type ExportResult struct {
+	Start   int64
+	End     int64
+	Commits int64
+	// 被挑战回滚后重新提交, 或回滚后尚未重新提交的轮次
+	Reverted       int64
+	LastRound      int64
+	FinalizedRound int64
+}
+
+// Export 扫描主链区块中执行成功的rollup提交交易, 按主链顺序写入导出文件
+func Export(chain MainChain, opt *ExportOption, w io.Writer) (*ExportResult, error) {
+
+	status, err := chain.GetRollupStatus(opt.Title)
+	if err != nil {
+		return nil, err
+	}
+	res := &ExportResult{Start: opt.Start, End: opt.End, LastRound: status.GetCommitRound(),
+		FinalizedRound: status.GetFinalizedRound()}
+	last, err := chain.LastHeight()
+	if err != nil {
+		return nil, err
+	}
+	if res.End < 0 || res.End > last {
+		res.End = last
+	}
+	if res.Start <= 0 && status.GetCommitRound() > 0 {
+		info, err := chain.GetRoundInfo(opt.Title, 1)
+		if err == nil && info.GetCommitHeight() > 0 {
+			res.Start = info.GetCommitHeight()
+		}
+	}
+
+	dw, err := NewWriter(w)
+	if err != nil {
+		return nil, err
+	}
+	for start := res.Start; start <= res.End; start += scanBlockCount {
+		end := start + scanBlockCount - 1
+		if end > res.End {
+			end = res.End
+		}
+		details, err := chain.GetBlocks(start, end)
+		if err != nil {
+			return res, err
+		}
+		for _, detail := range details {
+			if err = exportBlock(chain, opt.Title, status, detail, dw, res); err != nil {
+				return res, err
+			}
+		}
+		if opt.Progress != nil {
+			opt.Progress(end)
+		}
+	}
+	res.Commits = dw.Commits()
+	return res, dw.Close()
+}
+
+func exportBlock(chain MainChain, title string, status *rtypes.RollupStatus, detail *types.BlockDetail,
+	dw *Writer, res *ExportResult) error {
+
+	for i, tx := range detail.GetBlock().GetTxs() {
+		cp := getCommit(tx, title)
+		if cp == nil || i >= len(detail.GetReceipts()) || detail.GetReceipts()[i].GetTy() != types.ExecOk {
+			continue
+		}
+		// 回滚后尚未重新提交的轮次
+		if cp.GetCommitRound() > status.GetCommitRound() {
+			res.Reverted++
+			continue
+		}
+		info, err := chain.GetRoundInfo(title, cp.GetCommitRound())
+		if err != nil {
+			return err
+		}
+		// 被挑战回滚, 且已重新提交, 挑战分叉后轮次信息记录了最终提交的主链高度
+		if (info.GetCommitHeight() > 0 && info.GetCommitHeight() != detail.GetBlock().GetHeight()) ||
+			(info.GetBatchHash() != "" && info.GetBatchHash() != common.ToHex(common.Sha256(types.Encode(cp.GetBatch())))) {
+			dlog.Info("exportBlock reverted commit", "round", cp.GetCommitRound(), "height", detail.GetBlock().GetHeight())
+			res.Reverted++
+			continue
+		}
+		commit := &rtypes.DACommit{
+			MainHeight: detail.GetBlock().GetHeight(),
+			TxHash:     tx.Hash(),
+			CheckPoint: cp,
+			RoundInfo:  info,
+		}
+		if err = dw.Add(commit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 解析指定平行链的提交交易
+func getCommit(tx *types.Transaction, title string) *rtypes.CheckPoint {
+
+	if string(tx.GetExecer()) != rtypes.RollupX {
+		return nil
+	}
+	action := &rtypes.RollupAction{}
+	if err := types.Decode(tx.GetPayload(), action); err != nil || action.GetTy() != rtypes.TyCommitAction {
+		return nil
+	}
+	if action.GetCommit().GetChainTitle() != title {
+		return nil
+	}
+	return action.GetCommit()
+}
@@ -0,0 +1,342 @@
+package executor
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/33cn/chain33/common"
+	drivers "github.com/33cn/chain33/system/dapp"
+	"github.com/33cn/chain33/types"
+	paratypes "github.com/33cn/plugin/plugin/dapp/paracross/types"
+	rolluptypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
+)
+
+/*
+ * 提交挑战
+ * 挑战分叉后, 每轮提交需经过challengePeriod个主链区块的挑战期, 之后才能执行该轮的跨链交易
+ * 挑战期内任何人可提交该轮的完整batch数据, 若数据与区块头或跨链交易信息不一致, 回滚该轮及之后的提交,
+ * 并将提交者的押金转给挑战者
+ * 由于提交数据不包含交易签名, 无法重新计算区块头中的交易根哈希, 挑战只校验交易数量和跨链交易信息
+ * 跨链交易先在主链执行, 平行链执行结果可由主链交易回执确定时, 和batch中的跨链交易执行结果对比
+ */
+
+// Exec_Challenge 执行挑战交易
+func (r *rollup) Exec_Challenge(ch *rolluptypes.ChallengeCommit, tx *types.Transaction, index int) (*types.Receipt, error) {
+
+	status, roundInfo, cause, err := r.checkChallenge(ch)
+	if err != nil {
+		return nil, err
+	}
+
+	title := ch.GetChainTitle()
+	execAddr := drivers.ExecAddress(string(tx.Execer))
+	receipt := &types.Receipt{Ty: types.ExecOk}
+	record := &rolluptypes.ChallengeRecord{
+		ChainTitle:    title,
+		CommitRound:   ch.GetCommitRound(),
+		Challenger:    tx.From(),
+		CommitAddr:    roundInfo.GetCommitAddr(),
+		Cause:         cause,
+		Height:        r.GetHeight(),
+		RevertedRound: status.GetCommitRound(),
+	}
+	elog.Info("Exec_Challenge", "title", title, "round", ch.GetCommitRound(),
+		"challenger", record.Challenger, "commitAddr", record.CommitAddr, "cause", cause)
+
+	// 提交者押金转给挑战者
+	if roundInfo.GetCommitBond() > 0 {
+		rep, err := r.GetCoinsAccount().ExecTransferFrozen(roundInfo.GetCommitAddr(), tx.From(), execAddr, roundInfo.GetCommitBond())
+		if err != nil {
+			elog.Error("Exec_Challenge", "title", title, "round", ch.GetCommitRound(),
+				"commitAddr", roundInfo.GetCommitAddr(), "transfer bond err", err)
+			return nil, err
+		}
+		receipt.KV = append(receipt.KV, rep.KV...)
+		receipt.Logs = append(receipt.Logs, rep.Logs...)
+		record.SlashAmount = roundInfo.GetCommitBond()
+	}
+
+	// 之后的轮次基于被挑战的数据, 一并回滚, 解冻押金
+	for round := ch.GetCommitRound() + 1; round <= status.GetCommitRound(); round++ {
+		info, err := GetRoundInfo(r.GetStateDB(), title, round)
+		if err != nil {
+			elog.Error("Exec_Challenge", "title", title, "round", round, "get round info err", err)
+			return nil, ErrGetCommitRoundInfo
+		}
+		if info.GetCommitBond() <= 0 {
+			continue
+		}
+		rep, err := r.GetCoinsAccount().ExecActive(info.GetCommitAddr(), execAddr, info.GetCommitBond())
+		if err != nil {
+			elog.Error("Exec_Challenge", "title", title, "round", round,
+				"commitAddr", info.GetCommitAddr(), "active bond err", err)
+			return nil, err
+		}
+		receipt.KV = append(receipt.KV, rep.KV...)
+		receipt.Logs = append(receipt.Logs, rep.Logs...)
+	}
+
+	// 状态回退到被挑战轮次提交前, 重置时间戳以便验证者重新提交
+	revertStatus := types.Clone(roundInfo.GetPrevStatus()).(*rolluptypes.RollupStatus)
+	revertStatus.FinalizedRound = status.GetFinalizedRound()
+	revertStatus.Timestamp = r.GetBlockTime()
+	encodeVal := types.Encode(revertStatus)
+	receipt.KV = append(receipt.KV, &types.KeyValue{
+		Key:   formatRollupStatusKey(title),
+		Value: encodeVal,
+	})
+	receipt.Logs = append(receipt.Logs, &types.ReceiptLog{
+		Ty:  rolluptypes.TyRollupStatusLog,
+		Log: encodeVal,
+	})
+
+	encodeVal = types.Encode(record)
+	receipt.KV = append(receipt.KV, &types.KeyValue{
+		Key:   formatChallengeRecordKey(title, ch.GetCommitRound()),
+		Value: encodeVal,
+	})
+	receipt.Logs = append(receipt.Logs, &types.ReceiptLog{
+		Ty:  rolluptypes.TyChallengeLog,
+		Log: encodeVal,
+	})
+	return receipt, nil
+}
+
+// 校验挑战交易, 返回欺诈原因
+func (r *rollup) checkChallenge(ch *rolluptypes.ChallengeCommit) (*rolluptypes.RollupStatus, *rolluptypes.CommitRoundInfo, string, error) {
+
+	if !r.GetAPI().GetConfig().IsDappFork(r.GetHeight(), driverName, rolluptypes.ForkRollupChallenge) {
+		return nil, nil, "", types.ErrActionNotSupport
+	}
+	title := ch.GetChainTitle()
+	if !strings.HasPrefix(title, types.ParaKeyX) {
+		return nil, nil, "", ErrChainTitle
+	}
+	if len(ch.GetBatch().GetBlockHeaders()) < 1 {
+		return nil, nil, "", ErrNullCommitData
+	}
+
+	status, err := GetRollupStatus(r.GetStateDB(), title)
+	if err != nil {
+		elog.Error("checkChallenge", "title", title, "round", ch.GetCommitRound(), "get status err", err)
+		return nil, nil, "", ErrGetRollupStatus
+	}
+	if ch.GetCommitRound() <= status.GetFinalizedRound() || ch.GetCommitRound() > status.GetCommitRound() {
+		elog.Error("checkChallenge", "title", title, "round", ch.GetCommitRound(),
+			"finalizedRound", status.GetFinalizedRound(), "commitRound", status.GetCommitRound())
+		return nil, nil, "", ErrInvalidCommitRound
+	}
+
+	roundInfo, err := GetRoundInfo(r.GetStateDB(), title, ch.GetCommitRound())
+	if err != nil {
+		elog.Error("checkChallenge", "title", title, "round", ch.GetCommitRound(), "get round info err", err)
+		return nil, nil, "", ErrGetCommitRoundInfo
+	}
+	if r.GetHeight() >= roundInfo.GetChallengeEndHeight() {
+		elog.Error("checkChallenge", "title", title, "round", ch.GetCommitRound(),
+			"height", r.GetHeight(), "challengeEndHeight", roundInfo.GetChallengeEndHeight())
+		return nil, nil, "", ErrChallengePeriodEnd
+	}
+	batchHash := common.ToHex(common.Sha256(types.Encode(ch.GetBatch())))
+	if batchHash != roundInfo.GetBatchHash() {
+		elog.Error("checkChallenge", "title", title, "round", ch.GetCommitRound(),
+			"batchHash", batchHash, "commitHash", roundInfo.GetBatchHash())
+		return nil, nil, "", ErrChallengeBatchHash
+	}
+
+	cause := verifyBatchData(title, ch.GetBatch(), roundInfo.GetFirstTxIndex())
+	if cause == "" {
+		cause, err = r.verifyCrossTxReceipts(title, ch.GetBatch())
+		if err != nil {
+			return nil, nil, "", err
+		}
+	}
+	if cause == "" {
+		return nil, nil, "", ErrNoFraudProof
+	}
+	return status, roundInfo, cause, nil
+}
+
+// VerifyBatchData 检查batch数据和区块头, 跨链交易信息是否一致, 供离线工具使用
+func VerifyBatchData(title string, batch *rolluptypes.BlockBatch, firstTxIndex int32) string {
+	return verifyBatchData(title, batch, firstTxIndex)
+}
+
+// 检查batch数据和区块头, 跨链交易信息是否一致, 返回不一致的原因
+func verifyBatchData(title string, batch *rolluptypes.BlockBatch, firstTxIndex int32) string {
+
+	txCount := len(batch.GetTxList())
+	// 精简模式不包含交易数据, 无法校验
+	if txCount == 0 {
+		return ""
+	}
+	if len(batch.GetPubKeyList()) != txCount || len(batch.GetTxAddrIDList()) != txCount {
+		return "tx pubkey or addressID count mismatch"
+	}
+
+	headers := batch.GetBlockHeaders()
+	lastHeader := headers[len(headers)-1]
+	fragIndex := int64(batch.GetBlockFragIndex())
+	if fragIndex < 0 || (fragIndex > 0 && fragIndex >= lastHeader.GetTxCount()) ||
+		(len(headers) == 1 && fragIndex > 0 && fragIndex <= int64(firstTxIndex)) {
+		return "invalid block fragment index"
+	}
+	expectCount := -int64(firstTxIndex)
+	for _, header := range headers {
+		expectCount += header.GetTxCount()
+	}
+	if fragIndex > 0 {
+		expectCount -= lastHeader.GetTxCount() - fragIndex
+	}
+	if expectCount != int64(txCount) {
+		return "tx count mismatch with block headers"
+	}
+
+	crossTxHashes := make([][]byte, 0, 8)
+	for _, data := range batch.GetTxList() {
+		tx := &types.Transaction{}
+		if err := types.Decode(data, tx); err != nil {
+			return "decode tx failed"
+		}
+		execer := string(tx.GetExecer())
+		if !types.IsParaExecName(execer) || !strings.HasPrefix(execer, title) {
+			return "tx not belong to chain title"
+		}
+		if isCrossChainTx(tx) {
+			crossTxHashes = append(crossTxHashes, tx.Hash())
+		}
+	}
+
+	if !bytes.Equal(calcCrossTxCheckHash(crossTxHashes), batch.GetCrossTxCheckHash()) {
+		return "cross tx check hash mismatch"
+	}
+	if new(big.Int).SetBytes(batch.GetCrossTxResults()).BitLen() > len(crossTxHashes) {
+		return "cross tx results exceed cross tx count"
+	}
+	return ""
+}
+
+// 对比跨链交易在主链的执行回执, 检查batch中的跨链交易执行结果, 返回不一致的原因
+// 主链执行失败的跨链交易平行链不会执行, 结果必须为失败
+// 主链先执行的跨链交易(主链资产转入及平行链资产提回), 主链执行成功后平行链只需增发资产, 结果必须为成功
+// 平行链先执行的跨链交易, 结果取决于平行链状态, 无法在主链校验
+// 跨链交易在当前区块之前未被主链打包时, 挑战交易无效, 所有节点都拒绝该挑战
+func (r *rollup) verifyCrossTxReceipts(title string, batch *rolluptypes.BlockBatch) (string, error) {
+
+	results := new(big.Int).SetBytes(batch.GetCrossTxResults())
+	crossIndex := 0
+	for _, data := range batch.GetTxList() {
+		tx := &types.Transaction{}
+		if err := types.Decode(data, tx); err != nil {
+			return "decode tx failed", nil
+		}
+		if !isCrossChainTx(tx) {
+			continue
+		}
+		success := results.Bit(crossIndex) == 1
+		crossIndex++
+		detail, err := r.GetAPI().QueryTx(&types.ReqHash{Hash: tx.Hash()})
+		if err != nil || detail.GetReceipt() == nil || detail.GetHeight() >= r.GetHeight() {
+			elog.Error("verifyCrossTxReceipts", "title", title, "txHash", common.ToHex(tx.Hash()),
+				"height", r.GetHeight(), "txHeight", detail.GetHeight(), "query tx err", err)
+			return "", ErrCrossTxNotFound
+		}
+		receipt := detail.GetReceipt()
+		if !checkReceiptExecOk(receipt) {
+			if success {
+				return "cross tx failed on main chain but result is success", nil
+			}
+			continue
+		}
+		if !success && receipt.Ty == types.ExecOk && isMainFirstCrossTx(tx) {
+			return "cross tx executed on main chain but result is failure", nil
+		}
+	}
+	return "", nil
+}
+
+// 和paracross执行器checkReceiptExecOk一致, 交易组中主链交易失败时回执为ExecPack并包含错误日志
+func checkReceiptExecOk(receipt *types.ReceiptData) bool {
+	if receipt.Ty == types.ExecOk {
+		return true
+	}
+	for _, log := range receipt.Logs {
+		if log.Ty == types.TyLogErr {
+			return false
+		}
+	}
+	return true
+}
+
+// 和paracross执行器getCrossAction一致, 主链资产转入平行链和平行链资产从主链提回都是主链先执行
+func isMainFirstCrossTx(tx *types.Transaction) bool {
+
+	var payload paratypes.ParacrossAction
+	if err := types.Decode(tx.Payload, &payload); err != nil {
+		return false
+	}
+	transfer := payload.GetCrossAssetTransfer()
+	return transfer != nil && !types.IsParaExecName(transfer.GetAssetExec())
+}
+
+// 和rollup共识过滤跨链交易的规则一致
+func isCrossChainTx(tx *types.Transaction) bool {
+
+	execer := string(tx.GetExecer())
+	if !strings.HasSuffix(execer, paratypes.ParaX) {
+		return false
+	}
+	var payload paratypes.ParacrossAction
+	if err := types.Decode(tx.Payload, &payload); err != nil {
+		return false
+	}
+	return payload.Ty == paratypes.ParacrossActionCrossAssetTransfer
+}
+
+// 和paracross执行器CalcTxHashsHash计算方式一致
+func calcCrossTxCheckHash(txHashes [][]byte) []byte {
+	if len(txHashes) == 0 {
+		return nil
+	}
+	return common.Sha256(types.Encode(&types.ReqHashes{Hashes: txHashes}))
+}
+
+// 挑战期已过的轮次确认完成, 解冻押金, current为本次提交的轮次, 尚未写入状态数据库
+func (r *rollup) finalizeRounds(title string, status *rolluptypes.RollupStatus,
+	current *rolluptypes.CommitRoundInfo, execer string) (*types.Receipt, error) {
+
+	receipt := &types.Receipt{}
+	for round := status.GetFinalizedRound() + 1; round <= current.GetCommitRound(); round++ {
+		info := current
+		if round < current.GetCommitRound() {
+			var err error
+			info, err = GetRoundInfo(r.GetStateDB(), title, round)
+			if err != nil {
+				elog.Error("finalizeRounds", "title", title, "round", round, "get round info err", err)
+				return nil, ErrGetCommitRoundInfo
+			}
+		}
+		// 分叉前提交的轮次没有挑战期, 首次分叉后提交时, 之前的轮次全部确认
+		if info.GetCommitHeight() == 0 {
+			status.FinalizedRound = current.GetCommitRound() - 1
+			round = status.FinalizedRound
+			continue
+		}
+		if info.GetChallengeEndHeight() > r.GetHeight() {
+			break
+		}
+		if info.GetCommitBond() > 0 {
+			rep, err := r.GetCoinsAccount().ExecActive(info.GetCommitAddr(), drivers.ExecAddress(execer), info.GetCommitBond())
+			if err != nil {
+				elog.Error("finalizeRounds", "title", title, "round", round,
+					"commitAddr", info.GetCommitAddr(), "active bond err", err)
+				return nil, err
+			}
+			receipt.KV = append(receipt.KV, rep.KV...)
+			receipt.Logs = append(receipt.Logs, rep.Logs...)
+		}
+		status.FinalizedRound = round
+	}
+	return receipt, nil
+}
@@ -0,0 +1,226 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/account"
+	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	drivers "github.com/33cn/chain33/system/dapp"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	paratypes "github.com/33cn/plugin/plugin/dapp/paracross/types"
+	rtypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testTitle = "user.p.test."
+
+func newTestBatch(t *testing.T) *rtypes.BlockBatch {
+
+	batch := &rtypes.BlockBatch{BlockHeaders: []*types.Header{{Height: 1, TxCount: 2}}}
+	coinsTx := &types.Transaction{Execer: []byte(testTitle + "coins"), Payload: []byte("transfer")}
+	crossTx := &types.Transaction{
+		Execer: []byte(testTitle + paratypes.ParaX),
+		Payload: types.Encode(&paratypes.ParacrossAction{
+			Ty: paratypes.ParacrossActionCrossAssetTransfer,
+		}),
+	}
+	for _, tx := range []*types.Transaction{coinsTx, crossTx} {
+		batch.TxList = append(batch.TxList, types.Encode(tx))
+		batch.PubKeyList = append(batch.PubKeyList, []byte("pub"))
+		batch.TxAddrIDList = append(batch.TxAddrIDList, 0)
+	}
+	batch.CrossTxCheckHash = calcCrossTxCheckHash([][]byte{crossTx.Hash()})
+	batch.CrossTxResults = []byte{1}
+	require.Equal(t, "", verifyBatchData(testTitle, batch, 0))
+	return batch
+}
+
+func Test_verifyBatchData(t *testing.T) {
+
+	batch := newTestBatch(t)
+	require.Equal(t, "", verifyBatchData(testTitle, &rtypes.BlockBatch{}, 0))
+	require.NotEqual(t, "", verifyBatchData("user.p.other.", batch, 0))
+	require.NotEqual(t, "", verifyBatchData(testTitle, batch, 1))
+
+	// 区块分割, firstTxIndex为上一批次的分割位置, BlockFragIndex为本批次的分割位置
+	batch.BlockFragIndex = 1
+	require.NotEqual(t, "", verifyBatchData(testTitle, batch, 0))
+	batch.BlockFragIndex = 0
+	batch.BlockHeaders[0].TxCount = 3
+	require.NotEqual(t, "", verifyBatchData(testTitle, batch, 0))
+	require.Equal(t, "", verifyBatchData(testTitle, batch, 1))
+	batch.BlockFragIndex = 2
+	require.NotEqual(t, "", verifyBatchData(testTitle, batch, 1))
+	batch.BlockHeaders[0].TxCount = 4
+	require.Equal(t, "", verifyBatchData(testTitle, batch, 0))
+	batch.BlockFragIndex = 3
+	require.Equal(t, "", verifyBatchData(testTitle, batch, 1))
+
+	batch = newTestBatch(t)
+	batch.CrossTxResults = []byte{3}
+	require.Equal(t, "cross tx results exceed cross tx count", verifyBatchData(testTitle, batch, 0))
+	batch = newTestBatch(t)
+	batch.CrossTxCheckHash = nil
+	require.Equal(t, "cross tx check hash mismatch", verifyBatchData(testTitle, batch, 0))
+	batch.TxAddrIDList = nil
+	require.Equal(t, "tx pubkey or addressID count mismatch", verifyBatchData(testTitle, batch, 0))
+}
+
+func Test_verifyCrossTxReceipts(t *testing.T) {
+
+	newCrossTx := func(assetExec string) *types.Transaction {
+		return &types.Transaction{
+			Execer: []byte(testTitle + paratypes.ParaX),
+			Payload: types.Encode(&paratypes.ParacrossAction{
+				Ty: paratypes.ParacrossActionCrossAssetTransfer,
+				Value: &paratypes.ParacrossAction_CrossAssetTransfer{
+					CrossAssetTransfer: &paratypes.CrossAssetTransfer{AssetExec: assetExec, AssetSymbol: "bty", Amount: 1},
+				},
+			}),
+		}
+	}
+	// 主链资产转入先在主链执行, 平行链资产转出先在平行链执行
+	mainFirst, paraFirst := newCrossTx("coins"), newCrossTx(testTitle+"coins")
+	batch := &rtypes.BlockBatch{}
+	for _, tx := range []*types.Transaction{mainFirst, paraFirst} {
+		batch.TxList = append(batch.TxList, types.Encode(tx))
+	}
+	errLog := &types.ReceiptData{Ty: types.ExecPack, Logs: []*types.ReceiptLog{{Ty: types.TyLogErr}}}
+	okLog := &types.ReceiptData{Ty: types.ExecOk}
+
+	check := func(results byte, mainRcpt, paraRcpt *types.ReceiptData) string {
+		r := newRollup().(*rollup)
+		api := &mocks.QueueProtocolAPI{}
+		r.SetAPI(api)
+		r.SetEnv(10, 0, 0)
+		for tx, rcpt := range map[*types.Transaction]*types.ReceiptData{mainFirst: mainRcpt, paraFirst: paraRcpt} {
+			if rcpt == nil {
+				api.On("QueryTx", &types.ReqHash{Hash: tx.Hash()}).Return(nil, types.ErrTxNotExist)
+				continue
+			}
+			api.On("QueryTx", &types.ReqHash{Hash: tx.Hash()}).Return(&types.TransactionDetail{Receipt: rcpt, Height: 9}, nil)
+		}
+		batch.CrossTxResults = []byte{results}
+		cause, err := r.verifyCrossTxReceipts(testTitle, batch)
+		if err != nil {
+			return err.Error()
+		}
+		return cause
+	}
+
+	require.Equal(t, "", check(3, okLog, okLog))
+	// 平行链先执行的交易结果无法在主链校验
+	require.Equal(t, "", check(1, okLog, okLog))
+	require.Equal(t, "cross tx executed on main chain but result is failure", check(2, okLog, okLog))
+	require.Equal(t, "cross tx failed on main chain but result is success", check(3, okLog, errLog))
+	require.Equal(t, "cross tx failed on main chain but result is success", check(1, errLog, okLog))
+	require.Equal(t, "", check(0, errLog, errLog))
+	// 主链未查询到跨链交易时, 挑战交易无效
+	require.Equal(t, ErrCrossTxNotFound.Error(), check(2, nil, okLog))
+	require.Equal(t, ErrCrossTxNotFound.Error(), check(3, okLog, nil))
+}
+
+func execReceipt(t *testing.T, state dbm.KV, receipt *types.Receipt, err error) {
+	require.Nil(t, err)
+	for _, kv := range receipt.KV {
+		require.Nil(t, state.Set(kv.Key, kv.Value))
+	}
+}
+
+func TestRollup_Challenge(t *testing.T) {
+
+	r := newRollup().(*rollup)
+	dir, state, _ := util.CreateTestDB()
+	defer util.CloseTestDB(dir, state)
+	api := &mocks.QueueProtocolAPI{}
+	r.SetAPI(api)
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	api.On("GetConfig").Return(cfg)
+	api.On("QueryTx", mock.Anything).Return(&types.TransactionDetail{Receipt: &types.ReceiptData{Ty: types.ExecOk}, Height: 1}, nil)
+	r.SetStateDB(state)
+	subcfg = subConfig{ChallengePeriod: 10, CommitBond: 100}
+	defer func() { subcfg = subConfig{} }()
+
+	execAddr := drivers.ExecAddress(driverName)
+	coins := account.NewCoinsAccount(cfg)
+	coins.SetDB(state)
+	newSignedTx := func(action string, payload types.Message, priv crypto.PrivKey) *types.Transaction {
+		tx, err := r.GetExecutorType().CreateTransaction(action, payload)
+		require.Nil(t, err)
+		tx.Execer = []byte(driverName)
+		tx.Sign(types.SECP256K1, priv)
+		return tx
+	}
+	committer, commitPriv := util.Genaddress()
+	challenger, challengePriv := util.Genaddress()
+	coins.SaveExecAccount(execAddr, &types.Account{Addr: committer, Balance: 1000})
+
+	// 第1轮正常提交, 挑战失败
+	r.SetEnv(10, 100, 0)
+	cp := &rtypes.CheckPoint{ChainTitle: testTitle, CommitRound: 1, Batch: newTestBatch(t)}
+	receipt, err := r.Exec_Commit(cp, newSignedTx(rtypes.NameCommitAction, cp, commitPriv), 0)
+	execReceipt(t, state, receipt, err)
+	info, err := GetRoundInfo(state, testTitle, 1)
+	require.Nil(t, err)
+	require.Equal(t, int64(20), info.ChallengeEndHeight)
+	require.Equal(t, int64(100), coins.LoadExecAccount(committer, execAddr).Frozen)
+
+	ch := &rtypes.ChallengeCommit{ChainTitle: testTitle, CommitRound: 1, Batch: cp.Batch}
+	_, err = r.Exec_Challenge(ch, newSignedTx(rtypes.NameChallengeAction, ch, challengePriv), 0)
+	require.Equal(t, ErrNoFraudProof, err)
+
+	// 第2轮提交错误的跨链交易结果
+	r.SetEnv(12, 120, 0)
+	fraud := newTestBatch(t)
+	fraud.BlockHeaders[0].Height = 2
+	fraud.CrossTxResults = []byte{2}
+	cp2 := &rtypes.CheckPoint{ChainTitle: testTitle, CommitRound: 2, Batch: fraud}
+	receipt, err = r.Exec_Commit(cp2, newSignedTx(rtypes.NameCommitAction, cp2, commitPriv), 0)
+	execReceipt(t, state, receipt, err)
+	require.Equal(t, int64(200), coins.LoadExecAccount(committer, execAddr).Frozen)
+
+	ch = &rtypes.ChallengeCommit{ChainTitle: testTitle, CommitRound: 2, Batch: newTestBatch(t)}
+	tx := newSignedTx(rtypes.NameChallengeAction, ch, challengePriv)
+	require.Equal(t, ErrChallengeBatchHash, r.CheckTx(tx, 0))
+	ch.Batch = fraud
+	tx = newSignedTx(rtypes.NameChallengeAction, ch, challengePriv)
+	require.Nil(t, r.CheckTx(tx, 0))
+	receipt, err = r.Exec_Challenge(ch, tx, 0)
+	execReceipt(t, state, receipt, err)
+
+	status, err := GetRollupStatus(state, testTitle)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), status.CommitRound)
+	require.Equal(t, int64(120), status.Timestamp)
+	require.Equal(t, int64(100), coins.LoadExecAccount(committer, execAddr).Frozen)
+	require.Equal(t, int64(100), coins.LoadExecAccount(challenger, execAddr).Balance)
+	record, err := r.Query_GetChallengeRecord(&rtypes.ReqGetCommitRound{ChainTitle: testTitle, CommitRound: 2})
+	require.Nil(t, err)
+	require.Equal(t, challenger, record.(*rtypes.ChallengeRecord).Challenger)
+	require.Equal(t, int64(100), record.(*rtypes.ChallengeRecord).SlashAmount)
+	_, err = r.Exec_Challenge(ch, tx, 0)
+	require.Equal(t, ErrInvalidCommitRound, err)
+
+	// 挑战期结束后不能挑战, 重新提交第2轮时第1轮确认完成并解冻押金
+	r.SetEnv(20, 200, 0)
+	ch = &rtypes.ChallengeCommit{ChainTitle: testTitle, CommitRound: 1, Batch: cp.Batch}
+	_, err = r.Exec_Challenge(ch, newSignedTx(rtypes.NameChallengeAction, ch, challengePriv), 0)
+	require.Equal(t, ErrChallengePeriodEnd, err)
+	cp2.Batch = newTestBatch(t)
+	cp2.Batch.BlockHeaders[0].Height = 2
+	receipt, err = r.Exec_Commit(cp2, newSignedTx(rtypes.NameCommitAction, cp2, commitPriv), 0)
+	execReceipt(t, state, receipt, err)
+	status, err = GetRollupStatus(state, testTitle)
+	require.Nil(t, err)
+	require.Equal(t, int64(2), status.CommitRound)
+	require.Equal(t, int64(1), status.FinalizedRound)
+	require.Equal(t, int64(100), coins.LoadExecAccount(committer, execAddr).Frozen)
+
+	reply, err := r.Query_GetRollupStatus(&rtypes.ChainTitle{Value: testTitle})
+	require.Nil(t, err)
+	require.Equal(t, int64(10), reply.(*rtypes.RollupStatus).ChallengePeriod)
+}
@@ -24,6 +24,10 @@ func (r *rollup) CheckTx(tx *types.Transaction, index int) error {
 	if action.Ty == rtypes.TyCommitAction {
 
 		err = r.checkCommit(action.GetCommit())
+	} else if action.Ty == rtypes.TyChallengeAction {
+		_, _, _, err = r.checkChallenge(action.GetChallenge())
+	} else if action.Ty == rtypes.TyUpdateValidatorAction {
+		_, _, err = r.checkUpdateValidator(action.GetUpdateValidator())
 	} else {
 		err = types.ErrActionNotSupport
 	}
@@ -99,49 +103,26 @@ func (r *rollup) checkCommit(cp *rtypes.CheckPoint) error {
 	}
 
 	// check validator
-	pubs, err := r.getValidatorNodesBlsPubs(cp.GetChainTitle())
+	valPubs, err := r.getValidatorSet(cp.GetChainTitle(), commitRound)
 	if err != nil {
 		elog.Error("checkCommit", "title", cp.GetChainTitle(), "commitRound", commitRound,
-			"getValidatorNodesBlsPubs err", err)
+			"getValidatorSet err", err)
 		return ErrGetValPubs
 	}
 
-	valPubs := make(map[string]struct{}, len(pubs))
-	for _, pub := range pubs {
-		valPubs[rtypes.FormatHexPubKey(pub)] = struct{}{}
-	}
-
-	if len(cp.GetValidatorPubs()) < len(pubs)*2/3+1 {
-		elog.Error("checkCommit", "title", cp.GetChainTitle(),
-			"commitRound", commitRound, "err", "not enough validator")
-		return ErrInvalidValidator
-	}
-	blsPubs := make([]crypto.PubKey, 0, len(cp.GetValidatorPubs()))
-	for _, pub := range cp.GetValidatorPubs() {
-		hexPub := hex.EncodeToString(pub)
-		_, valid := valPubs[hexPub]
-		if !valid {
-			elog.Error("checkCommit", "title", cp.GetChainTitle(),
-				"commitRound", commitRound, "invalid validator pub", hexPub)
-			return ErrInvalidValidator
-		}
-		blsPub, _ := blsDriver.PubKeyFromBytes(pub)
-		blsPubs = append(blsPubs, blsPub)
-	}
-	blsSig, _ := blsDriver.SignatureFromBytes(cp.GetAggregateValidatorSign())
 	signMsg := common.Sha256(types.Encode(cp.GetBatch()))
-	aggreDriver := blsDriver.(crypto.AggregateCrypto)
-	err = aggreDriver.VerifyAggregatedOne(blsPubs, signMsg, blsSig)
+	err = verifyValidatorSign(valPubs, cp.GetValidatorPubs(), signMsg, cp.GetAggregateValidatorSign())
 	if err != nil {
 		elog.Error("checkCommit", "title", cp.GetChainTitle(),
-			"commitRound", commitRound, "verify bls sig err", err)
-		return ErrInvalidValidatorSign
+			"commitRound", commitRound, "verify validator sign err", err)
+		return err
 	}
 
 	// 精简模式, 不校验交易数据
 	if len(cp.GetBatch().GetTxList()) == 0 {
 		return nil
 	}
+	aggreDriver := blsDriver.(crypto.AggregateCrypto)
 	txCount := len(cp.GetBatch().GetTxList())
 	txPubs := make([]crypto.PubKey, 0, txCount)
 	for i, tx := range cp.GetBatch().GetTxList() {
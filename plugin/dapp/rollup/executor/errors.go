@@ -13,4 +13,14 @@ var (
 	ErrInvalidValidatorSign   = errors.New("ErrInvalidValidatorSign")
 	ErrInvalidBlsPub          = errors.New("ErrInvalidBlsPub")
 	ErrInvalidTxAggregateSign = errors.New("ErrInvalidTxAggregateSign")
+	ErrGetCommitRoundInfo     = errors.New("ErrGetCommitRoundInfo")
+	ErrChallengePeriodEnd     = errors.New("ErrChallengePeriodEnd")
+	ErrChallengeBatchHash     = errors.New("ErrChallengeBatchHash")
+	ErrNoFraudProof           = errors.New("ErrNoFraudProof")
+	ErrCrossTxNotFound        = errors.New("ErrCrossTxNotFound")
+	ErrInvalidEffectiveRound  = errors.New("ErrInvalidEffectiveRound")
+	ErrValidatorUpdatePending = errors.New("ErrValidatorUpdatePending")
+	ErrValidatorExist         = errors.New("ErrValidatorExist")
+	ErrValidatorNotExist      = errors.New("ErrValidatorNotExist")
+	ErrInvalidThreshold       = errors.New("ErrInvalidThreshold")
 )
@@ -3,6 +3,7 @@ package executor
 import (
 	"github.com/33cn/chain33/common"
 	"github.com/33cn/chain33/common/merkle"
+	drivers "github.com/33cn/chain33/system/dapp"
 
 	"github.com/33cn/chain33/types"
 	rolluptypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
@@ -42,6 +43,30 @@ func (r *rollup) Exec_Commit(cp *rolluptypes.CheckPoint, tx *types.Transaction,
 	}
 	roundInfo.BlockRootHash = common.ToHex(merkle.GetMerkleRoot(blkHashes))
 
+	forkChallenge := r.GetAPI().GetConfig().IsDappFork(r.GetHeight(), driverName, rolluptypes.ForkRollupChallenge)
+	if forkChallenge {
+		roundInfo.BatchHash = common.ToHex(common.Sha256(types.Encode(cp.GetBatch())))
+		roundInfo.CommitHeight = r.GetHeight()
+		roundInfo.CommitAddr = tx.From()
+		roundInfo.FirstTxIndex = status.GetBlockFragIndex()
+		roundInfo.PrevStatus = types.Clone(status).(*rolluptypes.RollupStatus)
+		if subcfg.ChallengePeriod > 0 {
+			roundInfo.ChallengeEndHeight = r.GetHeight() + subcfg.ChallengePeriod
+			roundInfo.CommitBond = subcfg.CommitBond
+		}
+	}
+	// 挑战期内冻结提交者押金
+	if roundInfo.CommitBond > 0 {
+		rep, err := r.GetCoinsAccount().ExecFrozen(tx.From(), drivers.ExecAddress(string(tx.Execer)), roundInfo.CommitBond)
+		if err != nil {
+			elog.Error("Exec_CommitBatch", "title", cp.GetChainTitle(), "round", commitRound,
+				"addr", tx.From(), "bond", roundInfo.CommitBond, "frozen err", err)
+			return nil, err
+		}
+		receipt.KV = append(receipt.KV, rep.KV...)
+		receipt.Logs = append(receipt.Logs, rep.Logs...)
+	}
+
 	encodeVal := types.Encode(roundInfo)
 	receipt.KV = append(receipt.KV, &types.KeyValue{
 		Key:   formatCommitRoundInfoKey(cp.GetChainTitle(), commitRound),
@@ -59,6 +84,15 @@ func (r *rollup) Exec_Commit(cp *rolluptypes.CheckPoint, tx *types.Transaction,
 	status.CommitBlockHash = calcBlockHash(headers[len(headers)-1])
 	status.CommitAddr = tx.From()
 	status.CrossTxSyncedHeight = cp.CrossTxSyncedHeight
+	if forkChallenge {
+		status.BlockFragIndex = cp.GetBatch().GetBlockFragIndex()
+		rep, err := r.finalizeRounds(cp.GetChainTitle(), status, roundInfo, string(tx.Execer))
+		if err != nil {
+			return nil, err
+		}
+		receipt.KV = append(receipt.KV, rep.KV...)
+		receipt.Logs = append(receipt.Logs, rep.Logs...)
+	}
 	encodeVal = types.Encode(status)
 	receipt.KV = append(receipt.KV, &types.KeyValue{
 		Key:   formatRollupStatusKey(cp.GetChainTitle()),
@@ -22,3 +22,11 @@ func formatRollupStatusKey(title string) []byte {
 func formatCommitRoundInfoKey(title string, round int64) []byte {
 	return []byte(KeyPrefixStateDB + fmt.Sprintf("%s-roundinfo-%018d", title, round))
 }
+
+func formatChallengeRecordKey(title string, round int64) []byte {
+	return []byte(KeyPrefixStateDB + fmt.Sprintf("%s-challenge-%018d", title, round))
+}
+
+func formatValidatorSetsKey(title string) []byte {
+	return []byte(KeyPrefixStateDB + fmt.Sprintf("%s-validatorsets", title))
+}
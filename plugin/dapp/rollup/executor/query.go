@@ -11,16 +11,40 @@ func (r *rollup) Query_GetValidatorPubs(title *rtypes.ChainTitle) (types.Message
 		return nil, ErrChainTitle
 	}
 
-	blsPubs, err := r.getValidatorNodesBlsPubs(title.GetValue())
-	reply := &rtypes.ValidatorPubs{BlsPubs: blsPubs}
-	return reply, err
+	// 下一提交轮次的验证者集合
+	status, err := GetRollupStatus(r.GetStateDB(), title.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	valPubs, err := r.getValidatorSet(title.GetValue(), status.GetCommitRound()+1)
+	if err != nil {
+		return nil, err
+	}
+	reply := types.Clone(valPubs).(*rtypes.ValidatorPubs)
+	reply.Threshold = int32(rtypes.GetMinSignCount(valPubs))
+	return reply, nil
+}
+
+func (r *rollup) Query_GetValidatorSets(title *rtypes.ChainTitle) (types.Message, error) {
+
+	if title.GetValue() == "" {
+		return nil, ErrChainTitle
+	}
+	return getValidatorSets(r.GetStateDB(), title.GetValue())
 }
 
 func (r *rollup) Query_GetRollupStatus(title *rtypes.ChainTitle) (types.Message, error) {
 	if title.GetValue() == "" {
 		return nil, ErrChainTitle
 	}
-	return GetRollupStatus(r.GetStateDB(), title.GetValue())
+	status, err := GetRollupStatus(r.GetStateDB(), title.GetValue())
+	if err != nil {
+		return nil, err
+	}
+	if r.GetAPI().GetConfig().IsDappFork(r.GetHeight(), driverName, rtypes.ForkRollupChallenge) {
+		status.ChallengePeriod = subcfg.ChallengePeriod
+	}
+	return status, nil
 }
 
 func (r *rollup) Query_GetCommitRoundInfo(req *rtypes.ReqGetCommitRound) (types.Message, error) {
@@ -31,3 +55,13 @@ func (r *rollup) Query_GetCommitRoundInfo(req *rtypes.ReqGetCommitRound) (types.
 
 	return GetRoundInfo(r.GetStateDB(), req.GetChainTitle(), req.GetCommitRound())
 }
+
+func (r *rollup) Query_GetChallengeRecord(req *rtypes.ReqGetCommitRound) (types.Message, error) {
+
+	if req.GetChainTitle() == "" {
+		return nil, ErrChainTitle
+	}
+	record := &rtypes.ChallengeRecord{}
+	err := readStateDB(r.GetStateDB(), formatChallengeRecordKey(req.GetChainTitle(), req.GetCommitRound()), record)
+	return record, err
+}
@@ -18,8 +18,12 @@ func TestRollup_Query_GetValidatorPubs(t *testing.T) {
 	funcName := "GetValidatorPubs"
 	_, err := r.Query(funcName, nil)
 	require.Equal(t, ErrChainTitle, err)
+	dir, state, _ := util.CreateTestDB()
+	defer util.CloseTestDB(dir, state)
 	api := &mocks.QueueProtocolAPI{}
 	r.SetAPI(api)
+	api.On("GetConfig").Return(types.NewChain33Config(types.GetDefaultCfgstring()))
+	r.SetStateDB(state)
 
 	api.On("Query", mock.Anything, "GetNodeGroupStatus", mock.Anything).Return(nil, types.ErrActionNotSupport).Once()
 	_, err = r.Query(funcName, types.Encode(&rtypes.ChainTitle{Value: "user.p.test"}))
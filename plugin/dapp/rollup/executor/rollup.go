@@ -22,8 +22,20 @@ var (
 var driverName = rolluptypes.RollupX
 var blsDriver, _ = crypto.Load(bls.Name, -1)
 
+type subConfig struct {
+	// ChallengePeriod 提交后的挑战期主链区块数, 为0时不启用挑战
+	ChallengePeriod int64 `json:"challengePeriod"`
+	// CommitBond 挑战期内冻结的提交者押金, 挑战成功后转给挑战者
+	CommitBond int64 `json:"commitBond"`
+}
+
+var subcfg subConfig
+
 // Init register dapp
 func Init(name string, cfg *types.Chain33Config, sub []byte) {
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
 	drivers.Register(cfg, GetName(), newRollup, cfg.GetDappFork(driverName, "Enable"))
 	InitExecType()
 }
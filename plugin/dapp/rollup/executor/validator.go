@@ -0,0 +1,211 @@
+package executor
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	rtypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
+)
+
+/*
+ * 验证者集合链上治理
+ * 未变更过的平行链, 验证者集合为平行链共识节点组的bls公钥
+ * 变更需要当前验证者集合聚合签名, 并在指定的提交轮次生效, 同一时间只能有一个待生效的变更
+ */
+
+// Exec_UpdateValidator 执行验证者集合变更
+func (r *rollup) Exec_UpdateValidator(update *rtypes.UpdateValidator, tx *types.Transaction, index int) (*types.Receipt, error) {
+
+	sets, newSet, err := r.checkUpdateValidator(update)
+	if err != nil {
+		return nil, err
+	}
+	elog.Info("Exec_UpdateValidator", "title", update.GetChainTitle(), "effectiveRound", newSet.GetEffectiveRound(),
+		"validators", len(newSet.GetBlsPubs()), "threshold", newSet.GetThreshold())
+
+	receipt := &types.Receipt{Ty: types.ExecOk}
+	receipt.KV = append(receipt.KV, &types.KeyValue{
+		Key:   formatValidatorSetsKey(update.GetChainTitle()),
+		Value: types.Encode(sets),
+	})
+	receipt.Logs = append(receipt.Logs, &types.ReceiptLog{
+		Ty:  rtypes.TyUpdateValidatorLog,
+		Log: types.Encode(newSet),
+	})
+	return receipt, nil
+}
+
+// 校验变更, 返回变更后的验证者集合列表及新集合
+func (r *rollup) checkUpdateValidator(update *rtypes.UpdateValidator) (*rtypes.ValidatorSets, *rtypes.ValidatorPubs, error) {
+
+	if !r.GetAPI().GetConfig().IsDappFork(r.GetHeight(), driverName, rtypes.ForkRollupValidator) {
+		return nil, nil, types.ErrActionNotSupport
+	}
+	title := update.GetChainTitle()
+	if !strings.HasPrefix(title, types.ParaKeyX) {
+		return nil, nil, ErrChainTitle
+	}
+	status, err := GetRollupStatus(r.GetStateDB(), title)
+	if err != nil {
+		elog.Error("checkUpdateValidator", "title", title, "get status err", err)
+		return nil, nil, ErrGetRollupStatus
+	}
+	sets, err := getValidatorSets(r.GetStateDB(), title)
+	if err != nil {
+		elog.Error("checkUpdateValidator", "title", title, "get validator sets err", err)
+		return nil, nil, err
+	}
+
+	// 只能在之后的轮次生效, 且存在待生效的变更时不能再次变更
+	nextRound := status.GetCommitRound() + 1
+	if update.GetEffectiveRound() < nextRound {
+		elog.Error("checkUpdateValidator", "title", title, "effectiveRound", update.GetEffectiveRound(),
+			"nextRound", nextRound)
+		return nil, nil, ErrInvalidEffectiveRound
+	}
+	if len(sets.GetSets()) > 0 && sets.GetSets()[len(sets.GetSets())-1].GetEffectiveRound() >= nextRound {
+		return nil, nil, ErrValidatorUpdatePending
+	}
+
+	curr, err := r.getValidatorSet(title, nextRound)
+	if err != nil {
+		return nil, nil, ErrGetValPubs
+	}
+	if err = verifyValidatorSign(curr, update.GetValidatorPubs(), rtypes.CalcUpdateValidatorMsg(update), update.GetAggregateSign()); err != nil {
+		elog.Error("checkUpdateValidator", "title", title, "effectiveRound", update.GetEffectiveRound(), "err", err)
+		return nil, nil, err
+	}
+
+	newSet, err := applyValidatorUpdate(curr, update)
+	if err != nil {
+		elog.Error("checkUpdateValidator", "title", title, "effectiveRound", update.GetEffectiveRound(), "err", err)
+		return nil, nil, err
+	}
+
+	// 只保留当前生效的集合和新集合
+	newSets := &rtypes.ValidatorSets{}
+	if len(sets.GetSets()) > 0 {
+		newSets.Sets = append(newSets.Sets, sets.GetSets()[len(sets.GetSets())-1])
+	}
+	newSets.Sets = append(newSets.Sets, newSet)
+	return newSets, newSet, nil
+}
+
+// 检查验证者签名数量满足要求, 并验证聚合签名
+func verifyValidatorSign(valPubs *rtypes.ValidatorPubs, signPubs [][]byte, msg, sign []byte) error {
+
+	valMap := make(map[string]struct{}, len(valPubs.GetBlsPubs()))
+	for _, pub := range valPubs.GetBlsPubs() {
+		valMap[strings.ToLower(rtypes.FormatHexPubKey(pub))] = struct{}{}
+	}
+	if len(signPubs) < rtypes.GetMinSignCount(valPubs) {
+		return ErrInvalidValidator
+	}
+	blsPubs := make([]crypto.PubKey, 0, len(signPubs))
+	for _, pub := range signPubs {
+		hexPub := hex.EncodeToString(pub)
+		if _, ok := valMap[hexPub]; !ok {
+			return ErrInvalidValidator
+		}
+		// 避免重复计数
+		delete(valMap, hexPub)
+		blsPub, err := blsDriver.PubKeyFromBytes(pub)
+		if err != nil {
+			return ErrInvalidBlsPub
+		}
+		blsPubs = append(blsPubs, blsPub)
+	}
+	blsSig, err := blsDriver.SignatureFromBytes(sign)
+	if err != nil {
+		return ErrInvalidValidatorSign
+	}
+	if err = blsDriver.(crypto.AggregateCrypto).VerifyAggregatedOne(blsPubs, msg, blsSig); err != nil {
+		return ErrInvalidValidatorSign
+	}
+	return nil
+}
+
+func applyValidatorUpdate(curr *rtypes.ValidatorPubs, update *rtypes.UpdateValidator) (*rtypes.ValidatorPubs, error) {
+
+	removed := make(map[string]bool, len(update.GetRemovePubs()))
+	for _, pub := range update.GetRemovePubs() {
+		removed[strings.ToLower(rtypes.FormatHexPubKey(pub))] = false
+	}
+	newSet := &rtypes.ValidatorPubs{
+		Threshold:      update.GetThreshold(),
+		EffectiveRound: update.GetEffectiveRound(),
+	}
+	exist := make(map[string]struct{}, len(curr.GetBlsPubs())+len(update.GetAddPubs()))
+	for _, pub := range curr.GetBlsPubs() {
+		pub = strings.ToLower(rtypes.FormatHexPubKey(pub))
+		if _, ok := removed[pub]; ok {
+			removed[pub] = true
+			continue
+		}
+		exist[pub] = struct{}{}
+		newSet.BlsPubs = append(newSet.BlsPubs, pub)
+	}
+	for _, ok := range removed {
+		if !ok {
+			return nil, ErrValidatorNotExist
+		}
+	}
+	for _, pub := range update.GetAddPubs() {
+		pub = strings.ToLower(rtypes.FormatHexPubKey(pub))
+		if _, ok := exist[pub]; ok {
+			return nil, ErrValidatorExist
+		}
+		pubBytes, err := common.FromHex(pub)
+		if err != nil {
+			return nil, ErrInvalidBlsPub
+		}
+		if _, err = blsDriver.PubKeyFromBytes(pubBytes); err != nil {
+			return nil, ErrInvalidBlsPub
+		}
+		exist[pub] = struct{}{}
+		newSet.BlsPubs = append(newSet.BlsPubs, pub)
+	}
+
+	// 门限需超过半数, 避免同一轮次的不同提交都能获得足够签名
+	count := len(newSet.GetBlsPubs())
+	threshold := int(newSet.GetThreshold())
+	if count == 0 || threshold < 0 || threshold > count || (threshold > 0 && threshold <= count/2) {
+		return nil, ErrInvalidThreshold
+	}
+	return newSet, nil
+}
+
+func getValidatorSets(kv db.KV, title string) (*rtypes.ValidatorSets, error) {
+
+	sets := &rtypes.ValidatorSets{}
+	err := readStateDB(kv, formatValidatorSetsKey(title), sets)
+	if err == types.ErrNotFound {
+		return sets, nil
+	}
+	return sets, err
+}
+
+// 获取指定提交轮次的验证者集合
+func (r *rollup) getValidatorSet(title string, round int64) (*rtypes.ValidatorPubs, error) {
+
+	sets, err := getValidatorSets(r.GetStateDB(), title)
+	if err != nil {
+		elog.Error("getValidatorSet", "title", title, "round", round, "err", err)
+		return nil, err
+	}
+	// 优先使用治理变更后的集合
+	for i := len(sets.GetSets()) - 1; i >= 0; i-- {
+		if sets.GetSets()[i].GetEffectiveRound() <= round {
+			return sets.GetSets()[i], nil
+		}
+	}
+	pubs, err := r.getValidatorNodesBlsPubs(title)
+	if err != nil {
+		return nil, err
+	}
+	return &rtypes.ValidatorPubs{BlsPubs: pubs}, nil
+}
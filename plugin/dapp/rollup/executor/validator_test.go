@@ -0,0 +1,138 @@
+package executor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	paratypes "github.com/33cn/plugin/plugin/dapp/paracross/types"
+	rtypes "github.com/33cn/plugin/plugin/dapp/rollup/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func signUpdate(t *testing.T, update *rtypes.UpdateValidator, privs []crypto.PrivKey) {
+
+	msg := rtypes.CalcUpdateValidatorMsg(update)
+	update.ValidatorPubs = nil
+	sigs := make([]crypto.Signature, 0, len(privs))
+	for _, priv := range privs {
+		update.ValidatorPubs = append(update.ValidatorPubs, priv.PubKey().Bytes())
+		sigs = append(sigs, priv.Sign(msg))
+	}
+	aggSig, err := blsDriver.(crypto.AggregateCrypto).Aggregate(sigs)
+	require.Nil(t, err)
+	update.AggregateSign = aggSig.Bytes()
+}
+
+func TestRollup_UpdateValidator(t *testing.T) {
+
+	r := newRollup().(*rollup)
+	dir, state, _ := util.CreateTestDB()
+	defer util.CloseTestDB(dir, state)
+	api := &mocks.QueueProtocolAPI{}
+	r.SetAPI(api)
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	api.On("GetConfig").Return(cfg)
+	r.SetStateDB(state)
+	r.SetEnv(10, 100, 0)
+
+	var privs []crypto.PrivKey
+	var blsPubKeys []string
+	for i := 0; i < 5; i++ {
+		priv, _ := blsDriver.GenKey()
+		privs = append(privs, priv)
+		blsPubKeys = append(blsPubKeys, common.ToHex(priv.PubKey().Bytes()))
+	}
+	nodeStatus := &paratypes.ParaNodeGroupStatus{BlsPubKeys: strings.Join(blsPubKeys[:4], ",")}
+	api.On("Query", mock.Anything, "GetNodeGroupStatus", mock.Anything).Return(nodeStatus, nil)
+	_ = state.Set(formatRollupStatusKey(testTitle), types.Encode(&rtypes.RollupStatus{CommitRound: 1}))
+
+	update := &rtypes.UpdateValidator{
+		ChainTitle:     testTitle,
+		AddPubs:        []string{blsPubKeys[4]},
+		RemovePubs:     []string{blsPubKeys[0]},
+		Threshold:      3,
+		EffectiveRound: 1,
+	}
+	signUpdate(t, update, privs[:3])
+	_, _, err := r.checkUpdateValidator(update)
+	require.Equal(t, ErrInvalidEffectiveRound, err)
+	update.EffectiveRound = 3
+	_, _, err = r.checkUpdateValidator(update)
+	require.Equal(t, ErrInvalidValidatorSign, err)
+
+	// 签名数量不足, 或包含非验证者签名
+	signUpdate(t, update, privs[:2])
+	_, _, err = r.checkUpdateValidator(update)
+	require.Equal(t, ErrInvalidValidator, err)
+	signUpdate(t, update, privs[2:5])
+	_, _, err = r.checkUpdateValidator(update)
+	require.Equal(t, ErrInvalidValidator, err)
+
+	// 门限需超过半数
+	update.Threshold = 2
+	signUpdate(t, update, privs[:3])
+	_, _, err = r.checkUpdateValidator(update)
+	require.Equal(t, ErrInvalidThreshold, err)
+	update.Threshold = 0
+	update.RemovePubs = []string{blsPubKeys[4]}
+	signUpdate(t, update, privs[:3])
+	_, _, err = r.checkUpdateValidator(update)
+	require.Equal(t, ErrValidatorNotExist, err)
+	update.RemovePubs = []string{blsPubKeys[0]}
+	update.AddPubs = []string{blsPubKeys[1]}
+	signUpdate(t, update, privs[:3])
+	_, _, err = r.checkUpdateValidator(update)
+	require.Equal(t, ErrValidatorExist, err)
+
+	update.AddPubs = []string{blsPubKeys[4]}
+	update.Threshold = 3
+	signUpdate(t, update, privs[:3])
+	tx, err := r.GetExecutorType().CreateTransaction(rtypes.NameUpdateValidatorAction, update)
+	require.Nil(t, err)
+	require.Nil(t, r.CheckTx(tx, 0))
+	receipt, err := r.Exec_UpdateValidator(update, tx, 0)
+	execReceipt(t, state, receipt, err)
+
+	// 生效前使用原有集合, 且不能再次变更
+	valPubs, err := r.getValidatorSet(testTitle, 2)
+	require.Nil(t, err)
+	require.Equal(t, blsPubKeys[:4], valPubs.GetBlsPubs())
+	_, _, err = r.checkUpdateValidator(update)
+	require.Equal(t, ErrValidatorUpdatePending, err)
+
+	valPubs, err = r.getValidatorSet(testTitle, 3)
+	require.Nil(t, err)
+	require.Equal(t, 4, len(valPubs.GetBlsPubs()))
+	require.Equal(t, 3, rtypes.GetMinSignCount(valPubs))
+	require.Equal(t, rtypes.FormatHexPubKey(blsPubKeys[4]), valPubs.GetBlsPubs()[3])
+
+	_ = state.Set(formatRollupStatusKey(testTitle), types.Encode(&rtypes.RollupStatus{CommitRound: 2}))
+	reply, err := r.Query_GetValidatorPubs(&rtypes.ChainTitle{Value: testTitle})
+	require.Nil(t, err)
+	require.Equal(t, int64(3), reply.(*rtypes.ValidatorPubs).EffectiveRound)
+	require.Equal(t, int32(3), reply.(*rtypes.ValidatorPubs).Threshold)
+
+	// 新集合生效后, 需由新集合签名变更
+	_ = state.Set(formatRollupStatusKey(testTitle), types.Encode(&rtypes.RollupStatus{CommitRound: 3}))
+	update = &rtypes.UpdateValidator{
+		ChainTitle:     testTitle,
+		RemovePubs:     []string{blsPubKeys[4]},
+		EffectiveRound: 5,
+	}
+	signUpdate(t, update, privs[:3])
+	_, _, err = r.checkUpdateValidator(update)
+	require.Equal(t, ErrInvalidValidator, err)
+	signUpdate(t, update, privs[2:5])
+	receipt, err = r.Exec_UpdateValidator(update, tx, 0)
+	execReceipt(t, state, receipt, err)
+	sets, err := r.Query_GetValidatorSets(&rtypes.ChainTitle{Value: testTitle})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(sets.(*rtypes.ValidatorSets).GetSets()))
+	require.Equal(t, int64(5), sets.(*rtypes.ValidatorSets).GetSets()[1].EffectiveRound)
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strings"
 
+	"github.com/33cn/chain33/common"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/types"
 )
@@ -24,8 +25,12 @@ const (
 const (
 	TyUnknowAction = iota + 100
 	TyCommitAction
+	TyChallengeAction
+	TyUpdateValidatorAction
 
-	NameCommitAction = "Commit"
+	NameCommitAction          = "Commit"
+	NameChallengeAction       = "Challenge"
+	NameUpdateValidatorAction = "UpdateValidator"
 )
 
 // log类型id值
@@ -33,9 +38,20 @@ const (
 	TyUnknownLog = iota + 100
 	TyCommitRoundInfoLog
 	TyRollupStatusLog
+	TyChallengeLog
+	TyUpdateValidatorLog
 
 	NameCommitRoundInfoLog = "CommitRoundInfoLog"
 	NameRollupStatusLog    = "RollupStatusLog"
+	NameChallengeLog       = "ChallengeLog"
+	NameUpdateValidatorLog = "UpdateValidatorLog"
+)
+
+const (
+	// ForkRollupChallenge 启用提交挑战期, 分叉后提交的轮次需经过挑战期才能执行跨链交易
+	ForkRollupChallenge = "ForkRollupChallenge"
+	// ForkRollupValidator 启用链上治理变更验证者集合
+	ForkRollupValidator = "ForkRollupValidator"
 )
 
 var (
@@ -43,12 +59,16 @@ var (
 	RollupX = "rollup"
 	//定义actionMap
 	actionMap = map[string]int32{
-		NameCommitAction: TyCommitAction,
+		NameCommitAction:          TyCommitAction,
+		NameChallengeAction:       TyChallengeAction,
+		NameUpdateValidatorAction: TyUpdateValidatorAction,
 	}
 	//定义log的id和具体log类型及名称，填入具体自定义log类型
 	logMap = map[int64]*types.LogInfo{
 		TyCommitRoundInfoLog: {Ty: reflect.TypeOf(CommitRoundInfo{}), Name: NameCommitRoundInfoLog},
 		TyRollupStatusLog:    {Ty: reflect.TypeOf(RollupStatus{}), Name: NameRollupStatusLog},
+		TyChallengeLog:       {Ty: reflect.TypeOf(ChallengeRecord{}), Name: NameChallengeLog},
+		TyUpdateValidatorLog: {Ty: reflect.TypeOf(ValidatorPubs{}), Name: NameUpdateValidatorLog},
 	}
 	tlog = log.New("module", "rollup.types")
 )
@@ -64,6 +84,8 @@ func init() {
 // InitFork defines register fork
 func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(RollupX, "Enable", 0)
+	cfg.RegisterDappFork(RollupX, ForkRollupChallenge, 0)
+	cfg.RegisterDappFork(RollupX, ForkRollupValidator, 0)
 }
 
 // InitExecutor defines register executor
@@ -104,3 +126,23 @@ func FormatHexPubKey(pubKey string) string {
 	}
 	return pubKey
 }
+
+// GetMinSignCount 提交需要的最少验证者签名数量
+func GetMinSignCount(valPubs *ValidatorPubs) int {
+	if valPubs.GetThreshold() > 0 {
+		return int(valPubs.GetThreshold())
+	}
+	return len(valPubs.GetBlsPubs())*2/3 + 1
+}
+
+// CalcUpdateValidatorMsg 验证者集合变更的签名数据
+func CalcUpdateValidatorMsg(update *UpdateValidator) []byte {
+	msg := &UpdateValidator{
+		ChainTitle:     update.GetChainTitle(),
+		AddPubs:        update.GetAddPubs(),
+		RemovePubs:     update.GetRemovePubs(),
+		Threshold:      update.GetThreshold(),
+		EffectiveRound: update.GetEffectiveRound(),
+	}
+	return common.Sha256(types.Encode(msg))
+}
@@ -33,6 +33,8 @@ type RollupAction struct {
 	Ty int32 `protobuf:"varint,1,opt,name=ty,proto3" json:"ty,omitempty"`
 	// Types that are assignable to Value:
 	//	*RollupAction_Commit
+	//	*RollupAction_Challenge
+	//	*RollupAction_UpdateValidator
 	Value isRollupAction_Value `protobuf_oneof:"value"`
 }
 
@@ -89,6 +91,20 @@ func (x *RollupAction) GetCommit() *CheckPoint {
 	return nil
 }
 
+func (x *RollupAction) GetChallenge() *ChallengeCommit {
+	if x, ok := x.GetValue().(*RollupAction_Challenge); ok {
+		return x.Challenge
+	}
+	return nil
+}
+
+func (x *RollupAction) GetUpdateValidator() *UpdateValidator {
+	if x, ok := x.GetValue().(*RollupAction_UpdateValidator); ok {
+		return x.UpdateValidator
+	}
+	return nil
+}
+
 type isRollupAction_Value interface {
 	isRollupAction_Value()
 }
@@ -97,8 +113,20 @@ type RollupAction_Commit struct {
 	Commit *CheckPoint `protobuf:"bytes,2,opt,name=commit,proto3,oneof"`
 }
 
+type RollupAction_Challenge struct {
+	Challenge *ChallengeCommit `protobuf:"bytes,3,opt,name=challenge,proto3,oneof"`
+}
+
+type RollupAction_UpdateValidator struct {
+	UpdateValidator *UpdateValidator `protobuf:"bytes,4,opt,name=updateValidator,proto3,oneof"`
+}
+
 func (*RollupAction_Commit) isRollupAction_Value() {}
 
+func (*RollupAction_Challenge) isRollupAction_Value() {}
+
+func (*RollupAction_UpdateValidator) isRollupAction_Value() {}
+
 type BlockBatch struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -377,6 +405,10 @@ type RollupStatus struct {
 	CommitAddr      string `protobuf:"bytes,6,opt,name=commitAddr,proto3" json:"commitAddr,omitempty"`
 	// main chain block height
 	CrossTxSyncedHeight int64 `protobuf:"varint,7,opt,name=crossTxSyncedHeight,proto3" json:"crossTxSyncedHeight,omitempty"`
+	// 已过挑战期的最大轮次
+	FinalizedRound int64 `protobuf:"varint,8,opt,name=finalizedRound,proto3" json:"finalizedRound,omitempty"`
+	// 挑战期主链区块数, 查询时按执行器配置填充, 不上链
+	ChallengePeriod int64 `protobuf:"varint,9,opt,name=challengePeriod,proto3" json:"challengePeriod,omitempty"`
 }
 
 func (x *RollupStatus) Reset() {
@@ -460,6 +492,20 @@ func (x *RollupStatus) GetCrossTxSyncedHeight() int64 {
 	return 0
 }
 
+func (x *RollupStatus) GetFinalizedRound() int64 {
+	if x != nil {
+		return x.FinalizedRound
+	}
+	return 0
+}
+
+func (x *RollupStatus) GetChallengePeriod() int64 {
+	if x != nil {
+		return x.ChallengePeriod
+	}
+	return 0
+}
+
 type CommitRoundInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -472,6 +518,14 @@ type CommitRoundInfo struct {
 	CrossTxCheckHash string `protobuf:"bytes,5,opt,name=crossTxCheckHash,proto3" json:"crossTxCheckHash,omitempty"`
 	CrossTxResults   string `protobuf:"bytes,6,opt,name=crossTxResults,proto3" json:"crossTxResults,omitempty"`
 	BlockRootHash    string `protobuf:"bytes,7,opt,name=blockRootHash,proto3" json:"blockRootHash,omitempty"`
+	// 以下字段在挑战分叉后记录, 用于挑战时校验数据及回滚
+	BatchHash          string        `protobuf:"bytes,8,opt,name=batchHash,proto3" json:"batchHash,omitempty"`
+	CommitHeight       int64         `protobuf:"varint,9,opt,name=commitHeight,proto3" json:"commitHeight,omitempty"`
+	ChallengeEndHeight int64         `protobuf:"varint,10,opt,name=challengeEndHeight,proto3" json:"challengeEndHeight,omitempty"`
+	CommitAddr         string        `protobuf:"bytes,11,opt,name=commitAddr,proto3" json:"commitAddr,omitempty"`
+	CommitBond         int64         `protobuf:"varint,12,opt,name=commitBond,proto3" json:"commitBond,omitempty"`
+	FirstTxIndex       int32         `protobuf:"varint,13,opt,name=firstTxIndex,proto3" json:"firstTxIndex,omitempty"`
+	PrevStatus         *RollupStatus `protobuf:"bytes,14,opt,name=prevStatus,proto3" json:"prevStatus,omitempty"`
 }
 
 func (x *CommitRoundInfo) Reset() {
@@ -555,6 +609,223 @@ func (x *CommitRoundInfo) GetBlockRootHash() string {
 	return ""
 }
 
+func (x *CommitRoundInfo) GetBatchHash() string {
+	if x != nil {
+		return x.BatchHash
+	}
+	return ""
+}
+
+func (x *CommitRoundInfo) GetCommitHeight() int64 {
+	if x != nil {
+		return x.CommitHeight
+	}
+	return 0
+}
+
+func (x *CommitRoundInfo) GetChallengeEndHeight() int64 {
+	if x != nil {
+		return x.ChallengeEndHeight
+	}
+	return 0
+}
+
+func (x *CommitRoundInfo) GetCommitAddr() string {
+	if x != nil {
+		return x.CommitAddr
+	}
+	return ""
+}
+
+func (x *CommitRoundInfo) GetCommitBond() int64 {
+	if x != nil {
+		return x.CommitBond
+	}
+	return 0
+}
+
+func (x *CommitRoundInfo) GetFirstTxIndex() int32 {
+	if x != nil {
+		return x.FirstTxIndex
+	}
+	return 0
+}
+
+func (x *CommitRoundInfo) GetPrevStatus() *RollupStatus {
+	if x != nil {
+		return x.PrevStatus
+	}
+	return nil
+}
+
+// 挑战已提交的轮次, batch需和提交时的数据一致
+type ChallengeCommit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainTitle  string      `protobuf:"bytes,1,opt,name=chainTitle,proto3" json:"chainTitle,omitempty"`
+	CommitRound int64       `protobuf:"varint,2,opt,name=commitRound,proto3" json:"commitRound,omitempty"`
+	Batch       *BlockBatch `protobuf:"bytes,3,opt,name=batch,proto3" json:"batch,omitempty"`
+}
+
+func (x *ChallengeCommit) Reset() {
+	*x = ChallengeCommit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rollup_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChallengeCommit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeCommit) ProtoMessage() {}
+
+func (x *ChallengeCommit) ProtoReflect() protoreflect.Message {
+	mi := &file_rollup_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeCommit.ProtoReflect.Descriptor instead.
+func (*ChallengeCommit) Descriptor() ([]byte, []int) {
+	return file_rollup_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ChallengeCommit) GetChainTitle() string {
+	if x != nil {
+		return x.ChainTitle
+	}
+	return ""
+}
+
+func (x *ChallengeCommit) GetCommitRound() int64 {
+	if x != nil {
+		return x.CommitRound
+	}
+	return 0
+}
+
+func (x *ChallengeCommit) GetBatch() *BlockBatch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+type ChallengeRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainTitle  string `protobuf:"bytes,1,opt,name=chainTitle,proto3" json:"chainTitle,omitempty"`
+	CommitRound int64  `protobuf:"varint,2,opt,name=commitRound,proto3" json:"commitRound,omitempty"`
+	Challenger  string `protobuf:"bytes,3,opt,name=challenger,proto3" json:"challenger,omitempty"`
+	CommitAddr  string `protobuf:"bytes,4,opt,name=commitAddr,proto3" json:"commitAddr,omitempty"`
+	Cause       string `protobuf:"bytes,5,opt,name=cause,proto3" json:"cause,omitempty"`
+	SlashAmount int64  `protobuf:"varint,6,opt,name=slashAmount,proto3" json:"slashAmount,omitempty"`
+	Height      int64  `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	// 回滚前的最新提交轮次, 从commitRound开始的轮次都被回滚
+	RevertedRound int64 `protobuf:"varint,8,opt,name=revertedRound,proto3" json:"revertedRound,omitempty"`
+}
+
+func (x *ChallengeRecord) Reset() {
+	*x = ChallengeRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rollup_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChallengeRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeRecord) ProtoMessage() {}
+
+func (x *ChallengeRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_rollup_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeRecord.ProtoReflect.Descriptor instead.
+func (*ChallengeRecord) Descriptor() ([]byte, []int) {
+	return file_rollup_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ChallengeRecord) GetChainTitle() string {
+	if x != nil {
+		return x.ChainTitle
+	}
+	return ""
+}
+
+func (x *ChallengeRecord) GetCommitRound() int64 {
+	if x != nil {
+		return x.CommitRound
+	}
+	return 0
+}
+
+func (x *ChallengeRecord) GetChallenger() string {
+	if x != nil {
+		return x.Challenger
+	}
+	return ""
+}
+
+func (x *ChallengeRecord) GetCommitAddr() string {
+	if x != nil {
+		return x.CommitAddr
+	}
+	return ""
+}
+
+func (x *ChallengeRecord) GetCause() string {
+	if x != nil {
+		return x.Cause
+	}
+	return ""
+}
+
+func (x *ChallengeRecord) GetSlashAmount() int64 {
+	if x != nil {
+		return x.SlashAmount
+	}
+	return 0
+}
+
+func (x *ChallengeRecord) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ChallengeRecord) GetRevertedRound() int64 {
+	if x != nil {
+		return x.RevertedRound
+	}
+	return 0
+}
+
 type ReqGetCommitRound struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -567,7 +838,7 @@ type ReqGetCommitRound struct {
 func (x *ReqGetCommitRound) Reset() {
 	*x = ReqGetCommitRound{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rollup_proto_msgTypes[6]
+		mi := &file_rollup_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -580,7 +851,7 @@ func (x *ReqGetCommitRound) String() string {
 func (*ReqGetCommitRound) ProtoMessage() {}
 
 func (x *ReqGetCommitRound) ProtoReflect() protoreflect.Message {
-	mi := &file_rollup_proto_msgTypes[6]
+	mi := &file_rollup_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -593,7 +864,7 @@ func (x *ReqGetCommitRound) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReqGetCommitRound.ProtoReflect.Descriptor instead.
 func (*ReqGetCommitRound) Descriptor() ([]byte, []int) {
-	return file_rollup_proto_rawDescGZIP(), []int{6}
+	return file_rollup_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ReqGetCommitRound) GetCommitRound() int64 {
@@ -621,7 +892,7 @@ type ChainTitle struct {
 func (x *ChainTitle) Reset() {
 	*x = ChainTitle{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rollup_proto_msgTypes[7]
+		mi := &file_rollup_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -634,7 +905,7 @@ func (x *ChainTitle) String() string {
 func (*ChainTitle) ProtoMessage() {}
 
 func (x *ChainTitle) ProtoReflect() protoreflect.Message {
-	mi := &file_rollup_proto_msgTypes[7]
+	mi := &file_rollup_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -647,7 +918,7 @@ func (x *ChainTitle) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChainTitle.ProtoReflect.Descriptor instead.
 func (*ChainTitle) Descriptor() ([]byte, []int) {
-	return file_rollup_proto_rawDescGZIP(), []int{7}
+	return file_rollup_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ChainTitle) GetValue() string {
@@ -663,12 +934,16 @@ type ValidatorPubs struct {
 	unknownFields protoimpl.UnknownFields
 
 	BlsPubs []string `protobuf:"bytes,1,rep,name=blsPubs,proto3" json:"blsPubs,omitempty"`
+	// 提交需要的最少验证者签名数量, 0表示默认的2/3+1
+	Threshold int32 `protobuf:"varint,2,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	// 验证者集合生效的提交轮次
+	EffectiveRound int64 `protobuf:"varint,3,opt,name=effectiveRound,proto3" json:"effectiveRound,omitempty"`
 }
 
 func (x *ValidatorPubs) Reset() {
 	*x = ValidatorPubs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_rollup_proto_msgTypes[8]
+		mi := &file_rollup_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -681,7 +956,7 @@ func (x *ValidatorPubs) String() string {
 func (*ValidatorPubs) ProtoMessage() {}
 
 func (x *ValidatorPubs) ProtoReflect() protoreflect.Message {
-	mi := &file_rollup_proto_msgTypes[8]
+	mi := &file_rollup_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -694,7 +969,7 @@ func (x *ValidatorPubs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidatorPubs.ProtoReflect.Descriptor instead.
 func (*ValidatorPubs) Descriptor() ([]byte, []int) {
-	return file_rollup_proto_rawDescGZIP(), []int{8}
+	return file_rollup_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ValidatorPubs) GetBlsPubs() []string {
@@ -704,112 +979,433 @@ func (x *ValidatorPubs) GetBlsPubs() []string {
 	return nil
 }
 
+func (x *ValidatorPubs) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *ValidatorPubs) GetEffectiveRound() int64 {
+	if x != nil {
+		return x.EffectiveRound
+	}
+	return 0
+}
+
+// 链上治理的验证者集合, 按生效轮次排序
+type ValidatorSets struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sets []*ValidatorPubs `protobuf:"bytes,1,rep,name=sets,proto3" json:"sets,omitempty"`
+}
+
+func (x *ValidatorSets) Reset() {
+	*x = ValidatorSets{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rollup_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidatorSets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidatorSets) ProtoMessage() {}
+
+func (x *ValidatorSets) ProtoReflect() protoreflect.Message {
+	mi := &file_rollup_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidatorSets.ProtoReflect.Descriptor instead.
+func (*ValidatorSets) Descriptor() ([]byte, []int) {
+	return file_rollup_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidatorSets) GetSets() []*ValidatorPubs {
+	if x != nil {
+		return x.Sets
+	}
+	return nil
+}
+
+// 变更验证者集合, 需要当前验证者集合聚合签名
+type UpdateValidator struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChainTitle     string   `protobuf:"bytes,1,opt,name=chainTitle,proto3" json:"chainTitle,omitempty"`
+	AddPubs        []string `protobuf:"bytes,2,rep,name=addPubs,proto3" json:"addPubs,omitempty"`
+	RemovePubs     []string `protobuf:"bytes,3,rep,name=removePubs,proto3" json:"removePubs,omitempty"`
+	Threshold      int32    `protobuf:"varint,4,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	EffectiveRound int64    `protobuf:"varint,5,opt,name=effectiveRound,proto3" json:"effectiveRound,omitempty"`
+	// 签名的验证者公钥及聚合签名, 签名数据为其他字段的哈希
+	ValidatorPubs [][]byte `protobuf:"bytes,6,rep,name=validatorPubs,proto3" json:"validatorPubs,omitempty"`
+	AggregateSign []byte   `protobuf:"bytes,7,opt,name=aggregateSign,proto3" json:"aggregateSign,omitempty"`
+}
+
+func (x *UpdateValidator) Reset() {
+	*x = UpdateValidator{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rollup_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateValidator) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateValidator) ProtoMessage() {}
+
+func (x *UpdateValidator) ProtoReflect() protoreflect.Message {
+	mi := &file_rollup_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateValidator.ProtoReflect.Descriptor instead.
+func (*UpdateValidator) Descriptor() ([]byte, []int) {
+	return file_rollup_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *UpdateValidator) GetChainTitle() string {
+	if x != nil {
+		return x.ChainTitle
+	}
+	return ""
+}
+
+func (x *UpdateValidator) GetAddPubs() []string {
+	if x != nil {
+		return x.AddPubs
+	}
+	return nil
+}
+
+func (x *UpdateValidator) GetRemovePubs() []string {
+	if x != nil {
+		return x.RemovePubs
+	}
+	return nil
+}
+
+func (x *UpdateValidator) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *UpdateValidator) GetEffectiveRound() int64 {
+	if x != nil {
+		return x.EffectiveRound
+	}
+	return 0
+}
+
+func (x *UpdateValidator) GetValidatorPubs() [][]byte {
+	if x != nil {
+		return x.ValidatorPubs
+	}
+	return nil
+}
+
+func (x *UpdateValidator) GetAggregateSign() []byte {
+	if x != nil {
+		return x.AggregateSign
+	}
+	return nil
+}
+
+// 数据可用性导出记录, 主链上的一次提交
+type DACommit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MainHeight int64       `protobuf:"varint,1,opt,name=mainHeight,proto3" json:"mainHeight,omitempty"`
+	TxHash     []byte      `protobuf:"bytes,2,opt,name=txHash,proto3" json:"txHash,omitempty"`
+	CheckPoint *CheckPoint `protobuf:"bytes,3,opt,name=checkPoint,proto3" json:"checkPoint,omitempty"`
+	// 导出时主链记录的轮次信息
+	RoundInfo *CommitRoundInfo `protobuf:"bytes,4,opt,name=roundInfo,proto3" json:"roundInfo,omitempty"`
+}
+
+func (x *DACommit) Reset() {
+	*x = DACommit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rollup_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DACommit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DACommit) ProtoMessage() {}
+
+func (x *DACommit) ProtoReflect() protoreflect.Message {
+	mi := &file_rollup_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DACommit.ProtoReflect.Descriptor instead.
+func (*DACommit) Descriptor() ([]byte, []int) {
+	return file_rollup_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DACommit) GetMainHeight() int64 {
+	if x != nil {
+		return x.MainHeight
+	}
+	return 0
+}
+
+func (x *DACommit) GetTxHash() []byte {
+	if x != nil {
+		return x.TxHash
+	}
+	return nil
+}
+
+func (x *DACommit) GetCheckPoint() *CheckPoint {
+	if x != nil {
+		return x.CheckPoint
+	}
+	return nil
+}
+
+func (x *DACommit) GetRoundInfo() *CommitRoundInfo {
+	if x != nil {
+		return x.RoundInfo
+	}
+	return nil
+}
+
 var File_rollup_proto protoreflect.FileDescriptor
 
 var file_rollup_proto_rawDesc = []byte{
 	0x0a, 0x0c, 0x72, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05,
 	0x74, 0x79, 0x70, 0x65, 0x73, 0x1a, 0x10, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x54, 0x0a, 0x0c, 0x52, 0x6f, 0x6c, 0x6c, 0x75,
-	0x70, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x02, 0x74, 0x79, 0x12, 0x2b, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x06, 0x63, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xc1, 0x02,
-	0x0a, 0x0a, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x31, 0x0a, 0x0c,
-	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x52, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12,
-	0x26, 0x0a, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x72, 0x61, 0x67, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x72,
-	0x61, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x4c, 0x69, 0x73,
-	0x74, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x78, 0x4c, 0x69, 0x73, 0x74, 0x12,
-	0x1e, 0x0a, 0x0a, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12,
-	0x28, 0x0a, 0x0f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x54, 0x78, 0x53, 0x69,
-	0x67, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
-	0x61, 0x74, 0x65, 0x54, 0x78, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x78, 0x41,
-	0x64, 0x64, 0x72, 0x49, 0x44, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x0c, 0x74, 0x78, 0x41, 0x64, 0x64, 0x72, 0x49, 0x44, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2a, 0x0a,
-	0x10, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x61, 0x73,
-	0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x72, 0x6f,
-	0x73, 0x73, 0x54, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x0e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x73, 0x22, 0x84, 0x01, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53,
-	0x69, 0x67, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b,
-	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79,
-	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x6d, 0x73, 0x67, 0x48, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x07, 0x6d, 0x73, 0x67, 0x48, 0x61, 0x73, 0x68, 0x22, 0x87, 0x02, 0x0a, 0x0a, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61,
-	0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x05, 0x62, 0x61, 0x74,
-	0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x62, 0x61, 0x74,
-	0x63, 0x68, 0x12, 0x24, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50,
-	0x75, 0x62, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64,
-	0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x61, 0x67, 0x67, 0x72,
-	0x65, 0x67, 0x61, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x69,
-	0x67, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x16, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
-	0x61, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x69, 0x67, 0x6e,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd0, 0x01, 0x0a, 0x0c, 0x52, 0x6f, 0x6c, 0x6c,
+	0x75, 0x70, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x74, 0x79, 0x12, 0x2b, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x48, 0x00, 0x52, 0x06, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x36, 0x0a, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e,
+	0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x43, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x48, 0x00, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x12, 0x42, 0x0a,
+	0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x48, 0x00,
+	0x52, 0x0f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xc1, 0x02, 0x0a, 0x0a, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x31, 0x0a, 0x0c, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0c,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0e,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x72, 0x61, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x72, 0x61, 0x67, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x78, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x0a, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x0f,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x54, 0x78, 0x53, 0x69, 0x67, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x54, 0x78, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x74, 0x78, 0x41, 0x64, 0x64, 0x72,
+	0x49, 0x44, 0x4c, 0x69, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x74, 0x78,
+	0x41, 0x64, 0x64, 0x72, 0x49, 0x44, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x72,
+	0x6f, 0x73, 0x73, 0x54, 0x78, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x10, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54,
+	0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e,
+	0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x84,
+	0x01, 0x0a, 0x10, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x69, 0x67, 0x6e,
+	0x4d, 0x73, 0x67, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d,
+	0x73, 0x67, 0x48, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6d, 0x73,
+	0x67, 0x48, 0x61, 0x73, 0x68, 0x22, 0x87, 0x02, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x74,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x54,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x27, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x24, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
+	0x72, 0x50, 0x75, 0x62, 0x73, 0x12, 0x36, 0x0a, 0x16, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x16, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x30, 0x0a,
+	0x13, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x53, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x48, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x63, 0x72, 0x6f, 0x73,
+	0x73, 0x54, 0x78, 0x53, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22,
+	0xf2, 0x02, 0x0a, 0x0c, 0x52, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x20,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x2c, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x26,
+	0x0a, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x72, 0x61, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x72, 0x61,
+	0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x41, 0x64, 0x64, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x41, 0x64, 0x64, 0x72,
 	0x12, 0x30, 0x0a, 0x13, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x53, 0x79, 0x6e, 0x63, 0x65,
-	0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x63,
+	0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x63,
 	0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x53, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x48, 0x65, 0x69, 0x67,
-	0x68, 0x74, 0x22, 0xa0, 0x02, 0x0a, 0x0c, 0x52, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
-	0x70, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f,
-	0x75, 0x6e, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11,
-	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x12, 0x26, 0x0a, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x72, 0x61, 0x67, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
-	0x46, 0x72, 0x61, 0x67, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
-	0x61, 0x73, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x41, 0x64, 0x64,
-	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x41,
-	0x64, 0x64, 0x72, 0x12, 0x30, 0x0a, 0x13, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x53, 0x79,
-	0x6e, 0x63, 0x65, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x13, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x53, 0x79, 0x6e, 0x63, 0x65, 0x64, 0x48,
-	0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xa9, 0x02, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b,
-	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x66,
-	0x69, 0x72, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x66, 0x69, 0x72, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68,
-	0x74, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x54, 0x78, 0x43, 0x6f, 0x75,
-	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x54, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x72, 0x6f, 0x73, 0x73,
-	0x54, 0x78, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x10, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48,
-	0x61, 0x73, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x52, 0x65,
-	0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x72, 0x6f,
-	0x73, 0x73, 0x54, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x62,
-	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73,
-	0x68, 0x22, 0x55, 0x0a, 0x11, 0x52, 0x65, 0x71, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68,
-	0x61, 0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x22, 0x22, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69,
-	0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x29, 0x0a, 0x0d,
-	0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x62, 0x6c, 0x73, 0x50, 0x75, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
-	0x62, 0x6c, 0x73, 0x50, 0x75, 0x62, 0x73, 0x32, 0x08, 0x0a, 0x06, 0x72, 0x6f, 0x6c, 0x6c, 0x75,
-	0x70, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x68, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x66, 0x69, 0x6e, 0x61,
+	0x6c, 0x69, 0x7a, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0f, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x50, 0x65,
+	0x72, 0x69, 0x6f, 0x64, 0x22, 0xb4, 0x04, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x2a, 0x0a, 0x10, 0x66, 0x69,
+	0x72, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x66, 0x69, 0x72, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x28, 0x0a, 0x0f, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0f, 0x6c, 0x61, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x54, 0x78, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x54,
+	0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2a, 0x0a, 0x10, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54,
+	0x78, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x48, 0x61,
+	0x73, 0x68, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x72, 0x6f, 0x73, 0x73, 0x54, 0x78, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x72, 0x6f, 0x73,
+	0x73, 0x54, 0x78, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x61, 0x73, 0x68, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x61, 0x73, 0x68, 0x12, 0x22,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x2e, 0x0a, 0x12, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x45,
+	0x6e, 0x64, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12,
+	0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x45, 0x6e, 0x64, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x41, 0x64, 0x64, 0x72,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x41, 0x64,
+	0x64, 0x72, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6f, 0x6e, 0x64,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x42, 0x6f,
+	0x6e, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x54, 0x78, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x54,
+	0x78, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x33, 0x0a, 0x0a, 0x70, 0x72, 0x65, 0x76, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x52, 0x6f, 0x6c, 0x6c, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
+	0x0a, 0x70, 0x72, 0x65, 0x76, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x7c, 0x0a, 0x0f, 0x43,
+	0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20,
+	0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x27, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x61, 0x74,
+	0x63, 0x68, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x22, 0x89, 0x02, 0x0a, 0x0f, 0x43, 0x68,
+	0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x6c, 0x6c, 0x65, 0x6e, 0x67, 0x65, 0x72, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x41, 0x64, 0x64, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x41, 0x64, 0x64, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x61, 0x75, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x61, 0x75, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x41, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x6c, 0x61, 0x73,
+	0x68, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12,
+	0x24, 0x0a, 0x0d, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x76, 0x65, 0x72, 0x74, 0x65, 0x64,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x55, 0x0a, 0x11, 0x52, 0x65, 0x71, 0x47, 0x65, 0x74, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x22, 0x22, 0x0a, 0x0a,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x6f, 0x0a, 0x0d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x73, 0x50, 0x75, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x07, 0x62, 0x6c, 0x73, 0x50, 0x75, 0x62, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09,
+	0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x66, 0x66,
+	0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x6f, 0x75, 0x6e,
+	0x64, 0x22, 0x39, 0x0a, 0x0d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x65,
+	0x74, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x50, 0x75, 0x62, 0x73, 0x52, 0x04, 0x73, 0x65, 0x74, 0x73, 0x22, 0xfd, 0x01, 0x0a,
+	0x0f, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x54, 0x69, 0x74, 0x6c, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x50, 0x75, 0x62, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x50, 0x75, 0x62, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x50, 0x75, 0x62, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
+	0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x50, 0x75, 0x62, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x68,
+	0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x65, 0x66, 0x66, 0x65,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x24, 0x0a, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x50, 0x75, 0x62,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0d, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74,
+	0x6f, 0x72, 0x50, 0x75, 0x62, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x61,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x22, 0xab, 0x01, 0x0a,
+	0x08, 0x44, 0x41, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x61, 0x69,
+	0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d,
+	0x61, 0x69, 0x6e, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x78, 0x48,
+	0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x74, 0x78, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x31, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x12, 0x34, 0x0a, 0x09, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x49, 0x6e, 0x66,
+	0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x09, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x32, 0x08, 0x0a, 0x06, 0x72, 0x6f,
+	0x6c, 0x6c, 0x75, 0x70, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -824,7 +1420,7 @@ func file_rollup_proto_rawDescGZIP() []byte {
 	return file_rollup_proto_rawDescData
 }
 
-var file_rollup_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_rollup_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_rollup_proto_goTypes = []interface{}{
 	(*RollupAction)(nil),      // 0: types.RollupAction
 	(*BlockBatch)(nil),        // 1: types.BlockBatch
@@ -832,20 +1428,32 @@ var file_rollup_proto_goTypes = []interface{}{
 	(*CheckPoint)(nil),        // 3: types.CheckPoint
 	(*RollupStatus)(nil),      // 4: types.RollupStatus
 	(*CommitRoundInfo)(nil),   // 5: types.CommitRoundInfo
-	(*ReqGetCommitRound)(nil), // 6: types.ReqGetCommitRound
-	(*ChainTitle)(nil),        // 7: types.chainTitle
-	(*ValidatorPubs)(nil),     // 8: types.ValidatorPubs
-	(*types.Header)(nil),      // 9: types.Header
+	(*ChallengeCommit)(nil),   // 6: types.ChallengeCommit
+	(*ChallengeRecord)(nil),   // 7: types.ChallengeRecord
+	(*ReqGetCommitRound)(nil), // 8: types.ReqGetCommitRound
+	(*ChainTitle)(nil),        // 9: types.chainTitle
+	(*ValidatorPubs)(nil),     // 10: types.ValidatorPubs
+	(*ValidatorSets)(nil),     // 11: types.ValidatorSets
+	(*UpdateValidator)(nil),   // 12: types.UpdateValidator
+	(*DACommit)(nil),          // 13: types.DACommit
+	(*types.Header)(nil),      // 14: types.Header
 }
 var file_rollup_proto_depIdxs = []int32{
-	3, // 0: types.RollupAction.commit:type_name -> types.CheckPoint
-	9, // 1: types.BlockBatch.blockHeaders:type_name -> types.Header
-	1, // 2: types.CheckPoint.batch:type_name -> types.BlockBatch
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3,  // 0: types.RollupAction.commit:type_name -> types.CheckPoint
+	6,  // 1: types.RollupAction.challenge:type_name -> types.ChallengeCommit
+	12, // 2: types.RollupAction.updateValidator:type_name -> types.UpdateValidator
+	14, // 3: types.BlockBatch.blockHeaders:type_name -> types.Header
+	1,  // 4: types.CheckPoint.batch:type_name -> types.BlockBatch
+	4,  // 5: types.CommitRoundInfo.prevStatus:type_name -> types.RollupStatus
+	1,  // 6: types.ChallengeCommit.batch:type_name -> types.BlockBatch
+	10, // 7: types.ValidatorSets.sets:type_name -> types.ValidatorPubs
+	3,  // 8: types.DACommit.checkPoint:type_name -> types.CheckPoint
+	5,  // 9: types.DACommit.roundInfo:type_name -> types.CommitRoundInfo
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_rollup_proto_init() }
@@ -927,7 +1535,7 @@ func file_rollup_proto_init() {
 			}
 		}
 		file_rollup_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReqGetCommitRound); i {
+			switch v := v.(*ChallengeCommit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -939,7 +1547,7 @@ func file_rollup_proto_init() {
 			}
 		}
 		file_rollup_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ChainTitle); i {
+			switch v := v.(*ChallengeRecord); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -951,6 +1559,30 @@ func file_rollup_proto_init() {
 			}
 		}
 		file_rollup_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReqGetCommitRound); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rollup_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChainTitle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rollup_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ValidatorPubs); i {
 			case 0:
 				return &v.state
@@ -962,9 +1594,47 @@ func file_rollup_proto_init() {
 				return nil
 			}
 		}
+		file_rollup_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidatorSets); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rollup_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateValidator); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rollup_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DACommit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_rollup_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*RollupAction_Commit)(nil),
+		(*RollupAction_Challenge)(nil),
+		(*RollupAction_UpdateValidator)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -972,7 +1642,7 @@ func file_rollup_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rollup_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
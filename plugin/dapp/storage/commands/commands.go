@@ -17,7 +17,8 @@ func Cmd() *cobra.Command {
 		Args:  cobra.MinimumNArgs(1),
 	}
 	cmd.AddCommand(
-	//add sub command
+		//add sub command
+		PaillierCmd(),
 	)
 	return cmd
 }
@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/plugin/plugin/crypto/paillier"
+	"github.com/spf13/cobra"
+)
+
+// PaillierCmd paillier同态加密相关命令, 用于生成EncryptStorage和EncryptAdd交易的密文
+func PaillierCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "paillier",
+		Short: "Paillier homomorphic encryption tools",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		paillierKeyGenCmd(),
+		paillierEncryptCmd(),
+		paillierDecryptCmd(),
+		paillierAddCmd(),
+	)
+	return cmd
+}
+
+func printJSON(result interface{}) {
+	data, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+func paillierKeyGenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generate paillier key pair",
+		Run:   paillierKeyGen,
+	}
+	cmd.Flags().IntP("bits", "b", 2048, "key bits")
+	return cmd
+}
+
+func paillierKeyGen(cmd *cobra.Command, args []string) {
+	bits, _ := cmd.Flags().GetInt("bits")
+	priv, err := paillier.GenerateKey(nil, bits)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	printJSON(map[string]string{
+		"pubKey":  hex.EncodeToString(priv.PublicKey.Bytes()),
+		"privKey": hex.EncodeToString(priv.Bytes()),
+	})
+}
+
+func paillierEncryptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt non-negative integer with paillier public key",
+		Run:   paillierEncrypt,
+	}
+	cmd.Flags().StringP("pub", "p", "", "paillier public key, hex")
+	cmd.MarkFlagRequired("pub")
+	cmd.Flags().StringP("value", "v", "", "plaintext integer, decimal")
+	cmd.MarkFlagRequired("value")
+	cmd.Flags().IntP("range", "r", 0, "generate range proof of value in [0, 2^range), 0 for no proof, max 64")
+	return cmd
+}
+
+func paillierEncrypt(cmd *cobra.Command, args []string) {
+	pubHex, _ := cmd.Flags().GetString("pub")
+	value, _ := cmd.Flags().GetString("value")
+	bits, _ := cmd.Flags().GetInt("range")
+	pubBytes, err := common.FromHex(pubHex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	pub, err := paillier.NewPublicKey(pubBytes)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	m, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "invalid value")
+		return
+	}
+	result := make(map[string]string)
+	if bits > 0 {
+		cipher, proof, err := pub.EncryptWithRangeProof(m, bits)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		result["cipher"] = hex.EncodeToString(cipher)
+		result["rangeProof"] = hex.EncodeToString(proof.Bytes())
+	} else {
+		cipher, err := pub.Encrypt(m)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		result["cipher"] = hex.EncodeToString(cipher)
+	}
+	printJSON(result)
+}
+
+func paillierDecryptCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decrypt",
+		Short: "Decrypt ciphertext with paillier private key",
+		Run:   paillierDecrypt,
+	}
+	cmd.Flags().StringP("priv", "k", "", "paillier private key, hex")
+	cmd.MarkFlagRequired("priv")
+	cmd.Flags().StringP("cipher", "c", "", "ciphertext, hex")
+	cmd.MarkFlagRequired("cipher")
+	return cmd
+}
+
+func paillierDecrypt(cmd *cobra.Command, args []string) {
+	privHex, _ := cmd.Flags().GetString("priv")
+	cipherHex, _ := cmd.Flags().GetString("cipher")
+	privBytes, err := common.FromHex(privHex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	priv, err := paillier.ParsePrivateKey(privBytes)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	cipher, err := common.FromHex(cipherHex)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	m, err := priv.Decrypt(cipher)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(m.String())
+}
+
+func paillierAddCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add",
+		Short: "Add two ciphertexts encrypted with the same public key",
+		Run:   paillierAdd,
+	}
+	cmd.Flags().StringP("cipher1", "a", "", "ciphertext, hex")
+	cmd.MarkFlagRequired("cipher1")
+	cmd.Flags().StringP("cipher2", "b", "", "ciphertext, hex")
+	cmd.MarkFlagRequired("cipher2")
+	return cmd
+}
+
+func paillierAdd(cmd *cobra.Command, args []string) {
+	cipher1, _ := cmd.Flags().GetString("cipher1")
+	cipher2, _ := cmd.Flags().GetString("cipher2")
+	sum, err := paillier.CiphertextAdd(cipher1, cipher2)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(sum)
+}
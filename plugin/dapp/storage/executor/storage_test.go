@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"math/big"
 	"math/rand"
 	"testing"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/33cn/chain33/common/crypto"
 	dbm "github.com/33cn/chain33/common/db"
 	"github.com/33cn/chain33/queue"
+	"github.com/33cn/plugin/plugin/crypto/paillier"
 	des "github.com/33cn/plugin/plugin/dapp/storage/crypto"
 	oty "github.com/33cn/plugin/plugin/dapp/storage/types"
 	"github.com/stretchr/testify/assert"
@@ -259,6 +261,8 @@ func Exec_Block(t *testing.T, stateDB dbm.DB, kvdb dbm.KVDB, env *execEnv, txs .
 	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
 	cfg.RegisterDappFork(oty.StorageX, oty.ForkStorageLocalDB, 0)
 	cfg.SetTitleOnlyForTest("chain33")
+	//固定的同态密文没有范围证明, 在ForkStorageRangeProof之前执行
+	cfg.RegisterDappFork(oty.StorageX, oty.ForkStorageRangeProof, types.MaxHeight)
 	exec := newStorage()
 	e := exec.(*storage)
 	for index, tx := range txs {
@@ -302,3 +306,25 @@ func Exec_Block(t *testing.T, stateDB dbm.DB, kvdb dbm.KVDB, env *execEnv, txs .
 	}
 	return nil
 }
+
+func TestVerifyRangeProof(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	cfg.SetTitleOnlyForTest("chain33")
+	cfg.RegisterDappFork(oty.StorageX, oty.ForkStorageRangeProof, 10)
+	priv, err := paillier.GenerateKey(nil, paillier.MinKeyBits)
+	assert.Nil(t, err)
+	m := big.NewInt(88)
+	r := big.NewInt(12345)
+	cipher, err := priv.PublicKey.EncryptWithNonce(m, r)
+	assert.Nil(t, err)
+	proof, err := paillier.ProveRange(cipher, m, r, 32)
+	assert.Nil(t, err)
+
+	//分叉之前不校验
+	assert.Nil(t, verifyRangeProof(cfg, 9, &oty.EncryptNotaryAdd{EncryptAdd: cipher}))
+	assert.Nil(t, verifyRangeProof(cfg, 10, &oty.EncryptNotaryAdd{EncryptAdd: cipher, RangeProof: proof.Bytes()}))
+	assert.Equal(t, oty.ErrRangeProof, verifyRangeProof(cfg, 10, &oty.EncryptNotaryAdd{EncryptAdd: cipher}))
+	other, err := priv.PublicKey.Encrypt(m)
+	assert.Nil(t, err)
+	assert.NotNil(t, verifyRangeProof(cfg, 10, &oty.EncryptNotaryAdd{EncryptAdd: other, RangeProof: proof.Bytes()}))
+}
@@ -204,6 +204,9 @@ func (s *StorageAction) EncryptAdd(payload *ety.EncryptNotaryAdd) (*types.Receip
 	}
 
 	cipherText := store.GetEncryptStorage().EncryptContent
+	if err := verifyRangeProof(cfg, s.height, payload); err != nil {
+		return nil, err
+	}
 	res, err := paillier.CiphertextAddBytes(cipherText, payload.EncryptAdd)
 	if err != nil {
 		return nil, fmt.Errorf("EncryptAdd.CiphertextAddBytes. err:%v", err)
@@ -233,6 +236,25 @@ func (s *StorageAction) EncryptAdd(payload *ety.EncryptNotaryAdd) (*types.Receip
 	return receipt, nil
 }
 
+//verifyRangeProof 校验待加密文的范围证明, 防止加上负数或者溢出的数, ForkStorageRangeProof之后必须附带证明
+func verifyRangeProof(cfg *types.Chain33Config, height int64, payload *ety.EncryptNotaryAdd) error {
+	if !cfg.IsDappFork(height, ety.StorageX, ety.ForkStorageRangeProof) {
+		return nil
+	}
+	if len(payload.RangeProof) == 0 {
+		return ety.ErrRangeProof
+	}
+	proof, err := paillier.ParseRangeProof(payload.RangeProof)
+	if err != nil {
+		return fmt.Errorf("EncryptAdd.ParseRangeProof. err:%v", err)
+	}
+	err = paillier.VerifyRange(payload.EncryptAdd, proof, paillier.MaxRangeBits)
+	if err != nil {
+		return fmt.Errorf("EncryptAdd.VerifyRange. err:%v", err)
+	}
+	return nil
+}
+
 //QueryStorageByTxHash ...
 func QueryStorageByTxHash(db dbm.KV, txhash string) (*ety.Storage, error) {
 	data, err := db.Get(Key(txhash))
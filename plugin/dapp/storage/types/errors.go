@@ -6,4 +6,5 @@ import "fmt"
 var (
 	ErrKeyExisted  = fmt.Errorf("%s", "The key has already existed!")
 	ErrStorageType = fmt.Errorf("%s", "The key has used storage another type!")
+	ErrRangeProof  = fmt.Errorf("%s", "The range proof of encrypt add is required!")
 )
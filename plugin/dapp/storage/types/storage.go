@@ -53,6 +53,8 @@ const (
 //fork
 var (
 	ForkStorageLocalDB = "ForkStorageLocalDB"
+	//ForkStorageRangeProof EncryptAdd必须附带范围证明
+	ForkStorageRangeProof = "ForkStorageRangeProof"
 )
 var (
 	//StorageX 执行器名称定义
@@ -89,6 +91,7 @@ func init() {
 func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(StorageX, "Enable", 0)
 	cfg.RegisterDappFork(StorageX, ForkStorageLocalDB, 0)
+	cfg.RegisterDappFork(StorageX, ForkStorageRangeProof, 0)
 }
 
 // InitExecutor defines register executor
@@ -0,0 +1,218 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/33cn/chain33/rpc/jsonclient"
+	rpctypes "github.com/33cn/chain33/rpc/types"
+	cmdtypes "github.com/33cn/chain33/system/dapp/commands/types"
+	"github.com/33cn/chain33/types"
+	ty "github.com/33cn/plugin/plugin/dapp/ticket/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// PoolCmd ticket mining pool
+func PoolCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pool",
+		Short: "Ticket mining pool management",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		poolCreateCmd(),
+		poolDepositCmd(),
+		poolWithdrawCmd(),
+		poolInfoCmd(),
+		poolListCmd(),
+		poolDepositorCmd(),
+		poolDepositorsCmd(),
+	)
+	return cmd
+}
+
+func createPoolTx(cmd *cobra.Command, ta *ty.TicketAction) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	paraName, _ := cmd.Flags().GetString("paraName")
+	cfg, err := cmdtypes.GetChainConfig(rpcLaddr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, errors.Wrapf(err, "GetChainConfig"))
+		return
+	}
+	rawTx := &types.Transaction{Payload: types.Encode(ta)}
+	tx, err := types.FormatTxExt(cfg.ChainID, len(paraName) > 0, cfg.MinTxFeeRate, ty.TicketX, rawTx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(hex.EncodeToString(types.Encode(tx)))
+}
+
+func getPoolAmount(cmd *cobra.Command) (int64, error) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	cfg, err := cmdtypes.GetChainConfig(rpcLaddr)
+	if err != nil {
+		return 0, errors.Wrapf(err, "GetChainConfig")
+	}
+	amount, _ := cmd.Flags().GetFloat64("amount")
+	return types.FormatFloatDisplay2Value(amount, cfg.CoinPrecision)
+}
+
+func queryPool(cmd *cobra.Command, funcName string, req types.Message, res types.Message) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	var params rpctypes.Query4Jrpc
+	params.Execer = ty.TicketX
+	params.FuncName = funcName
+	params.Payload = types.MustPBToJSON(req)
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, res)
+	ctx.Run()
+}
+
+func poolCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a mining pool, the signer is the pool operator",
+		Run:   poolCreate,
+	}
+	cmd.Flags().Int32P("fee_rate", "f", 0, "operator fee rate in basis points, 100 means 1%")
+	return cmd
+}
+
+func poolCreate(cmd *cobra.Command, args []string) {
+	feeRate, _ := cmd.Flags().GetInt32("fee_rate")
+	ta := &ty.TicketAction{
+		Ty:    ty.TicketActionPoolCreate,
+		Value: &ty.TicketAction_PoolCreate{PoolCreate: &ty.TicketPoolCreate{FeeRate: feeRate}},
+	}
+	createPoolTx(cmd, ta)
+}
+
+func poolDepositCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deposit",
+		Short: "Deposit coins of ticket executor into a mining pool",
+		Run:   poolDeposit,
+	}
+	cmd.Flags().StringP("pool_id", "p", "", "pool id")
+	cmd.MarkFlagRequired("pool_id")
+	cmd.Flags().Float64P("amount", "a", 0, "deposit amount")
+	cmd.MarkFlagRequired("amount")
+	return cmd
+}
+
+func poolDeposit(cmd *cobra.Command, args []string) {
+	poolID, _ := cmd.Flags().GetString("pool_id")
+	amount, err := getPoolAmount(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	ta := &ty.TicketAction{
+		Ty:    ty.TicketActionPoolDeposit,
+		Value: &ty.TicketAction_PoolDeposit{PoolDeposit: &ty.TicketPoolDeposit{PoolId: poolID, Amount: amount}},
+	}
+	createPoolTx(cmd, ta)
+}
+
+func poolWithdrawCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "withdraw",
+		Short: "Withdraw principal and reward from a mining pool",
+		Run:   poolWithdraw,
+	}
+	cmd.Flags().StringP("pool_id", "p", "", "pool id")
+	cmd.MarkFlagRequired("pool_id")
+	cmd.Flags().Float64P("amount", "a", 0, "withdraw amount")
+	cmd.MarkFlagRequired("amount")
+	return cmd
+}
+
+func poolWithdraw(cmd *cobra.Command, args []string) {
+	poolID, _ := cmd.Flags().GetString("pool_id")
+	amount, err := getPoolAmount(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	ta := &ty.TicketAction{
+		Ty:    ty.TicketActionPoolWithdraw,
+		Value: &ty.TicketAction_PoolWithdraw{PoolWithdraw: &ty.TicketPoolWithdraw{PoolId: poolID, Amount: amount}},
+	}
+	createPoolTx(cmd, ta)
+}
+
+func poolInfoCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "info",
+		Short: "Get mining pool info by pool id or pool address",
+		Run:   poolInfo,
+	}
+	cmd.Flags().StringP("pool", "p", "", "pool id or pool address")
+	cmd.MarkFlagRequired("pool")
+	return cmd
+}
+
+func poolInfo(cmd *cobra.Command, args []string) {
+	pool, _ := cmd.Flags().GetString("pool")
+	var res ty.ReplyTicketPool
+	queryPool(cmd, "PoolInfo", &types.ReqString{Data: pool}, &res)
+}
+
+func poolListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List mining pools, all pools if addr is not set",
+		Run:   poolList,
+	}
+	cmd.Flags().StringP("addr", "a", "", "operator or depositor address (optional)")
+	return cmd
+}
+
+func poolList(cmd *cobra.Command, args []string) {
+	addr, _ := cmd.Flags().GetString("addr")
+	var res ty.ReplyTicketPools
+	queryPool(cmd, "PoolList", &types.ReqString{Data: addr}, &res)
+}
+
+func poolDepositorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "depositor",
+		Short: "Get depositor info of a mining pool",
+		Run:   poolDepositor,
+	}
+	cmd.Flags().StringP("pool_id", "p", "", "pool id")
+	cmd.MarkFlagRequired("pool_id")
+	cmd.Flags().StringP("addr", "a", "", "depositor address")
+	cmd.MarkFlagRequired("addr")
+	return cmd
+}
+
+func poolDepositor(cmd *cobra.Command, args []string) {
+	poolID, _ := cmd.Flags().GetString("pool_id")
+	addr, _ := cmd.Flags().GetString("addr")
+	var res ty.TicketPoolDepositor
+	queryPool(cmd, "PoolDepositor", &ty.ReqTicketPoolDepositor{PoolId: poolID, Addr: addr}, &res)
+}
+
+func poolDepositorsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "depositors",
+		Short: "List depositors of a mining pool",
+		Run:   poolDepositors,
+	}
+	cmd.Flags().StringP("pool_id", "p", "", "pool id")
+	cmd.MarkFlagRequired("pool_id")
+	return cmd
+}
+
+func poolDepositors(cmd *cobra.Command, args []string) {
+	poolID, _ := cmd.Flags().GetString("pool_id")
+	var res ty.ReplyTicketPoolDepositors
+	queryPool(cmd, "PoolDepositors", &types.ReqString{Data: poolID}, &res)
+}
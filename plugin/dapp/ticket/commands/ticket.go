@@ -40,6 +40,7 @@ func TicketCmd() *cobra.Command {
 		GetColdAddrByMinerCmd(),
 		listTicketCmd(),
 		CreateCloseTicketCmd(),
+		PoolCmd(),
 	)
 
 	return cmd
@@ -54,3 +54,37 @@ func (t *Ticket) Exec_Miner(payload *ty.TicketMiner, tx *types.Transaction, inde
 	actiondb := NewAction(t, tx)
 	return actiondb.TicketMiner(payload, index)
 }
+
+func (t *Ticket) checkPoolFork() error {
+	if !t.GetAPI().GetConfig().IsDappFork(t.GetHeight(), ty.TicketX, ty.ForkTicketPool) {
+		return types.ErrActionNotSupport
+	}
+	return nil
+}
+
+// Exec_PoolCreate exec create pool
+func (t *Ticket) Exec_PoolCreate(payload *ty.TicketPoolCreate, tx *types.Transaction, index int) (*types.Receipt, error) {
+	if err := t.checkPoolFork(); err != nil {
+		return nil, err
+	}
+	actiondb := NewAction(t, tx)
+	return actiondb.PoolCreate(payload)
+}
+
+// Exec_PoolDeposit exec deposit to pool
+func (t *Ticket) Exec_PoolDeposit(payload *ty.TicketPoolDeposit, tx *types.Transaction, index int) (*types.Receipt, error) {
+	if err := t.checkPoolFork(); err != nil {
+		return nil, err
+	}
+	actiondb := NewAction(t, tx)
+	return actiondb.PoolDeposit(payload)
+}
+
+// Exec_PoolWithdraw exec withdraw from pool
+func (t *Ticket) Exec_PoolWithdraw(payload *ty.TicketPoolWithdraw, tx *types.Transaction, index int) (*types.Receipt, error) {
+	if err := t.checkPoolFork(); err != nil {
+		return nil, err
+	}
+	actiondb := NewAction(t, tx)
+	return actiondb.PoolWithdraw(payload)
+}
@@ -29,6 +29,12 @@ func (t *Ticket) execDelLocal(receiptData *types.ReceiptData) (*types.LocalDBSet
 			}
 			kv := t.delTicketBind(&ticketlog)
 			dbSet.KV = append(dbSet.KV, kv...)
+		} else if item.Ty == ty.TyLogTicketPool || item.Ty == ty.TyLogTicketPoolDepositor {
+			kv, err := poolLocalKV(item, true)
+			if err != nil {
+				panic(err) //数据错误了，已经被修改了
+			}
+			dbSet.KV = append(dbSet.KV, kv...)
 		}
 	}
 	return dbSet, nil
@@ -58,3 +64,18 @@ func (t *Ticket) ExecDelLocal_Tclose(payload *ty.TicketClose, tx *types.Transact
 func (t *Ticket) ExecDelLocal_Miner(payload *ty.TicketMiner, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
 	return t.execDelLocal(receiptData)
 }
+
+// ExecDelLocal_PoolCreate exec del local create pool
+func (t *Ticket) ExecDelLocal_PoolCreate(payload *ty.TicketPoolCreate, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return t.execDelLocal(receiptData)
+}
+
+// ExecDelLocal_PoolDeposit exec del local deposit to pool
+func (t *Ticket) ExecDelLocal_PoolDeposit(payload *ty.TicketPoolDeposit, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return t.execDelLocal(receiptData)
+}
+
+// ExecDelLocal_PoolWithdraw exec del local withdraw from pool
+func (t *Ticket) ExecDelLocal_PoolWithdraw(payload *ty.TicketPoolWithdraw, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return t.execDelLocal(receiptData)
+}
@@ -29,6 +29,12 @@ func (t *Ticket) execLocal(receiptData *types.ReceiptData) (*types.LocalDBSet, e
 			}
 			kv := t.saveTicketBind(&ticketlog)
 			dbSet.KV = append(dbSet.KV, kv...)
+		} else if item.Ty == ty.TyLogTicketPool || item.Ty == ty.TyLogTicketPoolDepositor {
+			kv, err := poolLocalKV(item, false)
+			if err != nil {
+				panic(err) //数据错误了，已经被修改了
+			}
+			dbSet.KV = append(dbSet.KV, kv...)
 		}
 	}
 	return dbSet, nil
@@ -58,3 +64,18 @@ func (t *Ticket) ExecLocal_Tclose(payload *ty.TicketClose, tx *types.Transaction
 func (t *Ticket) ExecLocal_Miner(payload *ty.TicketMiner, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
 	return t.execLocal(receiptData)
 }
+
+// ExecLocal_PoolCreate exec local create pool
+func (t *Ticket) ExecLocal_PoolCreate(payload *ty.TicketPoolCreate, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return t.execLocal(receiptData)
+}
+
+// ExecLocal_PoolDeposit exec local deposit to pool
+func (t *Ticket) ExecLocal_PoolDeposit(payload *ty.TicketPoolDeposit, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return t.execLocal(receiptData)
+}
+
+// ExecLocal_PoolWithdraw exec local withdraw from pool
+func (t *Ticket) ExecLocal_PoolWithdraw(payload *ty.TicketPoolWithdraw, tx *types.Transaction, receiptData *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	return t.execLocal(receiptData)
+}
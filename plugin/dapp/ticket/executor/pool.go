@@ -0,0 +1,392 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/address"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/system/dapp"
+	"github.com/33cn/chain33/types"
+	ty "github.com/33cn/plugin/plugin/dapp/ticket/types"
+	"github.com/golang/protobuf/proto"
+)
+
+//矿池:
+//1. 运营方创建矿池, 矿池地址没有私钥, 创建时绑定运营方为挖矿地址, 运营方按原有流程用矿池地址的币购买ticket并挖矿
+//2. 存款人把ticket合约中的币存入矿池地址, 按存款比例分配矿池ticket的挖矿收益, 运营方收取手续费
+//3. 存款人提取时先提取收益再提取本金, 矿池可用余额不足时保留申请的金额, 运营方不能再用保留的金额购买ticket,
+//   ticket关闭后再次提取, 有保留申请的存款人也可以关闭矿池的ticket
+
+var (
+	ticketPoolKeyPrefix     = []byte("mavl-ticket-pool")
+	ticketPoolAddrKeyPrefix = []byte("mavl-ticket-pooladdr-")
+)
+
+func calcPoolKey(poolID string) []byte {
+	return []byte(fmt.Sprintf("mavl-ticket-pool-%s", poolID))
+}
+
+func calcPoolDepositorKey(poolID, addr string) []byte {
+	return []byte(fmt.Sprintf("mavl-ticket-pool-%s-%s", poolID, addr))
+}
+
+func calcPoolAddrKey(poolAddr string) []byte {
+	return append(append([]byte{}, ticketPoolAddrKeyPrefix...), []byte(poolAddr)...)
+}
+
+// calcPoolAddress 矿池地址由矿池id生成, 没有对应的私钥
+func calcPoolAddress(poolID string) string {
+	return dapp.ExecAddress(ty.TicketX + "-pool-" + poolID)
+}
+
+func getPool(db dbm.KV, poolID string) (*ty.TicketPool, error) {
+	value, err := db.Get(calcPoolKey(poolID))
+	if err != nil || value == nil {
+		return nil, ty.ErrPoolNotFound
+	}
+	var pool ty.TicketPool
+	if err = types.Decode(value, &pool); err != nil {
+		return nil, err
+	}
+	return &pool, nil
+}
+
+// getPoolByAddr 地址不是矿池地址时返回ErrPoolNotFound
+func getPoolByAddr(db dbm.KV, poolAddr string) (*ty.TicketPool, error) {
+	value, err := db.Get(calcPoolAddrKey(poolAddr))
+	if err != nil || value == nil {
+		return nil, ty.ErrPoolNotFound
+	}
+	return getPool(db, string(value))
+}
+
+func getPoolDepositor(db dbm.KV, poolID, addr string) (*ty.TicketPoolDepositor, error) {
+	value, err := db.Get(calcPoolDepositorKey(poolID, addr))
+	if err != nil || value == nil {
+		return nil, types.ErrNotFound
+	}
+	var dep ty.TicketPoolDepositor
+	if err = types.Decode(value, &dep); err != nil {
+		return nil, err
+	}
+	return &dep, nil
+}
+
+func loadPoolDepositor(db dbm.KV, poolID, addr string) (*ty.TicketPoolDepositor, *ty.TicketPoolDepositor, error) {
+	dep, err := getPoolDepositor(db, poolID, addr)
+	if err == types.ErrNotFound {
+		return nil, &ty.TicketPoolDepositor{PoolId: poolID, Addr: addr}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return proto.Clone(dep).(*ty.TicketPoolDepositor), dep, nil
+}
+
+// calcPoolReward 存款按每单位收益计算的累计收益, 中间结果可能超出int64
+func calcPoolReward(amount, accRewardPerShare int64) int64 {
+	r := new(big.Int).Mul(big.NewInt(amount), big.NewInt(accRewardPerShare))
+	return r.Div(r, big.NewInt(ty.PoolRewardScale)).Int64()
+}
+
+// settlePoolDepositor 结算存款人到当前为止的收益
+func settlePoolDepositor(pool *ty.TicketPool, dep *ty.TicketPoolDepositor) {
+	dep.Reward += calcPoolReward(dep.Amount, pool.AccRewardPerShare) - dep.RewardDebt
+	dep.RewardDebt = calcPoolReward(dep.Amount, pool.AccRewardPerShare)
+}
+
+// distributePoolReward 扣除手续费后按存款比例分配挖矿收益, 返回运营方的手续费
+func distributePoolReward(pool *ty.TicketPool, value int64) int64 {
+	fee := value * int64(pool.FeeRate) / ty.PoolFeeRateBase
+	pool.TotalReward += value
+	//没有存款时收益全部归运营方
+	if pool.TotalDeposit == 0 {
+		pool.TotalFee += value
+		return value
+	}
+	units := new(big.Int).Mul(big.NewInt(value-fee), big.NewInt(ty.PoolRewardScale))
+	units.Add(units, big.NewInt(pool.Dust))
+	inc, dust := new(big.Int).DivMod(units, big.NewInt(pool.TotalDeposit), new(big.Int))
+	pool.AccRewardPerShare += inc.Int64()
+	pool.Dust = dust.Int64()
+	pool.TotalFee += fee
+	return fee
+}
+
+func (action *Action) poolLog(prev, current *ty.TicketPool) *types.ReceiptLog {
+	return &types.ReceiptLog{Ty: ty.TyLogTicketPool, Log: types.Encode(&ty.ReceiptTicketPool{Prev: prev, Current: current})}
+}
+
+func (action *Action) savePool(pool *ty.TicketPool) []*types.KeyValue {
+	kv := &types.KeyValue{Key: calcPoolKey(pool.PoolId), Value: types.Encode(pool)}
+	action.db.Set(kv.Key, kv.Value)
+	return []*types.KeyValue{kv}
+}
+
+func (action *Action) saveDepositor(prev, dep *ty.TicketPoolDepositor) ([]*types.KeyValue, *types.ReceiptLog) {
+	kv := &types.KeyValue{Key: calcPoolDepositorKey(dep.PoolId, dep.Addr), Value: types.Encode(dep)}
+	action.db.Set(kv.Key, kv.Value)
+	log := &types.ReceiptLog{
+		Ty:  ty.TyLogTicketPoolDepositor,
+		Log: types.Encode(&ty.ReceiptTicketPoolDepositor{Prev: prev, Current: dep}),
+	}
+	return []*types.KeyValue{kv}, log
+}
+
+// PoolCreate 创建矿池, 矿池地址绑定运营方为挖矿地址
+func (action *Action) PoolCreate(create *ty.TicketPoolCreate) (*types.Receipt, error) {
+	if create.FeeRate < 0 || create.FeeRate > ty.PoolFeeRateBase {
+		return nil, ty.ErrPoolFeeRate
+	}
+	poolID := common.ToHex(action.txhash)
+	pool := &ty.TicketPool{
+		PoolId:      poolID,
+		PoolAddress: calcPoolAddress(poolID),
+		Operator:    action.fromaddr,
+		FeeRate:     create.FeeRate,
+		CreateTime:  action.blocktime,
+	}
+	if action.getBind(pool.PoolAddress) != "" {
+		return nil, ty.ErrPoolAddrBind
+	}
+	var logs []*types.ReceiptLog
+	var kv []*types.KeyValue
+
+	tbind := &ty.TicketBind{MinerAddress: action.fromaddr, ReturnAddress: pool.PoolAddress}
+	saveBind(action.db, tbind)
+	kv = append(kv, getBindKV(tbind)...)
+	logs = append(logs, getBindLog(tbind, ""))
+
+	addrKV := &types.KeyValue{Key: calcPoolAddrKey(pool.PoolAddress), Value: []byte(poolID)}
+	action.db.Set(addrKV.Key, addrKV.Value)
+	kv = append(kv, addrKV)
+	kv = append(kv, action.savePool(pool)...)
+	logs = append(logs, action.poolLog(nil, pool))
+	return &types.Receipt{Ty: types.ExecOk, KV: kv, Logs: logs}, nil
+}
+
+// PoolDeposit 把ticket合约中的可用余额存入矿池
+func (action *Action) PoolDeposit(deposit *ty.TicketPoolDeposit) (*types.Receipt, error) {
+	if deposit.Amount <= 0 {
+		return nil, ty.ErrPoolAmount
+	}
+	pool, err := getPool(action.db, deposit.PoolId)
+	if err != nil {
+		return nil, err
+	}
+	prevDep, dep, err := loadPoolDepositor(action.db, pool.PoolId, action.fromaddr)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := action.coinsAccount.ExecTransfer(action.fromaddr, pool.PoolAddress, action.execaddr, deposit.Amount)
+	if err != nil {
+		tlog.Error("PoolDeposit.ExecTransfer", "addr", action.fromaddr, "pool", pool.PoolAddress, "amount", deposit.Amount)
+		return nil, err
+	}
+	prevPool := proto.Clone(pool).(*ty.TicketPool)
+	settlePoolDepositor(pool, dep)
+	if dep.Amount == 0 {
+		pool.Depositors++
+	}
+	dep.Amount += deposit.Amount
+	dep.RewardDebt = calcPoolReward(dep.Amount, pool.AccRewardPerShare)
+	pool.TotalDeposit += deposit.Amount
+
+	logs := receipt.Logs
+	kv := receipt.KV
+	depKV, depLog := action.saveDepositor(prevDep, dep)
+	kv = append(kv, depKV...)
+	kv = append(kv, action.savePool(pool)...)
+	logs = append(logs, depLog, action.poolLog(prevPool, pool))
+	return &types.Receipt{Ty: types.ExecOk, KV: kv, Logs: logs}, nil
+}
+
+// PoolWithdraw 先提取收益再提取本金, 矿池可用余额不足时保留申请的金额
+func (action *Action) PoolWithdraw(withdraw *ty.TicketPoolWithdraw) (*types.Receipt, error) {
+	pool, err := getPool(action.db, withdraw.PoolId)
+	if err != nil {
+		return nil, err
+	}
+	dep, err := getPoolDepositor(action.db, pool.PoolId, action.fromaddr)
+	if err != nil {
+		return nil, ty.ErrPoolAmount
+	}
+	prevDep := proto.Clone(dep).(*ty.TicketPoolDepositor)
+	prevPool := proto.Clone(pool).(*ty.TicketPool)
+	settlePoolDepositor(pool, dep)
+	if withdraw.Amount <= 0 || withdraw.Amount > dep.Reward+dep.Amount {
+		return nil, ty.ErrPoolAmount
+	}
+
+	var logs []*types.ReceiptLog
+	var kv []*types.KeyValue
+	//其他存款人保留的金额不能提取
+	balance := action.coinsAccount.LoadExecAccount(pool.PoolAddress, action.execaddr).Balance
+	if balance-(pool.Reserved-dep.Reserved) < withdraw.Amount {
+		pool.Reserved += withdraw.Amount - dep.Reserved
+		dep.Reserved = withdraw.Amount
+		tlog.Info("PoolWithdraw reserved", "pool", pool.PoolId, "addr", dep.Addr, "amount", withdraw.Amount, "balance", balance)
+	} else {
+		receipt, err := action.coinsAccount.ExecTransfer(pool.PoolAddress, action.fromaddr, action.execaddr, withdraw.Amount)
+		if err != nil {
+			tlog.Error("PoolWithdraw.ExecTransfer", "pool", pool.PoolAddress, "addr", action.fromaddr, "amount", withdraw.Amount)
+			return nil, err
+		}
+		logs = append(logs, receipt.Logs...)
+		kv = append(kv, receipt.KV...)
+		pool.Reserved -= dep.Reserved
+		dep.Reserved = 0
+
+		fromReward := withdraw.Amount
+		if fromReward > dep.Reward {
+			fromReward = dep.Reward
+		}
+		principal := withdraw.Amount - fromReward
+		dep.Reward -= fromReward
+		if principal > 0 {
+			dep.Amount -= principal
+			pool.TotalDeposit -= principal
+			if dep.Amount == 0 {
+				pool.Depositors--
+			}
+		}
+		dep.RewardDebt = calcPoolReward(dep.Amount, pool.AccRewardPerShare)
+	}
+	depKV, depLog := action.saveDepositor(prevDep, dep)
+	kv = append(kv, depKV...)
+	kv = append(kv, action.savePool(pool)...)
+	logs = append(logs, depLog, action.poolLog(prevPool, pool))
+	return &types.Receipt{Ty: types.ExecOk, KV: kv, Logs: logs}, nil
+}
+
+// checkPoolOpen 矿池购买ticket不能使用存款人保留提取的金额
+func (action *Action) checkPoolOpen(returnAddr string, amount int64) error {
+	pool, err := getPoolByAddr(action.db, returnAddr)
+	if err == ty.ErrPoolNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	balance := action.coinsAccount.LoadExecAccount(pool.PoolAddress, action.execaddr).Balance
+	if balance-pool.Reserved < amount {
+		tlog.Error("TicketOpen pool reserved", "pool", pool.PoolId, "balance", balance, "reserved", pool.Reserved, "amount", amount)
+		return ty.ErrPoolReserved
+	}
+	return nil
+}
+
+// checkPoolClose 有保留提取申请的存款人可以关闭矿池的ticket, closed为本交易已经关闭的金额
+// 矿池可用余额加上已经关闭的金额足够支付保留提取金额后, 不能再关闭更多的ticket
+func (action *Action) checkPoolClose(returnAddr string, closed int64) bool {
+	pool, err := getPoolByAddr(action.db, returnAddr)
+	if err != nil {
+		return false
+	}
+	dep, err := getPoolDepositor(action.db, pool.PoolId, action.fromaddr)
+	if err != nil || dep.Reserved <= 0 {
+		return false
+	}
+	balance := action.coinsAccount.LoadExecAccount(pool.PoolAddress, action.execaddr).Balance
+	if balance+closed >= pool.Reserved {
+		tlog.Error("TicketClose pool reserved covered", "pool", pool.PoolId, "balance", balance, "closed", closed, "reserved", pool.Reserved)
+		return false
+	}
+	return true
+}
+
+// poolCloseValue 关闭ticket后返还到矿池可用余额的金额
+func poolCloseValue(cfg *types.Chain33Config, ticket *ty.Ticket) int64 {
+	value := (&DB{Ticket: *ticket}).GetRealPrice(cfg)
+	if ticket.Status == ty.TicketMined {
+		value += ticket.MinerValue
+	}
+	return value
+}
+
+// poolMinerReward 矿池的ticket挖矿成功时分配收益
+func (action *Action) poolMinerReward(returnAddr string, value int64) ([]*types.KeyValue, []*types.ReceiptLog, error) {
+	pool, err := getPoolByAddr(action.db, returnAddr)
+	if err == ty.ErrPoolNotFound {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	prevPool := proto.Clone(pool).(*ty.TicketPool)
+	fee := distributePoolReward(pool, value)
+
+	var logs []*types.ReceiptLog
+	var kv []*types.KeyValue
+	if fee > 0 {
+		prevOp, op, err := loadPoolDepositor(action.db, pool.PoolId, pool.Operator)
+		if err != nil {
+			return nil, nil, err
+		}
+		settlePoolDepositor(pool, op)
+		op.Reward += fee
+		opKV, opLog := action.saveDepositor(prevOp, op)
+		kv = append(kv, opKV...)
+		logs = append(logs, opLog)
+	}
+	kv = append(kv, action.savePool(pool)...)
+	logs = append(logs, action.poolLog(prevPool, pool))
+	return kv, logs, nil
+}
+
+func calcPoolListKey(poolID string) []byte {
+	return []byte(fmt.Sprintf("LODB-ticket-pool:%s", poolID))
+}
+
+func calcPoolOperatorKey(operator, poolID string) []byte {
+	return []byte(fmt.Sprintf("LODB-ticket-poolop:%s:%s", address.FormatAddrKey(operator), poolID))
+}
+
+func calcPoolDepositorListKey(poolID, addr string) []byte {
+	return []byte(fmt.Sprintf("LODB-ticket-pooldep:%s:%s", poolID, address.FormatAddrKey(addr)))
+}
+
+func calcDepositorPoolKey(addr, poolID string) []byte {
+	return []byte(fmt.Sprintf("LODB-ticket-deppool:%s:%s", address.FormatAddrKey(addr), poolID))
+}
+
+// poolLocalKV 新建矿池和新的存款人时添加索引, 回滚时删除
+func poolLocalKV(item *types.ReceiptLog, isDel bool) ([]*types.KeyValue, error) {
+	var kvs []*types.KeyValue
+	switch item.Ty {
+	case ty.TyLogTicketPool:
+		var r ty.ReceiptTicketPool
+		if err := types.Decode(item.Log, &r); err != nil {
+			return nil, err
+		}
+		if r.Prev != nil {
+			return nil, nil
+		}
+		pool := r.Current
+		kvs = append(kvs, &types.KeyValue{Key: calcPoolListKey(pool.PoolId), Value: []byte(pool.PoolId)},
+			&types.KeyValue{Key: calcPoolOperatorKey(pool.Operator, pool.PoolId), Value: []byte(pool.PoolId)})
+	case ty.TyLogTicketPoolDepositor:
+		var r ty.ReceiptTicketPoolDepositor
+		if err := types.Decode(item.Log, &r); err != nil {
+			return nil, err
+		}
+		if r.Prev != nil {
+			return nil, nil
+		}
+		dep := r.Current
+		kvs = append(kvs, &types.KeyValue{Key: calcPoolDepositorListKey(dep.PoolId, dep.Addr), Value: []byte(dep.Addr)},
+			&types.KeyValue{Key: calcDepositorPoolKey(dep.Addr, dep.PoolId), Value: []byte(dep.PoolId)})
+	}
+	if isDel {
+		for _, kv := range kvs {
+			kv.Value = nil
+		}
+	}
+	return kvs, nil
+}
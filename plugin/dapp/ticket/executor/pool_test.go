@@ -0,0 +1,211 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package executor
+
+import (
+	"testing"
+
+	"github.com/33cn/chain33/account"
+	"github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/crypto"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/system/dapp"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/chain33/util"
+	ty "github.com/33cn/plugin/plugin/dapp/ticket/types"
+	"github.com/stretchr/testify/require"
+)
+
+func execPoolReceipt(t *testing.T, state dbm.KV, receipt *types.Receipt, err error) {
+	require.Nil(t, err)
+	for _, kv := range receipt.KV {
+		require.Nil(t, state.Set(kv.Key, kv.Value))
+	}
+}
+
+func ticketIDs(t *testing.T, receipt *types.Receipt) []string {
+	var ids []string
+	for _, log := range receipt.Logs {
+		if log.Ty == ty.TyLogNewTicket {
+			var r ty.ReceiptTicket
+			require.Nil(t, types.Decode(log.Log, &r))
+			ids = append(ids, r.TicketId)
+		}
+	}
+	return ids
+}
+
+func TestDistributePoolReward(t *testing.T) {
+	pool := &ty.TicketPool{FeeRate: 1000, TotalDeposit: 4e12}
+	fee := distributePoolReward(pool, 1800000001)
+	require.Equal(t, int64(180000000), fee)
+	require.Equal(t, int64(40500), pool.AccRewardPerShare)
+	require.Equal(t, int64(1e8), pool.Dust)
+	require.Equal(t, int64(1215000000), calcPoolReward(3e12, pool.AccRewardPerShare))
+	require.Equal(t, int64(405000000), calcPoolReward(1e12, pool.AccRewardPerShare))
+
+	//余数累计到下次分配
+	distributePoolReward(pool, 1800000001)
+	require.Equal(t, int64(81000), pool.AccRewardPerShare)
+	require.Equal(t, int64(2e8), pool.Dust)
+	require.Equal(t, int64(3600000002), pool.TotalReward)
+	require.Equal(t, int64(360000000), pool.TotalFee)
+
+	//没有存款时收益全部归运营方
+	empty := &ty.TicketPool{FeeRate: 1000}
+	require.Equal(t, int64(100), distributePoolReward(empty, 100))
+	require.Equal(t, int64(0), empty.AccRewardPerShare)
+}
+
+func TestTicketPool(t *testing.T) {
+	tk := newTicket().(*Ticket)
+	dir, state, localdb := util.CreateTestDB()
+	defer util.CloseTestDB(dir, state)
+	api := &mocks.QueueProtocolAPI{}
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	api.On("GetConfig").Return(cfg)
+	tk.SetAPI(api)
+	tk.SetStateDB(state)
+	tk.SetLocalDB(localdb)
+	tk.SetEnv(10, 100, 0)
+	ety := ty.NewType(cfg)
+
+	execAddr := dapp.ExecAddress(driverName)
+	coins := account.NewCoinsAccount(cfg)
+	coins.SetDB(state)
+	newTx := func(action string, payload types.Message, priv crypto.PrivKey) *types.Transaction {
+		tx, err := ety.CreateTransaction(action, payload)
+		require.Nil(t, err)
+		tx.Execer = []byte(driverName)
+		tx.Sign(types.SECP256K1, priv)
+		return tx
+	}
+	execLocal := func(receipt *types.Receipt) {
+		set, err := tk.execLocal(&types.ReceiptData{Ty: receipt.Ty, Logs: receipt.Logs})
+		require.Nil(t, err)
+		for _, kv := range set.KV {
+			require.Nil(t, localdb.Set(kv.Key, kv.Value))
+		}
+	}
+	operator, opPriv := util.Genaddress()
+	depA, privA := util.Genaddress()
+	depB, privB := util.Genaddress()
+	coins.SaveExecAccount(execAddr, &types.Account{Addr: depA, Balance: 3e12})
+	coins.SaveExecAccount(execAddr, &types.Account{Addr: depB, Balance: 1e12})
+
+	_, err := tk.Exec_PoolCreate(&ty.TicketPoolCreate{FeeRate: ty.PoolFeeRateBase + 1}, newTx("PoolCreate", &ty.TicketPoolCreate{}, opPriv), 0)
+	require.Equal(t, ty.ErrPoolFeeRate, err)
+	createTx := newTx("PoolCreate", &ty.TicketPoolCreate{FeeRate: 1000}, opPriv)
+	receipt, err := tk.Exec_PoolCreate(&ty.TicketPoolCreate{FeeRate: 1000}, createTx, 0)
+	execPoolReceipt(t, state, receipt, err)
+	execLocal(receipt)
+	reply, err := tk.Query_PoolInfo(&types.ReqString{Data: calcPoolAddress(common.ToHex(createTx.Hash()))})
+	require.Nil(t, err)
+	pool := reply.(*ty.ReplyTicketPool).Pool
+	require.Equal(t, operator, pool.Operator)
+	require.Equal(t, operator, NewAction(tk, createTx).getBind(pool.PoolAddress))
+
+	deposit := func(addr string, amount int64, priv crypto.PrivKey) {
+		payload := &ty.TicketPoolDeposit{PoolId: pool.PoolId, Amount: amount}
+		receipt, err := tk.Exec_PoolDeposit(payload, newTx("PoolDeposit", payload, priv), 0)
+		execPoolReceipt(t, state, receipt, err)
+		execLocal(receipt)
+	}
+	deposit(depA, 3e12, privA)
+	deposit(depB, 1e12, privB)
+	reply, err = tk.Query_PoolInfo(&types.ReqString{Data: pool.PoolId})
+	require.Nil(t, err)
+	require.Equal(t, int64(4e12), reply.(*ty.ReplyTicketPool).Balance)
+	require.Equal(t, int32(2), reply.(*ty.ReplyTicketPool).Pool.Depositors)
+
+	//运营方用矿池地址的币购买ticket
+	topen := &ty.TicketOpen{MinerAddress: operator, ReturnAddress: pool.PoolAddress, Count: 3, PubHashes: [][]byte{{1}, {2}, {3}}}
+	openTx := newTx("Topen", topen, opPriv)
+	receipt, err = tk.Exec_Topen(topen, openTx, 0)
+	execPoolReceipt(t, state, receipt, err)
+	poolIDs := ticketIDs(t, receipt)
+	require.Equal(t, 3, len(poolIDs))
+	require.Equal(t, int64(1e12), coins.LoadExecAccount(pool.PoolAddress, execAddr).Balance)
+
+	//模拟矿池的ticket挖矿收益
+	action := NewAction(tk, openTx)
+	receipt, err = coins.ExecDepositFrozen(pool.PoolAddress, execAddr, 1800000001)
+	execPoolReceipt(t, state, receipt, err)
+	kv, logs, err := action.poolMinerReward(pool.PoolAddress, 1800000001)
+	execPoolReceipt(t, state, &types.Receipt{KV: kv}, err)
+	execLocal(&types.Receipt{Ty: types.ExecOk, Logs: logs})
+	dep, err := tk.Query_PoolDepositor(&ty.ReqTicketPoolDepositor{PoolId: pool.PoolId, Addr: depB})
+	require.Nil(t, err)
+	require.Equal(t, int64(405000000), dep.(*ty.TicketPoolDepositor).Reward)
+	dep, err = tk.Query_PoolDepositor(&ty.ReqTicketPoolDepositor{PoolId: pool.PoolId, Addr: operator})
+	require.Nil(t, err)
+	require.Equal(t, int64(180000000), dep.(*ty.TicketPoolDepositor).Reward)
+
+	//存款人不能关闭矿池的ticket
+	topen = &ty.TicketOpen{MinerAddress: operator, ReturnAddress: pool.PoolAddress, Count: 1, PubHashes: [][]byte{{4}}}
+	openReceipt, err := tk.Exec_Topen(topen, newTx("Topen", topen, opPriv), 0)
+	require.Nil(t, err)
+	ids := ticketIDs(t, openReceipt)
+	require.Equal(t, 1, len(ids))
+	execPoolReceipt(t, state, openReceipt, err)
+	tk.SetEnv(20, 200, 0)
+	tclose := &ty.TicketClose{TicketId: ids}
+	_, err = tk.Exec_Tclose(tclose, newTx("Tclose", tclose, privB), 0)
+	require.Equal(t, types.ErrFromAddr, err)
+
+	//余额不足时保留提取金额, 运营方不能再用保留的金额购买ticket
+	withdraw := &ty.TicketPoolWithdraw{PoolId: pool.PoolId, Amount: 1e12 + 405000001}
+	_, err = tk.Exec_PoolWithdraw(withdraw, newTx("PoolWithdraw", withdraw, privB), 0)
+	require.Equal(t, ty.ErrPoolAmount, err)
+	withdraw.Amount = 1e12 + 405000000
+	receipt, err = tk.Exec_PoolWithdraw(withdraw, newTx("PoolWithdraw", withdraw, privB), 0)
+	execPoolReceipt(t, state, receipt, err)
+	pool, err = getPool(state, pool.PoolId)
+	require.Nil(t, err)
+	require.Equal(t, withdraw.Amount, pool.Reserved)
+	require.Equal(t, int64(0), coins.LoadExecAccount(depB, execAddr).Balance)
+	poolAcc := coins.LoadExecAccount(pool.PoolAddress, execAddr)
+	poolAcc.Balance += 1e12
+	coins.SaveExecAccount(execAddr, poolAcc)
+	topen = &ty.TicketOpen{MinerAddress: operator, ReturnAddress: pool.PoolAddress, Count: 1, PubHashes: [][]byte{{5}}}
+	_, err = tk.Exec_Topen(topen, newTx("Topen", topen, opPriv), 0)
+	require.Equal(t, ty.ErrPoolReserved, err)
+
+	//有保留申请的存款人只能关闭足够支付保留金额的ticket, 关闭后完成提取
+	over := &ty.TicketClose{TicketId: append([]string{ids[0]}, poolIDs[0])}
+	_, err = tk.Exec_Tclose(over, newTx("Tclose", over, privB), 0)
+	require.Equal(t, types.ErrFromAddr, err)
+	receipt, err = tk.Exec_Tclose(tclose, newTx("Tclose", tclose, privB), 0)
+	execPoolReceipt(t, state, receipt, err)
+	require.Equal(t, int64(2e12), coins.LoadExecAccount(pool.PoolAddress, execAddr).Balance)
+	over = &ty.TicketClose{TicketId: poolIDs[1:2]}
+	_, err = tk.Exec_Tclose(over, newTx("Tclose", over, privB), 0)
+	require.Equal(t, types.ErrFromAddr, err)
+	receipt, err = tk.Exec_PoolWithdraw(withdraw, newTx("PoolWithdraw", withdraw, privB), 0)
+	execPoolReceipt(t, state, receipt, err)
+	require.Equal(t, withdraw.Amount, coins.LoadExecAccount(depB, execAddr).Balance)
+	pool, err = getPool(state, pool.PoolId)
+	require.Nil(t, err)
+	require.Equal(t, int64(0), pool.Reserved)
+	require.Equal(t, int64(3e12), pool.TotalDeposit)
+	require.Equal(t, int32(1), pool.Depositors)
+
+	//本地索引
+	pools, err := tk.Query_PoolList(&types.ReqString{})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(pools.(*ty.ReplyTicketPools).Pools))
+	pools, err = tk.Query_PoolList(&types.ReqString{Data: depB})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(pools.(*ty.ReplyTicketPools).Pools))
+	deps, err := tk.Query_PoolDepositors(&types.ReqString{Data: pool.PoolId})
+	require.Nil(t, err)
+	require.Equal(t, 3, len(deps.(*ty.ReplyTicketPoolDepositors).Depositors))
+
+	set, err := tk.execDelLocal(&types.ReceiptData{Ty: types.ExecOk, Logs: logs})
+	require.Nil(t, err)
+	require.Equal(t, 2, len(set.KV))
+	require.Nil(t, set.KV[0].Value)
+}
@@ -5,6 +5,7 @@
 package executor
 
 import (
+	"github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
 	pty "github.com/33cn/plugin/plugin/dapp/ticket/types"
 )
@@ -49,3 +50,86 @@ func (ticket *Ticket) Query_MinerSourceList(param *types.ReqString) (types.Messa
 func (ticket *Ticket) Query_RandNumHash(param *types.ReqRandHash) (types.Message, error) {
 	return ticket.GetRandNum(param.Hash, param.BlockNum)
 }
+
+func (ticket *Ticket) getPoolReply(pool *pty.TicketPool) *pty.ReplyTicketPool {
+	acc := ticket.GetCoinsAccount().LoadExecAccount(pool.PoolAddress, dapp.ExecAddress(driverName))
+	return &pty.ReplyTicketPool{Pool: pool, Balance: acc.Balance, Frozen: acc.Frozen}
+}
+
+// Query_PoolInfo query pool by pool id or pool address
+func (ticket *Ticket) Query_PoolInfo(param *types.ReqString) (types.Message, error) {
+	pool, err := getPool(ticket.GetStateDB(), param.Data)
+	if err == pty.ErrPoolNotFound {
+		pool, err = getPoolByAddr(ticket.GetStateDB(), param.Data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ticket.getPoolReply(pool), nil
+}
+
+// Query_PoolList query all pools, or pools operated or deposited by the address
+func (ticket *Ticket) Query_PoolList(param *types.ReqString) (types.Message, error) {
+	var prefixes [][]byte
+	if param.Data == "" {
+		prefixes = append(prefixes, []byte("LODB-ticket-pool:"))
+	} else {
+		prefixes = append(prefixes, calcPoolOperatorKey(param.Data, ""), calcDepositorPoolKey(param.Data, ""))
+	}
+	reply := &pty.ReplyTicketPools{}
+	found := make(map[string]bool)
+	for _, prefix := range prefixes {
+		values, err := ticket.GetLocalDB().List(prefix, nil, 0, 1)
+		if err != nil && err != types.ErrNotFound {
+			return nil, err
+		}
+		for _, value := range values {
+			if found[string(value)] {
+				continue
+			}
+			found[string(value)] = true
+			pool, err := getPool(ticket.GetStateDB(), string(value))
+			if err != nil {
+				continue
+			}
+			reply.Pools = append(reply.Pools, ticket.getPoolReply(pool))
+		}
+	}
+	return reply, nil
+}
+
+// Query_PoolDepositor query depositor of pool, the reward includes unsettled reward
+func (ticket *Ticket) Query_PoolDepositor(param *pty.ReqTicketPoolDepositor) (types.Message, error) {
+	pool, err := getPool(ticket.GetStateDB(), param.PoolId)
+	if err != nil {
+		return nil, err
+	}
+	dep, err := getPoolDepositor(ticket.GetStateDB(), param.PoolId, param.Addr)
+	if err != nil {
+		return nil, err
+	}
+	settlePoolDepositor(pool, dep)
+	return dep, nil
+}
+
+// Query_PoolDepositors query all depositors of pool
+func (ticket *Ticket) Query_PoolDepositors(param *types.ReqString) (types.Message, error) {
+	pool, err := getPool(ticket.GetStateDB(), param.Data)
+	if err != nil {
+		return nil, err
+	}
+	values, err := ticket.GetLocalDB().List(calcPoolDepositorListKey(pool.PoolId, ""), nil, 0, 1)
+	if err != nil && err != types.ErrNotFound {
+		return nil, err
+	}
+	reply := &pty.ReplyTicketPoolDepositors{}
+	for _, value := range values {
+		dep, err := getPoolDepositor(ticket.GetStateDB(), pool.PoolId, string(value))
+		if err != nil {
+			continue
+		}
+		settlePoolDepositor(pool, dep)
+		reply.Depositors = append(reply.Depositors, dep)
+	}
+	return reply, nil
+}
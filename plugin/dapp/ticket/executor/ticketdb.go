@@ -251,6 +251,11 @@ func (action *Action) TicketOpen(topen *ty.TicketOpen) (*types.Receipt, error) {
 	}
 	//action.fromaddr == topen.ReturnAddress or mineraddr == action.fromaddr
 	cfg := ty.GetTicketMinerParam(chain33Cfg, action.height)
+	if chain33Cfg.IsDappFork(action.height, ty.TicketX, ty.ForkTicketPool) {
+		if err := action.checkPoolOpen(topen.ReturnAddress, int64(topen.Count)*cfg.TicketPrice); err != nil {
+			return nil, err
+		}
+	}
 	for i := 0; i < int(topen.Count); i++ {
 		id := prefix + fmt.Sprintf("%010d", i)
 		//add pubHash
@@ -375,6 +380,15 @@ func (action *Action) TicketMiner(miner *ty.TicketMiner, index int) (*types.Rece
 	kv = append(kv, receipt1.KV...)
 	logs = append(logs, receipt2.Logs...)
 	kv = append(kv, receipt2.KV...)
+	//矿池的ticket按存款比例分配收益
+	if chain33Cfg.IsDappFork(action.height, ty.TicketX, ty.ForkTicketPool) {
+		poolKV, poolLogs, err := action.poolMinerReward(t.ReturnAddress, ticket.MinerValue)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, poolLogs...)
+		kv = append(kv, poolKV...)
+	}
 	return &types.Receipt{Ty: types.ExecOk, KV: kv, Logs: logs}, nil
 }
 
@@ -383,6 +397,8 @@ func (action *Action) TicketClose(tclose *ty.TicketClose) (*types.Receipt, error
 	chain33Cfg := action.api.GetConfig()
 	tickets := make([]*DB, len(tclose.TicketId))
 	cfg := ty.GetTicketMinerParam(chain33Cfg, action.height)
+	//存款人关闭矿池ticket的金额
+	poolClosed := make(map[string]int64)
 	for i := 0; i < len(tclose.TicketId); i++ {
 		ticket, err := readTicket(action.db, tclose.TicketId[i])
 		if err != nil {
@@ -408,7 +424,10 @@ func (action *Action) TicketClose(tclose *ty.TicketClose) (*types.Receipt, error
 		}
 		//check from address
 		if action.fromaddr != ticket.MinerAddress && action.fromaddr != ticket.ReturnAddress {
-			return nil, types.ErrFromAddr
+			if !chain33Cfg.IsDappFork(action.height, ty.TicketX, ty.ForkTicketPool) || !action.checkPoolClose(ticket.ReturnAddress, poolClosed[ticket.ReturnAddress]) {
+				return nil, types.ErrFromAddr
+			}
+			poolClosed[ticket.ReturnAddress] += poolCloseValue(chain33Cfg, ticket)
 		}
 		prevstatus := ticket.Status
 		ticket.Status = ty.TicketClosed
@@ -29,4 +29,14 @@ var (
 	ErrNoVrf = errors.New("ErrNoVrf")
 	// ErrVrfVerify err type
 	ErrVrfVerify = errors.New("ErrVrfVerify")
+	// ErrPoolNotFound err type
+	ErrPoolNotFound = errors.New("ErrPoolNotFound")
+	// ErrPoolFeeRate err type
+	ErrPoolFeeRate = errors.New("ErrPoolFeeRate")
+	// ErrPoolAmount err type
+	ErrPoolAmount = errors.New("ErrPoolAmount")
+	// ErrPoolReserved err type
+	ErrPoolReserved = errors.New("ErrPoolReserved")
+	// ErrPoolAddrBind err type
+	ErrPoolAddrBind = errors.New("ErrPoolAddrBind")
 )
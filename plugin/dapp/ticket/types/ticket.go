@@ -36,6 +36,10 @@ const (
 	TyLogMinerTicket = 113
 	// TyLogTicketBind bind ticket log type
 	TyLogTicketBind = 114
+	// TyLogTicketPool pool log type
+	TyLogTicketPool = 115
+	// TyLogTicketPoolDepositor pool depositor log type
+	TyLogTicketPoolDepositor = 116
 )
 
 //ticket
@@ -54,6 +58,21 @@ const (
 	TicketActionMiner = 16
 	// TicketActionBind action bind
 	TicketActionBind = 17
+	// TicketActionPoolCreate action create pool
+	TicketActionPoolCreate = 18
+	// TicketActionPoolDeposit action deposit to pool
+	TicketActionPoolDeposit = 19
+	// TicketActionPoolWithdraw action withdraw from pool
+	TicketActionPoolWithdraw = 20
+)
+
+const (
+	// ForkTicketPool 支持矿池
+	ForkTicketPool = "ForkTicketPool"
+	// PoolFeeRateBase 矿池手续费的基数, 万分比
+	PoolFeeRateBase = 10000
+	// PoolRewardScale 每单位存款收益的放大倍数
+	PoolRewardScale = 1e8
 )
 
 // TicketOldParts old tick type
@@ -80,6 +99,7 @@ func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(TicketX, "Enable", 0)
 	cfg.RegisterDappFork(TicketX, "ForkTicketId", 0)
 	cfg.RegisterDappFork(TicketX, "ForkTicketVrf", 0)
+	cfg.RegisterDappFork(TicketX, ForkTicketPool, types.MaxHeight)
 }
 
 //InitExecutor ...
@@ -112,6 +132,9 @@ func (ticket *TicketType) GetLogMap() map[int64]*types.LogInfo {
 		TyLogCloseTicket: {Ty: reflect.TypeOf(ReceiptTicket{}), Name: "LogCloseTicket"},
 		TyLogMinerTicket: {Ty: reflect.TypeOf(ReceiptTicket{}), Name: "LogMinerTicket"},
 		TyLogTicketBind:  {Ty: reflect.TypeOf(ReceiptTicketBind{}), Name: "LogTicketBind"},
+
+		TyLogTicketPool:          {Ty: reflect.TypeOf(ReceiptTicketPool{}), Name: "LogTicketPool"},
+		TyLogTicketPoolDepositor: {Ty: reflect.TypeOf(ReceiptTicketPoolDepositor{}), Name: "LogTicketPoolDepositor"},
 	}
 }
 
@@ -142,6 +165,10 @@ func (ticket *TicketType) GetTypeMap() map[string]int32 {
 		"Tbind":   TicketActionBind,
 		"Tclose":  TicketActionClose,
 		"Miner":   TicketActionMiner,
+
+		"PoolCreate":   TicketActionPoolCreate,
+		"PoolDeposit":  TicketActionPoolDeposit,
+		"PoolWithdraw": TicketActionPoolWithdraw,
 	}
 }
 
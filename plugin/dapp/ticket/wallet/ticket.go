@@ -767,6 +767,15 @@ func (policy *ticketPolicy) getMinerColdAddr(addr string) ([]string, error) {
 	return reply.Datas, nil
 }
 
+// getPoolReserved 矿池地址中存款人保留提取的金额不能购买ticket
+func (policy *ticketPolicy) getPoolReserved(addr string) int64 {
+	msg, err := policy.walletOperate.GetAPI().Query(ty.TicketX, "PoolInfo", &types.ReqString{Data: addr})
+	if err != nil {
+		return 0
+	}
+	return msg.(*ty.ReplyTicketPool).GetPool().GetReserved()
+}
+
 func (policy *ticketPolicy) initMinerWhiteList(cfg *types.Wallet) {
 	if len(policy.cfg.Minerwhitelist) == 0 {
 		minerAddrWhiteList["*"] = true
@@ -814,7 +823,7 @@ func (policy *ticketPolicy) buyMinerAddrTicketOne(height int64, priv crypto.Priv
 		if err != nil {
 			return nil, 0, err
 		}
-		count := acc.Balance / cfg.TicketPrice
+		count := (acc.Balance - policy.getPoolReserved(addrs[i])) / cfg.TicketPrice
 		if count > 0 {
 			txhash, err := policy.openticket(addr, addrs[i], priv, addressID, int32(count))
 			if err != nil {
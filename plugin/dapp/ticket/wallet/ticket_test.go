@@ -135,12 +135,20 @@ func TestBuyMinerAddrTicketOne(t *testing.T) {
 
 	tlist := &types.ReplyStrings{Datas: []string{"12qyocayNF7Lv6C9qW4avxs2E7U41fKSfv"}}
 	qapi.On("Query", ty.TicketX, "MinerSourceList", mock.Anything).Return(tlist, nil)
+	qapi.On("Query", ty.TicketX, "PoolInfo", mock.Anything).Return(nil, ty.ErrPoolNotFound).Once()
 
 	hashs, r2, r3 := ticket.buyMinerAddrTicketOne(0, priKey, address.DefaultID)
 	assert.Equal(t, [][]byte{[]byte(sendhash)}, hashs)
 	assert.Equal(t, 10, r2)
 	assert.Nil(t, r3)
 
+	//矿池地址保留提取的金额不能购买ticket
+	pool := &ty.ReplyTicketPool{Pool: &ty.TicketPool{Reserved: 3 * ty.GetTicketMinerParam(cfg, 0).TicketPrice}}
+	qapi.On("Query", ty.TicketX, "PoolInfo", mock.Anything).Return(pool, nil).Once()
+	_, r2, r3 = ticket.buyMinerAddrTicketOne(0, priKey, address.DefaultID)
+	assert.Equal(t, 7, r2)
+	assert.Nil(t, r3)
+
 }
 
 type walletOperateMock struct {
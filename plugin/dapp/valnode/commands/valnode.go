@@ -43,6 +43,7 @@ func ValCmd() *cobra.Command {
 		GetBlockInfoCmd(),
 		GetNodeInfoCmd(),
 		GetPerfStatCmd(),
+		ListEvidenceCmd(),
 		AddNodeCmd(),
 		CreateCmd(),
 	)
@@ -116,6 +117,47 @@ func getBlockInfo(cmd *cobra.Command, args []string) {
 	ctx.Run()
 }
 
+// ListEvidenceCmd list evidence of double signing
+func ListEvidenceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "evidence",
+		Short: "List evidence of validators double signing",
+		Run:   listEvidence,
+	}
+	addListEvidenceFlags(cmd)
+	return cmd
+}
+
+func addListEvidenceFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64P("height", "t", 0, "start from the evidence packed at this height, 0 for the first or latest")
+	cmd.Flags().Int32P("index", "i", 0, "tx index of the start evidence in block")
+	cmd.Flags().Int32P("count", "c", 10, "evidence count")
+	cmd.Flags().Int32P("direction", "d", 0, "query direction, 0: desc, 1: asc")
+}
+
+func listEvidence(cmd *cobra.Command, args []string) {
+	rpcLaddr, _ := cmd.Flags().GetString("rpc_laddr")
+	height, _ := cmd.Flags().GetInt64("height")
+	index, _ := cmd.Flags().GetInt32("index")
+	count, _ := cmd.Flags().GetInt32("count")
+	direction, _ := cmd.Flags().GetInt32("direction")
+	req := &vt.ReqValNodeEvidences{
+		Height:    height,
+		Index:     index,
+		Count:     count,
+		Direction: direction,
+	}
+	params := rpctypes.Query4Jrpc{
+		Execer:   vt.ValNodeX,
+		FuncName: "ListValNodeEvidence",
+		Payload:  types.MustPBToJSON(req),
+	}
+
+	var res vt.ValNodeEvidenceRecords
+	ctx := jsonclient.NewRPCCtx(rpcLaddr, "Chain33.Query", params, &res)
+	ctx.Run()
+}
+
 // GetPerfStatCmd get block info
 func GetPerfStatCmd() *cobra.Command {
 	cmd := &cobra.Command{
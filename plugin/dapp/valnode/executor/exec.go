@@ -5,10 +5,14 @@
 package executor
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 
+	"github.com/33cn/chain33/common"
 	dbm "github.com/33cn/chain33/common/db"
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
 )
 
@@ -35,6 +39,190 @@ func (val *ValNode) Exec_BlockInfo(blockInfo *pty.TendermintBlockInfo, tx *types
 	return receipt, nil
 }
 
+// Exec_Evidence 验证作恶证据, 剔除作恶的验证者或者扣减投票权
+func (val *ValNode) Exec_Evidence(ev *pty.ValNodeEvidence, tx *types.Transaction, index int) (*types.Receipt, error) {
+	cfg := val.GetAPI().GetConfig()
+	if !cfg.IsDappFork(val.GetHeight(), pty.ValNodeX, pty.ForkValNodeEvidence) {
+		return nil, types.ErrActionNotSupport
+	}
+	if evidenceCrypto == nil {
+		return nil, types.ErrNotSupport
+	}
+	// 用证据高度区块中记录的共识状态验证, 该高度的区块必须已经上链
+	height, _, _ := ttypes.EvidenceHRK(ev)
+	if height <= 0 || height >= val.GetHeight() {
+		return nil, pty.ErrEvidenceHeight
+	}
+	state, err := val.getBlockState(height)
+	if err != nil {
+		clog.Error("Exec_Evidence getBlockState", "height", height, "err", err)
+		return nil, pty.ErrEvidenceHeight
+	}
+	maxAge := state.GetConsensusParams().GetEvidenceParams().GetMaxAge()
+	if maxAge > 0 && val.GetHeight()-height > maxAge {
+		return nil, pty.ErrEvidenceExpired
+	}
+	addr, err := ttypes.VerifyEvidence(state.GetChainID(), evidenceCrypto, ev)
+	if err != nil {
+		return nil, err
+	}
+	if findValidator(state.GetValidators(), addr).GetVotingPower() <= 0 {
+		return nil, pty.ErrNotValidator
+	}
+
+	key := CalcValNodeEvidenceKey(ttypes.EvidenceKey(ev))
+	if _, err := val.GetStateDB().Get(key); err == nil {
+		return nil, pty.ErrEvidenceExist
+	}
+	// 处罚和其他验证者变更一样作用于当前高度的验证者集合
+	current, err := getTxsState(val.GetTxs())
+	if err != nil {
+		return nil, err
+	}
+	power := findValidator(current.GetValidators(), addr).GetVotingPower()
+	changed, err := val.powerChanged(current.GetValidators(), index)
+	if err != nil {
+		return nil, err
+	}
+	address := fmt.Sprintf("%X", addr)
+	slashed, err := limitSlash(current.GetValidators(), changed, power, slashPower(cfg, val.GetHeight(), power))
+	if err != nil {
+		clog.Error("Exec_Evidence limitSlash", "address", address, "power", power, "changed", changed, "err", err)
+		return nil, err
+	}
+	record := &pty.ValNodeEvidenceRecord{
+		Evidence:     ev,
+		Address:      address,
+		Power:        power,
+		SlashedPower: slashed,
+		Height:       val.GetHeight(),
+		Index:        int32(index),
+		TxHash:       common.ToHex(tx.Hash()),
+	}
+	clog.Info("slash validator", "address", address, "evidence", ttypes.EvidenceKey(ev),
+		"power", record.Power, "slashedPower", record.SlashedPower)
+	receipt := &types.Receipt{
+		Ty:   types.ExecOk,
+		KV:   []*types.KeyValue{{Key: key, Value: types.Encode(record)}},
+		Logs: []*types.ReceiptLog{{Ty: pty.TyLogValNodeEvidence, Log: types.Encode(record)}},
+	}
+	return receipt, nil
+}
+
+// slashPower 按链配置mver.exec.sub.valnode.slashPercent扣减投票权, 返回0表示剔除出验证者集合
+// 扣减比例影响验证者集合, 所有节点必须一致, 所以不使用节点的执行器配置
+func slashPower(cfg *types.Chain33Config, height, power int64) int64 {
+	percent := types.Conf(cfg, "mver.exec.sub."+driverName).MGInt("slashPercent", height)
+	if percent <= 0 || percent >= 100 {
+		return 0
+	}
+	return power - power/100*percent - power%100*percent/100
+}
+
+// limitSlash 共识updateValidators拒绝一个区块内不小于总投票权1/3的变化, 处罚不能超过剩余的额度
+func limitSlash(valSet *pty.ValidatorSet, changed, power, slashed int64) (int64, error) {
+	var total int64
+	for _, v := range valSet.GetValidators() {
+		total += v.GetVotingPower()
+	}
+	allowed := total*1/3 - 1 - changed
+	if slashed >= power || power-slashed <= allowed {
+		return slashed, nil
+	}
+	if allowed <= 0 {
+		return 0, pty.ErrSlashLimit
+	}
+	return power - allowed, nil
+}
+
+// powerChanged 当前区块中index之前的验证者变更累计改变的投票权, 计算方式和共识updateValidators一致
+func (val *ValNode) powerChanged(valSet *pty.ValidatorSet, index int) (int64, error) {
+	var changed int64
+	txs := val.GetTxs()
+	for i := 0; i < index && i < len(txs); i++ {
+		if string(txs[i].Execer) != pty.ValNodeX {
+			continue
+		}
+		var action pty.ValNodeAction
+		if err := types.Decode(txs[i].GetPayload(), &action); err != nil {
+			continue
+		}
+		if node := action.GetNode(); node != nil {
+			pub, err := evidenceCrypto.PubKeyFromBytes(node.GetPubKey())
+			if err != nil {
+				return 0, err
+			}
+			v := findValidator(valSet, ttypes.GenAddressByPubKey(pub))
+			if v == nil {
+				changed += node.GetPower()
+			} else if v.GetVotingPower() > node.GetPower() {
+				changed += v.GetVotingPower() - node.GetPower()
+			} else {
+				changed += node.GetPower() - v.GetVotingPower()
+			}
+		} else if ev := action.GetEvidence(); ev != nil {
+			// 只统计本区块执行成功的处罚
+			record, err := getEvidenceRecord(val.GetStateDB(), ev)
+			if err != nil || record.GetHeight() != val.GetHeight() || record.GetIndex() != int32(i) {
+				continue
+			}
+			changed += record.GetPower() - record.GetSlashedPower()
+		}
+	}
+	return changed, nil
+}
+
+func findValidator(valSet *pty.ValidatorSet, address []byte) *pty.Validator {
+	for _, v := range valSet.GetValidators() {
+		if bytes.Equal(v.GetAddress(), address) {
+			return v
+		}
+	}
+	return nil
+}
+
+func getEvidenceRecord(db dbm.KV, ev *pty.ValNodeEvidence) (*pty.ValNodeEvidenceRecord, error) {
+	value, err := db.Get(CalcValNodeEvidenceKey(ttypes.EvidenceKey(ev)))
+	if err != nil {
+		return nil, err
+	}
+	record := &pty.ValNodeEvidenceRecord{}
+	err = types.Decode(value, record)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// getBlockState 读取已经上链区块中记录的共识状态, 执行结果只依赖区块数据
+func (val *ValNode) getBlockState(height int64) (*pty.State, error) {
+	details, err := val.GetAPI().GetBlocks(&types.ReqBlocks{Start: height, End: height})
+	if err != nil {
+		return nil, err
+	}
+	if len(details.GetItems()) != 1 {
+		return nil, types.ErrNotFound
+	}
+	return getTxsState(details.Items[0].GetBlock().GetTxs())
+}
+
+// getTxsState tendermint共识把记录共识状态的BlockInfo交易放在区块的第一笔
+func getTxsState(txs []*types.Transaction) (*pty.State, error) {
+	if len(txs) == 0 || string(txs[0].Execer) != pty.ValNodeX {
+		return nil, pty.ErrBlockState
+	}
+	var action pty.ValNodeAction
+	err := types.Decode(txs[0].GetPayload(), &action)
+	if err != nil {
+		return nil, err
+	}
+	state := action.GetBlockInfo().GetState()
+	if state == nil {
+		return nil, pty.ErrBlockState
+	}
+	return state, nil
+}
+
 func getConfigKey(key string, db dbm.KV) ([]byte, error) {
 	configKey := types.ConfigKey(key)
 	value, err := db.Get([]byte(configKey))
@@ -6,6 +6,7 @@ package executor
 
 import (
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
 )
 
@@ -24,3 +25,18 @@ func (val *ValNode) ExecDelLocal_BlockInfo(blockInfo *pty.TendermintBlockInfo, t
 	set.KV = append(set.KV, &types.KeyValue{Key: key, Value: nil})
 	return set, nil
 }
+
+// ExecDelLocal_Evidence method
+func (val *ValNode) ExecDelLocal_Evidence(ev *pty.ValNodeEvidence, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	set := &types.LocalDBSet{}
+	for _, item := range receipt.Logs {
+		if item.Ty != pty.TyLogValNodeEvidence {
+			continue
+		}
+		set.KV = append(set.KV,
+			&types.KeyValue{Key: CalcValNodeUpdateHeightIndexKey(val.GetHeight(), index), Value: nil},
+			&types.KeyValue{Key: CalcValNodeEvidenceHeightIndexKey(val.GetHeight(), index), Value: nil},
+			&types.KeyValue{Key: CalcValNodeEvidenceLocalKey(ttypes.EvidenceKey(ev)), Value: nil})
+	}
+	return set, nil
+}
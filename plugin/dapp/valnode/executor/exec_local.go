@@ -8,6 +8,7 @@ import (
 	"encoding/hex"
 
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
 )
 
@@ -27,3 +28,24 @@ func (val *ValNode) ExecLocal_BlockInfo(blockInfo *pty.TendermintBlockInfo, tx *
 	set.KV = append(set.KV, &types.KeyValue{Key: key, Value: types.Encode(blockInfo)})
 	return set, nil
 }
+
+// ExecLocal_Evidence 作恶验证者的投票权变化和其他验证者更新一样由共识在提交区块时生效
+func (val *ValNode) ExecLocal_Evidence(ev *pty.ValNodeEvidence, tx *types.Transaction, receipt *types.ReceiptData, index int) (*types.LocalDBSet, error) {
+	set := &types.LocalDBSet{}
+	for _, item := range receipt.Logs {
+		if item.Ty != pty.TyLogValNodeEvidence {
+			continue
+		}
+		var record pty.ValNodeEvidenceRecord
+		err := types.Decode(item.Log, &record)
+		if err != nil {
+			return nil, err
+		}
+		node := &pty.ValNode{PubKey: ev.GetPubKey(), Power: record.SlashedPower}
+		set.KV = append(set.KV,
+			&types.KeyValue{Key: CalcValNodeUpdateHeightIndexKey(val.GetHeight(), index), Value: types.Encode(node)},
+			&types.KeyValue{Key: CalcValNodeEvidenceHeightIndexKey(val.GetHeight(), index), Value: item.Log},
+			&types.KeyValue{Key: CalcValNodeEvidenceLocalKey(ttypes.EvidenceKey(ev)), Value: item.Log})
+	}
+	return set, nil
+}
@@ -6,9 +6,13 @@ package executor
 
 import (
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 	pty "github.com/33cn/plugin/plugin/dapp/valnode/types"
 )
 
+// 每次最多列出的处罚记录
+const maxEvidenceQuery = 100
+
 // Query_GetValNodeByHeight method
 func (val *ValNode) Query_GetValNodeByHeight(in *pty.ReqValNodes) (types.Message, error) {
 	height := in.GetHeight()
@@ -117,3 +121,55 @@ func (val *ValNode) Query_GetPerfState(in *pty.ReqPerfStat) (types.Message, erro
 		TxPerSecond: totalTx / totalSecond,
 	}, nil
 }
+
+// Query_GetValNodeEvidence 查询证据对应的作恶是否已经处罚
+func (val *ValNode) Query_GetValNodeEvidence(in *pty.ValNodeEvidence) (types.Message, error) {
+	if in.GetVoteA() == nil && in.GetProposalA() == nil {
+		return nil, types.ErrInvalidParam
+	}
+	value, err := val.GetLocalDB().Get(CalcValNodeEvidenceLocalKey(ttypes.EvidenceKey(in)))
+	if err != nil {
+		return nil, err
+	}
+	if len(value) == 0 {
+		return nil, types.ErrNotFound
+	}
+	reply := &pty.ValNodeEvidenceRecord{}
+	err = types.Decode(value, reply)
+	if err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// Query_ListValNodeEvidence 按打包高度列出处罚记录, height为0时从头或者从最新开始
+func (val *ValNode) Query_ListValNodeEvidence(in *pty.ReqValNodeEvidences) (types.Message, error) {
+	if in.GetHeight() < 0 || in.GetCount() < 0 || in.GetCount() > maxEvidenceQuery {
+		return nil, types.ErrInvalidParam
+	}
+	count := in.GetCount()
+	if count == 0 {
+		count = maxEvidenceQuery
+	}
+	var key []byte
+	if in.GetHeight() > 0 {
+		key = CalcValNodeEvidenceHeightIndexKey(in.GetHeight(), int(in.GetIndex()))
+	}
+	values, err := val.GetLocalDB().List([]byte("LODB-valnode-Evidence:"), key, count, in.GetDirection())
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, types.ErrNotFound
+	}
+	reply := &pty.ValNodeEvidenceRecords{}
+	for _, value := range values {
+		var record pty.ValNodeEvidenceRecord
+		err := types.Decode(value, &record)
+		if err != nil {
+			return nil, err
+		}
+		reply.Records = append(reply.Records, &record)
+	}
+	return reply, nil
+}
@@ -7,21 +7,45 @@ package executor
 import (
 	"fmt"
 
+	"github.com/33cn/chain33/common/crypto"
 	log "github.com/33cn/chain33/common/log/log15"
 	drivers "github.com/33cn/chain33/system/dapp"
 	"github.com/33cn/chain33/types"
+	ttypes "github.com/33cn/plugin/plugin/consensus/tendermint/types"
 )
 
 var clog = log.New("module", "execs.valnode")
 var driverName = "valnode"
 
+var (
+	// 验证作恶证据的签名, 和tendermint共识的signName一致
+	evidenceCrypto crypto.Crypto
+)
+
 // Init method
 func Init(name string, cfg *types.Chain33Config, sub []byte) {
 	clog.Debug("register valnode execer")
+	initEvidenceCrypto(cfg)
 	drivers.Register(cfg, GetName(), newValNode, 0)
 	InitExecType()
 }
 
+func initEvidenceCrypto(cfg *types.Chain33Config) {
+	var consensus struct {
+		SignName string `json:"signName"`
+	}
+	consensus.SignName = "ed25519"
+	if sub := cfg.GetSubConfig(); sub != nil && sub.Consensus["tendermint"] != nil {
+		types.MustDecode(sub.Consensus["tendermint"], &consensus)
+	}
+	cr, err := ttypes.LoadCrypto(consensus.SignName)
+	if err != nil {
+		clog.Error("initEvidenceCrypto", "signName", consensus.SignName, "err", err)
+		return
+	}
+	evidenceCrypto = cr
+}
+
 //InitExecType ...
 func InitExecType() {
 	ety := types.LoadExecutorType(driverName)
@@ -71,6 +95,21 @@ func CalcValNodeBlockInfoHeightKey(height int64) []byte {
 	return []byte(fmt.Sprintf("LODB-valnode-BlockInfo:%18d:", height))
 }
 
+// CalcValNodeEvidenceKey 记录作恶证据的状态数据key
+func CalcValNodeEvidenceKey(key string) []byte {
+	return []byte(fmt.Sprintf("mavl-valnode-evidence-%s", key))
+}
+
+// CalcValNodeEvidenceLocalKey 按作恶查询证据的本地数据key
+func CalcValNodeEvidenceLocalKey(key string) []byte {
+	return []byte(fmt.Sprintf("LODB-valnode-EvidenceKey:%s", key))
+}
+
+// CalcValNodeEvidenceHeightIndexKey 按打包高度列出证据的本地数据key
+func CalcValNodeEvidenceHeightIndexKey(height int64, index int) []byte {
+	return []byte(fmt.Sprintf("LODB-valnode-Evidence:%18d:%18d", height, int64(index)))
+}
+
 // CheckReceiptExecOk return true to check if receipt ty is ok
 func (val *ValNode) CheckReceiptExecOk() bool {
 	return true
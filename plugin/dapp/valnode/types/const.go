@@ -4,6 +4,8 @@
 
 package types
 
+import "errors"
+
 // ValNodeX define
 const ValNodeX = "valnode"
 
@@ -11,9 +13,38 @@ const ValNodeX = "valnode"
 const (
 	ValNodeActionUpdate    = 1
 	ValNodeActionBlockInfo = 2
+	ValNodeActionEvidence  = 3
 )
 
 // action name
 const (
 	ActionNodeUpdate = "NodeUpdate"
+	ActionEvidence   = "Evidence"
+)
+
+// fork
+const (
+	// ForkValNodeEvidence 启用作恶证据交易, 验证证据后处罚作恶的验证者
+	ForkValNodeEvidence = "ForkValNodeEvidence"
+)
+
+// log type
+const (
+	// TyLogValNodeEvidence 记录作恶证据和处罚结果
+	TyLogValNodeEvidence = 1001
+)
+
+var (
+	// ErrEvidenceExist 作恶已经被处罚
+	ErrEvidenceExist = errors.New("ErrEvidenceExist")
+	// ErrEvidenceExpired 证据超过了EvidenceParams.MaxAge
+	ErrEvidenceExpired = errors.New("ErrEvidenceExpired")
+	// ErrEvidenceHeight 证据高度的区块信息不存在
+	ErrEvidenceHeight = errors.New("ErrEvidenceHeight")
+	// ErrNotValidator 作恶者不是证据高度的验证者
+	ErrNotValidator = errors.New("ErrNotValidator")
+	// ErrBlockState 区块中没有记录共识状态的BlockInfo交易
+	ErrBlockState = errors.New("ErrBlockState")
+	// ErrSlashLimit 本区块验证者投票权的变化已经达到共识允许的1/3上限
+	ErrSlashLimit = errors.New("ErrSlashLimit")
 )
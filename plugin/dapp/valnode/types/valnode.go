@@ -7,6 +7,7 @@ package types
 import (
 	"encoding/hex"
 	"encoding/json"
+	"reflect"
 
 	"github.com/33cn/chain33/common/address"
 
@@ -25,6 +26,7 @@ func init() {
 //InitFork ...
 func InitFork(cfg *types.Chain33Config) {
 	cfg.RegisterDappFork(ValNodeX, "Enable", 0)
+	cfg.RegisterDappFork(ValNodeX, ForkValNodeEvidence, 0)
 }
 
 //InitExecutor ...
@@ -60,12 +62,15 @@ func (t *ValNodeType) GetTypeMap() map[string]int32 {
 	return map[string]int32{
 		"Node":      ValNodeActionUpdate,
 		"BlockInfo": ValNodeActionBlockInfo,
+		"Evidence":  ValNodeActionEvidence,
 	}
 }
 
 // GetLogMap method
 func (t *ValNodeType) GetLogMap() map[int64]*types.LogInfo {
-	return map[int64]*types.LogInfo{}
+	return map[int64]*types.LogInfo{
+		TyLogValNodeEvidence: {Ty: reflect.TypeOf(ValNodeEvidenceRecord{}), Name: "LogValNodeEvidence"},
+	}
 }
 
 // CreateTx ...
@@ -80,6 +85,14 @@ func (t *ValNodeType) CreateTx(action string, message json.RawMessage) (*types.T
 			return nil, types.ErrInvalidParam
 		}
 		return CreateNodeUpdateTx(cfg, &param)
+	} else if action == ActionEvidence {
+		var param ValNodeEvidence
+		err := types.JSONToPB(message, &param)
+		if err != nil {
+			tlog.Error("valnode.CreateTx", "err", err)
+			return nil, types.ErrInvalidParam
+		}
+		return CreateEvidenceTx(cfg, &param)
 	}
 	return nil, types.ErrNotSupport
 }
@@ -116,3 +129,23 @@ func CreateNodeUpdateTx(cfg *types.Chain33Config, parm *NodeUpdateTx) (*types.Tr
 	}
 	return tx, nil
 }
+
+// CreateEvidenceTx 提交作恶证据, 任何人都可以提交
+func CreateEvidenceTx(cfg *types.Chain33Config, ev *ValNodeEvidence) (*types.Transaction, error) {
+	if ev == nil || len(ev.PubKey) == 0 {
+		tlog.Error("CreateEvidenceTx", "evidence", ev)
+		return nil, types.ErrInvalidParam
+	}
+	action := &ValNodeAction{
+		Ty:    ValNodeActionEvidence,
+		Value: &ValNodeAction_Evidence{ev},
+	}
+
+	execName := cfg.ExecName(ValNodeX)
+	tx := &types.Transaction{
+		Execer:  []byte(execName),
+		Payload: types.Encode(action),
+		To:      address.ExecAddress(execName),
+	}
+	return types.FormatTx(cfg, execName, tx)
+}
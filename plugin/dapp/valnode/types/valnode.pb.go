@@ -136,6 +136,7 @@ type ValNodeAction struct {
 	// Types that are assignable to Value:
 	//	*ValNodeAction_Node
 	//	*ValNodeAction_BlockInfo
+	//	*ValNodeAction_Evidence
 	Value isValNodeAction_Value `protobuf_oneof:"value"`
 	Ty    int32                 `protobuf:"varint,3,opt,name=Ty,proto3" json:"Ty,omitempty"`
 }
@@ -193,6 +194,13 @@ func (x *ValNodeAction) GetBlockInfo() *TendermintBlockInfo {
 	return nil
 }
 
+func (x *ValNodeAction) GetEvidence() *ValNodeEvidence {
+	if x, ok := x.GetValue().(*ValNodeAction_Evidence); ok {
+		return x.Evidence
+	}
+	return nil
+}
+
 func (x *ValNodeAction) GetTy() int32 {
 	if x != nil {
 		return x.Ty
@@ -212,10 +220,16 @@ type ValNodeAction_BlockInfo struct {
 	BlockInfo *TendermintBlockInfo `protobuf:"bytes,2,opt,name=blockInfo,proto3,oneof"`
 }
 
+type ValNodeAction_Evidence struct {
+	Evidence *ValNodeEvidence `protobuf:"bytes,4,opt,name=evidence,proto3,oneof"`
+}
+
 func (*ValNodeAction_Node) isValNodeAction_Value() {}
 
 func (*ValNodeAction_BlockInfo) isValNodeAction_Value() {}
 
+func (*ValNodeAction_Evidence) isValNodeAction_Value() {}
+
 type ReqValNodes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -263,6 +277,299 @@ func (x *ReqValNodes) GetHeight() int64 {
 	return 0
 }
 
+// 验证者在同一高度和轮次签名了两个冲突的投票或提案
+type ValNodeEvidence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PubKey    []byte    `protobuf:"bytes,1,opt,name=pubKey,proto3" json:"pubKey,omitempty"`
+	VoteA     *Vote     `protobuf:"bytes,2,opt,name=voteA,proto3" json:"voteA,omitempty"`
+	VoteB     *Vote     `protobuf:"bytes,3,opt,name=voteB,proto3" json:"voteB,omitempty"`
+	ProposalA *Proposal `protobuf:"bytes,4,opt,name=proposalA,proto3" json:"proposalA,omitempty"`
+	ProposalB *Proposal `protobuf:"bytes,5,opt,name=proposalB,proto3" json:"proposalB,omitempty"`
+}
+
+func (x *ValNodeEvidence) Reset() {
+	*x = ValNodeEvidence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_valnode_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValNodeEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValNodeEvidence) ProtoMessage() {}
+
+func (x *ValNodeEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_valnode_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValNodeEvidence.ProtoReflect.Descriptor instead.
+func (*ValNodeEvidence) Descriptor() ([]byte, []int) {
+	return file_valnode_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ValNodeEvidence) GetPubKey() []byte {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *ValNodeEvidence) GetVoteA() *Vote {
+	if x != nil {
+		return x.VoteA
+	}
+	return nil
+}
+
+func (x *ValNodeEvidence) GetVoteB() *Vote {
+	if x != nil {
+		return x.VoteB
+	}
+	return nil
+}
+
+func (x *ValNodeEvidence) GetProposalA() *Proposal {
+	if x != nil {
+		return x.ProposalA
+	}
+	return nil
+}
+
+func (x *ValNodeEvidence) GetProposalB() *Proposal {
+	if x != nil {
+		return x.ProposalB
+	}
+	return nil
+}
+
+type ValNodeEvidenceRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Evidence     *ValNodeEvidence `protobuf:"bytes,1,opt,name=evidence,proto3" json:"evidence,omitempty"`
+	Address      string           `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Power        int64            `protobuf:"varint,3,opt,name=power,proto3" json:"power,omitempty"`
+	SlashedPower int64            `protobuf:"varint,4,opt,name=slashedPower,proto3" json:"slashedPower,omitempty"`
+	Height       int64            `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	Index        int32            `protobuf:"varint,6,opt,name=index,proto3" json:"index,omitempty"`
+	TxHash       string           `protobuf:"bytes,7,opt,name=txHash,proto3" json:"txHash,omitempty"`
+}
+
+func (x *ValNodeEvidenceRecord) Reset() {
+	*x = ValNodeEvidenceRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_valnode_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValNodeEvidenceRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValNodeEvidenceRecord) ProtoMessage() {}
+
+func (x *ValNodeEvidenceRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_valnode_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValNodeEvidenceRecord.ProtoReflect.Descriptor instead.
+func (*ValNodeEvidenceRecord) Descriptor() ([]byte, []int) {
+	return file_valnode_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ValNodeEvidenceRecord) GetEvidence() *ValNodeEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *ValNodeEvidenceRecord) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ValNodeEvidenceRecord) GetPower() int64 {
+	if x != nil {
+		return x.Power
+	}
+	return 0
+}
+
+func (x *ValNodeEvidenceRecord) GetSlashedPower() int64 {
+	if x != nil {
+		return x.SlashedPower
+	}
+	return 0
+}
+
+func (x *ValNodeEvidenceRecord) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ValNodeEvidenceRecord) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ValNodeEvidenceRecord) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+type ValNodeEvidenceRecords struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Records []*ValNodeEvidenceRecord `protobuf:"bytes,1,rep,name=records,proto3" json:"records,omitempty"`
+}
+
+func (x *ValNodeEvidenceRecords) Reset() {
+	*x = ValNodeEvidenceRecords{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_valnode_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValNodeEvidenceRecords) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValNodeEvidenceRecords) ProtoMessage() {}
+
+func (x *ValNodeEvidenceRecords) ProtoReflect() protoreflect.Message {
+	mi := &file_valnode_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValNodeEvidenceRecords.ProtoReflect.Descriptor instead.
+func (*ValNodeEvidenceRecords) Descriptor() ([]byte, []int) {
+	return file_valnode_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ValNodeEvidenceRecords) GetRecords() []*ValNodeEvidenceRecord {
+	if x != nil {
+		return x.Records
+	}
+	return nil
+}
+
+type ReqValNodeEvidences struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height    int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Index     int32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Count     int32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	Direction int32 `protobuf:"varint,4,opt,name=direction,proto3" json:"direction,omitempty"`
+}
+
+func (x *ReqValNodeEvidences) Reset() {
+	*x = ReqValNodeEvidences{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_valnode_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReqValNodeEvidences) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqValNodeEvidences) ProtoMessage() {}
+
+func (x *ReqValNodeEvidences) ProtoReflect() protoreflect.Message {
+	mi := &file_valnode_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqValNodeEvidences.ProtoReflect.Descriptor instead.
+func (*ReqValNodeEvidences) Descriptor() ([]byte, []int) {
+	return file_valnode_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReqValNodeEvidences) GetHeight() int64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ReqValNodeEvidences) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *ReqValNodeEvidences) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ReqValNodeEvidences) GetDirection() int32 {
+	if x != nil {
+		return x.Direction
+	}
+	return 0
+}
+
 type ReqBlockInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -274,7 +581,7 @@ type ReqBlockInfo struct {
 func (x *ReqBlockInfo) Reset() {
 	*x = ReqBlockInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_valnode_proto_msgTypes[4]
+		mi := &file_valnode_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -287,7 +594,7 @@ func (x *ReqBlockInfo) String() string {
 func (*ReqBlockInfo) ProtoMessage() {}
 
 func (x *ReqBlockInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_valnode_proto_msgTypes[4]
+	mi := &file_valnode_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -300,7 +607,7 @@ func (x *ReqBlockInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReqBlockInfo.ProtoReflect.Descriptor instead.
 func (*ReqBlockInfo) Descriptor() ([]byte, []int) {
-	return file_valnode_proto_rawDescGZIP(), []int{4}
+	return file_valnode_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ReqBlockInfo) GetHeight() int64 {
@@ -326,7 +633,7 @@ type ValNodeInfo struct {
 func (x *ValNodeInfo) Reset() {
 	*x = ValNodeInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_valnode_proto_msgTypes[5]
+		mi := &file_valnode_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -339,7 +646,7 @@ func (x *ValNodeInfo) String() string {
 func (*ValNodeInfo) ProtoMessage() {}
 
 func (x *ValNodeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_valnode_proto_msgTypes[5]
+	mi := &file_valnode_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +659,7 @@ func (x *ValNodeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValNodeInfo.ProtoReflect.Descriptor instead.
 func (*ValNodeInfo) Descriptor() ([]byte, []int) {
-	return file_valnode_proto_rawDescGZIP(), []int{5}
+	return file_valnode_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ValNodeInfo) GetNodeIP() string {
@@ -408,7 +715,7 @@ type ValNodeInfoSet struct {
 func (x *ValNodeInfoSet) Reset() {
 	*x = ValNodeInfoSet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_valnode_proto_msgTypes[6]
+		mi := &file_valnode_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -421,7 +728,7 @@ func (x *ValNodeInfoSet) String() string {
 func (*ValNodeInfoSet) ProtoMessage() {}
 
 func (x *ValNodeInfoSet) ProtoReflect() protoreflect.Message {
-	mi := &file_valnode_proto_msgTypes[6]
+	mi := &file_valnode_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -434,7 +741,7 @@ func (x *ValNodeInfoSet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValNodeInfoSet.ProtoReflect.Descriptor instead.
 func (*ValNodeInfoSet) Descriptor() ([]byte, []int) {
-	return file_valnode_proto_rawDescGZIP(), []int{6}
+	return file_valnode_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ValNodeInfoSet) GetNodes() []*ValNodeInfo {
@@ -459,7 +766,7 @@ type PerfStat struct {
 func (x *PerfStat) Reset() {
 	*x = PerfStat{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_valnode_proto_msgTypes[7]
+		mi := &file_valnode_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -472,7 +779,7 @@ func (x *PerfStat) String() string {
 func (*PerfStat) ProtoMessage() {}
 
 func (x *PerfStat) ProtoReflect() protoreflect.Message {
-	mi := &file_valnode_proto_msgTypes[7]
+	mi := &file_valnode_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -485,7 +792,7 @@ func (x *PerfStat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PerfStat.ProtoReflect.Descriptor instead.
 func (*PerfStat) Descriptor() ([]byte, []int) {
-	return file_valnode_proto_rawDescGZIP(), []int{7}
+	return file_valnode_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *PerfStat) GetTotalTx() int64 {
@@ -535,7 +842,7 @@ type ReqPerfStat struct {
 func (x *ReqPerfStat) Reset() {
 	*x = ReqPerfStat{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_valnode_proto_msgTypes[8]
+		mi := &file_valnode_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -548,7 +855,7 @@ func (x *ReqPerfStat) String() string {
 func (*ReqPerfStat) ProtoMessage() {}
 
 func (x *ReqPerfStat) ProtoReflect() protoreflect.Message {
-	mi := &file_valnode_proto_msgTypes[8]
+	mi := &file_valnode_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -561,7 +868,7 @@ func (x *ReqPerfStat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReqPerfStat.ProtoReflect.Descriptor instead.
 func (*ReqPerfStat) Descriptor() ([]byte, []int) {
-	return file_valnode_proto_rawDescGZIP(), []int{8}
+	return file_valnode_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ReqPerfStat) GetStart() int64 {
@@ -591,57 +898,101 @@ var file_valnode_proto_rawDesc = []byte{
 	0x30, 0x0a, 0x08, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x6e,
 	0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x79, 0x70,
 	0x65, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65,
-	0x73, 0x22, 0x8a, 0x01, 0x0a, 0x0d, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x63, 0x74,
+	0x73, 0x22, 0xc0, 0x01, 0x0a, 0x0d, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x63, 0x74,
 	0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64,
 	0x65, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x3a, 0x0a, 0x09, 0x62, 0x6c, 0x6f,
 	0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x74,
 	0x79, 0x70, 0x65, 0x73, 0x2e, 0x54, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x74, 0x42,
 	0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63,
-	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x54, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x02, 0x54, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x25,
-	0x0a, 0x0b, 0x52, 0x65, 0x71, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x16, 0x0a,
-	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68,
-	0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0x26, 0x0a, 0x0c, 0x52, 0x65, 0x71, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xa7, 0x01,
-	0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a,
-	0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x50, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e,
-	0x6f, 0x64, 0x65, 0x49, 0x50, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x18, 0x0a,
-	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65,
-	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12,
-	0x20, 0x0a, 0x0b, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x76, 0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65,
-	0x72, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63, 0x63, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x05, 0x61, 0x63, 0x63, 0x75, 0x6d, 0x22, 0x3a, 0x0a, 0x0e, 0x56, 0x61, 0x6c, 0x4e, 0x6f,
-	0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x53, 0x65, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x6e, 0x6f, 0x64,
-	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x2e, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x6e, 0x6f,
-	0x64, 0x65, 0x73, 0x22, 0xa8, 0x01, 0x0a, 0x08, 0x50, 0x65, 0x72, 0x66, 0x53, 0x74, 0x61, 0x74,
-	0x12, 0x18, 0x0a, 0x07, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x07, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x78,
-	0x50, 0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a,
-	0x74, 0x78, 0x50, 0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b,
-	0x74, 0x78, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x0b, 0x74, 0x78, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x22, 0x35,
-	0x0a, 0x0b, 0x52, 0x65, 0x71, 0x50, 0x65, 0x72, 0x66, 0x53, 0x74, 0x61, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74,
-	0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x03, 0x65, 0x6e, 0x64, 0x32, 0x6d, 0x0a, 0x07, 0x76, 0x61, 0x6c, 0x6e, 0x6f, 0x64, 0x65,
-	0x12, 0x2b, 0x0a, 0x06, 0x49, 0x73, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x0d, 0x2e, 0x74, 0x79, 0x70,
-	0x65, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x4e, 0x69, 0x6c, 0x1a, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x49, 0x73, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x22, 0x00, 0x12, 0x35, 0x0a,
-	0x0b, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0d, 0x2e, 0x74,
-	0x79, 0x70, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x4e, 0x69, 0x6c, 0x1a, 0x15, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x53,
-	0x65, 0x74, 0x22, 0x00, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x34, 0x0a, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x48,
+	0x00, 0x52, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x54,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x54, 0x79, 0x42, 0x07, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0x25, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x56, 0x61, 0x6c, 0x4e, 0x6f,
+	0x64, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xcd, 0x01, 0x0a, 0x0f,
+	0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x41,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56,
+	0x6f, 0x74, 0x65, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x41, 0x12, 0x21, 0x0a, 0x05, 0x76, 0x6f,
+	0x74, 0x65, 0x42, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x56, 0x6f, 0x74, 0x65, 0x52, 0x05, 0x76, 0x6f, 0x74, 0x65, 0x42, 0x12, 0x2d, 0x0a,
+	0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x41, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61,
+	0x6c, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x41, 0x12, 0x2d, 0x0a, 0x09,
+	0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x42, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c,
+	0x52, 0x09, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x42, 0x22, 0xe5, 0x01, 0x0a, 0x15,
+	0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x32, 0x0a, 0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52,
+	0x08, 0x65, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x6c, 0x61,
+	0x73, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x65, 0x64, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x78, 0x48, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x78, 0x48,
+	0x61, 0x73, 0x68, 0x22, 0x50, 0x0a, 0x16, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x45, 0x76,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x36, 0x0a,
+	0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x45, 0x76,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x77, 0x0a, 0x13, 0x52, 0x65, 0x71, 0x56, 0x61, 0x6c, 0x4e,
+	0x6f, 0x64, 0x65, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x68, 0x65,
+	0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x26,
+	0x0a, 0x0c, 0x52, 0x65, 0x71, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16,
+	0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x22, 0xa7, 0x01, 0x0a, 0x0b, 0x56, 0x61, 0x6c, 0x4e, 0x6f,
+	0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x50,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x50, 0x12, 0x16,
+	0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6e, 0x6f, 0x64, 0x65, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x6f, 0x74, 0x69,
+	0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x76,
+	0x6f, 0x74, 0x69, 0x6e, 0x67, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x63,
+	0x63, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x61, 0x63, 0x63, 0x75, 0x6d,
+	0x22, 0x3a, 0x0a, 0x0e, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x53,
+	0x65, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56, 0x61, 0x6c, 0x4e, 0x6f, 0x64,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x22, 0xa8, 0x01, 0x0a,
+	0x08, 0x50, 0x65, 0x72, 0x66, 0x53, 0x74, 0x61, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x54, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x54, 0x78, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x78, 0x50, 0x65, 0x72, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x78, 0x50, 0x65, 0x72, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x78, 0x50, 0x65, 0x72, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x74, 0x78, 0x50, 0x65,
+	0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x22, 0x35, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x50, 0x65,
+	0x72, 0x66, 0x53, 0x74, 0x61, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x65, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x32, 0x6d,
+	0x0a, 0x07, 0x76, 0x61, 0x6c, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x49, 0x73, 0x53,
+	0x79, 0x6e, 0x63, 0x12, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x52, 0x65, 0x71, 0x4e,
+	0x69, 0x6c, 0x1a, 0x10, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x49, 0x73, 0x48, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x79, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0d, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x52, 0x65,
+	0x71, 0x4e, 0x69, 0x6c, 0x1a, 0x15, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56, 0x61, 0x6c,
+	0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x53, 0x65, 0x74, 0x22, 0x00, 0x42, 0x0a, 0x5a,
+	0x08, 0x2e, 0x2e, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -656,35 +1007,48 @@ func file_valnode_proto_rawDescGZIP() []byte {
 	return file_valnode_proto_rawDescData
 }
 
-var file_valnode_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_valnode_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_valnode_proto_goTypes = []interface{}{
-	(*ValNode)(nil),             // 0: types.ValNode
-	(*ValNodes)(nil),            // 1: types.ValNodes
-	(*ValNodeAction)(nil),       // 2: types.ValNodeAction
-	(*ReqValNodes)(nil),         // 3: types.ReqValNodes
-	(*ReqBlockInfo)(nil),        // 4: types.ReqBlockInfo
-	(*ValNodeInfo)(nil),         // 5: types.ValNodeInfo
-	(*ValNodeInfoSet)(nil),      // 6: types.ValNodeInfoSet
-	(*PerfStat)(nil),            // 7: types.PerfStat
-	(*ReqPerfStat)(nil),         // 8: types.ReqPerfStat
-	(*TendermintBlockInfo)(nil), // 9: types.TendermintBlockInfo
-	(*types.ReqNil)(nil),        // 10: types.ReqNil
-	(*IsHealthy)(nil),           // 11: types.IsHealthy
+	(*ValNode)(nil),                // 0: types.ValNode
+	(*ValNodes)(nil),               // 1: types.ValNodes
+	(*ValNodeAction)(nil),          // 2: types.ValNodeAction
+	(*ReqValNodes)(nil),            // 3: types.ReqValNodes
+	(*ValNodeEvidence)(nil),        // 4: types.ValNodeEvidence
+	(*ValNodeEvidenceRecord)(nil),  // 5: types.ValNodeEvidenceRecord
+	(*ValNodeEvidenceRecords)(nil), // 6: types.ValNodeEvidenceRecords
+	(*ReqValNodeEvidences)(nil),    // 7: types.ReqValNodeEvidences
+	(*ReqBlockInfo)(nil),           // 8: types.ReqBlockInfo
+	(*ValNodeInfo)(nil),            // 9: types.ValNodeInfo
+	(*ValNodeInfoSet)(nil),         // 10: types.ValNodeInfoSet
+	(*PerfStat)(nil),               // 11: types.PerfStat
+	(*ReqPerfStat)(nil),            // 12: types.ReqPerfStat
+	(*TendermintBlockInfo)(nil),    // 13: types.TendermintBlockInfo
+	(*Vote)(nil),                   // 14: types.Vote
+	(*Proposal)(nil),               // 15: types.Proposal
+	(*types.ReqNil)(nil),           // 16: types.ReqNil
+	(*IsHealthy)(nil),              // 17: types.IsHealthy
 }
 var file_valnode_proto_depIdxs = []int32{
 	0,  // 0: types.ValNodes.nodes:type_name -> types.ValNode
 	0,  // 1: types.ValNodeAction.node:type_name -> types.ValNode
-	9,  // 2: types.ValNodeAction.blockInfo:type_name -> types.TendermintBlockInfo
-	5,  // 3: types.ValNodeInfoSet.nodes:type_name -> types.ValNodeInfo
-	10, // 4: types.valnode.IsSync:input_type -> types.ReqNil
-	10, // 5: types.valnode.GetNodeInfo:input_type -> types.ReqNil
-	11, // 6: types.valnode.IsSync:output_type -> types.IsHealthy
-	6,  // 7: types.valnode.GetNodeInfo:output_type -> types.ValNodeInfoSet
-	6,  // [6:8] is the sub-list for method output_type
-	4,  // [4:6] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	13, // 2: types.ValNodeAction.blockInfo:type_name -> types.TendermintBlockInfo
+	4,  // 3: types.ValNodeAction.evidence:type_name -> types.ValNodeEvidence
+	14, // 4: types.ValNodeEvidence.voteA:type_name -> types.Vote
+	14, // 5: types.ValNodeEvidence.voteB:type_name -> types.Vote
+	15, // 6: types.ValNodeEvidence.proposalA:type_name -> types.Proposal
+	15, // 7: types.ValNodeEvidence.proposalB:type_name -> types.Proposal
+	4,  // 8: types.ValNodeEvidenceRecord.evidence:type_name -> types.ValNodeEvidence
+	5,  // 9: types.ValNodeEvidenceRecords.records:type_name -> types.ValNodeEvidenceRecord
+	9,  // 10: types.ValNodeInfoSet.nodes:type_name -> types.ValNodeInfo
+	16, // 11: types.valnode.IsSync:input_type -> types.ReqNil
+	16, // 12: types.valnode.GetNodeInfo:input_type -> types.ReqNil
+	17, // 13: types.valnode.IsSync:output_type -> types.IsHealthy
+	10, // 14: types.valnode.GetNodeInfo:output_type -> types.ValNodeInfoSet
+	13, // [13:15] is the sub-list for method output_type
+	11, // [11:13] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_valnode_proto_init() }
@@ -743,7 +1107,7 @@ func file_valnode_proto_init() {
 			}
 		}
 		file_valnode_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReqBlockInfo); i {
+			switch v := v.(*ValNodeEvidence); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -755,7 +1119,7 @@ func file_valnode_proto_init() {
 			}
 		}
 		file_valnode_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValNodeInfo); i {
+			switch v := v.(*ValNodeEvidenceRecord); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -767,7 +1131,7 @@ func file_valnode_proto_init() {
 			}
 		}
 		file_valnode_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ValNodeInfoSet); i {
+			switch v := v.(*ValNodeEvidenceRecords); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -779,7 +1143,7 @@ func file_valnode_proto_init() {
 			}
 		}
 		file_valnode_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PerfStat); i {
+			switch v := v.(*ReqValNodeEvidences); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -791,6 +1155,54 @@ func file_valnode_proto_init() {
 			}
 		}
 		file_valnode_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReqBlockInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_valnode_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValNodeInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_valnode_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValNodeInfoSet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_valnode_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PerfStat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_valnode_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ReqPerfStat); i {
 			case 0:
 				return &v.state
@@ -806,6 +1218,7 @@ func file_valnode_proto_init() {
 	file_valnode_proto_msgTypes[2].OneofWrappers = []interface{}{
 		(*ValNodeAction_Node)(nil),
 		(*ValNodeAction_BlockInfo)(nil),
+		(*ValNodeAction_Evidence)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -813,7 +1226,7 @@ func file_valnode_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_valnode_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
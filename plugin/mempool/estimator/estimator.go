@@ -0,0 +1,319 @@
+// Package estimator 根据最近区块实际打包的手续费率估计交易在指定区块数内被打包所需的费率
+package estimator
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/33cn/chain33/client"
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	"github.com/golang/protobuf/proto"
+)
+
+var elog = log.New("module", "mempool.estimator")
+
+const (
+	defaultEstimateBlocks   = 100
+	defaultProperFeeTarget  = 3
+	defaultFullBlockPercent = 90
+	// 估计值需要覆盖的统计窗口比例
+	successPercent = 85
+	// 单次从blockchain获取的区块数
+	fetchBatch = 64
+	// 等待估计器处理的区块事件数
+	eventBuffer = 1024
+)
+
+// Config 手续费估计配置, 作为price和score的子配置
+type Config struct {
+	// 统计最近多少个区块
+	EstimateBlocks int64 `json:"estimateBlocks"`
+	// GetProperFee 估计的确认目标区块数
+	ProperFeeTarget int32 `json:"properFeeTarget"`
+	// 交易数或字节数达到上限的百分比时认为区块已满, 未满的区块最低费率即可打包
+	FullBlockPercent int64 `json:"fullBlockPercent"`
+}
+
+// blockFee 区块中能被打包的最低费率
+type blockFee struct {
+	height  int64
+	feeRate int64
+}
+
+// Estimator 手续费估计, 费率单位与chain33一致, 为每千字节的手续费
+type Estimator struct {
+	cfg        Config
+	minFeeRate int64
+	maxFeeRate int64
+
+	mu     sync.RWMutex
+	blocks []*blockFee
+
+	events chan *queue.Message
+	done   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New 创建估计器, mcfg 提供费率的上下限
+func New(cfg Config, mcfg *types.Mempool) *Estimator {
+	if cfg.EstimateBlocks <= 0 {
+		cfg.EstimateBlocks = defaultEstimateBlocks
+	}
+	if cfg.ProperFeeTarget <= 0 {
+		cfg.ProperFeeTarget = defaultProperFeeTarget
+	}
+	if cfg.FullBlockPercent <= 0 || cfg.FullBlockPercent > 100 {
+		cfg.FullBlockPercent = defaultFullBlockPercent
+	}
+	return &Estimator{
+		cfg:        cfg,
+		minFeeRate: mcfg.MinTxFeeRate,
+		maxFeeRate: mcfg.MaxTxFeeRate,
+		events:     make(chan *queue.Message, eventBuffer),
+		done:       make(chan struct{}),
+	}
+}
+
+// unitFeeRate 交易或交易组的每千字节费率, 交易组的手续费由第一笔交易支付
+func unitFeeRate(fee int64, size int) int64 {
+	if size <= 0 {
+		return 0
+	}
+	return fee * 1000 / int64(size)
+}
+
+// AddBlock 记录区块的最低打包费率, maxTxNumber为该高度区块的交易数上限
+func (e *Estimator) AddBlock(block *types.Block, maxTxNumber int64) {
+	feeRate := e.minFeeRate
+	var size int
+	var lowest int64 = -1
+	txs := block.GetTxs()
+	for i := 0; i < len(txs); i++ {
+		fee, txSize := txs[i].Fee, proto.Size(txs[i])
+		// 交易组按整组计算费率
+		if count := int(txs[i].GetGroupCount()); count > 1 && i+count <= len(txs) {
+			for _, tx := range txs[i+1 : i+count] {
+				fee += tx.Fee
+				txSize += proto.Size(tx)
+			}
+			i += count - 1
+		}
+		size += txSize
+		// 挖矿等交易不付手续费
+		if fee == 0 {
+			continue
+		}
+		if rate := unitFeeRate(fee, txSize); lowest < 0 || rate < lowest {
+			lowest = rate
+		}
+	}
+	full := int64(len(txs))*100 >= maxTxNumber*e.cfg.FullBlockPercent ||
+		int64(size)*100 >= int64(types.MaxBlockSize)*e.cfg.FullBlockPercent
+	if full && lowest > feeRate {
+		feeRate = lowest
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.dropFrom(block.Height)
+	e.blocks = append(e.blocks, &blockFee{height: block.Height, feeRate: feeRate})
+	if over := len(e.blocks) - int(e.cfg.EstimateBlocks); over > 0 {
+		e.blocks = e.blocks[over:]
+	}
+}
+
+// DelBlock 回滚时删除该高度及之后的记录
+func (e *Estimator) DelBlock(height int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.dropFrom(height)
+}
+
+func (e *Estimator) dropFrom(height int64) {
+	i := sort.Search(len(e.blocks), func(i int) bool { return e.blocks[i].height >= height })
+	e.blocks = e.blocks[:i]
+}
+
+func (e *Estimator) lastHeight() int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if len(e.blocks) == 0 {
+		return -1
+	}
+	return e.blocks[len(e.blocks)-1].height
+}
+
+// EstimateFeeRate 估计在target个区块内被打包所需的费率
+// 对最近区块中每个连续target个区块的窗口, 费率不低于窗口内最低打包费率即可被打包,
+// 取能覆盖successPercent窗口的费率. 记录的区块数不足target时返回false
+func (e *Estimator) EstimateFeeRate(target int32) (int64, bool) {
+	if target <= 0 {
+		target = e.cfg.ProperFeeTarget
+	}
+	e.mu.RLock()
+	n := len(e.blocks) - int(target) + 1
+	if n <= 0 {
+		e.mu.RUnlock()
+		return 0, false
+	}
+	windows := make([]int64, n)
+	for i := 0; i < n; i++ {
+		lowest := e.blocks[i].feeRate
+		for _, b := range e.blocks[i+1 : i+int(target)] {
+			if b.feeRate < lowest {
+				lowest = b.feeRate
+			}
+		}
+		windows[i] = lowest
+	}
+	e.mu.RUnlock()
+
+	sort.Slice(windows, func(i, j int) bool { return windows[i] < windows[j] })
+	index := (n*successPercent+99)/100 - 1
+	return e.round(windows[index]), true
+}
+
+// round 按最低费率的整数倍向上取整, 并限制在配置的范围内
+func (e *Estimator) round(feeRate int64) int64 {
+	if feeRate < e.minFeeRate {
+		feeRate = e.minFeeRate
+	}
+	if unit := e.minFeeRate; unit > 0 && feeRate%unit > 0 {
+		feeRate = (feeRate/unit + 1) * unit
+	}
+	if e.maxFeeRate > 0 && feeRate > e.maxFeeRate {
+		feeRate = e.maxFeeRate
+	}
+	return feeRate
+}
+
+// sync 从blockchain补齐到height为止的区块, 用于启动时和区块事件不连续时
+func (e *Estimator) sync(api client.QueueProtocolAPI, height int64) error {
+	last := e.lastHeight()
+	if height < last {
+		e.DelBlock(height + 1)
+		last = height
+	}
+	if height == last {
+		return nil
+	}
+	start := last + 1
+	if min := height - e.cfg.EstimateBlocks + 1; start < min {
+		start = min
+	}
+	if start < 0 {
+		start = 0
+	}
+	cfg := api.GetConfig()
+	for start <= height {
+		end := start + fetchBatch - 1
+		if end > height {
+			end = height
+		}
+		details, err := api.GetBlocks(&types.ReqBlocks{Start: start, End: end})
+		if err != nil {
+			return err
+		}
+		for _, item := range details.GetItems() {
+			block := item.GetBlock()
+			e.AddBlock(block, cfg.GetP(block.Height).MaxTxNumber)
+		}
+		start = end + 1
+	}
+	return nil
+}
+
+// procBlock 处理mempool收到的区块事件, 与已记录的区块不连续时先从blockchain补齐
+func (e *Estimator) procBlock(api client.QueueProtocolAPI, ty int64, block *types.Block) {
+	if ty == types.EventDelBlock {
+		e.DelBlock(block.Height)
+		return
+	}
+	if last := e.lastHeight(); block.Height > last+1 {
+		if err := e.sync(api, block.Height-1); err != nil {
+			elog.Error("estimator sync blocks", "height", block.Height-1, "err", err)
+		}
+	}
+	e.AddBlock(block, api.GetConfig().GetP(block.Height).MaxTxNumber)
+}
+
+func (e *Estimator) blockLoop(api client.QueueProtocolAPI) {
+	defer e.wg.Done()
+	for {
+		select {
+		case <-e.done:
+			return
+		case msg := <-e.events:
+			e.procBlock(api, msg.Ty, msg.GetData().(*types.BlockDetail).GetBlock())
+		}
+	}
+}
+
+// eventClient 把mempool收到的区块事件同时交给估计器, 其他行为与queue.Client一致
+type eventClient struct {
+	queue.Client
+	recv chan *queue.Message
+	e    *Estimator
+}
+
+func newEventClient(cli queue.Client, e *Estimator) *eventClient {
+	ec := &eventClient{Client: cli, recv: make(chan *queue.Message), e: e}
+	go ec.forward(cli.Recv())
+	return ec
+}
+
+// Recv mempool从这里接收消息
+func (ec *eventClient) Recv() chan *queue.Message {
+	return ec.recv
+}
+
+func (ec *eventClient) forward(recv chan *queue.Message) {
+	defer close(ec.recv)
+	for msg := range recv {
+		if msg.Ty == types.EventAddBlock || msg.Ty == types.EventDelBlock {
+			if _, ok := msg.GetData().(*types.BlockDetail); ok {
+				select {
+				case ec.e.events <- msg:
+				case <-ec.e.done:
+				}
+			}
+		}
+		ec.recv <- msg
+	}
+}
+
+// Mempool 在chain33 mempool的基础上跟踪新区块的打包费率
+type Mempool struct {
+	*drivers.Mempool
+	estimator *Estimator
+}
+
+// NewMempool 包装mempool, 区块事件交给估计器
+func NewMempool(mem *drivers.Mempool, e *Estimator) *Mempool {
+	return &Mempool{Mempool: mem, estimator: e}
+}
+
+// SetQueueClient 启动mempool, 区块事件同时交给估计器
+func (mem *Mempool) SetQueueClient(cli queue.Client) {
+	api, err := client.New(cli, nil)
+	if err != nil {
+		panic("estimator SetQueueClient client.New err")
+	}
+	mem.estimator.wg.Add(1)
+	go mem.estimator.blockLoop(api)
+	mem.Mempool.SetQueueClient(newEventClient(cli, mem.estimator))
+}
+
+// Close 停止估计器并关闭mempool
+func (mem *Mempool) Close() {
+	select {
+	case <-mem.estimator.done:
+	default:
+		close(mem.estimator.done)
+	}
+	mem.estimator.wg.Wait()
+	mem.Mempool.Close()
+}
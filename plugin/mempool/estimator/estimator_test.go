@@ -0,0 +1,206 @@
+package estimator
+
+import (
+	"testing"
+	"time"
+
+	apimocks "github.com/33cn/chain33/client/mocks"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	minFeeRate  = 100000
+	maxTxNumber = 10
+)
+
+func newEstimator(blocks int64) *Estimator {
+	return New(Config{EstimateBlocks: blocks}, &types.Mempool{MinTxFeeRate: minFeeRate, MaxTxFeeRate: 100 * minFeeRate})
+}
+
+// newTx 构造指定每千字节费率的交易
+func newTx(feeRate int64) *types.Transaction {
+	tx := &types.Transaction{Execer: []byte("coins"), Payload: make([]byte, 200), Fee: 1}
+	tx.Fee = feeRate * int64(proto.Size(tx)) / 1000
+	return tx
+}
+
+// newBlock 构造区块, full为true时交易数达到上限
+func newBlock(height int64, feeRate int64, full bool) *types.Block {
+	block := &types.Block{Height: height}
+	// 挖矿交易不付手续费
+	block.Txs = append(block.Txs, &types.Transaction{Execer: []byte("ticket")})
+	count := 2
+	if full {
+		count = maxTxNumber
+	}
+	for i := 1; i < count; i++ {
+		block.Txs = append(block.Txs, newTx(feeRate*int64(i)))
+	}
+	return block
+}
+
+func TestEstimateFeeRate(t *testing.T) {
+	e := newEstimator(20)
+	_, ok := e.EstimateFeeRate(1)
+	assert.False(t, ok)
+
+	// 区块未满时最低费率即可打包
+	for i := int64(0); i < 10; i++ {
+		e.AddBlock(newBlock(i, 5*minFeeRate, false), maxTxNumber)
+	}
+	for _, target := range []int32{1, 3, 10} {
+		feeRate, ok := e.EstimateFeeRate(target)
+		assert.True(t, ok)
+		assert.Equal(t, int64(minFeeRate), feeRate, "target %d", target)
+	}
+	_, ok = e.EstimateFeeRate(11)
+	assert.False(t, ok)
+
+	// 拥堵时每隔几个区块才有一个低费率的区块, 目标越远费率越低
+	for i := int64(10); i < 30; i++ {
+		feeRate := int64(8 * minFeeRate)
+		if i%3 == 0 {
+			feeRate = 4 * minFeeRate
+		}
+		if i%10 == 0 {
+			feeRate = 2 * minFeeRate
+		}
+		e.AddBlock(newBlock(i, feeRate, true), maxTxNumber)
+	}
+	assert.Equal(t, 20, len(e.blocks))
+	next, _ := e.EstimateFeeRate(1)
+	within3, _ := e.EstimateFeeRate(3)
+	within10, _ := e.EstimateFeeRate(10)
+	assert.Equal(t, int64(8*minFeeRate), next)
+	assert.Equal(t, int64(4*minFeeRate), within3)
+	assert.Equal(t, int64(2*minFeeRate), within10)
+	// 默认目标
+	feeRate, _ := e.EstimateFeeRate(0)
+	assert.Equal(t, within3, feeRate)
+
+	// 回滚后重新加入
+	e.DelBlock(25)
+	assert.Equal(t, int64(24), e.lastHeight())
+	e.AddBlock(newBlock(20, 8*minFeeRate, true), maxTxNumber)
+	assert.Equal(t, int64(20), e.lastHeight())
+}
+
+func TestAddBlockTxGroup(t *testing.T) {
+	e := newEstimator(10)
+	tx1, tx2, tx3 := newTx(0), newTx(0), newTx(0)
+	group, err := types.CreateTxGroup([]*types.Transaction{tx1, tx2, tx3}, minFeeRate)
+	assert.Nil(t, err)
+	block := newBlock(0, 9*minFeeRate, true)
+	block.Txs = append(block.Txs, group.GetTxs()...)
+	e.AddBlock(block, maxTxNumber)
+
+	var size int
+	for _, tx := range group.GetTxs() {
+		size += proto.Size(tx)
+	}
+	// 交易组的费率按整组计算, 是区块中最低的
+	groupRate := group.GetTxs()[0].Fee * 1000 / int64(size)
+	feeRate, ok := e.EstimateFeeRate(1)
+	assert.True(t, ok)
+	assert.Equal(t, e.round(groupRate), feeRate)
+	assert.True(t, feeRate < 9*minFeeRate)
+}
+
+func TestRound(t *testing.T) {
+	e := newEstimator(10)
+	assert.Equal(t, int64(minFeeRate), e.round(1))
+	assert.Equal(t, int64(2*minFeeRate), e.round(minFeeRate+1))
+	assert.Equal(t, int64(100*minFeeRate), e.round(1000*minFeeRate))
+}
+
+func newMockAPI(blocks map[int64]*types.Block) *apimocks.QueueProtocolAPI {
+	api := new(apimocks.QueueProtocolAPI)
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	api.On("GetConfig").Return(cfg)
+	api.On("GetBlocks", mock.Anything).Return(func(req *types.ReqBlocks) *types.BlockDetails {
+		details := &types.BlockDetails{}
+		for i := req.Start; i <= req.End; i++ {
+			details.Items = append(details.Items, &types.BlockDetail{Block: blocks[i]})
+		}
+		return details
+	}, nil)
+	return api
+}
+
+func TestSync(t *testing.T) {
+	e := newEstimator(50)
+	blocks := make(map[int64]*types.Block)
+	for i := int64(0); i < 200; i++ {
+		blocks[i] = newBlock(i, minFeeRate, false)
+	}
+	api := newMockAPI(blocks)
+
+	assert.Nil(t, e.sync(api, 99))
+	assert.Equal(t, 50, len(e.blocks))
+	assert.Equal(t, int64(50), e.blocks[0].height)
+	assert.Equal(t, int64(99), e.lastHeight())
+	assert.Nil(t, e.sync(api, 120))
+	assert.Equal(t, int64(71), e.blocks[0].height)
+	assert.Equal(t, int64(120), e.lastHeight())
+
+	// 回滚到更低的高度
+	assert.Nil(t, e.sync(api, 110))
+	assert.Equal(t, int64(110), e.lastHeight())
+	assert.Equal(t, 40, len(e.blocks))
+}
+
+func TestEventClient(t *testing.T) {
+	e := newEstimator(50)
+	blocks := make(map[int64]*types.Block)
+	for i := int64(0); i < 200; i++ {
+		blocks[i] = newBlock(i, minFeeRate, false)
+	}
+	api := newMockAPI(blocks)
+	e.wg.Add(1)
+	go e.blockLoop(api)
+	defer func() {
+		close(e.done)
+		e.wg.Wait()
+	}()
+
+	q := queue.New("channel")
+	q.SetConfig(types.NewChain33Config(types.GetDefaultCfgstring()))
+	cli := newEventClient(q.Client(), e)
+	cli.Sub("mempool")
+	sender := q.Client()
+	send := func(ty int64, height int64) {
+		msg := sender.NewMessage("mempool", ty, &types.BlockDetail{Block: blocks[height]})
+		assert.Nil(t, sender.Send(msg, false))
+		// 其他消息照常交给mempool
+		recv := <-cli.Recv()
+		assert.Equal(t, ty, recv.Ty)
+		assert.Equal(t, msg.ID, recv.ID)
+	}
+	waitHeight := func(height int64) {
+		assert.Eventually(t, func() bool { return e.lastHeight() == height }, time.Second, time.Millisecond)
+	}
+
+	// 第一个区块事件前从blockchain补齐记录
+	send(types.EventAddBlock, 99)
+	waitHeight(99)
+	assert.Equal(t, 50, len(e.blocks))
+	send(types.EventAddBlock, 100)
+	waitHeight(100)
+	send(types.EventDelBlock, 100)
+	waitHeight(99)
+	// 事件不连续时补齐中间的区块
+	send(types.EventAddBlock, 105)
+	waitHeight(105)
+	assert.Equal(t, int64(56), e.blocks[0].height)
+
+	msg := sender.NewMessage("mempool", types.EventGetProperFee, &types.ReqProperFee{})
+	assert.Nil(t, sender.Send(msg, false))
+	assert.Equal(t, msg.ID, (<-cli.Recv()).ID)
+	cli.Close()
+	_, ok := <-cli.Recv()
+	assert.False(t, ok)
+}
@@ -1,34 +1,77 @@
 package para
 
 import (
-	"context"
 	"sync/atomic"
+	"time"
 
 	"sync"
 
+	"github.com/33cn/chain33/common"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
-	"github.com/33cn/chain33/rpc/grpcclient"
 	"github.com/33cn/chain33/types"
 )
 
 var mlog = log.New("module", "mempool.para")
 var topic = "mempool"
 
+const (
+	defaultPoolCacheSize    = 10240
+	defaultForwardTimeout   = 10
+	defaultRetryInterval    = 1
+	defaultMaxRetryInterval = 60
+	defaultPendingExpire    = 600
+	retryTick               = time.Second
+)
+
 //Mempool mempool 基础类
 type Mempool struct {
-	key         string
-	wg          sync.WaitGroup
-	client      queue.Client
-	mainGrpcCli types.Chain33Client
+	key       string
+	wg        sync.WaitGroup
+	client    queue.Client
+	subcfg    subConfig
+	forwarder *forwarder
+	queue     *txQueue
+	backoff   *backoff
+	done      chan struct{}
 
 	isclose int32
 }
 
 //NewMempool 新建mempool 实例
 func NewMempool(cfg *types.Mempool) *Mempool {
+	var subcfg subConfig
+	if cfg != nil {
+		subcfg.PoolCacheSize = cfg.PoolCacheSize
+	}
+	return newMempool(subcfg)
+}
+
+func newMempool(subcfg subConfig) *Mempool {
+	if subcfg.PoolCacheSize <= 0 {
+		subcfg.PoolCacheSize = defaultPoolCacheSize
+	}
+	if subcfg.ForwardTimeout <= 0 {
+		subcfg.ForwardTimeout = defaultForwardTimeout
+	}
+	if subcfg.RetryInterval <= 0 {
+		subcfg.RetryInterval = defaultRetryInterval
+	}
+	if subcfg.MaxRetryInterval < subcfg.RetryInterval {
+		subcfg.MaxRetryInterval = defaultMaxRetryInterval
+	}
+	if subcfg.PendingExpire <= 0 {
+		subcfg.PendingExpire = defaultPendingExpire
+	}
 	pool := &Mempool{}
 	pool.key = topic
+	pool.subcfg = subcfg
+	pool.queue = newTxQueue(int(subcfg.PoolCacheSize))
+	pool.backoff = &backoff{
+		base: time.Duration(subcfg.RetryInterval) * time.Second,
+		max:  time.Duration(subcfg.MaxRetryInterval) * time.Second,
+	}
+	pool.done = make(chan struct{})
 	return pool
 }
 
@@ -37,57 +80,140 @@ func (mem *Mempool) SetQueueClient(client queue.Client) {
 	mem.client = client
 	mem.client.Sub(mem.key)
 	mem.setMainGrpcCli(client.GetConfig())
+	mem.start()
+}
+
+func (mem *Mempool) start() {
+	mem.wg.Add(1)
+	go mem.retryLoop()
 	mem.wg.Add(1)
 	go func() {
 		defer mem.wg.Done()
-		for msg := range client.Recv() {
-			var err error
-			var reply interface{}
-			switch msg.Ty {
-			case types.EventTx:
-				tx := msg.GetData().(*types.Transaction)
-				reply, err = mem.mainGrpcCli.SendTransaction(context.Background(), tx)
-				// 兼容rpc发送交易错误处理, 同常规mempool返回逻辑保持一致
-				if err != nil {
-					reply = &types.Reply{IsOk: false, Msg: []byte(err.Error())}
-					err = nil
-				}
-			case types.EventAddDelayTx:
-				dtx := msg.GetData().(*types.DelayTx)
-				reply, err = mem.mainGrpcCli.SendDelayTransaction(context.Background(), dtx)
-				// 兼容rpc接收返回时错误处理
-				if err != nil {
-					reply = &types.Reply{IsOk: false, Msg: []byte(err.Error())}
-					err = nil
-				}
+		for msg := range mem.client.Recv() {
+			mem.handleMsg(msg)
+		}
+	}()
+}
 
-			case types.EventGetProperFee:
-				reply, err = mem.mainGrpcCli.GetProperFee(context.Background(), &types.ReqProperFee{})
-			case types.EventGetMempoolSize:
-				// 消息类型EventGetMempoolSize：获取mempool大小
-				size := types.Conf(client.GetConfig(), "config.mempool").GInt("poolCacheSize")
-				msg.Reply(mem.client.NewMessage("rpc", types.EventMempoolSize, &types.MempoolSize{Size: size}))
-				continue
-			default:
-				msg.Reply(client.NewMessage(mem.key, types.EventReply, types.ErrActionNotSupport))
-				continue
-			}
-			if err != nil {
-				msg.Reply(client.NewMessage(mem.key, types.EventReply, err))
-			} else {
-				msg.Reply(client.NewMessage(mem.key, types.EventReply, reply))
+func (mem *Mempool) handleMsg(msg *queue.Message) {
+	client := mem.client
+	var err error
+	var reply interface{}
+	switch msg.Ty {
+	case types.EventTx:
+		tx := msg.GetData().(*types.Transaction)
+		reply = mem.sendTx(&pendingTx{tx: tx})
+	case types.EventAddDelayTx:
+		dtx := msg.GetData().(*types.DelayTx)
+		reply = mem.sendTx(&pendingTx{tx: dtx.GetTx(), delayTx: dtx})
+	case types.EventGetProperFee:
+		reply, err = mem.forwarder.getProperFee()
+	case types.EventGetMempoolSize:
+		// 消息类型EventGetMempoolSize：获取mempool大小
+		msg.Reply(client.NewMessage("rpc", types.EventMempoolSize, &types.MempoolSize{Size: int64(mem.queue.size())}))
+		return
+	case types.EventGetMempool:
+		msg.Reply(client.NewMessage("rpc", types.EventReplyTxList, &types.ReplyTxList{Txs: mem.queue.getTxs()}))
+		return
+	case types.EventGetLastMempool:
+		msg.Reply(client.NewMessage("rpc", types.EventReplyTxList, &types.ReplyTxList{Txs: mem.queue.getLatestTxs()}))
+		return
+	case types.EventGetAddrTxs:
+		addrs := msg.GetData().(*types.ReqAddrs)
+		msg.Reply(client.NewMessage("", types.EventReplyAddrTxs, mem.queue.getAccTxs(addrs)))
+		return
+	case types.EventAddBlock:
+		// 打包的交易从本地缓存中删除, 与chain33 mempool一致不需要回复
+		mem.queue.removeTxs(msg.GetData().(*types.BlockDetail).GetBlock().GetTxs())
+		return
+	default:
+		msg.Reply(client.NewMessage(mem.key, types.EventReply, types.ErrActionNotSupport))
+		return
+	}
+	if err != nil {
+		msg.Reply(client.NewMessage(mem.key, types.EventReply, err))
+	} else {
+		msg.Reply(client.NewMessage(mem.key, types.EventReply, reply))
+	}
+}
+
+// sendTx 转发交易到主链, 主链不可用时缓存在本地, 由retryLoop重试
+func (mem *Mempool) sendTx(p *pendingTx) *types.Reply {
+	now := types.Now()
+	p.enterTime = now
+	if mem.backoff.ready(now) {
+		reply, err := mem.forwarder.send(p)
+		if err == nil {
+			mem.backoff.reset()
+			if reply.GetIsOk() {
+				p.forwarded = true
+				if err = mem.queue.push(p); err == types.ErrTxExist {
+					mem.queue.setForwarded(p.hash())
+				}
 			}
+			return reply
 		}
-	}()
+		// 兼容rpc发送交易错误处理, 同常规mempool返回逻辑保持一致
+		if !isUnavailable(err) {
+			return &types.Reply{IsOk: false, Msg: []byte(err.Error())}
+		}
+		mem.backoff.fail(now)
+	}
+	if err := mem.queue.push(p); err != nil {
+		return &types.Reply{IsOk: false, Msg: []byte(err.Error())}
+	}
+	mlog.Info("sendTx main chain unavailable, cached", "hash", common.ToHex(p.tx.Hash()))
+	return &types.Reply{IsOk: true, Msg: p.tx.Hash()}
+}
+
+func (mem *Mempool) retryLoop() {
+	defer mem.wg.Done()
+	ticker := time.NewTicker(retryTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-mem.done:
+			return
+		case <-ticker.C:
+		}
+		mem.retry(types.Now())
+	}
+}
+
+// retry 删除过期的交易, 主链恢复后按进入顺序重新转发缓存的交易
+func (mem *Mempool) retry(now time.Time) {
+	for _, p := range mem.queue.removeExpired(now, time.Duration(mem.subcfg.PendingExpire)*time.Second) {
+		mlog.Error("retry drop expired tx", "hash", common.ToHex(p.tx.Hash()))
+	}
+	if !mem.backoff.ready(now) {
+		return
+	}
+	txs := mem.queue.unforwarded()
+	for i, p := range txs {
+		reply, err := mem.forwarder.send(p)
+		if isUnavailable(err) {
+			interval := mem.backoff.fail(now)
+			mlog.Error("retry main chain unavailable", "pending", len(txs)-i, "retryAfter", interval)
+			return
+		}
+		mem.backoff.reset()
+		if err != nil || !reply.GetIsOk() {
+			// 主链拒绝的交易不再重试
+			mlog.Error("retry tx rejected by main chain", "hash", common.ToHex(p.tx.Hash()), "err", err, "msg", string(reply.GetMsg()))
+			mem.queue.remove(p.hash())
+			continue
+		}
+		mem.queue.setForwarded(p.hash())
+	}
 }
 
 func (mem *Mempool) setMainGrpcCli(cfg *types.Chain33Config) {
 	if cfg != nil && cfg.IsPara() {
-		grpcCli, err := grpcclient.NewMainChainClient(cfg, "")
+		f, err := newForwarder(cfg, mem.subcfg.MainChainGrpcAddrs, time.Duration(mem.subcfg.ForwardTimeout)*time.Second)
 		if err != nil {
 			panic(err)
 		}
-		mem.mainGrpcCli = grpcCli
+		mem.forwarder = f
 	}
 }
 
@@ -99,6 +225,7 @@ func (mem *Mempool) Close() {
 	if !atomic.CompareAndSwapInt32(&mem.isclose, 0, 1) {
 		return
 	}
+	close(mem.done)
 	if mem.client != nil {
 		mem.client.Close()
 	}
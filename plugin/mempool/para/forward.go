@@ -0,0 +1,97 @@
+package para
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/33cn/chain33/rpc/grpcclient"
+	"github.com/33cn/chain33/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// forwarder 向主链转发交易, 当前节点连接失败时依次切换到其他节点
+type forwarder struct {
+	addrs   []string
+	clients []types.Chain33Client
+	current int32
+	timeout time.Duration
+}
+
+func newForwarder(cfg *types.Chain33Config, addrs []string, timeout time.Duration) (*forwarder, error) {
+	f := &forwarder{timeout: timeout}
+	if len(addrs) == 0 {
+		cli, err := grpcclient.NewMainChainClient(cfg, "")
+		if err != nil {
+			return nil, err
+		}
+		f.addrs = []string{cfg.GetModuleConfig().RPC.ParaChain.MainChainGrpcAddr}
+		f.clients = []types.Chain33Client{cli}
+		return f, nil
+	}
+	for _, addr := range addrs {
+		cli, err := grpcclient.NewMainChainClient(cfg, addr)
+		if err != nil {
+			return nil, err
+		}
+		f.addrs = append(f.addrs, addr)
+		f.clients = append(f.clients, cli)
+	}
+	return f, nil
+}
+
+// isUnavailable 连接失败或超时需要切换节点重试, 其他错误表示主链拒绝了交易
+func isUnavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
+
+// call 从当前节点开始依次调用, 直到主链返回结果或所有节点都不可用
+func (f *forwarder) call(fn func(ctx context.Context, cli types.Chain33Client) error) error {
+	start := int(atomic.LoadInt32(&f.current))
+	var err error
+	for i := 0; i < len(f.clients); i++ {
+		index := (start + i) % len(f.clients)
+		ctx, cancel := context.WithTimeout(context.Background(), f.timeout)
+		err = fn(ctx, f.clients[index])
+		cancel()
+		if !isUnavailable(err) {
+			if index != start {
+				atomic.StoreInt32(&f.current, int32(index))
+				mlog.Info("forwarder switch main chain grpc", "addr", f.addrs[index])
+			}
+			return err
+		}
+		mlog.Error("forwarder main chain grpc unavailable", "addr", f.addrs[index], "err", err)
+	}
+	return err
+}
+
+// send 转发交易或延时交易
+func (f *forwarder) send(p *pendingTx) (*types.Reply, error) {
+	var reply *types.Reply
+	err := f.call(func(ctx context.Context, cli types.Chain33Client) error {
+		var err error
+		if p.delayTx != nil {
+			reply, err = cli.SendDelayTransaction(ctx, p.delayTx)
+		} else {
+			reply, err = cli.SendTransaction(ctx, p.tx)
+		}
+		return err
+	})
+	return reply, err
+}
+
+func (f *forwarder) getProperFee() (*types.ReplyProperFee, error) {
+	var reply *types.ReplyProperFee
+	err := f.call(func(ctx context.Context, cli types.Chain33Client) error {
+		var err error
+		reply, err = cli.GetProperFee(ctx, &types.ReqProperFee{})
+		return err
+	})
+	return reply, err
+}
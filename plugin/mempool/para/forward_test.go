@@ -0,0 +1,196 @@
+package para
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	_ "github.com/33cn/chain33/system/address"
+	_ "github.com/33cn/chain33/system/crypto/init"
+)
+
+// mockClient 模拟主链grpc节点
+type mockClient struct {
+	types.Chain33Client
+	down   int32
+	reject error
+	calls  int32
+}
+
+func (m *mockClient) setDown(down bool) {
+	var v int32
+	if down {
+		v = 1
+	}
+	atomic.StoreInt32(&m.down, v)
+}
+
+func (m *mockClient) SendTransaction(ctx context.Context, tx *types.Transaction, opts ...grpc.CallOption) (*types.Reply, error) {
+	atomic.AddInt32(&m.calls, 1)
+	if atomic.LoadInt32(&m.down) == 1 {
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	}
+	if m.reject != nil {
+		return nil, m.reject
+	}
+	return &types.Reply{IsOk: true, Msg: tx.Hash()}, nil
+}
+
+func (m *mockClient) SendDelayTransaction(ctx context.Context, tx *types.DelayTx, opts ...grpc.CallOption) (*types.Reply, error) {
+	return m.SendTransaction(ctx, tx.GetTx(), opts...)
+}
+
+func newTestForwarder(clients ...*mockClient) *forwarder {
+	f := &forwarder{timeout: time.Second}
+	for i, cli := range clients {
+		f.addrs = append(f.addrs, string(rune('a'+i)))
+		f.clients = append(f.clients, cli)
+	}
+	return f
+}
+
+func newTestTx(t *testing.T, priv crypto.PrivKey) *types.Transaction {
+	tx := &types.Transaction{Execer: []byte("user.p.test.none"), Payload: []byte("test"), Fee: 100000, Nonce: types.Now().UnixNano()}
+	tx.Sign(types.SECP256K1, priv)
+	return tx
+}
+
+func genKey(t *testing.T) crypto.PrivKey {
+	c, err := crypto.Load(types.GetSignName("", types.SECP256K1), -1)
+	assert.Nil(t, err)
+	priv, err := c.GenKey()
+	assert.Nil(t, err)
+	return priv
+}
+
+func TestForwarderFailover(t *testing.T) {
+	a, b := &mockClient{}, &mockClient{}
+	a.setDown(true)
+	f := newTestForwarder(a, b)
+	tx := newTestTx(t, genKey(t))
+	reply, err := f.send(&pendingTx{tx: tx})
+	assert.Nil(t, err)
+	assert.True(t, reply.IsOk)
+	assert.Equal(t, int32(1), f.current)
+
+	// 主链拒绝交易时不切换节点
+	b.reject = errors.New("ErrTxExist")
+	_, err = f.send(&pendingTx{tx: tx})
+	assert.Equal(t, b.reject, err)
+	assert.False(t, isUnavailable(err))
+	assert.Equal(t, int32(1), f.current)
+
+	b.setDown(true)
+	_, err = f.send(&pendingTx{tx: tx})
+	assert.True(t, isUnavailable(err))
+}
+
+func TestSendTxRetry(t *testing.T) {
+	cli := &mockClient{}
+	cli.setDown(true)
+	mem := newMempool(subConfig{RetryInterval: 1, MaxRetryInterval: 4})
+	mem.forwarder = newTestForwarder(cli)
+	priv := genKey(t)
+
+	// 主链不可用时缓存在本地, 回退期间不再尝试连接
+	tx1, tx2 := newTestTx(t, priv), newTestTx(t, priv)
+	reply := mem.sendTx(&pendingTx{tx: tx1})
+	assert.True(t, reply.IsOk)
+	assert.Equal(t, tx1.Hash(), reply.Msg)
+	assert.True(t, mem.sendTx(&pendingTx{tx: tx2}).IsOk)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&cli.calls))
+	assert.Equal(t, 2, len(mem.queue.unforwarded()))
+
+	now := types.Now()
+	mem.retry(now)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&cli.calls))
+	mem.retry(now.Add(time.Second))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&cli.calls))
+	// 失败后重试间隔加倍
+	assert.False(t, mem.backoff.ready(now.Add(2*time.Second)))
+	assert.True(t, mem.backoff.ready(now.Add(3*time.Second)))
+
+	cli.setDown(false)
+	mem.retry(now.Add(3 * time.Second))
+	assert.Equal(t, 0, len(mem.queue.unforwarded()))
+	// 已转发的交易在打包前仍然可以查询
+	assert.Equal(t, []*types.Transaction{tx1, tx2}, mem.queue.getTxs())
+
+	// 主链拒绝的交易不再重试
+	cli.setDown(true)
+	tx3 := newTestTx(t, priv)
+	mem.backoff.reset()
+	assert.True(t, mem.sendTx(&pendingTx{tx: tx3}).IsOk)
+	cli.setDown(false)
+	cli.reject = errors.New("ErrNoBalance")
+	mem.retry(now.Add(time.Hour))
+	assert.Equal(t, 0, mem.queue.size())
+}
+
+func TestTxQueue(t *testing.T) {
+	q := newTxQueue(2)
+	priv := genKey(t)
+	p1 := &pendingTx{tx: newTestTx(t, priv), enterTime: types.Now()}
+	p2 := &pendingTx{tx: newTestTx(t, priv), enterTime: types.Now()}
+	p3 := &pendingTx{tx: newTestTx(t, priv), enterTime: types.Now()}
+	assert.Nil(t, q.push(p1))
+	assert.Equal(t, types.ErrTxExist, q.push(p1))
+	assert.Nil(t, q.push(p2))
+	// 未转发的交易不会被挤出
+	assert.Equal(t, types.ErrMemFull, q.push(p3))
+	q.setForwarded(p2.hash())
+	assert.Nil(t, q.push(p3))
+	assert.Equal(t, []*types.Transaction{p1.tx, p3.tx}, q.getTxs())
+
+	dropped := q.removeExpired(p1.enterTime.Add(time.Hour), time.Minute)
+	assert.Equal(t, []*pendingTx{p1, p3}, dropped)
+	assert.Equal(t, 0, q.size())
+}
+
+func TestMempoolQueries(t *testing.T) {
+	q := queue.New("channel")
+	q.SetConfig(types.NewChain33Config(types.GetDefaultCfgstring()))
+	cli := &mockClient{}
+	mem := newMempool(subConfig{})
+	mem.forwarder = newTestForwarder(cli)
+	mem.client = q.Client()
+	mem.client.Sub(mem.key)
+	mem.start()
+	defer mem.Close()
+
+	api := q.Client()
+	send := func(ty int64, data interface{}) interface{} {
+		msg := api.NewMessage(topic, ty, data)
+		assert.Nil(t, api.Send(msg, true))
+		reply, err := api.Wait(msg)
+		assert.Nil(t, err)
+		return reply.GetData()
+	}
+	priv := genKey(t)
+	tx1, tx2 := newTestTx(t, priv), newTestTx(t, genKey(t))
+	assert.True(t, send(types.EventTx, tx1).(*types.Reply).IsOk)
+	cli.setDown(true)
+	assert.True(t, send(types.EventTx, tx2).(*types.Reply).IsOk)
+
+	assert.Equal(t, int64(2), send(types.EventGetMempoolSize, nil).(*types.MempoolSize).Size)
+	assert.Equal(t, []*types.Transaction{tx1, tx2}, send(types.EventGetMempool, &types.ReqGetMempool{}).(*types.ReplyTxList).Txs)
+	assert.Equal(t, []*types.Transaction{tx2, tx1}, send(types.EventGetLastMempool, nil).(*types.ReplyTxList).Txs)
+	details := send(types.EventGetAddrTxs, &types.ReqAddrs{Addrs: []string{tx1.From()}}).(*types.TransactionDetails)
+	assert.Equal(t, 1, len(details.Txs))
+	assert.Equal(t, tx1, details.Txs[0].Tx)
+
+	// 打包后从本地缓存删除
+	msg := api.NewMessage(topic, types.EventAddBlock, &types.BlockDetail{Block: &types.Block{Txs: []*types.Transaction{tx1}}})
+	assert.Nil(t, api.Send(msg, false))
+	assert.Equal(t, int64(1), send(types.EventGetMempoolSize, nil).(*types.MempoolSize).Size)
+}
@@ -9,11 +9,30 @@ import (
 //--------------------------------------------------------------------------------
 // Module Mempool
 
+type subConfig struct {
+	// 本地缓存的交易数上限
+	PoolCacheSize int64 `json:"poolCacheSize"`
+	// 转发交易的主链grpc节点, 连接失败时依次切换, 为空时使用rpc.parachain.mainChainGrpcAddr
+	MainChainGrpcAddrs []string `json:"mainChainGrpcAddrs"`
+	// 单次转发的超时时间, 秒
+	ForwardTimeout int64 `json:"forwardTimeout"`
+	// 主链不可用时的重试间隔, 秒, 每次失败后加倍直到maxRetryInterval
+	RetryInterval    int64 `json:"retryInterval"`
+	MaxRetryInterval int64 `json:"maxRetryInterval"`
+	// 交易在本地缓存的最长时间, 秒, 超时仍未转发或打包则删除
+	PendingExpire int64 `json:"pendingExpire"`
+}
+
 func init() {
 	drivers.Reg("para", New)
 }
 
 //New 创建price cache 结构的 mempool
 func New(cfg *types.Mempool, sub []byte) queue.Module {
-	return NewMempool(cfg)
+	var subcfg subConfig
+	types.MustDecode(sub, &subcfg)
+	if subcfg.PoolCacheSize == 0 && cfg != nil {
+		subcfg.PoolCacheSize = cfg.PoolCacheSize
+	}
+	return newMempool(subcfg)
 }
@@ -0,0 +1,217 @@
+package para
+
+import (
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/common/listmap"
+	"github.com/33cn/chain33/types"
+)
+
+// 最新交易查询返回的交易数, 与chain33 mempool一致
+const latestTxCount = 10
+
+// pendingTx 本地缓存的平行链交易
+type pendingTx struct {
+	tx        *types.Transaction
+	delayTx   *types.DelayTx
+	enterTime time.Time
+	// 已经转发到主链, 等待打包
+	forwarded bool
+}
+
+func (p *pendingTx) hash() string {
+	return string(p.tx.Hash())
+}
+
+// txQueue 按进入顺序保存交易, 包括等待重试转发的和已转发等待打包的
+type txQueue struct {
+	mu      sync.Mutex
+	maxSize int
+	txs     *listmap.ListMap
+}
+
+func newTxQueue(maxSize int) *txQueue {
+	return &txQueue{maxSize: maxSize, txs: listmap.New()}
+}
+
+// push 加入交易, 队列满时删除最早的已转发交易, 都未转发则返回ErrMemFull
+func (q *txQueue) push(p *pendingTx) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	hash := p.hash()
+	if q.txs.Exist(hash) {
+		return types.ErrTxExist
+	}
+	if q.txs.Size() >= q.maxSize {
+		var oldest *pendingTx
+		q.txs.Walk(func(value interface{}) bool {
+			if item := value.(*pendingTx); item.forwarded {
+				oldest = item
+				return false
+			}
+			return true
+		})
+		if oldest == nil {
+			return types.ErrMemFull
+		}
+		q.txs.Remove(oldest.hash())
+	}
+	q.txs.Push(hash, p)
+	return nil
+}
+
+func (q *txQueue) remove(hash string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.txs.Remove(hash)
+}
+
+// removeTxs 删除已经打包的交易
+func (q *txQueue) removeTxs(txs []*types.Transaction) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, tx := range txs {
+		q.txs.Remove(string(tx.Hash()))
+	}
+}
+
+// removeExpired 删除缓存超过expire的交易, 返回其中未转发的交易
+func (q *txQueue) removeExpired(now time.Time, expire time.Duration) []*pendingTx {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var expired, dropped []*pendingTx
+	q.txs.Walk(func(value interface{}) bool {
+		item := value.(*pendingTx)
+		if now.Sub(item.enterTime) < expire {
+			return false
+		}
+		expired = append(expired, item)
+		return true
+	})
+	for _, item := range expired {
+		q.txs.Remove(item.hash())
+		if !item.forwarded {
+			dropped = append(dropped, item)
+		}
+	}
+	return dropped
+}
+
+func (q *txQueue) setForwarded(hash string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if item, err := q.txs.GetItem(hash); err == nil {
+		item.(*pendingTx).forwarded = true
+	}
+}
+
+// unforwarded 等待转发的交易, 按进入顺序
+func (q *txQueue) unforwarded() []*pendingTx {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var txs []*pendingTx
+	q.txs.Walk(func(value interface{}) bool {
+		if item := value.(*pendingTx); !item.forwarded {
+			txs = append(txs, item)
+		}
+		return true
+	})
+	return txs
+}
+
+func (q *txQueue) size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.txs.Size()
+}
+
+func (q *txQueue) walk(cb func(p *pendingTx) bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.txs.Walk(func(value interface{}) bool {
+		return cb(value.(*pendingTx))
+	})
+}
+
+// getTxs 所有缓存的交易
+func (q *txQueue) getTxs() []*types.Transaction {
+	var txs []*types.Transaction
+	q.walk(func(p *pendingTx) bool {
+		txs = append(txs, p.tx)
+		return true
+	})
+	return txs
+}
+
+// getLatestTxs 最新加入的交易, 最新的在前
+func (q *txQueue) getLatestTxs() []*types.Transaction {
+	txs := q.getTxs()
+	var latest []*types.Transaction
+	for i := len(txs) - 1; i >= 0 && len(latest) < latestTxCount; i-- {
+		latest = append(latest, txs[i])
+	}
+	return latest
+}
+
+// getAccTxs 获取对应账户地址(列表)中的全部交易
+func (q *txQueue) getAccTxs(addrs *types.ReqAddrs) *types.TransactionDetails {
+	accounts := make(map[string]bool)
+	for _, addr := range addrs.GetAddrs() {
+		accounts[addr] = true
+	}
+	res := &types.TransactionDetails{}
+	q.walk(func(p *pendingTx) bool {
+		from := p.tx.From()
+		if !accounts[from] {
+			return true
+		}
+		amount, err := p.tx.Amount()
+		if err != nil {
+			amount = 0
+		}
+		res.Txs = append(res.Txs, &types.TransactionDetail{
+			Tx:         p.tx,
+			Amount:     amount,
+			Fromaddr:   from,
+			ActionName: p.tx.ActionName(),
+		})
+		return true
+	})
+	return res
+}
+
+// backoff 主链不可用时的重试间隔, 每次失败后加倍
+type backoff struct {
+	mu        sync.Mutex
+	base      time.Duration
+	max       time.Duration
+	failures  uint
+	nextRetry time.Time
+}
+
+func (b *backoff) fail(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	interval := b.max
+	if b.failures < 32 && b.base<<b.failures < b.max {
+		interval = b.base << b.failures
+	}
+	b.failures++
+	b.nextRetry = now.Add(interval)
+	return interval
+}
+
+func (b *backoff) reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.nextRetry = time.Time{}
+}
+
+// ready 可以尝试连接主链
+func (b *backoff) ready(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !now.Before(b.nextRetry)
+}
@@ -3,6 +3,7 @@ package price
 import (
 	"github.com/33cn/chain33/common/skiplist"
 	"github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/plugin/plugin/mempool/estimator"
 	"github.com/golang/protobuf/proto"
 )
 
@@ -10,6 +11,7 @@ import (
 type Queue struct {
 	*skiplist.Queue
 	subConfig subConfig
+	estimator *estimator.Estimator
 }
 
 type priceScore struct {
@@ -70,8 +72,17 @@ func (cache *Queue) Walk(count int, cb func(tx *mempool.Item) bool) {
 	})
 }
 
-// GetProperFee 获取合适的手续费率,取前100的平均手续费率
+// GetProperFee 获取合适的手续费率, 优先根据最近区块的打包费率估计,
+// 区块数据不足时取队列前100的平均手续费率
 func (cache *Queue) GetProperFee() int64 {
+	if cache.estimator != nil {
+		if feeRate, ok := cache.estimator.EstimateFeeRate(0); ok {
+			if feeRate < cache.subConfig.ProperFee {
+				return cache.subConfig.ProperFee
+			}
+			return feeRate
+		}
+	}
 	var sumFeeRate int64
 	var properFeeRate int64
 	if cache.Size() < 100 {
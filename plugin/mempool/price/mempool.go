@@ -4,6 +4,7 @@ import (
 	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/mempool"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/mempool/estimator"
 )
 
 //--------------------------------------------------------------------------------
@@ -12,6 +13,8 @@ import (
 type subConfig struct {
 	PoolCacheSize int64 `json:"poolCacheSize"`
 	ProperFee     int64 `json:"properFee"`
+	// 根据最近区块的打包费率估计手续费
+	estimator.Config
 }
 
 func init() {
@@ -29,6 +32,8 @@ func New(cfg *types.Mempool, sub []byte) queue.Module {
 	if subcfg.ProperFee == 0 {
 		subcfg.ProperFee = cfg.MinTxFeeRate
 	}
-	c.SetQueueCache(NewQueue(subcfg))
-	return c
+	cache := NewQueue(subcfg)
+	cache.estimator = estimator.New(subcfg.Config, cfg)
+	c.SetQueueCache(cache)
+	return estimator.NewMempool(c, cache.estimator)
 }
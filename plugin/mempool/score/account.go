@@ -0,0 +1,191 @@
+package score
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/common/skiplist"
+	"github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	"github.com/golang/protobuf/proto"
+)
+
+const defaultReplaceFeeBump = 10
+
+// ErrReplaceFeeTooLow 替换交易的手续费提高得不够
+var ErrReplaceFeeTooLow = errors.New("ErrReplaceFeeTooLow")
+
+// accountTxs 单个账户在队列中的交易
+type accountTxs struct {
+	items map[string]*scoreScore
+	// 冲突key到交易hash
+	keys map[string]string
+}
+
+// lowest 账户中分数最低的交易, 分数相同时后进入的更低
+func (acc *accountTxs) lowest() *scoreScore {
+	var low *scoreScore
+	for _, item := range acc.items {
+		if low == nil || item.GetScore() < low.GetScore() ||
+			(item.GetScore() == low.GetScore() && item.Compare(low) == skiplist.Small) {
+			low = item
+		}
+	}
+	return low
+}
+
+// evicted 被替换或淘汰的交易, 等待mempool清理账户索引
+// 只在清理时对mempool可见, 清理通过mempool的Exist检查后才会删除账户索引, 最新交易缓存和手续费总和
+type evicted struct {
+	mu       sync.Mutex
+	items    map[string]*mempool.Item
+	cleaning map[string]bool
+	notify   chan struct{}
+}
+
+func newEvicted() *evicted {
+	return &evicted{
+		items:    make(map[string]*mempool.Item),
+		cleaning: make(map[string]bool),
+		notify:   make(chan struct{}, 1),
+	}
+}
+
+func (e *evicted) add(hash string, item *mempool.Item) {
+	e.mu.Lock()
+	e.items[hash] = item
+	e.mu.Unlock()
+	select {
+	case e.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (e *evicted) get(hash string) (*mempool.Item, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	item, ok := e.items[hash]
+	return item, ok
+}
+
+// visible 正在清理的交易
+func (e *evicted) visible(hash string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.cleaning[hash]
+}
+
+func (e *evicted) remove(hash string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, ok := e.items[hash]
+	delete(e.items, hash)
+	delete(e.cleaning, hash)
+	return ok
+}
+
+// clean 返回需要清理的交易, 这些交易在清理完成前对mempool可见
+func (e *evicted) clean() [][]byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	hashes := make([][]byte, 0, len(e.items))
+	for hash := range e.items {
+		e.cleaning[hash] = true
+		hashes = append(hashes, []byte(hash))
+	}
+	return hashes
+}
+
+// detached 替换evm交易时从mempool账户索引中移除的旧交易, 仍保留在队列中但对mempool不可见
+// 新交易加入队列时替换旧交易, 新交易没有加入时旧交易重新加入mempool索引
+type detached struct {
+	mu sync.Mutex
+	// false: 等待mempool删除索引, true: 已删除索引
+	items map[string]bool
+}
+
+func newDetached() *detached {
+	return &detached{items: make(map[string]bool)}
+}
+
+func (d *detached) mark(hash string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.items[hash] = false
+}
+
+// detach mempool删除索引时调用, 返回是否为等待删除的交易
+func (d *detached) detach(hash string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if done, ok := d.items[hash]; ok && !done {
+		d.items[hash] = true
+		return true
+	}
+	return false
+}
+
+// settle 返回交易是否已从mempool索引中移除, 未移除时取消标记
+func (d *detached) settle(hash string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.items[hash] {
+		delete(d.items, hash)
+		return false
+	}
+	return true
+}
+
+func (d *detached) has(hash string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.items[hash]
+}
+
+// remove 删除已从mempool索引中移除的交易标记
+func (d *detached) remove(hash string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.items[hash] {
+		delete(d.items, hash)
+		return true
+	}
+	return false
+}
+
+// feeRate 每千字节的手续费, 交易组的手续费和大小都在第一笔交易中
+func feeRate(tx *types.Transaction) int64 {
+	return tx.Fee * 1000 / int64(proto.Size(tx))
+}
+
+// replaceKey 同一账户中key相同的交易互相冲突, 只能保留一笔
+// evm交易按nonce冲突, 旧交易在nonce检查之前由Mempool.replaceEvmTx从mempool索引中移除, 这里完成替换
+// 其他交易除手续费, nonce和签名之外完全相同时冲突, 包括Expire和交易组
+func replaceKey(tx *types.Transaction) string {
+	if types.IsEthSignID(tx.GetSignature().GetTy()) {
+		return fmt.Sprintf("nonce:%d", tx.GetNonce())
+	}
+	if tx.GetGroupCount() > 1 {
+		var group types.Transactions
+		if err := types.Decode(tx.GetHeader(), &group); err == nil {
+			var key []byte
+			for _, gtx := range group.GetTxs() {
+				key = append(key, contentHash(gtx)...)
+			}
+			return "group:" + string(common.Sha256(key))
+		}
+	}
+	return "tx:" + string(contentHash(tx))
+}
+
+func contentHash(tx *types.Transaction) []byte {
+	copytx := types.CloneTx(tx)
+	copytx.Fee = 0
+	copytx.Nonce = 0
+	copytx.Signature = nil
+	copytx.Header = nil
+	copytx.Next = nil
+	return common.Sha256(types.Encode(copytx))
+}
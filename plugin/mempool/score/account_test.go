@@ -0,0 +1,343 @@
+package score
+
+import (
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/common/address"
+	"github.com/33cn/chain33/common/crypto"
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/33cn/chain33/system/address"
+	_ "github.com/33cn/chain33/system/crypto/init"
+)
+
+const testFee = 100000
+
+func newAccountQueue(t *testing.T, size, maxPerAccount int64) *Queue {
+	return NewQueue(subConfig{
+		PoolCacheSize:   size,
+		TimeParam:       1,
+		PriceConstant:   10,
+		PricePower:      1,
+		MaxTxPerAccount: maxPerAccount,
+		ReplaceFeeBump:  defaultReplaceFeeBump,
+	})
+}
+
+func genKey(t *testing.T) crypto.PrivKey {
+	c, err := crypto.Load(types.GetSignName("", types.SECP256K1), -1)
+	assert.Nil(t, err)
+	priv, err := c.GenKey()
+	assert.Nil(t, err)
+	return priv
+}
+
+func newSignedTx(priv crypto.PrivKey, payload string, fee, expire int64) *types.Transaction {
+	tx := &types.Transaction{Execer: []byte("none"), Payload: []byte(payload), Fee: fee, Expire: expire, Nonce: types.Now().UnixNano()}
+	tx.Sign(types.SECP256K1, priv)
+	return tx
+}
+
+func newTxItem(tx *types.Transaction) *drivers.Item {
+	return &drivers.Item{Value: tx, Priority: tx.Fee, EnterTime: types.Now().Unix()}
+}
+
+func walkHashes(cache *Queue) map[string]bool {
+	hashes := make(map[string]bool)
+	cache.Walk(0, func(item *drivers.Item) bool {
+		hashes[string(item.Value.Hash())] = true
+		return true
+	})
+	return hashes
+}
+
+func TestReplaceByFee(t *testing.T) {
+	cache := newAccountQueue(t, 100, 0)
+	priv := genKey(t)
+	tx := newSignedTx(priv, "transfer", testFee, 100)
+	assert.Nil(t, cache.Push(newTxItem(tx)))
+	// 其他内容不同的交易不冲突
+	other := newSignedTx(priv, "transfer", testFee, 101)
+	assert.Nil(t, cache.Push(newTxItem(other)))
+
+	// 手续费提高不足
+	low := newSignedTx(priv, "transfer", testFee*105/100, 100)
+	assert.Equal(t, ErrReplaceFeeTooLow, cache.Push(newTxItem(low)))
+
+	bump := newSignedTx(priv, "transfer", testFee*2, 100)
+	assert.Nil(t, cache.Push(newTxItem(bump)))
+	assert.Equal(t, 2, cache.Size())
+	hashes := walkHashes(cache)
+	assert.True(t, hashes[string(bump.Hash())])
+	assert.False(t, hashes[string(tx.Hash())])
+	// 被替换的交易在mempool清理之前不能重新加入, 只在清理时可见
+	assert.False(t, cache.Exist(string(tx.Hash())))
+	assert.Equal(t, types.ErrTxExist, cache.Push(newTxItem(tx)))
+	assert.Equal(t, [][]byte{tx.Hash()}, cache.evicted.clean())
+	assert.True(t, cache.Exist(string(tx.Hash())))
+	assert.Nil(t, cache.Remove(string(tx.Hash())))
+	assert.False(t, cache.Exist(string(tx.Hash())))
+
+	// 其他账户相同内容的交易不冲突
+	assert.Nil(t, cache.Push(newTxItem(newSignedTx(genKey(t), "transfer", testFee, 100))))
+	assert.Equal(t, 3, cache.Size())
+}
+
+func TestReplaceTxGroup(t *testing.T) {
+	cache := newAccountQueue(t, 100, 0)
+	priv := genKey(t)
+	newGroup := func(feeRate int64) *types.Transaction {
+		txs := []*types.Transaction{
+			{Execer: []byte("none"), Payload: []byte("a"), Expire: 100, Nonce: types.Now().UnixNano()},
+			{Execer: []byte("none"), Payload: []byte("b"), Expire: 100, Nonce: types.Now().UnixNano()},
+		}
+		group, err := types.CreateTxGroup(txs, feeRate)
+		assert.Nil(t, err)
+		for i := range group.GetTxs() {
+			assert.Nil(t, group.SignN(i, types.SECP256K1, priv))
+		}
+		return group.Tx()
+	}
+	group := newGroup(testFee)
+	assert.Nil(t, cache.Push(newTxItem(group)))
+	assert.Equal(t, ErrReplaceFeeTooLow, cache.Push(newTxItem(newGroup(testFee))))
+	bump := newGroup(testFee * 2)
+	assert.Nil(t, cache.Push(newTxItem(bump)))
+	assert.Equal(t, 1, cache.Size())
+	assert.True(t, walkHashes(cache)[string(bump.Hash())])
+}
+
+func TestReplaceKey(t *testing.T) {
+	priv := genKey(t)
+	tx := newSignedTx(priv, "a", testFee, 100)
+	assert.Equal(t, replaceKey(tx), replaceKey(newSignedTx(priv, "a", testFee*2, 100)))
+	assert.NotEqual(t, replaceKey(tx), replaceKey(newSignedTx(priv, "b", testFee, 100)))
+
+	// evm交易按nonce冲突
+	ethSign := &types.Signature{Ty: types.EncodeSignID(types.SECP256K1ETH, types.EthAddressID)}
+	evm1 := &types.Transaction{Execer: []byte("evm"), Payload: []byte("a"), Nonce: 3, Signature: ethSign}
+	evm2 := &types.Transaction{Execer: []byte("evm"), Payload: []byte("b"), Nonce: 3, Signature: ethSign}
+	evm3 := &types.Transaction{Execer: []byte("evm"), Payload: []byte("a"), Nonce: 4, Signature: ethSign}
+	assert.Equal(t, replaceKey(evm1), replaceKey(evm2))
+	assert.NotEqual(t, replaceKey(evm1), replaceKey(evm3))
+}
+
+func TestAccountLimit(t *testing.T) {
+	cache := newAccountQueue(t, 100, 2)
+	priv := genKey(t)
+	tx1 := newSignedTx(priv, "1", testFee, 100)
+	tx2 := newSignedTx(priv, "2", testFee*2, 100)
+	assert.Nil(t, cache.Push(newTxItem(tx1)))
+	assert.Nil(t, cache.Push(newTxItem(tx2)))
+	assert.Equal(t, types.ErrManyTx, cache.Push(newTxItem(newSignedTx(priv, "3", testFee*9/10, 100))))
+
+	// 费率更高的交易淘汰账户中分数最低的交易
+	tx3 := newSignedTx(priv, "3", testFee*3, 100)
+	assert.Nil(t, cache.Push(newTxItem(tx3)))
+	assert.Equal(t, 2, cache.Size())
+	hashes := walkHashes(cache)
+	assert.False(t, hashes[string(tx1.Hash())])
+	assert.True(t, hashes[string(tx2.Hash())])
+	assert.True(t, hashes[string(tx3.Hash())])
+	assert.False(t, cache.Exist(string(tx1.Hash())))
+}
+
+func TestEvictHeavyAccount(t *testing.T) {
+	cache := newAccountQueue(t, 3, 0)
+	heavy := genKey(t)
+	var heavyTxs []*types.Transaction
+	for i, payload := range []string{"1", "2", "3"} {
+		tx := newSignedTx(heavy, payload, testFee*int64(3-i), 100)
+		heavyTxs = append(heavyTxs, tx)
+		assert.Nil(t, cache.Push(newTxItem(tx)))
+	}
+
+	// 新账户的交易不会因为Mempool已满被拒绝, 而是淘汰交易最多的账户中分数最低的交易
+	newcomer := newSignedTx(genKey(t), "1", testFee*11/10, 100)
+	assert.Nil(t, cache.Push(newTxItem(newcomer)))
+	assert.Equal(t, 3, cache.Size())
+	hashes := walkHashes(cache)
+	assert.True(t, hashes[string(newcomer.Hash())])
+	assert.False(t, hashes[string(heavyTxs[2].Hash())])
+
+	// 费率低于该账户剩余的交易时不能淘汰
+	assert.Equal(t, types.ErrMemFull, cache.Push(newTxItem(newSignedTx(genKey(t), "1", testFee*9/10, 100))))
+	assert.Nil(t, cache.Push(newTxItem(newSignedTx(genKey(t), "1", testFee*21/10, 100))))
+	assert.False(t, walkHashes(cache)[string(heavyTxs[1].Hash())])
+
+	// 账户交易数相近时, 按分数和队列中最低的交易竞争
+	assert.Equal(t, types.ErrMemFull, cache.Push(newTxItem(newSignedTx(genKey(t), "1", testFee*9/10, 100))))
+	rich := newSignedTx(genKey(t), "1", testFee*10, 100)
+	assert.Nil(t, cache.Push(newTxItem(rich)))
+	assert.True(t, walkHashes(cache)[string(rich.Hash())])
+	assert.Equal(t, 3, cache.Size())
+}
+
+func TestCleanEvicted(t *testing.T) {
+	cfg := &types.Mempool{MinTxFeeRate: testFee, MaxTxFeeRate: 100 * testFee, PoolCacheSize: 100}
+	mem := New(cfg, nil).(*Mempool)
+	mem.wg.Add(1)
+	go mem.cleanEvicted()
+	defer mem.Close()
+
+	priv := genKey(t)
+	tx := newSignedTx(priv, "transfer", testFee, 100)
+	assert.Nil(t, mem.PushTx(tx))
+	assert.Nil(t, mem.PushTx(newSignedTx(priv, "transfer", testFee*2, 100)))
+	// mempool的账户索引中被替换的交易最终被删除
+	assert.Eventually(t, func() bool {
+		return mem.TxNumOfAccount(tx.From()) == 1 && !mem.cache.Exist(string(tx.Hash()))
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func newEvmTx(priv crypto.PrivKey, nonce, fee int64) *types.Transaction {
+	tx := &types.Transaction{Execer: []byte("evm"), Payload: []byte("call"), Fee: fee, Nonce: nonce,
+		To: address.ExecAddress("evm")}
+	tx.Sign(types.EncodeSignID(types.SECP256K1ETH, types.EthAddressID), priv)
+	return tx
+}
+
+func TestReplaceEvmTx(t *testing.T) {
+	cfg := &types.Mempool{MinTxFeeRate: testFee, MaxTxFeeRate: 100 * testFee, PoolCacheSize: 100}
+	mem := New(cfg, nil).(*Mempool)
+	defer mem.Close()
+	c, err := crypto.Load(types.GetSignName("", types.SECP256K1ETH), -1)
+	assert.Nil(t, err)
+	priv, err := c.GenKey()
+	assert.Nil(t, err)
+
+	old := newEvmTx(priv, 1, testFee)
+	assert.Nil(t, mem.PushTx(old))
+	assert.Nil(t, mem.PushTx(newEvmTx(priv, 2, testFee)))
+	from := old.From()
+	replace := func(tx *types.Transaction) *types.Transaction {
+		return mem.replaceEvmTx(queue.NewMessage(0, "mempool", types.EventTx, tx))
+	}
+
+	// 手续费提高不足或签名无效时保留旧交易
+	replace(newEvmTx(priv, 1, testFee*105/100))
+	assert.Equal(t, int64(2), mem.TxNumOfAccount(from))
+	forged := newEvmTx(priv, 1, testFee*2)
+	forged.Fee = testFee * 3
+	replace(forged)
+	assert.Equal(t, int64(2), mem.TxNumOfAccount(from))
+
+	// 在nonce检查之前从mempool索引中移除旧交易, 新交易加入队列时完成替换
+	bump := newEvmTx(priv, 1, testFee*2)
+	assert.Equal(t, old.Hash(), replace(bump).Hash())
+	assert.Equal(t, int64(1), mem.TxNumOfAccount(from))
+	assert.False(t, mem.cache.Exist(string(old.Hash())))
+	assert.Equal(t, 2, mem.cache.Size())
+	assert.Nil(t, mem.PushTx(bump))
+	assert.Equal(t, int64(2), mem.TxNumOfAccount(from))
+	assert.Equal(t, 2, mem.cache.Size())
+	assert.False(t, walkHashes(mem.cache)[string(old.Hash())])
+
+	// 新交易没有加入时恢复旧交易
+	bump2 := newEvmTx(priv, 1, testFee*3)
+	assert.Equal(t, bump.Hash(), replace(bump2).Hash())
+	assert.False(t, walkHashes(mem.cache)[string(bump.Hash())])
+	mem.restoreEvmTx(bump)
+	assert.Equal(t, int64(2), mem.TxNumOfAccount(from))
+	assert.True(t, mem.cache.Exist(string(bump.Hash())))
+	assert.True(t, walkHashes(mem.cache)[string(bump.Hash())])
+}
+
+// 模拟mempool依赖的模块, 执行模块检查余额时拒绝noBalance中的交易
+func mockModules(q queue.Queue, noBalance map[string]bool) {
+	handle := func(topic string, cb func(cli queue.Client, msg *queue.Message)) {
+		cli := q.Client()
+		cli.Sub(topic)
+		go func() {
+			for msg := range cli.Recv() {
+				cb(cli, msg)
+			}
+		}()
+	}
+	handle("blockchain", func(cli queue.Client, msg *queue.Message) {
+		switch msg.Ty {
+		case types.EventGetLastHeader:
+			msg.Reply(cli.NewMessage("", types.EventHeader, &types.Header{Height: 1, BlockTime: types.Now().Unix()}))
+		case types.EventIsSync:
+			msg.Reply(cli.NewMessage("", types.EventReplyIsSync, &types.IsCaughtUp{Iscaughtup: true}))
+		case types.EventTxHashList:
+			msg.Reply(cli.NewMessage("", types.EventTxHashListReply, &types.TxHashList{}))
+		}
+	})
+	handle("execs", func(cli queue.Client, msg *queue.Message) {
+		result := &types.ReceiptCheckTxList{}
+		for _, tx := range msg.GetData().(*types.ExecTxList).Txs {
+			errMsg := ""
+			if noBalance[string(tx.Hash())] {
+				errMsg = types.ErrNoBalance.Error()
+			}
+			result.Errs = append(result.Errs, errMsg)
+		}
+		msg.Reply(cli.NewMessage("", types.EventReceiptCheckTx, result))
+	})
+	handle("rpc", func(cli queue.Client, msg *queue.Message) {
+		msg.Reply(cli.NewMessage("", types.EventGetEvmNonce, &types.EvmAccountNonce{}))
+	})
+	handle("p2p", func(cli queue.Client, msg *queue.Message) {})
+}
+
+func TestReplaceEvmTxRejected(t *testing.T) {
+	cfg := types.NewChain33Config(types.GetDefaultCfgstring())
+	q := queue.New("channel")
+	q.SetConfig(cfg)
+	defer q.Close()
+	mcfg := cfg.GetModuleConfig().Mempool
+	mcfg.MinTxFeeRate = testFee
+	mcfg.MaxTxFeeRate = 100 * testFee
+	mcfg.PoolCacheSize = 100
+	mcfg.ForceAccept = true
+	mem := New(mcfg, nil).(*Mempool)
+	noBalance := make(map[string]bool)
+	mockModules(q, noBalance)
+	mem.SetQueueClient(q.Client())
+	mem.Wait()
+	defer mem.Close()
+
+	c, err := crypto.Load(types.GetSignName("", types.SECP256K1ETH), -1)
+	assert.Nil(t, err)
+	priv, err := c.GenKey()
+	assert.Nil(t, err)
+	newTx := func(nonce, fee int64) *types.Transaction {
+		tx := newEvmTx(priv, nonce, fee)
+		tx.ChainID = cfg.GetChainID()
+		tx.Sign(types.EncodeSignID(types.SECP256K1ETH, types.EthAddressID), priv)
+		return tx
+	}
+	cli := q.Client()
+	send := func(tx *types.Transaction) *types.Reply {
+		msg := cli.NewMessage("mempool", types.EventTx, tx)
+		assert.Nil(t, cli.Send(msg, true))
+		reply, err := cli.Wait(msg)
+		assert.Nil(t, err)
+		return reply.GetData().(*types.Reply)
+	}
+
+	old := newTx(1, testFee)
+	assert.True(t, send(old).GetIsOk())
+	// 替换交易余额检查失败, 旧交易仍在mempool中
+	bump := newTx(1, testFee*2)
+	noBalance[string(bump.Hash())] = true
+	reply := send(bump)
+	assert.False(t, reply.GetIsOk())
+	assert.Equal(t, types.ErrNoBalance.Error(), string(reply.GetMsg()))
+	assert.Eventually(t, func() bool {
+		return mem.TxNumOfAccount(old.From()) == 1 && mem.cache.Exist(string(old.Hash()))
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, walkHashes(mem.cache)[string(old.Hash())])
+	assert.False(t, mem.cache.Exist(string(bump.Hash())))
+
+	bump = newTx(1, testFee*3)
+	assert.True(t, send(bump).GetIsOk())
+	assert.Equal(t, int64(1), mem.TxNumOfAccount(old.From()))
+	assert.False(t, mem.cache.Exist(string(old.Hash())))
+	assert.True(t, mem.cache.Exist(string(bump.Hash())))
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/33cn/chain33/common/skiplist"
 	"github.com/33cn/chain33/system/mempool"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/mempool/estimator"
 	"github.com/golang/protobuf/proto"
 )
 
@@ -12,6 +14,10 @@ import (
 type Queue struct {
 	*skiplist.Queue
 	subConfig subConfig
+	estimator *estimator.Estimator
+	accounts  map[string]*accountTxs
+	evicted   *evicted
+	detached  *detached
 }
 
 type scoreScore struct {
@@ -51,6 +57,9 @@ func NewQueue(subcfg subConfig) *Queue {
 	return &Queue{
 		Queue:     skiplist.NewQueue(subcfg.PoolCacheSize),
 		subConfig: subcfg,
+		accounts:  make(map[string]*accountTxs),
+		evicted:   newEvicted(),
+		detached:  newDetached(),
 	}
 }
 
@@ -60,29 +69,190 @@ func NewQueue(subcfg subConfig) *Queue {
 //		cache.subConfig.PricePower - cache.subConfig.TimeParam*item.EnterTime, Value: item}, nil
 //}
 
+// Exist 是否存在, 被淘汰的交易只在mempool清理时存在, 等待替换的交易不存在
+func (cache *Queue) Exist(hash string) bool {
+	return (cache.Queue.Exist(hash) && !cache.detached.has(hash)) || cache.evicted.visible(hash)
+}
+
 //GetItem 获取数据通过 key
 func (cache *Queue) GetItem(hash string) (*mempool.Item, error) {
+	if cache.detached.has(hash) {
+		return nil, types.ErrNotFound
+	}
 	item, err := cache.Queue.GetItem(hash)
 	if err != nil {
+		if evicted, ok := cache.evicted.get(hash); ok {
+			return evicted, nil
+		}
 		return nil, err
 	}
 	return item.(*scoreScore).Item, nil
 }
 
 // Push 把给定tx添加到Queue；如果tx已经存在Queue中或Mempool已满则返回对应error
+// 同一账户的冲突交易在手续费提高足够时替换旧交易, 账户交易数达到上限或Mempool已满时淘汰费率更低的交易
+// 等待替换的交易重新加入时只恢复mempool索引, 队列不变
 func (cache *Queue) Push(item *mempool.Item) error {
-	return cache.Queue.Push(&scoreScore{Item: item, subConfig: cache.subConfig})
+	hash := string(item.Value.Hash())
+	if cache.detached.remove(hash) {
+		return nil
+	}
+	if cache.Queue.Exist(hash) {
+		return types.ErrTxExist
+	}
+	// 被淘汰的交易在mempool清理之前不能重新加入
+	if _, ok := cache.evicted.get(hash); ok {
+		return types.ErrTxExist
+	}
+	newItem := &scoreScore{Item: item, subConfig: cache.subConfig}
+	from := item.Value.From()
+	key := replaceKey(item.Value)
+	if acc, ok := cache.accounts[from]; ok {
+		if oldHash, ok := acc.keys[key]; ok {
+			old := acc.items[oldHash]
+			if !cache.canReplace(old.Value, item.Value) {
+				return ErrReplaceFeeTooLow
+			}
+			cache.evict(old)
+			cache.insert(hash, key, newItem)
+			return nil
+		}
+		if max := cache.subConfig.MaxTxPerAccount; max > 0 && int64(len(acc.items)) >= max {
+			low := acc.lowest()
+			if feeRate(item.Value) <= feeRate(low.Value) {
+				return types.ErrManyTx
+			}
+			cache.evict(low)
+		}
+	}
+	if int64(cache.Size()) >= cache.MaxSize() {
+		victim := cache.victim(from, newItem)
+		if victim == nil {
+			return types.ErrMemFull
+		}
+		cache.evict(victim)
+	}
+	cache.insert(hash, key, newItem)
+	return nil
+}
+
+// Remove 删除数据
+func (cache *Queue) Remove(hash string) error {
+	if cache.evicted.remove(hash) {
+		return nil
+	}
+	// 等待替换的交易只从mempool索引中移除, 保留在队列中
+	if cache.detached.detach(hash) {
+		return nil
+	}
+	item, err := cache.Queue.GetItem(hash)
+	if err != nil {
+		return err
+	}
+	if err = cache.Queue.Remove(hash); err != nil {
+		return err
+	}
+	cache.unindex(hash, item.(*scoreScore))
+	return nil
+}
+
+// canReplace 替换交易的手续费至少提高ReplaceFeeBump百分比, 费率也不能降低
+func (cache *Queue) canReplace(old, tx *types.Transaction) bool {
+	return tx.Fee*100 >= old.Fee*(100+cache.subConfig.ReplaceFeeBump) && feeRate(tx) >= feeRate(old)
+}
+
+// victim Mempool已满时选择淘汰的交易, 避免单个账户占满Mempool:
+// 交易最多的账户比新交易的账户多出一笔以上时, 淘汰该账户分数最低的交易, 新交易的费率不能更低;
+// 否则新交易的分数必须高于队列中分数最低的交易
+func (cache *Queue) victim(from string, item *scoreScore) *scoreScore {
+	rate := feeRate(item.Value)
+	var heavy *accountTxs
+	for _, acc := range cache.accounts {
+		if heavy == nil || len(acc.items) > len(heavy.items) {
+			heavy = acc
+		}
+	}
+	var count int
+	if acc, ok := cache.accounts[from]; ok {
+		count = len(acc.items)
+	}
+	if heavy != nil && len(heavy.items) > count+1 {
+		if low := heavy.lowest(); rate >= feeRate(low.Value) {
+			return low
+		}
+	}
+	tail := cache.Last()
+	if tail == nil {
+		return nil
+	}
+	sv, tv := cache.CreateSkipValue(item), cache.CreateSkipValue(tail)
+	if cmp := sv.Compare(tv); cmp == skiplist.Big || (cmp == skiplist.Equal && item.Compare(tail) == skiplist.Big) {
+		return tail.(*scoreScore)
+	}
+	return nil
+}
+
+func (cache *Queue) insert(hash, key string, item *scoreScore) {
+	cache.Queue.Insert(hash, item)
+	from := item.Value.From()
+	acc, ok := cache.accounts[from]
+	if !ok {
+		acc = &accountTxs{items: make(map[string]*scoreScore), keys: make(map[string]string)}
+		cache.accounts[from] = acc
+	}
+	acc.items[hash] = item
+	acc.keys[key] = hash
+}
+
+func (cache *Queue) unindex(hash string, item *scoreScore) {
+	from := item.Value.From()
+	acc, ok := cache.accounts[from]
+	if !ok {
+		return
+	}
+	delete(acc.items, hash)
+	if key := replaceKey(item.Value); acc.keys[key] == hash {
+		delete(acc.keys, key)
+	}
+	if len(acc.items) == 0 {
+		delete(cache.accounts, from)
+	}
+}
+
+// evict 从队列中淘汰交易, mempool的账户索引等由Mempool.cleanEvicted清理
+func (cache *Queue) evict(item *scoreScore) {
+	hash := string(item.Value.Hash())
+	if err := cache.Queue.Remove(hash); err != nil {
+		return
+	}
+	cache.unindex(hash, item)
+	// 已从mempool索引中移除的交易无需再清理
+	if cache.detached.remove(hash) {
+		return
+	}
+	cache.evicted.add(hash, item.Item)
 }
 
 // Walk 遍历整个队列
 func (cache *Queue) Walk(count int, cb func(value *mempool.Item) bool) {
 	cache.Queue.Walk(count, func(item skiplist.Scorer) bool {
+		if cache.detached.has(string(item.(*scoreScore).Value.Hash())) {
+			return true
+		}
 		return cb(item.(*scoreScore).Item)
 	})
 }
 
-// GetProperFee 获取合适的手续费
+// GetProperFee 获取合适的手续费, 优先根据最近区块的打包费率估计, 区块数据不足时根据队列前100的分数计算
 func (cache *Queue) GetProperFee() int64 {
+	if cache.estimator != nil {
+		if feeRate, ok := cache.estimator.EstimateFeeRate(0); ok {
+			if feeRate < cache.subConfig.ProperFee {
+				return cache.subConfig.ProperFee
+			}
+			return feeRate
+		}
+	}
 	var sumScore int64
 	var properFeerate int64
 	if cache.Size() == 0 {
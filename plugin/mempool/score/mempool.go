@@ -1,11 +1,19 @@
 package score
 
 import (
+	"bytes"
+	"sync"
+
+	"github.com/33cn/chain33/common"
+	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
 	drivers "github.com/33cn/chain33/system/mempool"
 	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/mempool/estimator"
 )
 
+var mlog = log.New("module", "mempool.score")
+
 //--------------------------------------------------------------------------------
 // Module Mempool
 
@@ -15,6 +23,12 @@ type subConfig struct {
 	PriceConstant int64 `json:"priceConstant"`
 	PricePower    int64 `json:"pricePower"`
 	ProperFee     int64 `json:"properFee"`
+	// 单个账户在队列中的最大交易数, 0表示只受mempool的maxTxNumPerAccount限制
+	MaxTxPerAccount int64 `json:"maxTxPerAccount"`
+	// 替换同一账户冲突交易时手续费至少提高的百分比
+	ReplaceFeeBump int64 `json:"replaceFeeBump"`
+	// 根据最近区块的打包费率估计手续费
+	estimator.Config
 }
 
 func init() {
@@ -32,6 +46,151 @@ func New(cfg *types.Mempool, sub []byte) queue.Module {
 	if subcfg.ProperFee == 0 {
 		subcfg.ProperFee = cfg.MinTxFeeRate
 	}
-	c.SetQueueCache(NewQueue(subcfg))
-	return c
+	if subcfg.ReplaceFeeBump <= 0 {
+		subcfg.ReplaceFeeBump = defaultReplaceFeeBump
+	}
+	cache := NewQueue(subcfg)
+	cache.estimator = estimator.New(subcfg.Config, cfg)
+	c.SetQueueCache(cache)
+	return &Mempool{
+		Mempool: estimator.NewMempool(c, cache.estimator),
+		cache:   cache,
+		done:    make(chan struct{}),
+	}
+}
+
+// Mempool 队列替换或淘汰交易后, 清理mempool中这些交易的账户索引
+type Mempool struct {
+	*estimator.Mempool
+	cache *Queue
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// SetQueueClient 启动mempool并开始清理被淘汰的交易
+func (mem *Mempool) SetQueueClient(cli queue.Client) {
+	mem.Mempool.SetQueueClient(newReplaceClient(cli, mem))
+	mem.wg.Add(1)
+	go mem.cleanEvicted()
+}
+
+// Close 停止清理并关闭mempool
+func (mem *Mempool) Close() {
+	select {
+	case <-mem.done:
+	default:
+		close(mem.done)
+	}
+	mem.wg.Wait()
+	mem.Mempool.Close()
+}
+
+// cleanEvicted 通过mempool删除被淘汰的交易, 同时清理账户索引, 最新交易缓存和手续费总和
+func (mem *Mempool) cleanEvicted() {
+	defer mem.wg.Done()
+	for {
+		select {
+		case <-mem.done:
+			return
+		case <-mem.cache.evicted.notify:
+		}
+		_ = mem.RemoveTxs(&types.TxHashList{Hashes: mem.cache.evicted.clean()})
+	}
+}
+
+// replaceClient 在chain33检查evm交易的nonce之前处理替换, 其他行为与queue.Client一致
+// chain33拒绝nonce与mempool中交易相同的evm交易, 所以手续费提高足够时先从mempool索引中移除旧交易,
+// 旧交易仍保留在队列中, 新交易加入队列时才替换, 新交易加入失败时恢复旧交易
+type replaceClient struct {
+	queue.Client
+	recv chan *queue.Message
+	mem  *Mempool
+}
+
+func newReplaceClient(cli queue.Client, mem *Mempool) *replaceClient {
+	rc := &replaceClient{Client: cli, recv: make(chan *queue.Message), mem: mem}
+	go rc.forward(cli.Recv())
+	return rc
+}
+
+// Recv mempool从这里接收消息
+func (rc *replaceClient) Recv() chan *queue.Message {
+	return rc.recv
+}
+
+func (rc *replaceClient) forward(recv chan *queue.Message) {
+	defer close(rc.recv)
+	for msg := range recv {
+		if msg.Ty == types.EventTx {
+			if old := rc.mem.replaceEvmTx(msg); old != nil {
+				rc.forwardReplace(msg, old)
+				continue
+			}
+		}
+		rc.recv <- msg
+	}
+}
+
+// forwardReplace 替换交易通过新消息交给mempool处理, 等待处理结果后回复原消息
+func (rc *replaceClient) forwardReplace(msg *queue.Message, old *types.Transaction) {
+	inner := queue.NewMessage(msg.ID, msg.Topic, msg.Ty, msg.GetData())
+	rc.recv <- inner
+	go func() {
+		reply, err := rc.Wait(inner)
+		if err != nil {
+			rc.mem.restoreEvmTx(old)
+			msg.Reply(rc.NewMessage("", types.EventReply, &types.Reply{Msg: []byte(err.Error())}))
+			return
+		}
+		if res, ok := reply.GetData().(*types.Reply); !ok || !res.GetIsOk() {
+			rc.mem.restoreEvmTx(old)
+		}
+		msg.Reply(reply)
+	}()
+}
+
+// replaceEvmTx 新交易签名有效且手续费提高足够时, 从mempool索引中移除同一账户相同nonce的旧交易并返回
+func (mem *Mempool) replaceEvmTx(msg *queue.Message) *types.Transaction {
+	group, ok := msg.GetData().(types.TxGroup)
+	if !ok {
+		return nil
+	}
+	tx := group.Tx()
+	if !types.IsEthSignID(tx.GetSignature().GetTy()) {
+		return nil
+	}
+	var old *types.Transaction
+	for _, detail := range mem.GetAccTxs(&types.ReqAddrs{Addrs: []string{tx.From()}}).GetTxs() {
+		if detail.GetTx().GetNonce() == tx.GetNonce() {
+			old = detail.GetTx()
+			break
+		}
+	}
+	if old == nil || bytes.Equal(old.Hash(), tx.Hash()) || !mem.cache.canReplace(old, tx) {
+		return nil
+	}
+	if !tx.CheckSign(mem.Height() + 1) {
+		return nil
+	}
+	hash := old.Hash()
+	mem.cache.detached.mark(string(hash))
+	_ = mem.RemoveTxs(&types.TxHashList{Hashes: [][]byte{hash}})
+	if !mem.cache.detached.settle(string(hash)) {
+		return nil
+	}
+	mlog.Info("replaceEvmTx", "from", tx.From(), "nonce", tx.GetNonce(), "old", common.ToHex(hash), "new", common.ToHex(tx.Hash()))
+	return old
+}
+
+// restoreEvmTx 新交易没有加入队列时, 旧交易重新加入mempool索引
+func (mem *Mempool) restoreEvmTx(old *types.Transaction) {
+	hash := old.Hash()
+	if !mem.cache.detached.has(string(hash)) {
+		return
+	}
+	if err := mem.PushTx(old); err != nil {
+		mlog.Error("restoreEvmTx", "hash", common.ToHex(hash), "err", err)
+		return
+	}
+	mlog.Info("restoreEvmTx", "from", old.From(), "nonce", old.GetNonce(), "hash", common.ToHex(hash))
 }
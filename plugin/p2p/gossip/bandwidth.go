@@ -0,0 +1,306 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/types"
+)
+
+// 广播消息类型, 按类型分别统计流量和限速
+const (
+	bwTx = iota
+	bwBlock
+	bwQuery
+	bwTypeNum
+)
+
+var bwTypeNames = [bwTypeNum]string{"tx", "block", "query"}
+
+// 流量方向
+const (
+	bwIn = iota
+	bwOut
+)
+
+const (
+	//令牌桶容量为bandwidthBurstTime内允许的流量
+	bandwidthBurstTime = 2
+	//超过该时间没有流量的节点统计会被清理, 秒
+	bandwidthIdleTime = 3600
+)
+
+// bandwidthLimit 某一类广播消息的带宽限制, 接收和发送分别限制, 0表示不限制
+type bandwidthLimit struct {
+	// 所有节点总速率, KB/s
+	GlobalRate int32 `json:"globalRate,omitempty"`
+	// 单个节点速率, KB/s
+	PeerRate int32 `json:"peerRate,omitempty"`
+	// 单个节点每秒消息数
+	PeerMsgRate int32 `json:"peerMsgRate,omitempty"`
+}
+
+// TrafficStat 流量统计, 字节数及超过限制被丢弃的消息数
+type TrafficStat struct {
+	BytesIn  int64 `json:"bytesIn"`
+	BytesOut int64 `json:"bytesOut"`
+	MsgIn    int64 `json:"msgIn"`
+	MsgOut   int64 `json:"msgOut"`
+	DropIn   int64 `json:"dropIn"`
+	DropOut  int64 `json:"dropOut"`
+}
+
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	//上次补充令牌的时间, 纳秒
+	update int64
+}
+
+func newTokenBucket(rate float64, now int64) *tokenBucket {
+	if rate <= 0 {
+		return nil
+	}
+	burst := rate * bandwidthBurstTime
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, update: now}
+}
+
+// 补充令牌并检查是否允许通过, 单条消息超过桶容量时桶满即可通过, 之后透支的令牌需要时间补充
+func (b *tokenBucket) check(n float64, now int64) bool {
+	if b == nil {
+		return true
+	}
+	if now > b.update {
+		b.tokens = math.Min(b.burst, b.tokens+b.rate*float64(now-b.update)/float64(time.Second))
+		b.update = now
+	}
+	return b.tokens >= math.Min(n, b.burst)
+}
+
+func (b *tokenBucket) take(n float64) {
+	if b != nil {
+		b.tokens -= n
+	}
+}
+
+type peerTraffic struct {
+	stats      [bwTypeNum]TrafficStat
+	bytes      [2][bwTypeNum]*tokenBucket
+	msgs       [2][bwTypeNum]*tokenBucket
+	lastActive int64
+}
+
+// Bandwidth 节点广播流量统计及令牌桶限速
+type Bandwidth struct {
+	mtx    sync.Mutex
+	limits [bwTypeNum]*bandwidthLimit
+	global [2][bwTypeNum]*tokenBucket
+	//key:peerName
+	peers map[string]*peerTraffic
+	//上次采样后的广播流量字节数, 及最近一个采样周期的速率, 字节/秒
+	bytes   [2]int64
+	rate    [2]float64
+	sampled int64
+}
+
+// NewBandwidth new bandwidth by sub config
+func NewBandwidth(cfg *subConfig) *Bandwidth {
+	now := types.Now().UnixNano()
+	bw := &Bandwidth{peers: make(map[string]*peerTraffic), sampled: now}
+	bw.limits = [bwTypeNum]*bandwidthLimit{cfg.TxBandwidth, cfg.BlockBandwidth, cfg.QueryBandwidth}
+	for ty, limit := range bw.limits {
+		if limit == nil {
+			continue
+		}
+		for dir := range bw.global {
+			bw.global[dir][ty] = newTokenBucket(float64(limit.GlobalRate)*1024, now)
+		}
+	}
+	return bw
+}
+
+func (bw *Bandwidth) getPeer(pid string, now int64) *peerTraffic {
+	pt, ok := bw.peers[pid]
+	if !ok {
+		pt = &peerTraffic{}
+		for ty, limit := range bw.limits {
+			if limit == nil {
+				continue
+			}
+			for dir := range pt.bytes {
+				pt.bytes[dir][ty] = newTokenBucket(float64(limit.PeerRate)*1024, now)
+				pt.msgs[dir][ty] = newTokenBucket(float64(limit.PeerMsgRate), now)
+			}
+		}
+		bw.peers[pid] = pt
+	}
+	pt.lastActive = now
+	return pt
+}
+
+// Allow 统计流量并检查是否超过带宽限制, 返回是否允许以及是否因单个节点超限被拒绝
+func (bw *Bandwidth) Allow(pid string, ty, dir int, size int) (allow bool, peerLimited bool) {
+	return bw.allow(pid, ty, dir, size, types.Now().UnixNano())
+}
+
+func (bw *Bandwidth) allow(pid string, ty, dir int, size int, now int64) (allow bool, peerLimited bool) {
+	bw.mtx.Lock()
+	defer bw.mtx.Unlock()
+	pt := bw.getPeer(pid, now)
+	stat := &pt.stats[ty]
+	n := float64(size)
+	peerLimited = !pt.bytes[dir][ty].check(n, now) || !pt.msgs[dir][ty].check(1, now)
+	if peerLimited || !bw.global[dir][ty].check(n, now) {
+		if dir == bwIn {
+			stat.DropIn++
+		} else {
+			stat.DropOut++
+		}
+		return false, peerLimited
+	}
+	pt.bytes[dir][ty].take(n)
+	pt.msgs[dir][ty].take(1)
+	bw.global[dir][ty].take(n)
+	bw.bytes[dir] += int64(size)
+	if dir == bwIn {
+		stat.BytesIn += int64(size)
+		stat.MsgIn++
+	} else {
+		stat.BytesOut += int64(size)
+		stat.MsgOut++
+	}
+	return true, false
+}
+
+// Stats 获取节点各类型消息的流量统计, key为消息类型
+func (bw *Bandwidth) Stats(pid string) map[string]*TrafficStat {
+	bw.mtx.Lock()
+	defer bw.mtx.Unlock()
+	pt, ok := bw.peers[pid]
+	if !ok {
+		return nil
+	}
+	stats := make(map[string]*TrafficStat, bwTypeNum)
+	for ty := range pt.stats {
+		stat := pt.stats[ty]
+		stats[bwTypeNames[ty]] = &stat
+	}
+	return stats
+}
+
+// Total 所有节点各类型消息的流量合计, 已清理的节点不计入
+func (bw *Bandwidth) Total() map[string]*TrafficStat {
+	bw.mtx.Lock()
+	defer bw.mtx.Unlock()
+	stats := make(map[string]*TrafficStat, bwTypeNum)
+	for ty, name := range bwTypeNames {
+		total := &TrafficStat{}
+		for _, pt := range bw.peers {
+			stat := pt.stats[ty]
+			total.BytesIn += stat.BytesIn
+			total.BytesOut += stat.BytesOut
+			total.MsgIn += stat.MsgIn
+			total.MsgOut += stat.MsgOut
+			total.DropIn += stat.DropIn
+			total.DropOut += stat.DropOut
+		}
+		stats[name] = total
+	}
+	return stats
+}
+
+// Rate 最近一个采样周期的接收和发送速率, 字节/秒
+func (bw *Bandwidth) Rate() (in, out float64) {
+	bw.mtx.Lock()
+	defer bw.mtx.Unlock()
+	return bw.rate[bwIn], bw.rate[bwOut]
+}
+
+func (bw *Bandwidth) sample(now int64) {
+	bw.mtx.Lock()
+	defer bw.mtx.Unlock()
+	elapsed := float64(now-bw.sampled) / float64(time.Second)
+	if elapsed <= 0 {
+		return
+	}
+	for dir := range bw.bytes {
+		bw.rate[dir] = float64(bw.bytes[dir]) / elapsed
+		bw.bytes[dir] = 0
+	}
+	bw.sampled = now
+}
+
+// 与dht网络相同的速率格式
+func formatRate(rate float64) string {
+	kbytes := rate / 1024
+	if kbytes/1024 > 0.1 {
+		return fmt.Sprintf("%.3f MB/s", kbytes/1024)
+	}
+	return fmt.Sprintf("%.3f KB/s", kbytes)
+}
+
+// Prune 清理长时间没有流量的节点统计
+func (bw *Bandwidth) Prune() {
+	bw.prune(types.Now().UnixNano())
+}
+
+func (bw *Bandwidth) prune(now int64) {
+	bw.mtx.Lock()
+	defer bw.mtx.Unlock()
+	for pid, pt := range bw.peers {
+		if now-pt.lastActive > bandwidthIdleTime*int64(time.Second) {
+			delete(bw.peers, pid)
+		}
+	}
+}
+
+// 广播消息对应的限速类型, 心跳等其他消息不限速
+func broadcastType(data *types.BroadCastData) (int, bool) {
+	switch data.GetValue().(type) {
+	case *types.BroadCastData_Tx, *types.BroadCastData_LtTx:
+		return bwTx, true
+	case *types.BroadCastData_Block, *types.BroadCastData_LtBlock:
+		return bwBlock, true
+	case *types.BroadCastData_Query, *types.BroadCastData_BlockRep:
+		return bwQuery, true
+	}
+	return 0, false
+}
+
+// 检查发送或接收的广播消息是否超过带宽限制, 接收时单个节点超限会降低该节点评分
+func (n *Node) checkBandwidth(data *types.BroadCastData, dir int, pid, peerAddr string) bool {
+	ty, ok := broadcastType(data)
+	if !ok {
+		return true
+	}
+	allow, peerLimited := n.nodeInfo.bandwidth.Allow(pid, ty, dir, types.Size(data))
+	if allow {
+		return true
+	}
+	log.Debug("checkBandwidth drop", "pid", pid, "type", bwTypeNames[ty], "dir", dir, "peerLimited", peerLimited)
+	if dir == bwIn && peerLimited {
+		n.scorePeer(pid, peerAddr, scoreRateLimit, "rateLimit")
+	}
+	return false
+}
+
+func (n *Node) monitorBandwidth() {
+	ticker := time.NewTicker(CheckPeerScoreInterval)
+	defer ticker.Stop()
+	for {
+		if n.isClose() {
+			log.Info("monitorBandwidth", "loop", "done")
+			return
+		}
+		<-ticker.C
+		n.nodeInfo.bandwidth.sample(types.Now().UnixNano())
+		n.nodeInfo.bandwidth.Prune()
+	}
+}
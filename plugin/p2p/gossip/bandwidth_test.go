@@ -0,0 +1,95 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBandwidth(t *testing.T) {
+	bw := NewBandwidth(&subConfig{
+		TxBandwidth:    &bandwidthLimit{GlobalRate: 3, PeerRate: 2, PeerMsgRate: 3},
+		BlockBandwidth: &bandwidthLimit{PeerRate: 1},
+	})
+	now := types.Now().UnixNano()
+	bw.sample(now)
+	//单个节点的令牌桶容量为2秒的流量
+	allow, _ := bw.allow("pid1", bwTx, bwIn, 2048, now)
+	assert.True(t, allow)
+	allow, _ = bw.allow("pid1", bwTx, bwIn, 2048, now)
+	assert.True(t, allow)
+	allow, peerLimited := bw.allow("pid1", bwTx, bwIn, 1, now)
+	assert.False(t, allow)
+	assert.True(t, peerLimited)
+	//接收和发送分别限速
+	allow, _ = bw.allow("pid1", bwTx, bwOut, 1024, now)
+	assert.True(t, allow)
+
+	//全局限速, 总容量6KB已使用4KB
+	allow, peerLimited = bw.allow("pid2", bwTx, bwIn, 3072, now)
+	assert.False(t, allow)
+	assert.False(t, peerLimited)
+	allow, _ = bw.allow("pid2", bwTx, bwIn, 2048, now)
+	assert.True(t, allow)
+
+	//消息数限制
+	allow, _ = bw.allow("pid3", bwTx, bwOut, 1, now)
+	assert.True(t, allow)
+	for i := 0; i < 5; i++ {
+		bw.allow("pid3", bwTx, bwOut, 1, now)
+	}
+	allow, peerLimited = bw.allow("pid3", bwTx, bwOut, 1, now)
+	assert.False(t, allow)
+	assert.True(t, peerLimited)
+
+	//超过桶容量的单条消息在桶满时允许通过, 透支部分需等待补充
+	allow, _ = bw.allow("pid1", bwBlock, bwIn, 10240, now)
+	assert.True(t, allow)
+	allow, _ = bw.allow("pid1", bwBlock, bwIn, 1, now+int64(5*time.Second))
+	assert.False(t, allow)
+	allow, _ = bw.allow("pid1", bwBlock, bwIn, 1, now+int64(9*time.Second))
+	assert.True(t, allow)
+	//未配置限制的类型不限速
+	allow, _ = bw.allow("pid1", bwQuery, bwIn, 1<<30, now)
+	assert.True(t, allow)
+
+	stats := bw.Stats("pid1")
+	assert.Equal(t, int64(4096), stats["tx"].BytesIn)
+	assert.Equal(t, int64(2), stats["tx"].MsgIn)
+	assert.Equal(t, int64(1), stats["tx"].DropIn)
+	assert.Equal(t, int64(1024), stats["tx"].BytesOut)
+	assert.Equal(t, int64(10241), stats["block"].BytesIn)
+	assert.Equal(t, int64(1), stats["block"].DropIn)
+	assert.Equal(t, int64(1), bw.Stats("pid2")["tx"].DropIn)
+	assert.Nil(t, bw.Stats("pid4"))
+	total := bw.Total()
+	assert.Equal(t, stats["tx"].DropIn+bw.Stats("pid2")["tx"].DropIn+bw.Stats("pid3")["tx"].DropIn, total["tx"].DropIn)
+	assert.Equal(t, stats["tx"].MsgOut+bw.Stats("pid2")["tx"].MsgOut+bw.Stats("pid3")["tx"].MsgOut, total["tx"].MsgOut)
+	assert.Equal(t, int64(10241), total["block"].BytesIn)
+
+	//采样周期内的速率
+	bw.sample(now + int64(2*time.Second))
+	in, out := bw.Rate()
+	assert.Equal(t, float64(2048+2048+2048+10241+1<<30)/2, in)
+	assert.Equal(t, float64(1024+6)/2, out)
+	assert.Equal(t, "0.503 KB/s", formatRate(out))
+	assert.Equal(t, "512.008 MB/s", formatRate(in))
+	bw.sample(now + int64(4*time.Second))
+	in, _ = bw.Rate()
+	assert.Equal(t, float64(0), in)
+
+	bw.prune(now + int64(bandwidthIdleTime+10)*int64(time.Second))
+	assert.Nil(t, bw.Stats("pid1"))
+
+	ty, ok := broadcastType(&types.BroadCastData{Value: &types.BroadCastData_LtBlock{}})
+	assert.True(t, ok)
+	assert.Equal(t, bwBlock, ty)
+	_, ok = broadcastType(&types.BroadCastData{Value: &types.BroadCastData_Ping{}})
+	assert.False(t, ok)
+}
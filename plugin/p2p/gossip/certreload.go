@@ -0,0 +1,234 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCaCert CA证书格式错误
+	ErrCaCert = errors.New("ErrCaCert")
+	// ErrCrlSignature CRL不是由配置的CA签发
+	ErrCrlSignature = errors.New("ErrCrlSignature")
+)
+
+// 黑名单查询中被吊销证书的节点名称前缀
+const revokedCertPrefix = "cert:"
+
+// certReloader 监控证书, 私钥, CA证书及CRL文件, 文件变化后重新加载, 新的连接使用新证书, 不需要重启节点
+type certReloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+	crlFile  string
+
+	mtx    sync.RWMutex
+	cert   *tls.Certificate
+	pool   *x509.CertPool
+	serial string
+	//已加载文件的修改时间
+	modTimes map[string]time.Time
+}
+
+func newCertReloader(cfg *subConfig) (*certReloader, error) {
+	r := &certReloader{
+		certFile: cfg.CertFile,
+		keyFile:  cfg.KeyFile,
+		caFile:   cfg.CaCert,
+		crlFile:  cfg.CrlFile,
+	}
+	if _, _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.caFile != "" {
+		files = append(files, r.caFile)
+	}
+	if r.crlFile != "" {
+		files = append(files, r.crlFile)
+	}
+	return files
+}
+
+// reload 文件有变化时重新加载, 加载失败保留原有证书, 下次检查时重试, 返回CRL中新吊销的证书序列号
+func (r *certReloader) reload() (revoked []string, changed bool, err error) {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, false, err
+		}
+		modTimes[file] = info.ModTime()
+		r.mtx.RLock()
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			changed = true
+		}
+		r.mtx.RUnlock()
+	}
+	if !changed {
+		return nil, false, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return nil, false, err
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, false, err
+	}
+	//未配置CA时和grpc.NewClientTLSFromFile一致, 信任证书本身
+	caFile := r.caFile
+	if caFile == "" {
+		caFile = r.certFile
+	}
+	caPem, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, false, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPem) {
+		return nil, false, ErrCaCert
+	}
+	var crlSerials []string
+	if r.crlFile != "" {
+		var issuers []*x509.Certificate
+		if r.caFile != "" {
+			issuers = parseCerts(caPem)
+		}
+		crlSerials, err = loadCRL(r.crlFile, issuers)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	r.mtx.Lock()
+	r.cert = &cert
+	r.pool = pool
+	r.serial = leaf.SerialNumber.String()
+	r.modTimes = modTimes
+	r.mtx.Unlock()
+	return setCrlSerials(crlSerials), true, nil
+}
+
+func (r *certReloader) getSerial() string {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	return r.serial
+}
+
+func (r *certReloader) serverConfig() *tls.Config {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	cfg := &tls.Config{Certificates: []tls.Certificate{*r.cert}}
+	if r.caFile != "" {
+		//校验客户端证书,用ca.pem校验
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		cfg.ClientCAs = r.pool
+	}
+	return cfg
+}
+
+func (r *certReloader) clientConfig() *tls.Config {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+	cfg := &tls.Config{RootCAs: r.pool}
+	if r.caFile != "" {
+		cfg.Certificates = []tls.Certificate{*r.cert}
+	}
+	return cfg
+}
+
+func parseCerts(data []byte) []*x509.Certificate {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certs
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+}
+
+// 加载PEM或DER格式的X.509 CRL, 配置了CA时要求CRL由CA签发
+func loadCRL(file string, issuers []*x509.Certificate) ([]string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	crl, err := x509.ParseRevocationList(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(issuers) > 0 {
+		verified := false
+		for _, ca := range issuers {
+			if crl.CheckSignatureFrom(ca) == nil {
+				verified = true
+				break
+			}
+		}
+		if !verified {
+			return nil, ErrCrlSignature
+		}
+	}
+	if !crl.NextUpdate.IsZero() && time.Now().After(crl.NextUpdate) {
+		log.Warn("loadCRL crl expired", "file", file, "nextUpdate", crl.NextUpdate)
+	}
+	serials := make([]string, 0, len(crl.RevokedCertificates))
+	for _, revoked := range crl.RevokedCertificates {
+		serials = append(serials, revoked.SerialNumber.String())
+	}
+	return serials, nil
+}
+
+func (n *Node) monitorCertFiles() {
+	reloader := n.nodeInfo.certReloader
+	if reloader == nil {
+		return
+	}
+	ticker := time.NewTicker(CheckCertFileInterval)
+	defer ticker.Stop()
+	for {
+		if n.isClose() {
+			log.Info("monitorCertFiles", "loop", "done")
+			return
+		}
+		<-ticker.C
+		revoked, changed, err := reloader.reload()
+		if err != nil {
+			log.Error("monitorCertFiles", "reload err", err)
+			continue
+		}
+		if !changed {
+			continue
+		}
+		log.Info("monitorCertFiles reload tls files", "serial", reloader.getSerial(), "revoked", revoked)
+		for _, serial := range revoked {
+			n.removeRevokedPeer(serial, getCertSerial(serial).ip)
+		}
+	}
+}
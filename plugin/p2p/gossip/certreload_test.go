@@ -0,0 +1,142 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	t    *testing.T
+	dir  string
+	key  *ecdsa.PrivateKey
+	cert *x509.Certificate
+	//每次写文件后修改时间递增, 保证能检测到变化
+	modTime time.Time
+}
+
+func (ca *testCA) write(name string, blockType string, der []byte) string {
+	file := filepath.Join(ca.dir, name)
+	require.Nil(ca.t, ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	ca.modTime = ca.modTime.Add(time.Second)
+	require.Nil(ca.t, os.Chtimes(file, ca.modTime, ca.modTime))
+	return file
+}
+
+func (ca *testCA) issue(serial int64) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(ca.t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "node"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.Nil(ca.t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.Nil(ca.t, err)
+	return ca.write("node.crt", "CERTIFICATE", der), ca.write("node.key", "EC PRIVATE KEY", keyDer)
+}
+
+func (ca *testCA) revoke(number int64, serials ...int64) string {
+	tmpl := &x509.RevocationList{Number: big.NewInt(number), ThisUpdate: time.Now(), NextUpdate: time.Now().Add(time.Hour)}
+	for _, serial := range serials {
+		tmpl.RevokedCertificates = append(tmpl.RevokedCertificates, pkix.RevokedCertificate{SerialNumber: big.NewInt(serial), RevocationTime: time.Now()})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, tmpl, ca.cert, ca.key)
+	require.Nil(ca.t, err)
+	return ca.write("ca.crl", "X509 CRL", der)
+}
+
+func newTestCA(t *testing.T) (*testCA, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.Nil(t, err)
+	ca := &testCA{t: t, dir: t.TempDir(), key: key, cert: cert, modTime: time.Now().Add(-time.Hour)}
+	return ca, ca.write("ca.crt", "CERTIFICATE", der)
+}
+
+func TestCertReloader(t *testing.T) {
+	defer setCrlSerials(nil)
+	ca, caFile := newTestCA(t)
+	certFile, keyFile := ca.issue(100)
+	crlFile := ca.revoke(1, 200)
+	reloader, err := newCertReloader(&subConfig{CertFile: certFile, KeyFile: keyFile, CaCert: caFile, CrlFile: crlFile})
+	require.Nil(t, err)
+	require.Equal(t, "100", reloader.getSerial())
+	require.True(t, isRevoke("200"))
+	require.False(t, isRevoke("100"))
+	addCertSerial("200", "192.168.1.2")
+	defer removeCertSerial("200")
+	require.Equal(t, map[string]string{"200": "192.168.1.2"}, getRevokedSerials())
+
+	creds := newReloadTLS(reloader.serverConfig).(*Tls)
+	require.Equal(t, []string{alpnProtoStrH2}, creds.getConfig().NextProtos)
+	require.NotNil(t, creds.getConfig().ClientCAs)
+
+	//文件没有变化不重新加载
+	_, changed, err := reloader.reload()
+	require.Nil(t, err)
+	require.False(t, changed)
+
+	//更换证书并吊销旧证书
+	ca.issue(101)
+	ca.revoke(2, 100, 200)
+	revoked, changed, err := reloader.reload()
+	require.Nil(t, err)
+	require.True(t, changed)
+	require.Equal(t, []string{"100"}, revoked)
+	require.Equal(t, "101", reloader.getSerial())
+	require.True(t, isRevoke("100"))
+	leaf, err := x509.ParseCertificate(creds.getConfig().Certificates[0].Certificate[0])
+	require.Nil(t, err)
+	require.Equal(t, int64(101), leaf.SerialNumber.Int64())
+
+	//其他CA签发的CRL不被接受, 保留原有吊销列表
+	other, _ := newTestCA(t)
+	other.dir = ca.dir
+	other.modTime = ca.modTime
+	other.revoke(3)
+	_, _, err = reloader.reload()
+	require.Equal(t, ErrCrlSignature, err)
+	require.True(t, isRevoke("100"))
+
+	//解除吊销
+	ca.modTime = other.modTime
+	ca.revoke(4, 200)
+	revoked, changed, err = reloader.reload()
+	require.Nil(t, err)
+	require.True(t, changed)
+	require.Nil(t, revoked)
+	require.False(t, isRevoke("100"))
+	require.Equal(t, map[string]string{"200": "192.168.1.2"}, getRevokedSerials())
+}
@@ -26,6 +26,11 @@ var (
 	CheckBlackListInterVal      = 30 * time.Second
 	CheckCfgSeedsInterVal       = 1 * time.Minute
 	CheckCfgCertInterVal        = 30 * time.Second
+	CheckCertFileInterval       = 10 * time.Second
+	CheckPeerScoreInterval      = 5 * time.Minute
+	DownloadBlockTimeout        = 60 * time.Second
+	//下载区块超过该时间认为节点响应慢
+	SlowDownloadTime = 10 * time.Second
 )
 
 const (
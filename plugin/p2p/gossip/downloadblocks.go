@@ -153,21 +153,34 @@ func (d *DownloadJob) appendRetryItem(item *pb.Inventory) {
 }
 
 // GetFreePeer get free peer ,return peer
+// 优先选择评分非负且任务数最少的节点, 任务数相同时选择评分较高的节点
 func (d *DownloadJob) GetFreePeer(blockHeight int64) *Peer {
 	infos := d.p2pcli.network.node.nodeInfo.peerInfos.GetPeerInfos()
+	scores := d.p2pcli.network.node.nodeInfo.peerScores
 	var minJobNum int32 = 10
+	var bestScore float64
 	var bestPeer *Peer
 	//对download peer读取需要增加保护
 	for _, peer := range d.getDownloadPeers() {
 
 		peerName := peer.GetPeerName()
-		if d.isBusyPeer(peerName) {
+		if d.isBusyPeer(peerName) || infos[peerName].GetHeader().GetHeight() < blockHeight {
 			continue
 		}
 
-		if jobNum := d.getJobNum(peerName); jobNum < minJobNum &&
-			infos[peerName].GetHeader().GetHeight() >= blockHeight {
+		jobNum := d.getJobNum(peerName)
+		score := scores.Get(peerName)
+		better := jobNum < minJobNum
+		if bestPeer != nil {
+			if (score < 0) != (bestScore < 0) {
+				better = score >= 0
+			} else if jobNum == minJobNum {
+				better = score > bestScore
+			}
+		}
+		if better {
 			minJobNum = jobNum
+			bestScore = score
 			bestPeer = peer
 		}
 	}
@@ -203,14 +216,22 @@ func (d *DownloadJob) DownloadBlock(invs []*pb.Inventory,
 		d.wg.Add(1)
 		go func(peer *Peer, inv *pb.Inventory) {
 			defer d.wg.Done()
+			node := d.p2pcli.network.node
+			beg := pb.Now()
 			err := d.syncDownloadBlock(peer, inv, bchan)
 			if err != nil {
 				d.removePeer(peer.GetPeerName())
 				log.Error("DownloadBlock:syncDownloadBlock", "height", inv.GetHeight(), "peer", peer.GetPeerName(), "err", err)
 				d.appendRetryItem(inv) //失败的下载，放在下一轮ReDownload进行下载
+				node.scorePeer(peer.GetPeerName(), peer.Addr(), scoreDownloadFail, "downloadFail")
 
 			} else {
 				d.setFreePeer(peer.GetPeerName())
+				if pb.Since(beg) > SlowDownloadTime {
+					node.scorePeer(peer.GetPeerName(), peer.Addr(), scoreDownloadSlow, "downloadSlow")
+				} else {
+					node.scorePeer(peer.GetPeerName(), peer.Addr(), scoreDownloadOk, "download")
+				}
 			}
 
 		}(freePeer, inv)
@@ -240,7 +261,8 @@ func (d *DownloadJob) syncDownloadBlock(peer *Peer, inv *pb.Inventory, bchan cha
 	var p2pdata pb.P2PGetData
 	p2pdata.Version = d.p2pcli.network.node.nodeInfo.channelVersion
 	p2pdata.Invs = []*pb.Inventory{inv}
-	ctx, cancel := context.WithCancel(context.Background())
+	//超时未返回视为节点阻塞下载
+	ctx, cancel := context.WithTimeout(context.Background(), DownloadBlockTimeout)
 	//主动取消grpc流, 即时释放资源
 	defer cancel()
 	beg := pb.Now()
@@ -265,6 +287,9 @@ func (d *DownloadJob) syncDownloadBlock(peer *Peer, inv *pb.Inventory, bchan cha
 	}
 
 	block := invData.Items[0].GetBlock()
+	if block == nil || block.GetHeight() != inv.GetHeight() {
+		return fmt.Errorf("InvalidRecvBlock")
+	}
 	log.Debug("download", "frompeer", peer.Addr(), "blockheight", inv.GetHeight(), "blockSize", block.Size())
 	bchan <- &pb.BlockPid{Pid: peer.GetPeerName(), Block: block} //加入到输出通道
 	return nil
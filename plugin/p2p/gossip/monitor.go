@@ -638,14 +638,7 @@ func (n *Node) monitorCerts() {
 				//设置证书序列号状态
 				certinfo := updateCertSerial(serialNum, true)
 				delete(tempCerts, serialNum)
-				for pname, peer := range n.nodeInfo.peerInfos.GetPeerInfos() {
-					if peer.GetAddr() == certinfo.ip {
-						v, ok := latestSerials.Load(certinfo.ip)
-						if ok && v.(string) == serialNum {
-							n.remove(pname) //断开已经连接的节点
-						}
-					}
-				}
+				n.removeRevokedPeer(serialNum, certinfo.ip)
 			}
 			log.Debug("monitorCert", "tempCerts", tempCerts)
 			//处理解除吊销的节点
@@ -660,3 +653,18 @@ func (n *Node) monitorCerts() {
 	}
 
 }
+
+//removeRevokedPeer 断开使用已吊销证书的节点, 接入的节点在p2pserver的stream中断开
+func (n *Node) removeRevokedPeer(serialNum, ip string) {
+	if ip == "" {
+		return
+	}
+	for pname, peer := range n.nodeInfo.peerInfos.GetPeerInfos() {
+		if peer.GetAddr() == ip {
+			v, ok := latestSerials.Load(ip)
+			if ok && v.(string) == serialNum {
+				n.remove(pname) //断开已经连接的节点
+			}
+		}
+	}
+}
@@ -5,13 +5,9 @@
 package gossip
 
 import (
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
-	"io/ioutil"
 	"math/rand"
 
-	"google.golang.org/grpc/credentials"
 
 	"github.com/33cn/chain33/p2p"
 
@@ -88,6 +84,9 @@ type Node struct {
 	pubsub     *pubsub.PubSub
 	chainCfg   *types.Chain33Config
 	p2pMgr     *p2p.Manager
+	//转发后等待mempool, blockchain校验结果的交易和区块
+	txChecks    chan *rejectCheck
+	blockChecks chan *rejectCheck
 }
 
 // SetQueueClient return client for nodeinfo
@@ -100,10 +99,12 @@ func NewNode(mgr *p2p.Manager, mcfg *subConfig) (*Node, error) {
 
 	cfg := mgr.ChainCfg
 	node := &Node{
-		outBound:   make(map[string]*Peer),
-		cacheBound: make(map[string]*Peer),
-		pubsub:     pubsub.NewPubSub(10200),
-		p2pMgr:     mgr,
+		outBound:    make(map[string]*Peer),
+		cacheBound:  make(map[string]*Peer),
+		pubsub:      pubsub.NewPubSub(10200),
+		p2pMgr:      mgr,
+		txChecks:    make(chan *rejectCheck, rejectCheckQueueSize),
+		blockChecks: make(chan *rejectCheck, rejectCheckQueueSize),
 	}
 	//node.tls = &Tls{serials:make(map[*big.Int]*certInfo)}
 	node.listenPort = 13802
@@ -128,52 +129,18 @@ func NewNode(mgr *p2p.Manager, mcfg *subConfig) (*Node, error) {
 	}
 	node.nodeInfo = NewNodeInfo(cfg.GetModuleConfig().P2P, mcfg)
 	node.chainCfg = cfg
-	if mcfg.EnableTls { //读取证书，初始化tls客户端
-		var err error
-		if mcfg.CaCert == "" {
-			//不需要CA
-			node.nodeInfo.cliCreds, err = credentials.NewClientTLSFromFile(mcfg.CertFile, "")
-			if err != nil {
-				panic(fmt.Sprintf("NewClientTLSFromFile panic:%v", err.Error()))
-			}
-			node.nodeInfo.servCreds, err = credentials.NewServerTLSFromFile(mcfg.CertFile, mcfg.KeyFile)
-			if err != nil {
-				panic(fmt.Sprintf("NewServerTLSFromFile panic:%v", err.Error()))
-			}
-		} else {
-			//CA
-			cert, err := tls.LoadX509KeyPair(mcfg.CertFile, mcfg.KeyFile)
-			if err != nil {
-				panic(fmt.Sprintf("LoadX509KeyPair panic:%v", err.Error()))
-			}
-			certPool := x509.NewCertPool()
-			//添加CA校验
-			//把CA证书读进去，动态更新CA中的吊销列表
-			ca, err := ioutil.ReadFile(mcfg.CaCert)
-			if err != nil {
-				panic(fmt.Sprintf("readFile ca panic:%v", err.Error()))
-			}
-
-			if ok := certPool.AppendCertsFromPEM(ca); !ok {
-				panic("certPool.AppendCertsFromPEM err")
-			}
-
-			node.nodeInfo.servCreds = newTLS(&tls.Config{
-				Certificates: []tls.Certificate{cert},
-				ClientAuth:   tls.RequireAndVerifyClientCert, //校验客户端证书,用ca.pem校验
-				ClientCAs:    certPool,
-			})
-
-			// 构建基于 TLS 的 TransportCredentials 选项
-			// 在 Client 请求 Server 端时，Client 端会使用根证书和 ServerName 去对 Server 端进行校验
-			node.nodeInfo.cliCreds = newTLS(&tls.Config{
-				Certificates: []tls.Certificate{cert},
-				ServerName:   "",
-				RootCAs:      certPool,
-			})
+	if mcfg.EnableTls { //读取证书，初始化tls客户端, 证书文件变化后自动重新加载
+		reloader, err := newCertReloader(mcfg)
+		if err != nil {
+			panic(fmt.Sprintf("load tls cert panic:%v", err.Error()))
+		}
+		node.nodeInfo.certReloader = reloader
+		// 在 Client 请求 Server 端时，Client 端会使用根证书和 ServerName 去对 Server 端进行校验
+		node.nodeInfo.servCreds = newReloadTLS(reloader.serverConfig)
+		node.nodeInfo.cliCreds = newReloadTLS(reloader.clientConfig)
+		if mcfg.CaCert != "" {
 			node.nodeInfo.caServer = mcfg.CaServer
 		}
-
 	}
 	if mcfg.ServerStart {
 		node.server = newListener(protocol, node)
@@ -412,10 +379,15 @@ func (n *Node) monitor() {
 	go n.monitorPeerInfo()
 	go n.monitorDialPeers()
 	go n.monitorBlackList()
+	go n.monitorPeerScore()
+	go n.monitorRejected(n.txChecks, invalidTxErrs)
+	go n.monitorRejected(n.blockChecks, invalidBlockErrs)
+	go n.monitorBandwidth()
 	go n.monitorFilter()
 	go n.monitorPeers()
 	go n.nodeReBalance()
 	go n.monitorCerts()
+	go n.monitorCertFiles()
 }
 
 func (n *Node) needMore() bool {
@@ -28,6 +28,8 @@ type NodeInfo struct {
 	cfg            *subConfig
 	client         queue.Client
 	blacklist      *BlackList
+	peerScores     *PeerScores
+	bandwidth      *Bandwidth
 	peerInfos      *PeerInfos
 	addrBook       *AddrBook // known peers
 	natDone        int32
@@ -37,6 +39,7 @@ type NodeInfo struct {
 	cliCreds       credentials.TransportCredentials
 	servCreds      credentials.TransportCredentials
 	caServer       string
+	certReloader   *certReloader
 }
 
 // NewNodeInfo new a node object
@@ -46,6 +49,8 @@ func NewNodeInfo(p2pCfg *types.P2P, subCfg *subConfig) *NodeInfo {
 	nodeInfo.natNoticeChain = make(chan struct{}, 1)
 	nodeInfo.natResultChain = make(chan bool, 1)
 	nodeInfo.blacklist = &BlackList{badPeers: make(map[string]int64)}
+	nodeInfo.peerScores = NewPeerScores(subCfg)
+	nodeInfo.bandwidth = NewBandwidth(subCfg)
 	nodeInfo.p2pCfg = p2pCfg
 	nodeInfo.cfg = subCfg
 	nodeInfo.peerInfos = new(PeerInfos)
@@ -66,6 +66,22 @@ type subConfig struct {
 	CertFile  string `json:"certFile,omitempty"`
 	// 私钥文件
 	KeyFile string `json:"keyFile,omitempty"`
+	// X.509 CRL文件, 吊销列表中的证书不能连接
+	CrlFile string `json:"crlFile,omitempty"`
+	// 节点评分低于该值时断开连接并加入黑名单
+	ScoreBanThreshold int32 `json:"scoreBanThreshold,omitempty"`
+	// 节点评分衰减的半衰期, 秒
+	ScoreHalfLife int32 `json:"scoreHalfLife,omitempty"`
+	// 首次因评分过低加入黑名单的时间, 秒, 之后每次加倍
+	ScoreBanTime int32 `json:"scoreBanTime,omitempty"`
+	// 因评分过低加入黑名单的最长时间, 秒
+	ScoreMaxBanTime int32 `json:"scoreMaxBanTime,omitempty"`
+	// 交易广播带宽限制
+	TxBandwidth *bandwidthLimit `json:"txBandwidth,omitempty"`
+	// 区块广播带宽限制
+	BlockBandwidth *bandwidthLimit `json:"blockBandwidth,omitempty"`
+	// 轻广播区块交易请求及回复的带宽限制
+	QueryBandwidth *bandwidthLimit `json:"queryBandwidth,omitempty"`
 }
 
 // P2p interface
@@ -116,6 +132,18 @@ func New(mgr *p2p.Manager, subCfg []byte) p2p.IP2P {
 		mcfg.InnerBounds = 500
 	}
 	log.Info("p2p", "InnerBounds", mcfg.InnerBounds)
+	if mcfg.ScoreBanThreshold >= 0 {
+		mcfg.ScoreBanThreshold = defaultScoreBanThreshold
+	}
+	if mcfg.ScoreHalfLife <= 0 {
+		mcfg.ScoreHalfLife = defaultScoreHalfLife
+	}
+	if mcfg.ScoreBanTime <= 0 {
+		mcfg.ScoreBanTime = defaultScoreBanTime
+	}
+	if mcfg.ScoreMaxBanTime < mcfg.ScoreBanTime {
+		mcfg.ScoreMaxBanTime = defaultScoreMaxBanTime
+	}
 
 	node, err := NewNode(mgr, mcfg)
 	if err != nil {
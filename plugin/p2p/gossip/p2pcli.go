@@ -560,6 +560,11 @@ func (m *Cli) GetNetInfo(msg *queue.Message, taskindex int64) {
 	netinfo.Service = m.network.node.nodeInfo.IsOutService()
 	netinfo.Outbounds = int32(m.network.node.Size())
 	netinfo.Inbounds = int32(len(m.network.node.server.p2pserver.getInBoundPeers()))
+	//广播消息的流量速率
+	in, out := m.network.node.nodeInfo.bandwidth.Rate()
+	netinfo.Ratein = formatRate(in)
+	netinfo.Rateout = formatRate(out)
+	netinfo.Ratetotal = formatRate(in + out)
 	msg.Reply(m.network.client.NewMessage("rpc", pb.EventReplyNetInfo, &netinfo))
 
 }
@@ -605,6 +610,7 @@ func (m *Cli) peerInfos() []*pb.Peer {
 		}
 		peers = append(peers, peer)
 	}
+	m.network.node.nodeInfo.peerScores.sortPeers(peers)
 	return peers
 }
 
@@ -765,6 +771,10 @@ func (m *Cli) ShowBlacklist(msg *queue.Message, taskindex int64) {
 		}
 		list.Blackinfo = append(list.Blackinfo, info)
 	}
+	//被吊销的证书一直禁止连接, 节点名称为cert:序列号, 剩余时间为-1
+	for serial, ip := range getRevokedSerials() {
+		list.Blackinfo = append(list.Blackinfo, &pb.BlackInfo{PeerName: revokedCertPrefix + serial, RemoteAddr: ip, Lifetime: -1})
+	}
 
 	msg.Reply(m.network.client.NewMessage("rpc", pb.EventShowBlacklist, &list))
 }
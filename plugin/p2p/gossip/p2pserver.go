@@ -440,6 +440,14 @@ func (s *P2pserver) ServerStreamSend(in *pb.P2PPing, stream pb.P2Pgservice_Serve
 		if s.IsClose() {
 			return fmt.Errorf("node close")
 		}
+		//评分过低被加入黑名单, 断开连接
+		if s.node.nodeInfo.blacklist.Has(peerIP) {
+			return fmt.Errorf("blacklist %v no authorized", peerIP)
+		}
+		//证书被吊销, 断开连接
+		if isRevokedConn(stream.Context()) {
+			return fmt.Errorf("peer %v certificate revoked", peerIP)
+		}
 		sendData, doSend := s.node.processSendP2P(data, peerInfo.p2pversion, peerName, peerInfo.addr)
 		if !doSend {
 			continue
@@ -478,6 +486,13 @@ func (s *P2pserver) ServerStreamRead(stream pb.P2Pgservice_ServerStreamReadServe
 			log.Error("ServerStreamRead", "Recv", err)
 			return err
 		}
+		if s.node.nodeInfo.blacklist.Has(peerIP) {
+			return fmt.Errorf("blacklist %v no authorized", peerIP)
+		}
+		//证书被吊销, 断开连接
+		if isRevokedConn(stream.Context()) {
+			return fmt.Errorf("peer %v certificate revoked", peerIP)
+		}
 
 		if s.node.processRecvP2P(in, peername, s.pubToStream, peeraddr) {
 
@@ -13,6 +13,7 @@ import (
 	"github.com/33cn/chain33/p2p/utils"
 
 	"github.com/33cn/chain33/common/merkle"
+	"github.com/33cn/chain33/queue"
 	"github.com/33cn/chain33/types"
 )
 
@@ -66,6 +67,10 @@ func (n *Node) processSendP2P(rawData interface{}, peerVersion int32, pid, peerA
 		doSend = true
 		sendData.Value = &types.BroadCastData_Ping{Ping: ping}
 	}
+	//超过带宽限制不发送
+	if doSend && !n.checkBandwidth(sendData, bwOut, pid, peerAddr) {
+		doSend = false
+	}
 	log.Debug("ProcessSendP2PEnd", "peerAddr", peerAddr, "doSend", doSend)
 	return
 }
@@ -83,6 +88,10 @@ func (n *Node) processRecvP2P(data *types.BroadCastData, pid string, pubPeerFunc
 		return false
 	}
 	handled = true
+	//超过带宽限制直接丢弃
+	if !n.checkBandwidth(data, bwIn, pid, peerAddr) {
+		return
+	}
 	if tx := data.GetTx(); tx != nil {
 		n.recvTx(tx, pid, peerAddr)
 	} else if ltTx := data.GetLtTx(); ltTx != nil {
@@ -185,16 +194,21 @@ func (n *Node) sendTx(tx *types.P2PTx, p2pData *types.BroadCastData, peerVersion
 }
 
 func (n *Node) recvTx(tx *types.P2PTx, pid, peerAddr string) {
-	if tx.GetTx() == nil {
+	if tx.GetTx() == nil || types.Size(tx.GetTx()) > types.MaxTxSize {
+		n.scorePeer(pid, peerAddr, scoreInvalidTx, "invalidTx")
 		return
 	}
 	txHash := hex.EncodeToString(tx.GetTx().Hash())
 	//将节点id添加到发送过滤, 避免冗余发送
-	n.addIgnoreSendPeerAtomic(txSendFilter, txHash, pid)
+	sentByPeer := n.addIgnoreSendPeerAtomic(txSendFilter, txHash, pid)
 	//重复接收
 	isDuplicate := txHashFilter.AddWithCheckAtomic(txHash, true)
 	//log.Debug("recvTx", "tx", txHash, "ttl", tx.GetRoute().GetTTL(), "peerAddr", peerAddr, "duplicateTx", isDuplicate)
 	if isDuplicate {
+		//同一节点重复发送
+		if sentByPeer {
+			n.scorePeer(pid, peerAddr, scoreDuplicateMsg, "duplicateTx")
+		}
 		return
 	}
 	//有可能收到老版本的交易路由,此时route是空指针
@@ -203,10 +217,12 @@ func (n *Node) recvTx(tx *types.P2PTx, pid, peerAddr string) {
 	}
 	txHashFilter.Add(txHash, tx.GetRoute())
 
-	errs := n.postMempool(txHash, tx.GetTx())
+	msg, errs := n.postMempool(txHash, tx.GetTx())
 	if errs != nil {
 		log.Error("recvTx", "process post mempool EventTx msg Error", errs.Error())
+		return
 	}
+	n.checkRejected(n.txChecks, msg, pid, peerAddr, scoreInvalidTx, "rejectedTx")
 
 }
 
@@ -214,9 +230,12 @@ func (n *Node) recvLtTx(tx *types.LightTx, pid, peerAddr string, pubPeerFunc pub
 
 	txHash := hex.EncodeToString(tx.TxHash)
 	//将节点id添加到发送过滤, 避免冗余发送
-	n.addIgnoreSendPeerAtomic(txSendFilter, txHash, pid)
+	sentByPeer := n.addIgnoreSendPeerAtomic(txSendFilter, txHash, pid)
 	exist := txHashFilter.Contains(txHash)
 	//log.Debug("recvLtTx", "txHash", txHash, "ttl", tx.GetRoute().GetTTL(), "peerAddr", peerAddr, "exist", exist)
+	if exist && sentByPeer {
+		n.scorePeer(pid, peerAddr, scoreDuplicateMsg, "duplicateLtTx")
+	}
 	//本地不存在, 需要向对端节点发起完整交易请求. 如果存在则表示本地已经接收过此交易, 不做任何操作
 	if !exist {
 
@@ -246,10 +265,16 @@ func (n *Node) recvBlock(block *types.P2PBlock, pid, peerAddr string) {
 	if isDuplicate {
 		return
 	}
-	//发送至blockchain执行
-	if err := n.postBlockChain(blockHash, pid, block.GetBlock()); err != nil {
-		log.Error("recvBlock", "send block to blockchain Error", err.Error())
+	//交易根哈希不一致, 区块数据被篡改
+	if !bytes.Equal(block.GetBlock().TxHash, merkle.CalcMerkleRoot(n.chainCfg, block.GetBlock().Height, block.GetBlock().Txs)) {
+		log.Error("recvBlock", "blockHash", blockHash, "peerAddr", peerAddr, "err", "TxHashCheckFail")
+		blockHashFilter.Remove(blockHash)
+		n.scorePeer(pid, peerAddr, scoreInvalidBlock, "invalidBlock")
+		return
 	}
+	n.scorePeer(pid, peerAddr, scoreValidBlock, "recvBlock")
+	//发送至blockchain执行
+	n.postCheckBlockChain(blockHash, pid, peerAddr, block.GetBlock(), "recvBlock")
 
 }
 
@@ -323,10 +348,9 @@ func (n *Node) recvLtBlock(ltBlock *types.LightBlock, pid, peerAddr string, pubP
 		if bytes.Equal(block.TxHash, merkle.CalcMerkleRoot(n.chainCfg, block.Height, block.Txs)) {
 			log.Debug("recvLtBlock", "height", block.GetHeight(), "peerAddr", peerAddr,
 				"blockHash", blockHash, "block size(KB)", float32(ltBlock.Size)/1024)
+			n.scorePeer(pid, peerAddr, scoreValidBlock, "recvLtBlock")
 			//发送至blockchain执行
-			if err := n.postBlockChain(blockHash, pid, block); err != nil {
-				log.Error("recvLtBlock", "send block to blockchain Error", err.Error())
-			}
+			n.postCheckBlockChain(blockHash, pid, peerAddr, block, "recvLtBlock")
 			return
 		}
 		log.Debug("recvLtBlock:TxHashCheckFail", "height", block.GetHeight(), "peerAddr", peerAddr,
@@ -408,6 +432,10 @@ func (n *Node) recvQueryReply(rep *types.P2PBlockTxReply, pid, peerAddr string,
 	if !exist || block == nil {
 		return
 	}
+	if len(rep.TxIndices) > 0 && len(rep.TxIndices) != len(rep.Txs) {
+		n.scorePeer(pid, peerAddr, scoreInvalidBlock, "invalidBlockTxReply")
+		return
+	}
 	for i, idx := range rep.TxIndices {
 		block.Txs[idx] = rep.Txs[i]
 	}
@@ -422,10 +450,9 @@ func (n *Node) recvQueryReply(rep *types.P2PBlockTxReply, pid, peerAddr string,
 
 		log.Debug("recvQueryReplyBlock", "blockHeight", block.GetHeight(), "peerAddr", peerAddr,
 			"block size(KB)", float32(block.Size())/1024, "blockHash", rep.BlockHash)
+		n.scorePeer(pid, peerAddr, scoreValidBlock, "recvQueryReplyBlock")
 		//发送至blockchain执行
-		if err := n.postBlockChain(rep.BlockHash, pid, block); err != nil {
-			log.Error("recvQueryReplyBlock", "send block to blockchain Error", err.Error())
-		}
+		n.postCheckBlockChain(rep.BlockHash, pid, peerAddr, block, "recvQueryReplyBlock")
 	} else if len(rep.TxIndices) != 0 {
 		log.Debug("recvQueryReplyBlock", "GetTotalBlock", block.GetHeight())
 		//不一致尝试请求整个区块的交易, 且判定是否已经请求过完整交易
@@ -441,6 +468,10 @@ func (n *Node) recvQueryReply(rep *types.P2PBlockTxReply, pid, peerAddr string,
 		ltBlockCache.Add(rep.BlockHash, block, block.Size())
 		//pub to specified peer
 		pubPeerFunc(query, pid)
+	} else {
+		//对端节点返回的完整交易与区块头不一致
+		log.Error("recvQueryReplyBlock", "blockHash", rep.BlockHash, "peerAddr", peerAddr, "err", "TxHashCheckFail")
+		n.scorePeer(pid, peerAddr, scoreInvalidBlock, "invalidLtBlock")
 	}
 }
 
@@ -460,14 +491,22 @@ func (n *Node) queryMempool(ty int64, data interface{}) (interface{}, error) {
 	return resp.Data, nil
 }
 
-func (n *Node) postBlockChain(blockHash, pid string, block *types.Block) error {
-	_, err := n.p2pMgr.PubBroadCast(blockHash, &types.BlockPid{Pid: pid, Block: block}, types.EventBroadcastAddBlock)
-	return err
+func (n *Node) postBlockChain(blockHash, pid string, block *types.Block) (*queue.Message, error) {
+	return n.p2pMgr.PubBroadCast(blockHash, &types.BlockPid{Pid: pid, Block: block}, types.EventBroadcastAddBlock)
+}
+
+// 发送区块至blockchain执行, 被拒绝时对来源节点扣分
+func (n *Node) postCheckBlockChain(blockHash, pid, peerAddr string, block *types.Block, reason string) {
+	msg, err := n.postBlockChain(blockHash, pid, block)
+	if err != nil {
+		log.Error(reason, "send block to blockchain Error", err.Error())
+		return
+	}
+	n.checkRejected(n.blockChecks, msg, pid, peerAddr, scoreInvalidBlock, "rejectedBlock")
 }
 
-func (n *Node) postMempool(txHash string, tx *types.Transaction) error {
-	_, err := n.p2pMgr.PubBroadCast(txHash, tx, types.EventTx)
-	return err
+func (n *Node) postMempool(txHash string, tx *types.Transaction) (*queue.Message, error) {
+	return n.p2pMgr.PubBroadCast(txHash, tx, types.EventTx)
 }
 
 //检测是否冗余发送, 或者添加到发送过滤(内部存在直接修改读写保护的数据, 对filter lru的读写需要外层锁保护)
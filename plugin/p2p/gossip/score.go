@@ -0,0 +1,298 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+)
+
+// 节点行为对应的评分变化
+const (
+	scoreInvalidBlock = -50
+	scoreInvalidTx    = -10
+	scoreDuplicateMsg = -1
+	scoreDownloadFail = -10
+	scoreDownloadSlow = -2
+	scoreRateLimit    = -1
+	scoreValidBlock   = 1
+	scoreDownloadOk   = 1
+	//评分上限, 避免节点长期积累的评分抵消作恶
+	maxPeerScore = 100
+)
+
+// 转发给mempool或blockchain后被拒绝, 确定是数据本身无效的错误
+var (
+	invalidTxErrs = map[string]bool{
+		types.ErrSign.Error():            true,
+		types.ErrEmptyTx.Error():         true,
+		types.ErrInvalidAddress.Error():  true,
+		types.ErrTxMsgSizeTooBig.Error(): true,
+		types.ErrTxGroupFormat.Error():   true,
+	}
+	invalidBlockErrs = map[string]bool{
+		types.ErrSign.Error():           true,
+		types.ErrCheckStateHash.Error(): true,
+		types.ErrCheckTxHash.Error():    true,
+		types.ErrBlockSize.Error():      true,
+		types.ErrTxDup.Error():          true,
+	}
+)
+
+const (
+	//等待mempool或blockchain处理结果的队列长度, 队列满时不再检查
+	rejectCheckQueueSize = 1024
+	rejectCheckTimeout   = 30 * time.Second
+)
+
+// 评分相关的默认配置
+const (
+	defaultScoreBanThreshold = -100
+	defaultScoreHalfLife     = 600
+	defaultScoreBanTime      = 600
+	defaultScoreMaxBanTime   = 7 * 24 * 3600
+)
+
+type peerScore struct {
+	score float64
+	//上次更新时间, 按半衰期计算衰减
+	update int64
+	addr   string
+}
+
+// PeerScores 节点评分, 评分随时间向0衰减, 低于阈值的节点加入黑名单, 黑名单时间随被禁次数指数增长
+type PeerScores struct {
+	mtx sync.Mutex
+	//key:peerName
+	scores map[string]*peerScore
+	//key:ip, 被禁次数
+	bans       map[string]int32
+	threshold  float64
+	halfLife   int64
+	banTime    int64
+	maxBanTime int64
+}
+
+// PeerScoreInfo 节点评分信息
+type PeerScoreInfo struct {
+	Name  string  `json:"name"`
+	Addr  string  `json:"addr"`
+	Score float64 `json:"score"`
+	Bans  int32   `json:"bans"`
+}
+
+// NewPeerScores new peer scores by sub config
+func NewPeerScores(cfg *subConfig) *PeerScores {
+	return &PeerScores{
+		scores:     make(map[string]*peerScore),
+		bans:       make(map[string]int32),
+		threshold:  float64(cfg.ScoreBanThreshold),
+		halfLife:   int64(cfg.ScoreHalfLife),
+		banTime:    int64(cfg.ScoreBanTime),
+		maxBanTime: int64(cfg.ScoreMaxBanTime),
+	}
+}
+
+func (s *PeerScores) decay(ps *peerScore, now int64) {
+	if now > ps.update && s.halfLife > 0 {
+		ps.score *= math.Pow(0.5, float64(now-ps.update)/float64(s.halfLife))
+	}
+	ps.update = now
+}
+
+// Add 增加节点评分, 返回当前评分以及是否需要禁止该节点
+func (s *PeerScores) Add(pid, addr string, delta float64) (float64, bool) {
+	return s.add(pid, addr, delta, types.Now().Unix())
+}
+
+func (s *PeerScores) add(pid, addr string, delta float64, now int64) (float64, bool) {
+	if pid == "" {
+		return 0, false
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	ps, ok := s.scores[pid]
+	if !ok {
+		ps = &peerScore{update: now}
+		s.scores[pid] = ps
+	}
+	s.decay(ps, now)
+	if addr != "" {
+		ps.addr = addr
+	}
+	ps.score = math.Min(ps.score+delta, maxPeerScore)
+	return ps.score, ps.score < s.threshold
+}
+
+// Get 获取节点当前评分
+func (s *PeerScores) Get(pid string) float64 {
+	return s.get(pid, types.Now().Unix())
+}
+
+func (s *PeerScores) get(pid string, now int64) float64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	ps, ok := s.scores[pid]
+	if !ok {
+		return 0
+	}
+	s.decay(ps, now)
+	return ps.score
+}
+
+// Ban 记录节点被禁, 重置评分并返回黑名单时间(秒), 每次被禁时间加倍
+func (s *PeerScores) Ban(pid, ip string) int64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	delete(s.scores, pid)
+	count := s.bans[ip]
+	s.bans[ip] = count + 1
+	lifetime := s.banTime
+	for i := int32(0); i < count && lifetime < s.maxBanTime; i++ {
+		lifetime *= 2
+	}
+	if lifetime > s.maxBanTime {
+		lifetime = s.maxBanTime
+	}
+	return lifetime
+}
+
+// Prune 清理衰减后接近0的评分记录
+func (s *PeerScores) Prune() {
+	s.prune(types.Now().Unix())
+}
+
+func (s *PeerScores) prune(now int64) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for pid, ps := range s.scores {
+		s.decay(ps, now)
+		if math.Abs(ps.score) < 0.01 {
+			delete(s.scores, pid)
+		}
+	}
+}
+
+// List 列出所有节点的评分, 按评分从高到低排序
+func (s *PeerScores) List() []*PeerScoreInfo {
+	return s.list(types.Now().Unix())
+}
+
+func (s *PeerScores) list(now int64) []*PeerScoreInfo {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	infos := make([]*PeerScoreInfo, 0, len(s.scores))
+	for pid, ps := range s.scores {
+		s.decay(ps, now)
+		info := &PeerScoreInfo{Name: pid, Addr: ps.addr, Score: ps.score}
+		if ip, _, err := P2pComm.ParaseNetAddr(ps.addr); err == nil {
+			info.Bans = s.bans[ip]
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Score > infos[j].Score
+	})
+	return infos
+}
+
+// sortPeers 节点信息按评分从高到低排序, 查询节点信息时评分高的节点在前
+func (s *PeerScores) sortPeers(peers []*types.Peer) {
+	scores := make(map[string]float64)
+	for _, info := range s.List() {
+		scores[info.Name] = info.Score
+	}
+	sort.SliceStable(peers, func(i, j int) bool {
+		return scores[peers[i].GetName()] > scores[peers[j].GetName()]
+	})
+}
+
+// 根据节点行为调整评分, 低于阈值时断开连接并加入黑名单
+func (n *Node) scorePeer(pid, addr string, delta float64, reason string) {
+	score, ban := n.nodeInfo.peerScores.Add(pid, addr, delta)
+	if delta < 0 {
+		log.Debug("scorePeer", "pid", pid, "addr", addr, "reason", reason, "score", score)
+	}
+	if !ban {
+		return
+	}
+	if peer := n.GetRegisterPeer(pid); peer != nil && addr == "" {
+		addr = peer.Addr()
+	}
+	ip, _, err := P2pComm.ParaseNetAddr(addr)
+	if err != nil {
+		log.Error("scorePeer", "pid", pid, "addr", addr, "err", err)
+		return
+	}
+	lifetime := n.nodeInfo.peerScores.Ban(pid, ip)
+	log.Info("scorePeer ban peer", "pid", pid, "addr", addr, "reason", reason, "score", score, "lifetime", lifetime)
+	n.nodeInfo.blacklist.Add(ip, lifetime)
+	n.nodeInfo.blacklist.Add(addr, lifetime)
+	n.nodeInfo.blacklist.addPeerStore(addr, pid)
+	n.nodeInfo.blacklist.addPeerStore(pid, addr)
+	n.remove(pid)
+}
+
+func (n *Node) monitorPeerScore() {
+	ticker := time.NewTicker(CheckPeerScoreInterval)
+	defer ticker.Stop()
+	for {
+		if n.isClose() {
+			log.Info("monitorPeerScore", "loop", "done")
+			return
+		}
+		<-ticker.C
+		n.nodeInfo.peerScores.Prune()
+	}
+}
+
+// 等待mempool或blockchain处理的转发消息
+type rejectCheck struct {
+	msg    *queue.Message
+	pid    string
+	addr   string
+	delta  float64
+	reason string
+}
+
+// checkRejected 异步等待转发消息的处理结果, 因数据无效被拒绝时对来源节点扣分
+func (n *Node) checkRejected(checks chan *rejectCheck, msg *queue.Message, pid, addr string, delta float64, reason string) {
+	if msg == nil {
+		return
+	}
+	select {
+	case checks <- &rejectCheck{msg: msg, pid: pid, addr: addr, delta: delta, reason: reason}:
+	default:
+		log.Debug("checkRejected", "queue", "full", "reason", reason)
+	}
+}
+
+func (n *Node) monitorRejected(checks chan *rejectCheck, invalidErrs map[string]bool) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		if n.isClose() {
+			log.Info("monitorRejected", "loop", "done")
+			return
+		}
+		select {
+		case check := <-checks:
+			resp, err := n.p2pMgr.Client.WaitTimeout(check.msg, rejectCheckTimeout)
+			if err != nil {
+				continue
+			}
+			reply, ok := resp.GetData().(*types.Reply)
+			if ok && !reply.GetIsOk() && invalidErrs[string(reply.GetMsg())] {
+				n.scorePeer(check.pid, check.addr, check.delta, check.reason)
+			}
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gossip
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/33cn/chain33/p2p"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerScores(t *testing.T) {
+	scores := NewPeerScores(&subConfig{
+		ScoreBanThreshold: -100,
+		ScoreHalfLife:     100,
+		ScoreBanTime:      60,
+		ScoreMaxBanTime:   200,
+	})
+	now := int64(1000)
+	score, ban := scores.add("pid1", "192.168.1.1:13802", scoreInvalidBlock, now)
+	assert.Equal(t, float64(scoreInvalidBlock), score)
+	assert.False(t, ban)
+	//经过一个半衰期评分减半
+	assert.InDelta(t, float64(scoreInvalidBlock)/2, scores.get("pid1", now+100), 0.001)
+	assert.Equal(t, float64(0), scores.get("pid2", now))
+
+	//评分上限
+	for i := 0; i < 200; i++ {
+		scores.add("pid2", "192.168.1.2:13802", scoreValidBlock, now)
+	}
+	assert.Equal(t, float64(maxPeerScore), scores.get("pid2", now))
+
+	//低于阈值需要禁止
+	scores.add("pid1", "", scoreInvalidBlock, now+100)
+	_, ban = scores.add("pid1", "", scoreInvalidBlock, now+100)
+	assert.True(t, ban)
+	list := scores.list(now + 100)
+	assert.Equal(t, 2, len(list))
+	assert.Equal(t, "pid2", list[0].Name)
+	assert.Equal(t, "192.168.1.1:13802", list[1].Addr)
+
+	//黑名单时间指数增长, 不超过最大值
+	assert.Equal(t, int64(60), scores.Ban("pid1", "192.168.1.1"))
+	assert.Equal(t, float64(0), scores.get("pid1", now+100))
+	assert.Equal(t, int64(120), scores.Ban("pid1", "192.168.1.1"))
+	assert.Equal(t, int64(200), scores.Ban("pid1", "192.168.1.1"))
+	assert.Equal(t, int64(200), scores.Ban("pid1", "192.168.1.1"))
+	assert.Equal(t, int64(60), scores.Ban("pid3", "192.168.1.3"))
+
+	//衰减到接近0的记录被清理
+	scores.prune(now + 100*20)
+	assert.Equal(t, 0, len(scores.list(now+100*20)))
+
+	//节点信息按评分排序, 没有评分的节点为0分
+	scores.Add("pid1", "", scoreInvalidBlock)
+	scores.Add("pid2", "", scoreValidBlock)
+	peers := []*types.Peer{{Name: "pid1"}, {Name: "pid3"}, {Name: "pid2"}}
+	scores.sortPeers(peers)
+	assert.Equal(t, []string{"pid2", "pid3", "pid1"}, []string{peers[0].Name, peers[1].Name, peers[2].Name})
+}
+
+func TestCheckRejected(t *testing.T) {
+	q := queue.New("channel")
+	cli := q.Client()
+	n := &Node{
+		p2pMgr:   &p2p.Manager{Client: cli},
+		nodeInfo: &NodeInfo{peerScores: NewPeerScores(&subConfig{ScoreBanThreshold: -100, ScoreHalfLife: 600})},
+		txChecks: make(chan *rejectCheck, 2),
+	}
+	reply := func(err error) *queue.Message {
+		msg := cli.NewMessage("mempool", types.EventTx, nil)
+		msg.Reply(cli.NewMessage("", types.EventReply, &types.Reply{Msg: []byte(err.Error())}))
+		return msg
+	}
+	n.checkRejected(n.txChecks, nil, "pid1", "", scoreInvalidTx, "rejectedTx")
+	//交易已存在等错误不扣分
+	n.checkRejected(n.txChecks, reply(types.ErrTxExist), "pid1", "", scoreInvalidTx, "rejectedTx")
+	n.checkRejected(n.txChecks, reply(types.ErrSign), "pid1", "", scoreInvalidTx, "rejectedTx")
+	//队列满时丢弃
+	n.checkRejected(n.txChecks, reply(types.ErrSign), "pid1", "", scoreInvalidTx, "rejectedTx")
+	assert.Equal(t, 2, len(n.txChecks))
+
+	go n.monitorRejected(n.txChecks, invalidTxErrs)
+	defer atomic.StoreInt32(&n.closed, 1)
+	for i := 0; i < 100 && n.nodeInfo.peerScores.get("pid1", types.Now().Unix()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, 0, len(n.txChecks))
+	assert.InDelta(t, float64(scoreInvalidTx), n.nodeInfo.peerScores.get("pid1", types.Now().Unix()), 0.1)
+}
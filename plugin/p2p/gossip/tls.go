@@ -13,12 +13,15 @@ import (
 	"syscall"
 
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 //Tls defines the specific interface for all the live gRPC wire
 // protocols and supported transport security protocols (e.g., TLS, SSL).
 type Tls struct {
 	config *tls.Config
+	//证书热加载时每次握手获取最新的配置
+	reload func() *tls.Config
 }
 
 type certInfo struct {
@@ -31,6 +34,8 @@ var (
 	serials       = make(map[string]*certInfo)
 	revokeLock    sync.Mutex
 	latestSerials sync.Map
+	//CRL文件中吊销的证书序列号
+	crlSerials = make(map[string]bool)
 )
 
 //serialNum -->ip
@@ -52,9 +57,53 @@ func updateCertSerial(serial string, revoke bool) certInfo {
 	return certInfo{}
 }
 
+func getCertSerial(serial string) certInfo {
+	revokeLock.Lock()
+	defer revokeLock.Unlock()
+	if v, ok := serials[serial]; ok {
+		return *v
+	}
+	return certInfo{}
+}
+
+//setCrlSerials 更新CRL吊销列表, 返回新吊销的序列号
+func setCrlSerials(list []string) []string {
+	revokeLock.Lock()
+	defer revokeLock.Unlock()
+	var added []string
+	latest := make(map[string]bool, len(list))
+	for _, serial := range list {
+		if !crlSerials[serial] {
+			added = append(added, serial)
+		}
+		latest[serial] = true
+	}
+	crlSerials = latest
+	return added
+}
+
+//getRevokedSerials 被CA服务或CRL文件吊销的证书序列号及使用该证书的节点ip
+func getRevokedSerials() map[string]string {
+	revokeLock.Lock()
+	defer revokeLock.Unlock()
+	revoked := make(map[string]string)
+	for serial := range crlSerials {
+		revoked[serial] = ""
+	}
+	for serial, info := range serials {
+		if info.revoke || crlSerials[serial] {
+			revoked[serial] = info.ip
+		}
+	}
+	return revoked
+}
+
 func isRevoke(serial string) bool {
 	revokeLock.Lock()
 	defer revokeLock.Unlock()
+	if crlSerials[serial] {
+		return true
+	}
 	if r, ok := serials[serial]; ok {
 		return r.revoke
 	}
@@ -95,6 +144,29 @@ func (c Tls) Info() credentials.ProtocolInfo {
 	}
 }
 
+//isRevokedConn 连接对端使用的证书是否已被吊销
+func isRevokedConn(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return false
+	}
+	return isRevoke(tlsInfo.State.PeerCertificates[0].SerialNumber.String())
+}
+
+func (c *Tls) getConfig() *tls.Config {
+	if c.reload == nil {
+		return c.config
+	}
+	cfg := c.reload()
+	cfg.ServerName = c.config.ServerName
+	cfg.NextProtos = c.config.NextProtos
+	return cfg
+}
+
 func CloneTLSConfig(cfg *tls.Config) *tls.Config {
 	if cfg == nil {
 		return &tls.Config{}
@@ -105,7 +177,7 @@ func CloneTLSConfig(cfg *tls.Config) *tls.Config {
 func (c *Tls) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (_ net.Conn, _ credentials.AuthInfo, err error) {
 
 	// use local cfg to avoid clobbering ServerName if using multiple endpoints
-	cfg := CloneTLSConfig(c.config)
+	cfg := CloneTLSConfig(c.getConfig())
 	if cfg.ServerName == "" {
 		serverName, _, err := net.SplitHostPort(authority)
 		if err != nil {
@@ -164,7 +236,7 @@ func (c *Tls) ClientHandshake(ctx context.Context, authority string, rawConn net
 
 //ServerHandshake check cert
 func (c *Tls) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
-	conn := tls.Server(rawConn, c.config)
+	conn := tls.Server(rawConn, c.getConfig())
 	if err := conn.Handshake(); err != nil {
 		conn.Close()
 		return nil, nil, err
@@ -213,8 +285,17 @@ func newTLS(c *tls.Config) credentials.TransportCredentials {
 	return tc
 }
 
+// newReloadTLS uses reload to construct a TransportCredentials which loads the latest certificates for each handshake.
+func newReloadTLS(reload func() *tls.Config) credentials.TransportCredentials {
+	tc := newTLS(reload()).(*Tls)
+	tc.reload = reload
+	return tc
+}
+
 func (c *Tls) Clone() credentials.TransportCredentials {
-	return newTLS(c.config)
+	tc := newTLS(c.config).(*Tls)
+	tc.reload = c.reload
+	return tc
 }
 
 func (c *Tls) OverrideServerName(serverNameOverride string) error {
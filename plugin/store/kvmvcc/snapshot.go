@@ -0,0 +1,17 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccdb
+
+import (
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+)
+
+// ImportSnapshot kvmvcc的状态hash只是对单个区块kv集合的hash, 不是对全部状态的承诺
+// 无法从导入的kv重新计算并与区块头校验, 所以不支持导入
+func (mvccs *KVMVCCStore) ImportSnapshot(header *snapshot.Header, next func() ([]*types.KeyValue, error)) ([]byte, error) {
+	klog.Error("store kvmvcc import snapshot not supported, state hash can not be rebuilt from kvs", "height", header.Height)
+	return nil, snapshot.ErrNotSupported
+}
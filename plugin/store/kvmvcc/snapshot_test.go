@@ -0,0 +1,61 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccdb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func commitBlocks(t *testing.T, store *KVMVCCStore, from, to int64, hash []byte) [][]byte {
+	var hashes [][]byte
+	for i := from; i <= to; i++ {
+		kvs := []*types.KeyValue{
+			{Key: []byte(fmt.Sprintf("mavl-k%d", i%3)), Value: []byte(fmt.Sprintf("v%d", i))},
+			{Key: []byte(fmt.Sprintf("mavl-height%d", i)), Value: []byte(fmt.Sprintf("%d", i))},
+		}
+		var err error
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kvs, Height: i}, true)
+		assert.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		assert.Nil(t, err)
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	storeCfg, sub := newStoreCfgIter(dir)
+	src := New(storeCfg, sub, nil).(*KVMVCCStore)
+	defer src.Close()
+	hashes := commitBlocks(t, src, 0, 5, nil)
+
+	var buf bytes.Buffer
+	header := &snapshot.Header{Driver: "kvmvcc", Height: 5, StateHash: common.ToHex(hashes[5]), ChunkSize: 16}
+	keys, err := snapshot.Export(src, header, &buf)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(9), keys)
+
+	dir2, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir2)
+	storeCfg, sub = newStoreCfgIter(dir2)
+	dst := New(storeCfg, sub, nil).(*KVMVCCStore)
+	defer dst.Close()
+	// 状态hash无法从kv重建, 不支持导入
+	_, err = snapshot.Import(bytes.NewReader(buf.Bytes()), dst, hashes[5], 5)
+	assert.Equal(t, snapshot.ErrNotSupported, err)
+}
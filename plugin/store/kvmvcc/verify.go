@@ -0,0 +1,14 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccdb
+
+import (
+	"github.com/33cn/plugin/plugin/store/tools"
+)
+
+// VerifyState 检查版本height的hash, 并用这个版本修改的kv重新计算状态hash
+func (mvccs *KVMVCCStore) VerifyState(height int64, prevHash, stateHash []byte) error {
+	return tools.VerifyMVCC(mvccs.GetDB(), mvccs.mvcc, height, prevHash, stateHash, true)
+}
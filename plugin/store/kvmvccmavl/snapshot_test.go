@@ -0,0 +1,71 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+func commitBlocks(t *testing.T, store *KVmMavlStore, from, to int64, hash []byte) [][]byte {
+	var hashes [][]byte
+	for i := from; i <= to; i++ {
+		kvs := []*types.KeyValue{
+			{Key: []byte(fmt.Sprintf("mavl-k%d", i%3)), Value: []byte(fmt.Sprintf("v%d", i))},
+			{Key: []byte(fmt.Sprintf("mavl-height%d", i)), Value: []byte(fmt.Sprintf("%d", i))},
+		}
+		var err error
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kvs, Height: i}, true)
+		assert.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		assert.Nil(t, err)
+		hashes = append(hashes, hash)
+	}
+	return hashes
+}
+
+func TestSnapshot(t *testing.T) {
+	kvmvccMavlFork = 4
+	defer func() {
+		kvmvccMavlFork = 200 * 10000
+	}()
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	storeCfg, sub := newStoreCfgIter(dir)
+	src := New(storeCfg, sub, nil).(*KVmMavlStore)
+	defer src.Close()
+	hashes := commitBlocks(t, src, 0, 7, nil)
+
+	dir2, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir2)
+	storeCfg, sub = newStoreCfgIter(dir2)
+	dst := New(storeCfg, sub, nil).(*KVmMavlStore)
+	defer dst.Close()
+
+	// 分叉前后的状态hash都不能从kv重建
+	var buf bytes.Buffer
+	header := &snapshot.Header{Driver: "kvmvccmavl", Height: 2, StateHash: common.ToHex(hashes[2])}
+	_, err = snapshot.Export(src, header, &buf)
+	assert.Nil(t, err)
+	_, err = snapshot.Import(bytes.NewReader(buf.Bytes()), dst, hashes[2], 2)
+	assert.Equal(t, snapshot.ErrNotSupported, err)
+
+	buf.Reset()
+	header = &snapshot.Header{Driver: "kvmvccmavl", Height: 7, StateHash: common.ToHex(hashes[7]), ChunkSize: 16}
+	_, err = snapshot.Export(src, header, &buf)
+	assert.Nil(t, err)
+	_, err = snapshot.Import(bytes.NewReader(buf.Bytes()), dst, hashes[7], 7)
+	assert.Equal(t, snapshot.ErrNotSupported, err)
+}
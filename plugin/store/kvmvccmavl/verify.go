@@ -0,0 +1,30 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package kvmvccmavl
+
+import (
+	"bytes"
+
+	"github.com/33cn/plugin/plugin/store/tools"
+)
+
+// VerifyState 检查高度height的状态
+// 分叉高度之前mvcc版本使用mavl的roothash, 只检查版本对应的hash;
+// 开启空块处理时空块没有mvcc版本, 检查状态hash映射的版本
+func (kvmMavls *KVmMavlStore) VerifyState(height int64, prevHash, stateHash []byte) error {
+	mvccs := kvmMavls.KVMVCCStore
+	if !mvccs.kvmvccCfg.EnableEmptyBlockHandle {
+		return tools.VerifyMVCC(mvccs.db, mvccs.mvcc, height, prevHash, stateHash, height >= kvmvccMavlFork)
+	}
+	hash, err := mvccs.GetHashRdm(stateHash, height)
+	if err != nil || hash == nil {
+		return tools.ErrStateNotFound
+	}
+	version, err := mvccs.mvcc.GetVersion(hash)
+	if err != nil {
+		return tools.ErrStateNotFound
+	}
+	return tools.VerifyMVCC(mvccs.db, mvccs.mvcc, version, prevHash, hash, height >= kvmvccMavlFork && bytes.Equal(hash, stateHash))
+}
@@ -0,0 +1,61 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	"github.com/spf13/cobra"
+)
+
+// Cmd mpt store cmd register
+func Cmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mpt",
+		Short: "Mpt store management",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		ReadableHeightsCmd(),
+	)
+	return cmd
+}
+
+// ReadableHeightsCmd query readable heights from the store db
+func ReadableHeightsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "readable",
+		Short: "Show the height ranges whose state can still be read, heights before trackedFrom are not reported, the node must be stopped",
+		Run:   readableHeights,
+	}
+	cmd.Flags().StringP("conf", "c", "chain33.toml", "node config file, locate the store")
+	return cmd
+}
+
+func readableHeights(cmd *cobra.Command, args []string) {
+	conf, _ := cmd.Flags().GetString("conf")
+	if _, err := os.Stat(conf); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	mcfg := types.NewChain33Config(types.ReadFile(conf)).GetModuleConfig().Store
+	if mcfg == nil || mcfg.Name != "mpt" {
+		fmt.Fprintln(os.Stderr, "store driver is not mpt")
+		return
+	}
+	//直接打开store数据库, 不创建store, 避免启动后台裁剪
+	db := dbm.NewDB("store", mcfg.Driver, mcfg.DbPath, mcfg.DbCache)
+	defer db.Close()
+	data, err := json.MarshalIndent(GetReadableHeights(db), "", "    ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Println(string(data))
+}
@@ -310,6 +310,17 @@ func (db *Database) Node(hash common.Hash) ([]byte, error) {
 	return db.db.Get(hash[:])
 }
 
+// NodeChildren 返回节点引用的子节点hash, 内嵌的子节点会展开
+func (db *Database) NodeChildren(hash common.Hash) ([]common.Hash, error) {
+	n := db.node(hash, 0)
+	if n == nil {
+		return nil, &MissingNodeError{NodeHash: hash}
+	}
+	var children []common.Hash
+	gatherChildren(n, &children)
+	return children, nil
+}
+
 // preimage retrieves a cached trie node pre-image from memory. If it cannot be
 // found cached, the method queries the persistent database for the content.
 func (db *Database) preimage(hash common.Hash) ([]byte, error) {
@@ -452,6 +463,12 @@ func (db *Database) dereference(child common.Hash, parent common.Hash) {
 //
 // As a side effect, all pre-images accumulated up to this point are also written.
 func (db *Database) Commit(node common.Hash, report bool) error {
+	_, err := db.CommitNodes(node, report)
+	return err
+}
+
+// CommitNodes 同Commit, 并返回本次首次写入磁盘的节点hash, 用于按高度删除trie数据
+func (db *Database) CommitNodes(node common.Hash, report bool) ([]common.Hash, error) {
 	// Create a database batch to flush persistent data out. It is important that
 	// outside code doesn't see an inconsistent state (referenced data removed from
 	// memory cache during commit but not yet in persistent storage). This is ensured
@@ -481,16 +498,17 @@ func (db *Database) Commit(node common.Hash, report bool) error {
 	}
 	// Move the trie itself into the batch, flushing if enough data is accumulated
 	nodes, storage := len(db.nodes), db.nodesSize
-	if err := db.commit(node, batch); err != nil {
+	var fresh []common.Hash
+	if err := db.commit(node, batch, &fresh); err != nil {
 		mptlog.Error("Failed to commit trie from trie database", "err", err)
 		db.lock.RUnlock()
-		return err
+		return nil, err
 	}
 	// Write batch ready, unlock for readers during persistence
 	if err := batch.Write(); err != nil {
 		mptlog.Error("Failed to write trie to disk", "err", err)
 		db.lock.RUnlock()
-		return err
+		return nil, err
 	}
 	db.lock.RUnlock()
 
@@ -518,22 +536,26 @@ func (db *Database) Commit(node common.Hash, report bool) error {
 	db.gcnodes, db.gcsize, db.gctime = 0, 0, 0
 	db.flushnodes, db.flushsize, db.flushtime = 0, 0, 0
 
-	return nil
+	return fresh, nil
 }
 
 // commit is the private locked version of Commit.
-func (db *Database) commit(hash common.Hash, batch dbm.Batch) error {
+func (db *Database) commit(hash common.Hash, batch dbm.Batch, fresh *[]common.Hash) error {
 	// If the node does not exist, it's a previously committed node
 	node, ok := db.nodes[hash]
 	if !ok {
 		return nil
 	}
 	for _, child := range node.childs() {
-		if err := db.commit(child, batch); err != nil {
+		if err := db.commit(child, batch, fresh); err != nil {
 			return err
 		}
 	}
 	//println(hex.EncodeToString(hash[:]), len(node.proto()))
+	// 相同内容的节点可能已经落盘, 只记录首次写入的节点
+	if _, err := db.db.Get(hash[:]); err != nil {
+		*fresh = append(*fresh, hash)
+	}
 	batch.Set(hash[:], node.proto())
 	return nil
 }
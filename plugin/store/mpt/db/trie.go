@@ -478,6 +478,11 @@ func (t *Trie) Commit2Db(node common.Hash, report bool) error {
 	return nil
 }
 
+// Database 返回trie使用的内存数据库
+func (t *Trie) Database() *Database {
+	return t.db
+}
+
 // TrieEx Trie扩展，可以相应enableSecure
 type TrieEx struct {
 	*Trie
@@ -5,7 +5,12 @@
 package mpt
 
 import (
+	"bytes"
+	"fmt"
+	"sync"
+
 	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
 	clog "github.com/33cn/chain33/common/log"
 	log "github.com/33cn/chain33/common/log/log15"
 	"github.com/33cn/chain33/queue"
@@ -17,6 +22,23 @@ import (
 
 var mlog = log.New("module", "mpt")
 
+var (
+	// 每个高度提交的roothash
+	heightRootPrefix = []byte("mpt-height-root:")
+	// 每个高度首次写入磁盘的trie节点, 用于按高度删除
+	heightNodesPrefix = []byte("mpt-height-nodes:")
+	// 升级重建时内存中trie节点的上限, 超过后落盘
+	upgradeCacheSize float64 = 256 * 1024 * 1024
+)
+
+func calcHeightRootKey(height int64) []byte {
+	return []byte(fmt.Sprintf("%s%020d", heightRootPrefix, height))
+}
+
+func calcHeightNodesKey(height int64) []byte {
+	return []byte(fmt.Sprintf("%s%020d", heightNodesPrefix, height))
+}
+
 // SetLogLevel set log level
 func SetLogLevel(level string) {
 	clog.SetLogLevel(level)
@@ -32,6 +54,18 @@ type Store struct {
 	*drivers.BaseStore
 	trees map[string]*mpt.TrieEx
 	cache *lru.Cache
+
+	mu      sync.Mutex
+	heights map[string]int64
+	// 升级重建时的内存trie, 中间状态不落盘
+	upgradeDB     *mpt.Database
+	upgradeRoot   []byte
+	upgradeHeight int64
+	upgrades      map[string]int64
+
+	pruner *pruner
+	// 已保存开始记录高度索引的高度
+	tracked bool
 }
 
 func init() {
@@ -41,26 +75,89 @@ func init() {
 // New new mpt store module
 func New(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Module {
 	bs := drivers.NewBaseStore(cfg)
-	mpts := &Store{bs, make(map[string]*mpt.TrieEx), nil}
+	mpts := &Store{
+		BaseStore: bs,
+		trees:     make(map[string]*mpt.TrieEx),
+		heights:   make(map[string]int64),
+		upgrades:  make(map[string]int64),
+	}
 	mpts.cache, _ = lru.New(10)
+	var subcfg subConfig
+	if sub != nil {
+		types.MustDecode(sub, &subcfg)
+	}
+	if subcfg.EnablePrune {
+		mpts.pruner = newPruner(&subcfg)
+		mpts.pruner.wg.Add(1)
+		go mpts.pruneLoop()
+	}
 	bs.SetChild(mpts)
 	return mpts
 }
 
 // Close close mpt store
 func (mpts *Store) Close() {
+	if mpts.pruner != nil {
+		mpts.pruner.stop()
+	}
+	mpts.mu.Lock()
+	if err := mpts.flushUpgrade(); err != nil {
+		mlog.Error("store mpt close flush upgrade", "err", err)
+	}
+	mpts.mu.Unlock()
 	mpts.BaseStore.Close()
 	mlog.Info("store mavl closed")
 }
 
 // Set set k v to mpt store db; sync is true represent write sync
 func (mpts *Store) Set(datas *types.StoreSet, sync bool) ([]byte, error) {
-	hash, err := mpt.SetKVPair(mpts.GetDB(), datas, sync)
+	database := mpt.NewDatabase(mpts.GetDB())
+	tree, err := mpt.NewEx(common.BytesToHash(datas.StateHash), database)
 	if err != nil {
 		mlog.Error("mpt store error", "err", err)
 		return nil, err
 	}
-	return hash, nil
+	for i := 0; i < len(datas.KV); i++ {
+		tree.Update(datas.KV[i].Key, datas.KV[i].Value)
+	}
+	root, err := tree.Commit(nil)
+	if err != nil {
+		mlog.Error("mpt store error", "err", err)
+		return nil, err
+	}
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
+	if err = mpts.commitHeight(database, root[:], datas.Height); err != nil {
+		mlog.Error("mpt store error", "err", err)
+		return nil, err
+	}
+	return root[:], nil
+}
+
+// commitHeight trie落盘, 同时记录该高度的roothash和首次写入的节点
+func (mpts *Store) commitHeight(database *mpt.Database, root []byte, height int64) error {
+	fresh, err := database.CommitNodes(common.BytesToHash(root), true)
+	if err != nil {
+		return err
+	}
+	nodes := &types.ReplyHashes{}
+	for _, hash := range fresh {
+		nodes.Hashes = append(nodes.Hashes, common.CopyBytes(hash[:]))
+	}
+	batch := mpts.GetDB().NewBatch(true)
+	batch.Set(calcHeightRootKey(height), root)
+	batch.Set(calcHeightNodesKey(height), types.Encode(nodes))
+	if !mpts.tracked {
+		setTrackedFrom(mpts.GetDB(), batch, height)
+	}
+	if err = batch.Write(); err != nil {
+		return err
+	}
+	mpts.tracked = true
+	if mpts.pruner != nil {
+		mpts.pruner.notify(height)
+	}
+	return nil
 }
 
 // Get get values by keys
@@ -69,10 +166,20 @@ func (mpts *Store) Get(datas *types.StoreGet) [][]byte {
 	var err error
 	values := make([][]byte, len(datas.Keys))
 	search := string(datas.StateHash)
+	mpts.mu.Lock()
+	memTree, inMem := mpts.trees[search]
+	upgradeDB := mpts.upgradeDB
+	mpts.mu.Unlock()
 	if data, ok := mpts.cache.Get(search); ok {
 		tree = data.(*mpt.TrieEx)
-	} else if data, ok := mpts.trees[search]; ok {
-		tree = data
+	} else if inMem {
+		tree = memTree
+	} else if upgradeDB != nil {
+		// 升级重建中的状态只在内存中, 不加入缓存
+		tree, err = mpt.NewEx(common.BytesToHash(datas.StateHash), upgradeDB)
+		if nil != err {
+			mlog.Error("Store get can not find a trie")
+		}
 	} else {
 		tree, err = mpt.NewEx(common.BytesToHash(datas.StateHash), mpt.NewDatabase(mpts.GetDB()))
 		if nil != err {
@@ -98,6 +205,15 @@ func (mpts *Store) Get(datas *types.StoreGet) [][]byte {
 func (mpts *Store) MemSet(datas *types.StoreSet, sync bool) ([]byte, error) {
 	var err error
 	var tree *mpt.TrieEx
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
+	// 在升级重建的状态上继续执行区块, 先将其落盘
+	if mpts.upgradeRoot != nil && bytes.Equal(mpts.upgradeRoot, datas.StateHash) {
+		if err = mpts.flushUpgrade(); err != nil {
+			mlog.Error("MemSet flush upgrade trie", "err", err)
+			return nil, err
+		}
+	}
 	tree, err = mpt.NewEx(common.BytesToHash(datas.StateHash), mpt.NewDatabase(mpts.GetDB()))
 	if err != nil {
 		mlog.Info("MemSet create a new trie", "err", err)
@@ -113,6 +229,7 @@ func (mpts *Store) MemSet(datas *types.StoreSet, sync bool) ([]byte, error) {
 	}
 	hash := root[:]
 	mpts.trees[string(hash)] = tree
+	mpts.heights[string(hash)] = datas.Height
 	if len(mpts.trees) > 1000 {
 		mlog.Error("too many trees in cache")
 	}
@@ -121,47 +238,164 @@ func (mpts *Store) MemSet(datas *types.StoreSet, sync bool) ([]byte, error) {
 
 // Commit convert memcory mpt to storage db
 func (mpts *Store) Commit(req *types.ReqHash) ([]byte, error) {
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
 	tree, ok := mpts.trees[string(req.Hash)]
 	if !ok {
 		mlog.Error("store mpt commit", "err", types.ErrHashNotFound)
 		return nil, types.ErrHashNotFound
 	}
-	err := tree.Commit2Db(common.BytesToHash(req.Hash), true)
+	err := mpts.commitHeight(tree.Database(), req.Hash, mpts.heights[string(req.Hash)])
 	if nil != err {
-		mlog.Error("store mpt commit", "err", types.ErrHashNotFound)
+		mlog.Error("store mpt commit", "err", err)
 		return nil, types.ErrDataBaseDamage
 	}
 	delete(mpts.trees, string(req.Hash))
+	delete(mpts.heights, string(req.Hash))
 	return req.Hash, nil
 }
 
-// MemSetUpgrade set keys values to memcory mpt, return root hash and error
+// MemSetUpgrade 升级时在内存中重建mpt, 中间状态不落盘, return root hash and error
 func (mpts *Store) MemSetUpgrade(datas *types.StoreSet, sync bool) ([]byte, error) {
-	//not support
-	return nil, nil
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
+	if len(datas.KV) == 0 {
+		mlog.Info("store mpt memset upgrade,use preStateHash as stateHash for kvset is null")
+		mpts.upgrades[string(datas.StateHash)] = datas.Height
+		return datas.StateHash, nil
+	}
+	if mpts.upgradeDB == nil {
+		mpts.upgradeDB = mpt.NewDatabase(mpts.GetDB())
+	}
+	tree, err := mpt.NewEx(common.BytesToHash(datas.StateHash), mpts.upgradeDB)
+	if err != nil {
+		mlog.Error("MemSetUpgrade create a new trie", "err", err)
+		return nil, err
+	}
+	for i := 0; i < len(datas.KV); i++ {
+		tree.Update(datas.KV[i].Key, datas.KV[i].Value)
+	}
+	root, err := tree.Commit(nil)
+	if err != nil {
+		mlog.Error("MemSetUpgrade Commit to memory trie fail")
+		return nil, err
+	}
+	mpts.upgradeDB.Reference(root, common.Hash{})
+	mpts.upgrades[string(root[:])] = datas.Height
+	return root[:], nil
 }
 
-// CommitUpgrade convert memcory mpt to storage db
+// CommitUpgrade 升级时只保留最新状态在内存中, 超过缓存上限或升级结束后落盘
 func (mpts *Store) CommitUpgrade(req *types.ReqHash) ([]byte, error) {
-	//not support
-	return nil, nil
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
+	height, ok := mpts.upgrades[string(req.Hash)]
+	if !ok {
+		mlog.Error("store mpt commit upgrade", "err", types.ErrHashNotFound)
+		return nil, types.ErrHashNotFound
+	}
+	delete(mpts.upgrades, string(req.Hash))
+	if mpts.upgradeDB == nil {
+		// 空区块且状态已在磁盘上
+		return req.Hash, nil
+	}
+	if mpts.upgradeRoot != nil && !bytes.Equal(mpts.upgradeRoot, req.Hash) {
+		mpts.upgradeDB.Dereference(common.BytesToHash(mpts.upgradeRoot))
+	}
+	mpts.upgradeRoot = req.Hash
+	mpts.upgradeHeight = height
+	if nodes, _ := mpts.upgradeDB.Size(); nodes > upgradeCacheSize {
+		if err := mpts.flushUpgrade(); err != nil {
+			mlog.Error("store mpt commit upgrade", "err", err)
+			return nil, types.ErrDataBaseDamage
+		}
+	}
+	return req.Hash, nil
+}
+
+// flushUpgrade 将升级重建的最新状态落盘
+func (mpts *Store) flushUpgrade() error {
+	if mpts.upgradeDB == nil || mpts.upgradeRoot == nil {
+		return nil
+	}
+	err := mpts.commitHeight(mpts.upgradeDB, mpts.upgradeRoot, mpts.upgradeHeight)
+	if err != nil {
+		return err
+	}
+	mpts.upgradeDB = nil
+	mpts.upgradeRoot = nil
+	return nil
 }
 
 // Rollback 回退将缓存的mpt树删除掉
 func (mpts *Store) Rollback(req *types.ReqHash) ([]byte, error) {
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
 	_, ok := mpts.trees[string(req.Hash)]
 	if !ok {
 		mlog.Error("store mavl rollback", "err", types.ErrHashNotFound)
 		return nil, types.ErrHashNotFound
 	}
 	delete(mpts.trees, string(req.Hash))
+	delete(mpts.heights, string(req.Hash))
 	return req.Hash, nil
 }
 
-// Del ...
+// Del 删除大于等于指定高度的状态数据, 与kvmvcc一致用于回滚本地数据
 func (mpts *Store) Del(req *types.StoreDel) ([]byte, error) {
-	//not support
-	return nil, nil
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
+	db := mpts.GetDB()
+	batch := db.NewBatch(true)
+	var roots [][]byte
+	it := db.Iterator(heightRootPrefix, nil, true)
+	for it.Rewind(); it.Valid(); it.Next() {
+		height, ok := parseHeight(it.Key(), heightRootPrefix)
+		if !ok {
+			continue
+		}
+		if height < req.Height {
+			break
+		}
+		roots = append(roots, common.CopyBytes(it.Value()))
+		batch.Delete(common.CopyBytes(it.Key()))
+		if err := delHeightNodes(db, batch, height); err != nil {
+			it.Close()
+			mlog.Error("store mpt del", "height", height, "err", err)
+			return nil, err
+		}
+	}
+	it.Close()
+	// 回滚到已裁剪的高度之前, 重新提交的高度需要再次参与裁剪
+	if loadPruneProgress(db) >= req.Height {
+		batch.Set(pruneProgressKey, types.Encode(&types.Int64{Data: req.Height - 1}))
+	}
+	// 重新提交的高度会记录索引
+	if tracked, ok := loadTrackedFrom(db); ok && tracked > req.Height {
+		batch.Set(trackedFromKey, types.Encode(&types.Int64{Data: req.Height}))
+	}
+	if err := batch.Write(); err != nil {
+		mlog.Error("store mpt del", "err", err)
+		return nil, err
+	}
+	for _, root := range roots {
+		mpts.cache.Remove(string(root))
+	}
+	mlog.Info("store mpt del", "height", req.Height, "roots", len(roots))
+	return req.StateHash, nil
+}
+
+// delHeightNodes 该高度首次写入的节点只会被该高度及之后的状态引用
+func delHeightNodes(db dbm.DB, batch dbm.Batch, height int64) error {
+	nodes, err := loadHeightNodes(db, height)
+	if err != nil {
+		return err
+	}
+	for _, hash := range nodes {
+		batch.Delete(common.CopyBytes(hash[:]))
+	}
+	batch.Delete(calcHeightNodesKey(height))
+	return nil
 }
 
 // IterateRangeByStateHash 迭代实现功能； statehash：当前状态hash, start：开始查找的key, end: 结束的key, ascending：升序，降序, fn 迭代回调函数
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
 	drivers "github.com/33cn/chain33/system/store"
 	"github.com/33cn/chain33/types"
 	"github.com/stretchr/testify/assert"
@@ -115,7 +116,6 @@ func TestKvdbMemSet(t *testing.T) {
 }
 
 func TestKvmvccdbMemSetUpgrade(t *testing.T) {
-	// not support
 	dir, err := ioutil.TempDir("", "example")
 	assert.Nil(t, err)
 	defer os.RemoveAll(dir) // clean up
@@ -123,11 +123,65 @@ func TestKvmvccdbMemSetUpgrade(t *testing.T) {
 	var storeCfg = newStoreCfg(dir)
 	store := New(storeCfg, nil, nil).(*Store)
 	assert.NotNil(t, store)
-	store.MemSetUpgrade(nil, false)
+
+	hash := drivers.EmptyRoot[:]
+	for i := 0; i < 10; i++ {
+		var kvs []*types.KeyValue
+		kvs = append(kvs, &types.KeyValue{Key: []byte(fmt.Sprintf("k%d", i)), Value: []byte(fmt.Sprintf("v%d", i))})
+		kvs = append(kvs, &types.KeyValue{Key: []byte(fmt.Sprintf("key%d", i)), Value: []byte(fmt.Sprintf("value%d", i))})
+		datas := &types.StoreSet{
+			StateHash: hash,
+			KV:        kvs,
+			Height:    int64(i)}
+
+		hash, err = store.MemSetUpgrade(datas, true)
+		assert.Nil(t, err)
+		actHash, _ := store.CommitUpgrade(&types.ReqHash{Hash: hash})
+		assert.Equal(t, hash, actHash)
+		keys := [][]byte{[]byte(fmt.Sprintf("k%d", i)), []byte(fmt.Sprintf("key%d", i)), []byte("k0")}
+		get := &types.StoreGet{StateHash: hash, Keys: keys}
+		values := store.Get(get)
+		assert.Len(t, values, 3)
+		assert.Equal(t, []byte(fmt.Sprintf("v%d", i)), values[0])
+		assert.Equal(t, []byte(fmt.Sprintf("value%d", i)), values[1])
+		assert.Equal(t, []byte("v0"), values[2])
+	}
+	notExistHash, _ := store.CommitUpgrade(&types.ReqHash{Hash: drivers.EmptyRoot[:]})
+	assert.Nil(t, notExistHash)
+
+	// 空区块沿用上一个状态
+	datas := &types.StoreSet{StateHash: hash, Height: 10}
+	emptyHash, err := store.MemSetUpgrade(datas, true)
+	assert.Nil(t, err)
+	assert.Equal(t, hash, emptyHash)
+	actHash, _ := store.CommitUpgrade(&types.ReqHash{Hash: emptyHash})
+	assert.Equal(t, hash, actHash)
+
+	// 升级重建的中间状态不落盘
+	_, err = store.GetDB().Get(calcHeightRootKey(5))
+	assert.Equal(t, dbm.ErrNotFoundInDb, err)
+	_, err = store.GetDB().Get(calcHeightRootKey(9))
+	assert.Equal(t, dbm.ErrNotFoundInDb, err)
+
+	// 继续执行普通区块时升级状态落盘
+	kv := []*types.KeyValue{{Key: []byte("k10"), Value: []byte("v10")}}
+	hash1, err := store.MemSet(&types.StoreSet{StateHash: hash, KV: kv, Height: 11}, true)
+	assert.Nil(t, err)
+	actHash, _ = store.Commit(&types.ReqHash{Hash: hash1})
+	assert.Equal(t, hash1, actHash)
+	root, err := store.GetDB().Get(calcHeightRootKey(10))
+	assert.Nil(t, err)
+	assert.Equal(t, hash, root)
+	_, err = store.GetDB().Get(calcHeightRootKey(9))
+	assert.Equal(t, dbm.ErrNotFoundInDb, err)
+
+	store.Close()
+	store = New(storeCfg, nil, nil).(*Store)
+	values := store.Get(&types.StoreGet{StateHash: hash1, Keys: [][]byte{[]byte("k0"), []byte("key9"), []byte("k10")}})
+	assert.Equal(t, [][]byte{[]byte("v0"), []byte("value9"), []byte("v10")}, values)
 }
 
 func TestKvmvccdbCommitUpgrade(t *testing.T) {
-	// not support
 	dir, err := ioutil.TempDir("", "example")
 	assert.Nil(t, err)
 	defer os.RemoveAll(dir) // clean up
@@ -135,7 +189,102 @@ func TestKvmvccdbCommitUpgrade(t *testing.T) {
 	var storeCfg = newStoreCfg(dir)
 	store := New(storeCfg, nil, nil).(*Store)
 	assert.NotNil(t, store)
-	store.CommitUpgrade(nil)
+
+	// 先正常提交, 再从头升级重建, 得到的状态hash一致
+	var hashes [][]byte
+	hash := drivers.EmptyRoot[:]
+	for i := 0; i < 20; i++ {
+		kvs := []*types.KeyValue{{Key: []byte(fmt.Sprintf("k%d", i%5)), Value: []byte(fmt.Sprintf("v%d", i))}}
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kvs, Height: int64(i)}, true)
+		assert.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		assert.Nil(t, err)
+		hashes = append(hashes, hash)
+	}
+
+	old := upgradeCacheSize
+	upgradeCacheSize = 0
+	defer func() {
+		upgradeCacheSize = old
+	}()
+	hash = drivers.EmptyRoot[:]
+	for i := 0; i < 20; i++ {
+		kvs := []*types.KeyValue{{Key: []byte(fmt.Sprintf("k%d", i%5)), Value: []byte(fmt.Sprintf("v%d", i))}}
+		hash, err = store.MemSetUpgrade(&types.StoreSet{StateHash: hash, KV: kvs, Height: int64(i)}, true)
+		assert.Nil(t, err)
+		assert.Equal(t, hashes[i], hash)
+		actHash, err := store.CommitUpgrade(&types.ReqHash{Hash: hash})
+		assert.Nil(t, err)
+		assert.Equal(t, hash, actHash)
+	}
+	store.Close()
+}
+
+func TestKvdbDel(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir) // clean up
+	os.RemoveAll(dir)       //删除已存在目录
+	var storeCfg = newStoreCfg(dir)
+	store := New(storeCfg, nil, nil).(*Store)
+	assert.NotNil(t, store)
+
+	var kv []*types.KeyValue
+	kv = append(kv, &types.KeyValue{Key: []byte("mk1"), Value: []byte("v1")})
+	kv = append(kv, &types.KeyValue{Key: []byte("mk2"), Value: []byte("v2")})
+	datas := &types.StoreSet{
+		StateHash: drivers.EmptyRoot[:],
+		KV:        kv,
+		Height:    0}
+	hash, err := store.Set(datas, true)
+	assert.Nil(t, err)
+
+	hashes := [][]byte{hash}
+	for i := 1; i <= 20; i++ {
+		kvs := []*types.KeyValue{
+			{Key: []byte("mk1"), Value: []byte(fmt.Sprintf("v1-%03d", i))},
+			{Key: []byte(fmt.Sprintf("mk%d", i+2)), Value: []byte(fmt.Sprintf("v%d", i+2))},
+		}
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kvs, Height: int64(i)}, true)
+		assert.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		assert.Nil(t, err)
+		hashes = append(hashes, hash)
+	}
+	keys := [][]byte{[]byte("mk1"), []byte("mk2"), []byte("mk12")}
+	values := store.Get(&types.StoreGet{StateHash: hashes[20], Keys: keys})
+	assert.Equal(t, [][]byte{[]byte("v1-020"), []byte("v2"), []byte("v12")}, values)
+
+	// 删除高度10及之后的状态
+	actHash, err := store.Del(&types.StoreDel{StateHash: hashes[10], Height: 10})
+	assert.Nil(t, err)
+	assert.Equal(t, hashes[10], actHash)
+	for i := 10; i <= 20; i++ {
+		_, err = store.GetDB().Get(calcHeightRootKey(int64(i)))
+		assert.Equal(t, dbm.ErrNotFoundInDb, err)
+		values = store.Get(&types.StoreGet{StateHash: hashes[i], Keys: keys})
+		assert.Nil(t, values[0])
+	}
+	for i := 0; i < 10; i++ {
+		values = store.Get(&types.StoreGet{StateHash: hashes[i], Keys: keys})
+		assert.Equal(t, []byte("v2"), values[1])
+	}
+	values = store.Get(&types.StoreGet{StateHash: hashes[9], Keys: keys})
+	assert.Equal(t, [][]byte{[]byte("v1-009"), []byte("v2"), nil}, values)
+
+	// 回滚后重新执行
+	kv2 := []*types.KeyValue{{Key: []byte("mk1"), Value: []byte("v11")}}
+	hash, err = store.MemSet(&types.StoreSet{StateHash: hashes[9], KV: kv2, Height: 10}, true)
+	assert.Nil(t, err)
+	_, err = store.Commit(&types.ReqHash{Hash: hash})
+	assert.Nil(t, err)
+	values = store.Get(&types.StoreGet{StateHash: hash, Keys: keys})
+	assert.Equal(t, [][]byte{[]byte("v11"), []byte("v2"), nil}, values)
+
+	// 没有大于等于该高度的数据
+	actHash, err = store.Del(&types.StoreDel{StateHash: hash, Height: 100})
+	assert.Nil(t, err)
+	assert.Equal(t, hash, actHash)
 }
 
 func TestKvdbRollback(t *testing.T) {
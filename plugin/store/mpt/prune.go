@@ -0,0 +1,402 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+)
+
+var (
+	// 裁剪进度, 已裁剪到的高度
+	pruneProgressKey = []byte("mpt-prune-progress")
+	// 开始记录高度索引的高度, 更早提交的高度没有索引, 无法确定状态是否完整
+	trackedFromKey = []byte("mpt-tracked-from")
+	// 每轮最多裁剪的高度数, 控制持有写锁的时间
+	pruneBatchHeights int64 = 100
+)
+
+const (
+	defaultPruneKeepHeights = 10000
+	defaultPruneInterval    = 1000
+)
+
+type subConfig struct {
+	// 开启历史状态裁剪
+	EnablePrune bool `json:"enablePrune"`
+	// 保留最近多少个高度的状态
+	PruneKeepHeights int64 `json:"pruneKeepHeights"`
+	// 每隔多少个高度保留一个检查点状态, 0表示不保留检查点
+	PruneCheckpointInterval int64 `json:"pruneCheckpointInterval"`
+	// 固定保留的高度
+	PinHeights []int64 `json:"pinHeights"`
+	// 每提交多少个高度触发一次裁剪
+	PruneInterval int64 `json:"pruneInterval"`
+}
+
+// HeightRange 高度区间[Start, End]
+type HeightRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// ReadableHeights 仍然可以读取状态的高度
+// 只报告有高度索引的高度, TrackedFrom之前的高度在开始记录索引之前提交, 不报告为可读, -1表示还没有记录
+type ReadableHeights struct {
+	Ranges      []*HeightRange `json:"ranges"`
+	PruneHeight int64          `json:"pruneHeight"`
+	TrackedFrom int64          `json:"trackedFrom"`
+}
+
+// pruner 后台裁剪历史状态, 保留最近keep个高度和检查点高度
+type pruner struct {
+	keep       int64
+	checkpoint int64
+	interval   int64
+	pinned     map[int64]bool
+	notifyC    chan int64
+	done       chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newPruner(cfg *subConfig) *pruner {
+	p := &pruner{
+		keep:       cfg.PruneKeepHeights,
+		checkpoint: cfg.PruneCheckpointInterval,
+		interval:   cfg.PruneInterval,
+		pinned:     make(map[int64]bool),
+		notifyC:    make(chan int64, 1),
+		done:       make(chan struct{}),
+	}
+	if p.keep <= 0 {
+		p.keep = defaultPruneKeepHeights
+	}
+	if p.interval <= 0 {
+		p.interval = defaultPruneInterval
+	}
+	for _, height := range cfg.PinHeights {
+		p.pinned[height] = true
+	}
+	return p
+}
+
+func (p *pruner) isPinned(height int64) bool {
+	if p.pinned[height] {
+		return true
+	}
+	return p.checkpoint > 0 && height%p.checkpoint == 0
+}
+
+// notify 提交新高度后触发裁剪, 不阻塞提交流程
+func (p *pruner) notify(height int64) {
+	if height <= 0 || height%p.interval != 0 {
+		return
+	}
+	select {
+	case p.notifyC <- height:
+	default:
+	}
+}
+
+func (p *pruner) stop() {
+	close(p.done)
+	p.wg.Wait()
+}
+
+func (mpts *Store) pruneLoop() {
+	p := mpts.pruner
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-p.notifyC:
+		}
+		for {
+			more, err := mpts.pruneOnce()
+			if err != nil {
+				mlog.Error("store mpt prune", "err", err)
+				break
+			}
+			if !more {
+				break
+			}
+			select {
+			case <-p.done:
+				return
+			default:
+			}
+		}
+	}
+}
+
+// prunePlan 一轮裁剪的候选节点和需要保留的状态
+type prunePlan struct {
+	end   int64
+	roots map[int64]common.Hash
+	// 待裁剪高度首次写入的节点
+	candidates map[common.Hash]bool
+	// 保留高度首次写入的节点
+	kept        map[common.Hash]bool
+	keptRoots   []common.Hash
+	visited     map[common.Hash]bool
+	pruned      []int64
+	prunedRoots []common.Hash
+}
+
+// collect 读取(last, tip]之间各高度的root和首次写入的节点
+func (plan *prunePlan) collect(db dbm.DB, p *pruner, from, to int64) error {
+	for height := from; height <= to; height++ {
+		root, err := db.Get(calcHeightRootKey(height))
+		if err != nil {
+			continue
+		}
+		nodes, err := loadHeightNodes(db, height)
+		if err != nil {
+			return err
+		}
+		plan.roots[height] = common.BytesToHash(root)
+		if height <= plan.end && !p.isPinned(height) {
+			plan.pruned = append(plan.pruned, height)
+			plan.prunedRoots = append(plan.prunedRoots, common.BytesToHash(root))
+			for _, hash := range nodes {
+				plan.candidates[hash] = true
+			}
+			continue
+		}
+		plan.keptRoots = append(plan.keptRoots, common.BytesToHash(root))
+		for _, hash := range nodes {
+			plan.kept[hash] = true
+		}
+	}
+	return nil
+}
+
+// mark 保留状态可达的候选节点不删除, 已访问的节点不重复遍历
+func (plan *prunePlan) mark(database *mpt.Database, hash common.Hash) error {
+	if plan.visited[hash] {
+		return nil
+	}
+	plan.visited[hash] = true
+	// 更早高度写入的节点, 其子节点也更早, 不可能是候选节点
+	if !plan.candidates[hash] && !plan.kept[hash] {
+		return nil
+	}
+	delete(plan.candidates, hash)
+	children, err := database.NodeChildren(hash)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if err = plan.mark(database, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markKept 从第from个保留状态开始标记
+func (plan *prunePlan) markKept(database *mpt.Database, from int) error {
+	for _, root := range plan.keptRoots[from:] {
+		if err := plan.mark(database, root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneOnce 裁剪一批高度, 返回是否还有需要裁剪的高度
+// 某高度首次写入的节点只可能被该高度及之后的状态引用, 因此只需从保留的较新状态出发标记候选节点
+// 标记阶段不持有锁, 删除前在锁内补充标记期间新提交的高度, 期间发生回滚则放弃本轮
+func (mpts *Store) pruneOnce() (bool, error) {
+	p := mpts.pruner
+	db := mpts.GetDB()
+	mpts.mu.Lock()
+	// 升级重建期间状态还在内存中, 暂不裁剪
+	upgrading := mpts.upgradeDB != nil
+	tip, ok := maxHeight(db)
+	last := loadPruneProgress(db)
+	mpts.mu.Unlock()
+	if upgrading || !ok {
+		return false, nil
+	}
+	target := tip - p.keep
+	if target <= last {
+		return false, nil
+	}
+	end := last + pruneBatchHeights
+	if end > target {
+		end = target
+	}
+	plan := &prunePlan{
+		end:        end,
+		roots:      make(map[int64]common.Hash),
+		candidates: make(map[common.Hash]bool),
+		kept:       make(map[common.Hash]bool),
+		visited:    make(map[common.Hash]bool),
+	}
+	if err := plan.collect(db, p, last+1, tip); err != nil {
+		return false, err
+	}
+	database := mpt.NewDatabase(db)
+	if err := plan.markKept(database, 0); err != nil {
+		return false, err
+	}
+
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
+	if mpts.upgradeDB != nil {
+		return false, nil
+	}
+	if loadPruneProgress(db) != last {
+		return true, nil
+	}
+	newTip, ok := maxHeight(db)
+	if !ok || newTip < tip {
+		return true, nil
+	}
+	for height, root := range plan.roots {
+		value, err := db.Get(calcHeightRootKey(height))
+		if err != nil || common.BytesToHash(value) != root {
+			// 标记期间发生回滚, 重新规划
+			return true, nil
+		}
+	}
+	// 标记期间新提交的高度
+	if newTip > tip {
+		from := len(plan.keptRoots)
+		if err := plan.collect(db, p, tip+1, newTip); err != nil {
+			return false, err
+		}
+		if err := plan.markKept(database, from); err != nil {
+			return false, err
+		}
+	}
+
+	// sweep
+	batch := db.NewBatch(true)
+	for hash := range plan.candidates {
+		batch.Delete(common.CopyBytes(hash[:]))
+	}
+	for _, height := range plan.pruned {
+		batch.Delete(calcHeightRootKey(height))
+		batch.Delete(calcHeightNodesKey(height))
+	}
+	batch.Set(pruneProgressKey, types.Encode(&types.Int64{Data: end}))
+	if err := batch.Write(); err != nil {
+		return false, err
+	}
+	for _, root := range plan.prunedRoots {
+		mpts.cache.Remove(string(root[:]))
+	}
+	mlog.Info("store mpt prune", "from", last+1, "to", end, "heights", len(plan.pruned), "nodes", len(plan.candidates))
+	return end < target, nil
+}
+
+func loadPruneProgress(db dbm.DB) int64 {
+	value, err := db.Get(pruneProgressKey)
+	if err != nil {
+		return -1
+	}
+	progress := &types.Int64{}
+	if err = types.Decode(value, progress); err != nil {
+		panic(err)
+	}
+	return progress.Data
+}
+
+func loadHeightNodes(db dbm.DB, height int64) ([]common.Hash, error) {
+	value, err := db.Get(calcHeightNodesKey(height))
+	if err == dbm.ErrNotFoundInDb {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	nodes := &types.ReplyHashes{}
+	if err = types.Decode(value, nodes); err != nil {
+		return nil, err
+	}
+	hashes := make([]common.Hash, 0, len(nodes.Hashes))
+	for _, hash := range nodes.Hashes {
+		hashes = append(hashes, common.BytesToHash(hash))
+	}
+	return hashes, nil
+}
+
+func parseHeight(key, prefix []byte) (int64, bool) {
+	var height int64
+	if _, err := fmt.Sscanf(string(key[len(prefix):]), "%d", &height); err != nil {
+		return 0, false
+	}
+	return height, true
+}
+
+func maxHeight(db dbm.DB) (int64, bool) {
+	it := db.Iterator(heightRootPrefix, nil, true)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		if height, ok := parseHeight(it.Key(), heightRootPrefix); ok {
+			return height, true
+		}
+	}
+	return 0, false
+}
+
+// loadTrackedFrom 返回开始记录高度索引的高度
+func loadTrackedFrom(db dbm.DB) (int64, bool) {
+	value, err := db.Get(trackedFromKey)
+	if err != nil {
+		return -1, false
+	}
+	tracked := &types.Int64{}
+	if err = types.Decode(value, tracked); err != nil {
+		panic(err)
+	}
+	return tracked.Data, true
+}
+
+// setTrackedFrom 首次记录高度索引时保存起始高度, 已有索引的旧数据以最低的索引高度为准
+func setTrackedFrom(db dbm.DB, batch dbm.Batch, height int64) {
+	if _, ok := loadTrackedFrom(db); ok {
+		return
+	}
+	it := db.Iterator(heightRootPrefix, nil, false)
+	for it.Rewind(); it.Valid(); it.Next() {
+		if min, ok := parseHeight(it.Key(), heightRootPrefix); ok && min < height {
+			height = min
+		}
+		break
+	}
+	it.Close()
+	batch.Set(trackedFromKey, types.Encode(&types.Int64{Data: height}))
+}
+
+// GetReadableHeights 返回仍保存完整状态的高度区间
+func GetReadableHeights(db dbm.DB) *ReadableHeights {
+	tracked, _ := loadTrackedFrom(db)
+	reply := &ReadableHeights{PruneHeight: loadPruneProgress(db), TrackedFrom: tracked}
+	it := db.Iterator(heightRootPrefix, nil, false)
+	defer it.Close()
+	var cur *HeightRange
+	for it.Rewind(); it.Valid(); it.Next() {
+		height, ok := parseHeight(it.Key(), heightRootPrefix)
+		if !ok {
+			continue
+		}
+		if cur != nil && height == cur.End+1 {
+			cur.End = height
+			continue
+		}
+		cur = &HeightRange{Start: height, End: height}
+		reply.Ranges = append(reply.Ranges, cur)
+	}
+	return reply
+}
@@ -0,0 +1,139 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newPruneStore(t *testing.T, dir string) *Store {
+	sub, err := json.Marshal(&subConfig{
+		EnablePrune:             true,
+		PruneKeepHeights:        5,
+		PruneCheckpointInterval: 10,
+		PinHeights:              []int64{3},
+		// 测试中手动触发裁剪
+		PruneInterval: 1 << 30,
+	})
+	require.Nil(t, err)
+	return New(newStoreCfg(dir), sub, nil).(*Store)
+}
+
+// commitHeights 每个高度修改部分key, 其余key在各状态之间共享
+func commitHeights(t *testing.T, store *Store, from, to int64, hash []byte) ([][]byte, []byte) {
+	var hashes [][]byte
+	for i := from; i <= to; i++ {
+		kvs := []*types.KeyValue{
+			{Key: []byte(fmt.Sprintf("k%d", i%7)), Value: []byte(fmt.Sprintf("v%d", i))},
+			{Key: []byte(fmt.Sprintf("height%d", i)), Value: []byte(fmt.Sprintf("%d", i))},
+		}
+		var err error
+		hash, err = store.MemSet(&types.StoreSet{StateHash: hash, KV: kvs, Height: i}, true)
+		require.Nil(t, err)
+		_, err = store.Commit(&types.ReqHash{Hash: hash})
+		require.Nil(t, err)
+		hashes = append(hashes, hash)
+	}
+	return hashes, hash
+}
+
+func countKeys(store *Store, hash []byte) int {
+	count := 0
+	store.IterateRangeByStateHash(hash, nil, nil, true, func(key, value []byte) bool {
+		count++
+		return false
+	})
+	return count
+}
+
+func pruneAll(t *testing.T, store *Store) {
+	for {
+		more, err := store.pruneOnce()
+		require.Nil(t, err)
+		if !more {
+			return
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	store := newPruneStore(t, dir)
+
+	hashes, _ := commitHeights(t, store, 0, 29, drivers.EmptyRoot[:])
+	nodesBefore := countDBKeys(store)
+
+	// 模拟裁剪中途重启
+	old := pruneBatchHeights
+	pruneBatchHeights = 4
+	defer func() {
+		pruneBatchHeights = old
+	}()
+	more, err := store.pruneOnce()
+	require.Nil(t, err)
+	require.True(t, more)
+	require.Equal(t, int64(3), loadPruneProgress(store.GetDB()))
+	store.Close()
+	store = newPruneStore(t, dir)
+	defer store.Close()
+	pruneAll(t, store)
+	require.Equal(t, int64(24), loadPruneProgress(store.GetDB()))
+	require.True(t, countDBKeys(store) < nodesBefore)
+
+	readable := GetReadableHeights(store.GetDB())
+	require.Equal(t, int64(24), readable.PruneHeight)
+	require.Equal(t, int64(0), readable.TrackedFrom)
+	require.Equal(t, []*HeightRange{{0, 0}, {3, 3}, {10, 10}, {20, 20}, {25, 29}}, readable.Ranges)
+	for i, hash := range hashes {
+		height := int64(i)
+		keys := [][]byte{[]byte(fmt.Sprintf("height%d", i)), []byte("height0")}
+		values := store.Get(&types.StoreGet{StateHash: hash, Keys: keys})
+		if height == 0 || height == 3 || height == 10 || height == 20 || height >= 25 {
+			require.Equal(t, []byte(fmt.Sprint(i)), values[0], "height %d", i)
+			require.Equal(t, []byte("0"), values[1], "height %d", i)
+			require.Equal(t, stateKeys(i), countKeys(store, hash), "height %d", i)
+		} else {
+			require.Nil(t, values[0], "height %d", i)
+		}
+	}
+
+	// 继续提交后可以再次裁剪, 回滚到裁剪高度之前会重置进度
+	hashes2, _ := commitHeights(t, store, 30, 34, hashes[29])
+	pruneAll(t, store)
+	require.Equal(t, int64(29), loadPruneProgress(store.GetDB()))
+	_, err = store.Del(&types.StoreDel{StateHash: hashes2[0], Height: 28})
+	require.Nil(t, err)
+	require.Equal(t, int64(27), loadPruneProgress(store.GetDB()))
+	readable = GetReadableHeights(store.GetDB())
+	require.Equal(t, []*HeightRange{{0, 0}, {3, 3}, {10, 10}, {20, 20}}, readable.Ranges)
+}
+
+// stateKeys 高度i的状态中k0-k6最多7个key, 加上每个高度一个key
+func stateKeys(i int) int {
+	if i < 6 {
+		return 2 * (i + 1)
+	}
+	return 7 + i + 1
+}
+
+func countDBKeys(store *Store) int {
+	count := 0
+	it := store.GetDB().Iterator(nil, nil, false)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		count++
+	}
+	return count
+}
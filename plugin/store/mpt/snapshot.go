@@ -0,0 +1,89 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+)
+
+// ImportSnapshot 从快照重建快照高度的trie, 返回重建的roothash
+// 内存中的节点超过upgradeCacheSize后先落盘, 所有落盘的节点都记在快照高度下, 回滚时一起删除
+func (mpts *Store) ImportSnapshot(header *snapshot.Header, next func() ([]*types.KeyValue, error)) ([]byte, error) {
+	mpts.mu.Lock()
+	defer mpts.mu.Unlock()
+	db := mpts.GetDB()
+	if _, ok := maxHeight(db); ok {
+		return nil, snapshot.ErrStoreNotEmpty
+	}
+	database := mpt.NewDatabase(db)
+	tree, err := mpt.NewEx(common.Hash{}, database)
+	if err != nil {
+		return nil, err
+	}
+	var fresh []common.Hash
+	for {
+		kvs, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range kvs {
+			if err = tree.TryUpdate(kv.Key, kv.Value); err != nil {
+				return nil, err
+			}
+		}
+		if nodes, _ := database.Size(); nodes <= upgradeCacheSize {
+			continue
+		}
+		root, err := tree.Commit(nil)
+		if err != nil {
+			return nil, err
+		}
+		hashes, err := database.CommitNodes(root, false)
+		if err != nil {
+			return nil, err
+		}
+		fresh = append(fresh, hashes...)
+		if tree, err = mpt.NewEx(root, database); err != nil {
+			return nil, err
+		}
+	}
+	root, err := tree.Commit(nil)
+	if err != nil {
+		return nil, err
+	}
+	hashes, err := database.CommitNodes(root, true)
+	if err != nil {
+		return nil, err
+	}
+	fresh = append(fresh, hashes...)
+	// 与区块头不一致时不记录高度索引, 节点不会被当作有效状态
+	if !bytes.Equal(root[:], header.Hash()) {
+		mlog.Error("store mpt import snapshot", "root", common.ToHex(root[:]), "stateHash", header.StateHash)
+		return nil, snapshot.ErrRootMismatch
+	}
+	nodes := &types.ReplyHashes{}
+	for _, hash := range fresh {
+		nodes.Hashes = append(nodes.Hashes, common.CopyBytes(hash[:]))
+	}
+	batch := db.NewBatch(true)
+	batch.Set(calcHeightRootKey(header.Height), root[:])
+	batch.Set(calcHeightNodesKey(header.Height), types.Encode(nodes))
+	setTrackedFrom(db, batch, header.Height)
+	if err = batch.Write(); err != nil {
+		return nil, err
+	}
+	mpts.tracked = true
+	mlog.Info("store mpt import snapshot", "height", header.Height, "root", common.ToHex(root[:]), "nodes", len(fresh))
+	return common.CopyBytes(root[:]), nil
+}
@@ -0,0 +1,88 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	src := New(newStoreCfg(dir), nil, nil).(*Store)
+	defer src.Close()
+	hashes, _ := commitHeights(t, src, 0, 9, drivers.EmptyRoot[:])
+
+	var buf bytes.Buffer
+	header := &snapshot.Header{Driver: "mpt", Height: 9, StateHash: common.ToHex(hashes[9]), ChunkSize: 64}
+	keys, err := snapshot.Export(src, header, &buf)
+	require.Nil(t, err)
+	require.Equal(t, uint64(stateKeys(9)), keys)
+
+	dir2, err := ioutil.TempDir("", "example")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir2)
+	dst := New(newStoreCfg(dir2), nil, nil).(*Store)
+	defer dst.Close()
+	// 内存中的节点超过上限时分批落盘
+	old := upgradeCacheSize
+	upgradeCacheSize = 0
+	_, err = snapshot.Import(bytes.NewReader(buf.Bytes()), dst, hashes[9], 9)
+	upgradeCacheSize = old
+	require.Nil(t, err)
+	require.Equal(t, stateKeys(9), countKeys(dst, hashes[9]))
+	values := dst.Get(&types.StoreGet{StateHash: hashes[9], Keys: [][]byte{[]byte("k2"), []byte("height0")}})
+	require.Equal(t, [][]byte{[]byte("v9"), []byte("0")}, values)
+	readable := GetReadableHeights(dst.GetDB())
+	require.Equal(t, []*HeightRange{{9, 9}}, readable.Ranges)
+	// 快照之前的高度没有状态, 不能报告为可读
+	require.Equal(t, int64(9), readable.TrackedFrom)
+
+	// 导入后继续执行区块, 状态与源节点一致
+	srcHashes, _ := commitHeights(t, src, 10, 12, hashes[9])
+	dstHashes, _ := commitHeights(t, dst, 10, 12, hashes[9])
+	require.Equal(t, srcHashes, dstHashes)
+
+	// 回滚到快照高度之后, 快照状态仍然完整
+	_, err = dst.Del(&types.StoreDel{StateHash: dstHashes[0], Height: 10})
+	require.Nil(t, err)
+	require.Equal(t, stateKeys(9), countKeys(dst, hashes[9]))
+
+	_, err = snapshot.Import(bytes.NewReader(buf.Bytes()), dst, hashes[9], 9)
+	require.Equal(t, snapshot.ErrStoreNotEmpty, err)
+}
+
+func TestSnapshotRootMismatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "example")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	store := New(newStoreCfg(dir), nil, nil).(*Store)
+	defer store.Close()
+
+	// 快照文件声称的状态hash与其中的数据不符
+	var buf bytes.Buffer
+	fake := common.Sha256([]byte("fake"))
+	sw, err := snapshot.NewWriter(&buf, &snapshot.Header{Driver: "mpt", Height: 5, StateHash: common.ToHex(fake)})
+	require.Nil(t, err)
+	for i := 0; i < 10; i++ {
+		require.Nil(t, sw.Add([]byte(fmt.Sprintf("k%d", i)), []byte("v")))
+	}
+	require.Nil(t, sw.Close())
+	_, err = snapshot.Import(bytes.NewReader(buf.Bytes()), store, fake, 5)
+	require.Equal(t, snapshot.ErrRootMismatch, err)
+	_, ok := maxHeight(store.GetDB())
+	require.False(t, ok)
+}
@@ -0,0 +1,34 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mpt
+
+import (
+	"bytes"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	mpt "github.com/33cn/plugin/plugin/store/mpt/db"
+	"github.com/33cn/plugin/plugin/store/tools"
+)
+
+// VerifyState 检查高度height提交的roothash和状态hash一致, 并且根节点在数据库中
+func (mpts *Store) VerifyState(height int64, prevHash, stateHash []byte) error {
+	db := mpts.GetDB()
+	root, err := db.Get(calcHeightRootKey(height))
+	if err == dbm.ErrNotFoundInDb {
+		return tools.ErrStateNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, stateHash) {
+		return tools.ErrStateHash
+	}
+	if _, err = mpt.NewEx(common.BytesToHash(root), mpt.NewDatabase(db)); err != nil {
+		mlog.Error("VerifyState load root", "height", height, "root", common.ToHex(root), "err", err)
+		return err
+	}
+	return nil
+}
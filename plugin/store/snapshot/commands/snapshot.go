@@ -0,0 +1,190 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/snapshot"
+	"github.com/spf13/cobra"
+)
+
+// SnapshotCmd store snapshot cmd register
+func SnapshotCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Export or import store state snapshot, the node must be stopped",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		ExportCmd(),
+		ImportCmd(),
+		VerifyCmd(),
+	)
+	return cmd
+}
+
+// ExportCmd export state snapshot
+func ExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export all key/values of a state hash to snapshot file",
+		Run:   export,
+	}
+	addExportFlags(cmd)
+	return cmd
+}
+
+func addExportFlags(cmd *cobra.Command) {
+	addStoreFlags(cmd)
+	cmd.Flags().Int("chunk", snapshot.DefaultChunkSize, "chunk size in bytes")
+}
+
+func addStoreFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("conf", "c", "chain33.toml", "node config file, locate the store")
+	cmd.Flags().StringP("hash", "s", "", "state hash in block header")
+	cmd.MarkFlagRequired("hash")
+	cmd.Flags().Int64P("height", "t", 0, "block height of the state hash")
+	cmd.MarkFlagRequired("height")
+	cmd.Flags().StringP("file", "f", "", "snapshot file")
+	cmd.MarkFlagRequired("file")
+}
+
+func export(cmd *cobra.Command, args []string) {
+	conf, _ := cmd.Flags().GetString("conf")
+	hash, _ := cmd.Flags().GetString("hash")
+	height, _ := cmd.Flags().GetInt64("height")
+	file, _ := cmd.Flags().GetString("file")
+	chunk, _ := cmd.Flags().GetInt("chunk")
+
+	st, driver, err := openStore(conf)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer st.Close()
+	iter, ok := st.(snapshot.Iterator)
+	if !ok {
+		fmt.Fprintln(os.Stderr, snapshot.ErrNotSupported, driver)
+		return
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer f.Close()
+	header := &snapshot.Header{Driver: driver, Height: height, StateHash: hash, ChunkSize: chunk}
+	keys, err := snapshot.Export(iter, header, f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Remove(file)
+		return
+	}
+	fmt.Printf("exported %d keys at height %d to %s\n", keys, height, file)
+}
+
+// ImportCmd import state snapshot
+func ImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import snapshot file into an empty store, check rebuilt state against block header state hash, mpt store only",
+		Long: "Import snapshot file into an empty store, check rebuilt state against block header state hash.\n" +
+			"Only the store is rebuilt, the blockchain db must already contain blocks up to the snapshot height,\n" +
+			"the node then continues executing from the next block. Only mpt store supports import.",
+		Run: importSnapshot,
+	}
+	addStoreFlags(cmd)
+	return cmd
+}
+
+func importSnapshot(cmd *cobra.Command, args []string) {
+	conf, _ := cmd.Flags().GetString("conf")
+	hash, _ := cmd.Flags().GetString("hash")
+	height, _ := cmd.Flags().GetInt64("height")
+	file, _ := cmd.Flags().GetString("file")
+
+	stateHash, err := common.FromHex(hash)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer f.Close()
+	st, driver, err := openStore(conf)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer st.Close()
+	importer, ok := st.(snapshot.Importer)
+	if !ok {
+		fmt.Fprintln(os.Stderr, snapshot.ErrNotSupported, driver)
+		return
+	}
+	header, err := snapshot.Import(f, importer, stateHash, height)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if err != snapshot.ErrStoreNotEmpty && err != snapshot.ErrStateHash && err != snapshot.ErrHeight {
+			fmt.Fprintln(os.Stderr, "store may contain partial state, remove the store directory before retry")
+		}
+		return
+	}
+	if header.Driver != driver {
+		fmt.Fprintf(os.Stderr, "warning: snapshot exported from %s store, imported into %s\n", header.Driver, driver)
+	}
+	fmt.Printf("imported snapshot at height %d, state hash %s\n", header.Height, header.StateHash)
+}
+
+// VerifyCmd verify snapshot file checksums
+func VerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify checksums of snapshot file",
+		Run:   verify,
+	}
+	cmd.Flags().StringP("file", "f", "", "snapshot file")
+	cmd.MarkFlagRequired("file")
+	return cmd
+}
+
+func verify(cmd *cobra.Command, args []string) {
+	file, _ := cmd.Flags().GetString("file")
+	f, err := os.Open(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer f.Close()
+	header, keys, err := snapshot.Verify(f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	fmt.Printf("driver: %s\nheight: %d\nstateHash: %s\nkeys: %d\n", header.Driver, header.Height, header.StateHash, keys)
+}
+
+// openStore 按节点配置打开store, 不注册到queue
+func openStore(conf string) (queue.Module, string, error) {
+	if _, err := os.Stat(conf); err != nil {
+		return nil, "", err
+	}
+	cfg := types.NewChain33Config(types.ReadFile(conf))
+	mcfg := cfg.GetModuleConfig().Store
+	if mcfg == nil {
+		return nil, "", errors.New("store config not found")
+	}
+	return store.New(cfg), mcfg.Name, nil
+}
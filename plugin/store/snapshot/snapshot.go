@@ -0,0 +1,367 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package snapshot 导出和导入某个状态hash下的全部kv
+//
+// 只重建store, 不初始化blockchain: 导入的节点必须已经有快照高度及之前的区块数据(例如拷贝的blockchain数据库),
+// 启动后从快照高度的下一个区块继续执行. 只有能从kv重新计算状态hash的store(mpt)支持导入
+//
+// 文件格式:
+//
+//	magic(8) | headerLen(4) | header(json)
+//	{ chunkLen(4) | chunk(types.LocalDBSet) | sha256(chunk) }*
+//	0(4) | keys(8) | sha256(所有chunk的校验和)
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/33cn/chain33/common"
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/types"
+)
+
+var slog = log.New("module", "store.snapshot")
+
+const (
+	// Version 快照文件格式版本
+	Version = 1
+	// DefaultChunkSize 默认每个chunk的大小
+	DefaultChunkSize = 4 * 1024 * 1024
+	// 单个chunk的上限, 防止损坏的文件导致分配过大的内存
+	maxChunkSize = 256 * 1024 * 1024
+)
+
+var magic = []byte("C33SNAP\x00")
+
+var (
+	ErrBadMagic        = errors.New("ErrSnapshotBadMagic")
+	ErrBadVersion      = errors.New("ErrSnapshotBadVersion")
+	ErrChunkChecksum   = errors.New("ErrSnapshotChunkChecksum")
+	ErrChunkTooLarge   = errors.New("ErrSnapshotChunkTooLarge")
+	ErrFileChecksum    = errors.New("ErrSnapshotFileChecksum")
+	ErrKeyCount        = errors.New("ErrSnapshotKeyCount")
+	ErrEmptyState      = errors.New("ErrSnapshotEmptyState")
+	ErrStateHash       = errors.New("ErrSnapshotStateHash")
+	ErrHeight          = errors.New("ErrSnapshotHeight")
+	ErrRootMismatch    = errors.New("ErrSnapshotRootMismatch")
+	ErrNotSupported    = errors.New("ErrSnapshotNotSupported")
+	ErrStoreNotEmpty   = errors.New("ErrSnapshotStoreNotEmpty")
+	ErrUnexpectedChunk = errors.New("ErrSnapshotUnexpectedChunk")
+)
+
+// Header 快照文件头
+type Header struct {
+	Version   int    `json:"version"`
+	Driver    string `json:"driver"`
+	Height    int64  `json:"height"`
+	StateHash string `json:"stateHash"`
+	ChunkSize int    `json:"chunkSize"`
+}
+
+// Hash 返回状态hash
+func (h *Header) Hash() []byte {
+	hash, err := common.FromHex(h.StateHash)
+	if err != nil || len(hash) == 0 {
+		return nil
+	}
+	return hash
+}
+
+// Iterator 可以按状态hash遍历的store, 各store插件都实现了该接口
+type Iterator interface {
+	IterateRangeByStateHash(statehash []byte, start []byte, end []byte, ascending bool, fn func(key, value []byte) bool)
+}
+
+// Importer 由store插件实现, 从快照中重建状态
+// next 依次返回每个chunk中的kv, 读完时返回 io.EOF
+// 返回重建后的状态hash, 由调用者和区块头中的状态hash比较
+type Importer interface {
+	ImportSnapshot(header *Header, next func() ([]*types.KeyValue, error)) ([]byte, error)
+}
+
+// Writer 按chunk写入快照
+type Writer struct {
+	w         *bufio.Writer
+	chunkSize int
+	kvs       []*types.KeyValue
+	size      int
+	keys      uint64
+	sum       []byte
+	chunks    int
+}
+
+// NewWriter 写入文件头并返回Writer
+func NewWriter(w io.Writer, header *Header) (*Writer, error) {
+	header.Version = Version
+	if header.ChunkSize <= 0 {
+		header.ChunkSize = DefaultChunkSize
+	}
+	data, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	bw := bufio.NewWriter(w)
+	if _, err = bw.Write(magic); err != nil {
+		return nil, err
+	}
+	if err = writeBytes(bw, data); err != nil {
+		return nil, err
+	}
+	return &Writer{w: bw, chunkSize: header.ChunkSize, sum: make([]byte, 0, sha256.Size)}, nil
+}
+
+// Add 添加一个kv, 达到chunk大小后写入
+func (sw *Writer) Add(key, value []byte) error {
+	sw.kvs = append(sw.kvs, &types.KeyValue{Key: common.CopyBytes(key), Value: common.CopyBytes(value)})
+	sw.size += len(key) + len(value)
+	sw.keys++
+	if sw.size >= sw.chunkSize {
+		return sw.flush()
+	}
+	return nil
+}
+
+func (sw *Writer) flush() error {
+	if len(sw.kvs) == 0 {
+		return nil
+	}
+	data := types.Encode(&types.LocalDBSet{KV: sw.kvs})
+	if err := writeBytes(sw.w, data); err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	if _, err := sw.w.Write(sum[:]); err != nil {
+		return err
+	}
+	fileSum := sha256.Sum256(append(sw.sum, sum[:]...))
+	sw.sum = fileSum[:]
+	sw.kvs = nil
+	sw.size = 0
+	sw.chunks++
+	return nil
+}
+
+// Close 写入最后一个chunk和文件尾
+func (sw *Writer) Close() error {
+	if err := sw.flush(); err != nil {
+		return err
+	}
+	var buf [12]byte
+	binary.BigEndian.PutUint64(buf[4:], sw.keys)
+	if _, err := sw.w.Write(buf[:]); err != nil {
+		return err
+	}
+	if _, err := sw.w.Write(sw.fileSum()); err != nil {
+		return err
+	}
+	return sw.w.Flush()
+}
+
+func (sw *Writer) fileSum() []byte {
+	if len(sw.sum) == 0 {
+		sum := sha256.Sum256(nil)
+		return sum[:]
+	}
+	return sw.sum
+}
+
+// Reader 按chunk读取快照并校验
+type Reader struct {
+	r      *bufio.Reader
+	header *Header
+	keys   uint64
+	sum    []byte
+	done   bool
+}
+
+// NewReader 读取并检查文件头
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	buf := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, buf); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(buf, magic) {
+		return nil, ErrBadMagic
+	}
+	data, err := readBytes(br)
+	if err != nil {
+		return nil, err
+	}
+	header := &Header{}
+	if err = json.Unmarshal(data, header); err != nil {
+		return nil, err
+	}
+	if header.Version != Version {
+		return nil, ErrBadVersion
+	}
+	return &Reader{r: br, header: header}, nil
+}
+
+// Header 返回文件头
+func (sr *Reader) Header() *Header {
+	return sr.header
+}
+
+// Keys 返回已读取的key数量
+func (sr *Reader) Keys() uint64 {
+	return sr.keys
+}
+
+// Next 返回下一个chunk中的kv, 读完并校验文件尾后返回 io.EOF
+func (sr *Reader) Next() ([]*types.KeyValue, error) {
+	if sr.done {
+		return nil, io.EOF
+	}
+	data, err := readBytes(sr.r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, sr.readFooter()
+	}
+	sum := make([]byte, sha256.Size)
+	if _, err = io.ReadFull(sr.r, sum); err != nil {
+		return nil, err
+	}
+	calc := sha256.Sum256(data)
+	if !bytes.Equal(calc[:], sum) {
+		return nil, ErrChunkChecksum
+	}
+	fileSum := sha256.Sum256(append(sr.sum, sum...))
+	sr.sum = fileSum[:]
+	var set types.LocalDBSet
+	if err = types.Decode(data, &set); err != nil {
+		return nil, err
+	}
+	sr.keys += uint64(len(set.KV))
+	return set.KV, nil
+}
+
+func (sr *Reader) readFooter() error {
+	var buf [8 + sha256.Size]byte
+	if _, err := io.ReadFull(sr.r, buf[:]); err != nil {
+		return err
+	}
+	if binary.BigEndian.Uint64(buf[:8]) != sr.keys {
+		return ErrKeyCount
+	}
+	sum := sr.sum
+	if len(sum) == 0 {
+		empty := sha256.Sum256(nil)
+		sum = empty[:]
+	}
+	if !bytes.Equal(buf[8:], sum) {
+		return ErrFileChecksum
+	}
+	if _, err := sr.r.ReadByte(); err != io.EOF {
+		return ErrUnexpectedChunk
+	}
+	sr.done = true
+	return io.EOF
+}
+
+// Verify 完整读取快照并校验所有chunk, 不写入store
+func Verify(r io.Reader) (*Header, uint64, error) {
+	sr, err := NewReader(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	for {
+		_, err = sr.Next()
+		if err == io.EOF {
+			return sr.Header(), sr.Keys(), nil
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+}
+
+// Export 导出stateHash下的全部kv
+func Export(store Iterator, header *Header, w io.Writer) (uint64, error) {
+	stateHash := header.Hash()
+	if stateHash == nil {
+		return 0, ErrStateHash
+	}
+	sw, err := NewWriter(w, header)
+	if err != nil {
+		return 0, err
+	}
+	store.IterateRangeByStateHash(stateHash, nil, nil, true, func(key, value []byte) bool {
+		err = sw.Add(key, value)
+		return err != nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	// 状态不存在时各store只打印日志不返回错误
+	if sw.keys == 0 {
+		return 0, ErrEmptyState
+	}
+	if err = sw.Close(); err != nil {
+		return 0, err
+	}
+	slog.Info("snapshot export", "height", header.Height, "stateHash", header.StateHash, "keys", sw.keys, "chunks", sw.chunks)
+	return sw.keys, nil
+}
+
+// Import 导入快照, stateHash和height来自可信的区块头
+// 所有chunk的校验和通过并且重建的状态hash与区块头一致才算成功
+func Import(r io.Reader, importer Importer, stateHash []byte, height int64) (*Header, error) {
+	sr, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	header := sr.Header()
+	if !bytes.Equal(header.Hash(), stateHash) {
+		return nil, ErrStateHash
+	}
+	if header.Height != height {
+		return nil, ErrHeight
+	}
+	root, err := importer.ImportSnapshot(header, sr.Next)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(root, stateHash) {
+		slog.Error("snapshot import", "root", common.ToHex(root), "stateHash", header.StateHash)
+		return nil, ErrRootMismatch
+	}
+	slog.Info("snapshot import", "height", header.Height, "stateHash", header.StateHash, "keys", sr.Keys())
+	return header, nil
+}
+
+func writeBytes(w io.Writer, data []byte) error {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(len(data)))
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+func readBytes(r io.Reader) ([]byte, error) {
+	var buf [4]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(buf[:])
+	if size > maxChunkSize {
+		return nil, ErrChunkTooLarge
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
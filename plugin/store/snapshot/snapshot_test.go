@@ -0,0 +1,146 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package snapshot
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/33cn/chain33/common"
+	"github.com/33cn/chain33/types"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	kvs map[string][]byte
+}
+
+func (m *memStore) IterateRangeByStateHash(statehash []byte, start []byte, end []byte, ascending bool, fn func(key, value []byte) bool) {
+	var keys []string
+	for key := range m.kvs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if fn([]byte(key), m.kvs[key]) {
+			return
+		}
+	}
+}
+
+// ImportSnapshot 按固定规则计算一个root, 便于测试校验流程
+func (m *memStore) ImportSnapshot(header *Header, next func() ([]*types.KeyValue, error)) ([]byte, error) {
+	for {
+		kvs, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range kvs {
+			m.kvs[string(kv.Key)] = kv.Value
+		}
+	}
+	return rootOf(m.kvs), nil
+}
+
+func rootOf(kvs map[string][]byte) []byte {
+	var buf bytes.Buffer
+	var keys []string
+	for key := range kvs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		buf.WriteString(key)
+		buf.Write(kvs[key])
+	}
+	return common.Sha256(buf.Bytes())
+}
+
+func newMemStore(n int) *memStore {
+	m := &memStore{kvs: make(map[string][]byte)}
+	for i := 0; i < n; i++ {
+		m.kvs[fmt.Sprintf("key%04d", i)] = []byte(fmt.Sprintf("value%d", i))
+	}
+	return m
+}
+
+func exportMem(t *testing.T, m *memStore, height int64) ([]byte, []byte) {
+	root := rootOf(m.kvs)
+	var buf bytes.Buffer
+	keys, err := Export(m, &Header{Driver: "mem", Height: height, StateHash: common.ToHex(root), ChunkSize: 100}, &buf)
+	require.Nil(t, err)
+	require.Equal(t, uint64(len(m.kvs)), keys)
+	return buf.Bytes(), root
+}
+
+func TestExportImport(t *testing.T) {
+	src := newMemStore(100)
+	data, root := exportMem(t, src, 10)
+
+	header, keys, err := Verify(bytes.NewReader(data))
+	require.Nil(t, err)
+	require.Equal(t, uint64(100), keys)
+	require.Equal(t, int64(10), header.Height)
+	require.Equal(t, 100, header.ChunkSize)
+
+	dst := newMemStore(0)
+	header, err = Import(bytes.NewReader(data), dst, root, 10)
+	require.Nil(t, err)
+	require.Equal(t, "mem", header.Driver)
+	require.Equal(t, src.kvs, dst.kvs)
+
+	// 区块头不一致
+	_, err = Import(bytes.NewReader(data), newMemStore(0), root, 11)
+	require.Equal(t, ErrHeight, err)
+	_, err = Import(bytes.NewReader(data), newMemStore(0), common.Sha256(root), 10)
+	require.Equal(t, ErrStateHash, err)
+	// 重建的状态与区块头不一致
+	dirty := newMemStore(0)
+	dirty.kvs["extra"] = []byte("value")
+	_, err = Import(bytes.NewReader(data), dirty, root, 10)
+	require.Equal(t, ErrRootMismatch, err)
+
+	_, err = Export(newMemStore(0), &Header{Height: 10, StateHash: common.ToHex(root)}, &bytes.Buffer{})
+	require.Equal(t, ErrEmptyState, err)
+}
+
+func TestImportCorrupted(t *testing.T) {
+	data, _ := exportMem(t, newMemStore(50), 1)
+	verify := func(data []byte) error {
+		_, _, err := Verify(bytes.NewReader(data))
+		return err
+	}
+	require.Nil(t, verify(data))
+
+	bad := common.CopyBytes(data)
+	bad[0] = 'X'
+	require.Equal(t, ErrBadMagic, verify(bad))
+
+	// 修改第一个chunk中的数据
+	headerLen := len(magic) + 4 + int(bad[len(magic)+3])
+	bad = common.CopyBytes(data)
+	bad[headerLen+10] ^= 0xff
+	require.Equal(t, ErrChunkChecksum, verify(bad))
+
+	// 修改文件尾的key数量
+	bad = common.CopyBytes(data)
+	bad[len(bad)-33] ^= 0x01
+	require.Equal(t, ErrKeyCount, verify(bad))
+
+	// 修改文件尾的校验和
+	bad = common.CopyBytes(data)
+	bad[len(bad)-1] ^= 0xff
+	require.Equal(t, ErrFileChecksum, verify(bad))
+
+	// 截断和多余的数据
+	require.Equal(t, io.ErrUnexpectedEOF, verify(data[:len(data)-10]))
+	require.Equal(t, ErrUnexpectedChunk, verify(append(common.CopyBytes(data), 0)))
+}
@@ -0,0 +1,190 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+)
+
+// DefaultEngines 参与对比的store插件
+var DefaultEngines = []string{"kvdb", "kvmvcc", "kvmvccmavl", "mpt"}
+
+// kvdb不保存历史版本, 只能在最新状态上查询
+var unversioned = map[string]bool{"kvdb": true}
+
+// 回放时采样内存的区块间隔
+const memSampleBlocks = 100
+
+// Engine 回放需要的store接口, 各store插件都实现了
+type Engine interface {
+	MemSet(datas *types.StoreSet, sync bool) ([]byte, error)
+	Commit(hash *types.ReqHash) ([]byte, error)
+	Get(datas *types.StoreGet) [][]byte
+	Close()
+}
+
+// Options 新建引擎的参数
+type Options struct {
+	// 每个引擎使用 Dir/name 目录, 需要为空
+	Dir    string
+	Driver string
+	// 各引擎的sub配置, 没有时使用默认配置
+	Sub  map[string][]byte
+	Sync bool
+}
+
+// OpenEngine 在 Dir/name 下新建store插件
+func OpenEngine(opt *Options, name string) (Engine, error) {
+	create, err := drivers.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &types.Store{Name: name, Driver: opt.Driver, DbPath: filepath.Join(opt.Dir, name), DbCache: 128}
+	engine, ok := create(cfg, opt.Sub[name], nil).(Engine)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return engine, nil
+}
+
+// replayer 在一个引擎上按顺序回放记录, 高度从0开始重新编号, 前一个状态hash使用引擎自己计算的hash
+type replayer struct {
+	name   string
+	engine Engine
+	sync   bool
+	height int64
+	hash   []byte
+	// 每个区块提交后的状态hash, 用于查询历史版本
+	hashes [][]byte
+	keep   bool
+}
+
+func (r *replayer) apply(set *types.StoreSet) error {
+	hash, err := r.engine.MemSet(&types.StoreSet{StateHash: r.hash, KV: set.KV, Height: r.height}, r.sync)
+	if err != nil {
+		return err
+	}
+	if _, err = r.engine.Commit(&types.ReqHash{Hash: hash}); err != nil {
+		return err
+	}
+	r.hash = hash
+	r.height++
+	if r.keep {
+		r.hashes = append(r.hashes, hash)
+	}
+	return nil
+}
+
+func (r *replayer) get(hash []byte, keys [][]byte) [][]byte {
+	return r.engine.Get(&types.StoreGet{StateHash: hash, Keys: keys})
+}
+
+// Result 单个引擎的回放统计
+type Result struct {
+	Engine  string
+	Blocks  int64
+	KVs     int64
+	Bytes   int64
+	Elapsed time.Duration
+	// 关闭引擎后数据目录的大小
+	DiskUsage int64
+	// 回放期间分配的内存和堆内存的峰值
+	Alloc     uint64
+	HeapInuse uint64
+}
+
+// BlocksPerSec 每秒回放的区块数
+func (r *Result) BlocksPerSec() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Blocks) / r.Elapsed.Seconds()
+}
+
+// KVsPerSec 每秒写入的kv数
+func (r *Result) KVsPerSec() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.KVs) / r.Elapsed.Seconds()
+}
+
+// Bench 新建引擎回放记录文件
+func Bench(opt *Options, name string, r io.Reader) (*Result, error) {
+	reader, err := NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	engine, err := OpenEngine(opt, name)
+	if err != nil {
+		return nil, err
+	}
+	rp := &replayer{name: name, engine: engine, sync: opt.Sync}
+	res := &Result{Engine: name}
+	var before, stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	res.HeapInuse = before.HeapInuse
+	var elapsed time.Duration
+	for {
+		set, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			engine.Close()
+			return nil, err
+		}
+		beg := time.Now()
+		err = rp.apply(set)
+		elapsed += time.Since(beg)
+		if err != nil {
+			engine.Close()
+			return nil, err
+		}
+		res.Blocks++
+		res.KVs += int64(len(set.KV))
+		for _, kv := range set.KV {
+			res.Bytes += int64(len(kv.Key) + len(kv.Value))
+		}
+		if res.Blocks%memSampleBlocks == 0 {
+			runtime.ReadMemStats(&stats)
+			if stats.HeapInuse > res.HeapInuse {
+				res.HeapInuse = stats.HeapInuse
+			}
+		}
+	}
+	runtime.ReadMemStats(&stats)
+	if stats.HeapInuse > res.HeapInuse {
+		res.HeapInuse = stats.HeapInuse
+	}
+	res.Alloc = stats.TotalAlloc - before.TotalAlloc
+	res.Elapsed = elapsed
+	engine.Close()
+	res.DiskUsage, err = DirSize(filepath.Join(opt.Dir, name))
+	return res, err
+}
+
+// DirSize 目录下所有文件的大小
+func DirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
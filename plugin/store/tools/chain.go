@@ -0,0 +1,82 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"fmt"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/common/db/table"
+	"github.com/33cn/chain33/types"
+)
+
+// 创世区块执行时的前一个状态hash, 与blockchain一致
+var zeroHash = make([]byte, 32)
+
+// Chain 离线读取节点区块数据库中的区块头和区块kv, 节点需要先停止
+type Chain struct {
+	db      dbm.DB
+	headers *table.Table
+	kvs     *table.Table
+}
+
+// NewChain 使用已经打开的区块数据库
+func NewChain(db dbm.DB) *Chain {
+	kvdb := dbm.NewKVDB(db)
+	return &Chain{db: db, headers: blockchain.NewHeaderTable(kvdb), kvs: blockchain.NewBlockKVTable(kvdb)}
+}
+
+// Height 最新区块高度
+func (c *Chain) Height() (int64, error) {
+	return blockchain.LoadBlockStoreHeight(c.db)
+}
+
+// 与blockchain中的key保持一致
+func calcHeightToHashKey(height int64) []byte {
+	return []byte(fmt.Sprintf("Height:%v", height))
+}
+
+func calcHeightHashKey(height int64, hash []byte) []byte {
+	return append([]byte(fmt.Sprintf("%012d", height)), hash...)
+}
+
+// Header 主链上高度为height的区块头
+func (c *Chain) Header(height int64) (*types.Header, error) {
+	hash, err := c.db.Get(calcHeightToHashKey(height))
+	if err != nil || hash == nil {
+		return nil, types.ErrHeightNotExist
+	}
+	rows, err := c.headers.ListIndex("", calcHeightHashKey(height, hash), nil, 1, dbm.ListASC)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, types.ErrHeightNotExist
+	}
+	header, ok := rows[0].Data.(*types.Header)
+	if !ok {
+		return nil, types.ErrDecode
+	}
+	return header, nil
+}
+
+// BlockKVs 区块执行后写入store的kv
+func (c *Chain) BlockKVs(height int64, hash []byte) (*types.BlockKVs, error) {
+	rows, err := c.kvs.ListIndex("", calcHeightHashKey(height, hash), nil, 1, dbm.ListASC)
+	if err == types.ErrNotFound || (err == nil && len(rows) == 0) {
+		tlog.Error("BlockKVs not saved, enable blockchain.enableSaveBlockKVs", "height", height, "hash", common.ToHex(hash))
+		return nil, ErrNoBlockKVs
+	}
+	if err != nil {
+		return nil, err
+	}
+	kvs, ok := rows[0].Data.(*types.BlockKVs)
+	if !ok {
+		return nil, types.ErrDecode
+	}
+	return kvs, nil
+}
@@ -0,0 +1,172 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/33cn/chain33/types"
+)
+
+// 检查历史状态时单次查询的key数
+const checkBatch = 1024
+
+// Mismatch 引擎的查询结果和记录不一致
+type Mismatch struct {
+	Engine string
+	// 记录中的区块高度
+	Height int64
+	Key    []byte
+	Expect []byte
+	Value  []byte
+}
+
+// CheckResult 一致性检查的结果
+type CheckResult struct {
+	Blocks int64
+	Gets   int64
+	// 最多保留limit个不一致, Total是全部不一致的数量
+	Mismatches []*Mismatch
+	Total      int64
+	limit      int
+}
+
+func (cr *CheckResult) add(m *Mismatch) {
+	cr.Total++
+	if len(cr.Mismatches) < cr.limit {
+		cr.Mismatches = append(cr.Mismatches, m)
+	}
+}
+
+// blockKVs 区块写入的key和值, 同一个key以最后一次写入为准
+func blockKVs(set *types.StoreSet) ([][]byte, map[string][]byte) {
+	values := make(map[string][]byte)
+	var keys [][]byte
+	for _, kv := range set.KV {
+		if _, ok := values[string(kv.Key)]; !ok {
+			keys = append(keys, kv.Key)
+		}
+		values[string(kv.Key)] = kv.Value
+	}
+	return keys, values
+}
+
+// Check 在各引擎上同步回放记录并比较查询结果
+// 每个区块提交后, 在各引擎的最新状态上查询本区块写入的key, 和记录中的值比较;
+// 回放完成后再读一遍记录, 引擎从空状态开始回放, 每个状态的全部key就是记录中到该区块为止写入过的key,
+// 在保存历史版本的引擎上每隔interval个区块(以及最后一个区块)按状态hash查询全部key, 和按记录计算的状态比较
+func Check(opt *Options, names []string, open func() (io.ReadCloser, error), limit int, interval int64) (*CheckResult, error) {
+	if interval <= 0 {
+		interval = 1
+	}
+	var replayers []*replayer
+	defer func() {
+		for _, r := range replayers {
+			r.engine.Close()
+		}
+	}()
+	for _, name := range names {
+		engine, err := OpenEngine(opt, name)
+		if err != nil {
+			return nil, err
+		}
+		replayers = append(replayers, &replayer{name: name, engine: engine, sync: opt.Sync, keep: !unversioned[name]})
+	}
+	res := &CheckResult{limit: limit}
+	err := readRecord(open, func(set *types.StoreSet) error {
+		keys, values := blockKVs(set)
+		for _, r := range replayers {
+			if err := r.apply(set); err != nil {
+				tlog.Error("Check apply", "engine", r.name, "height", set.Height, "err", err)
+				return err
+			}
+			for i, value := range r.get(r.hash, keys) {
+				res.Gets++
+				if expect := values[string(keys[i])]; !bytes.Equal(expect, value) {
+					res.add(&Mismatch{Engine: r.name, Height: set.Height, Key: keys[i], Expect: expect, Value: value})
+				}
+			}
+		}
+		res.Blocks++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var versioned []*replayer
+	for _, r := range replayers {
+		if r.keep {
+			versioned = append(versioned, r)
+		}
+	}
+	if len(versioned) == 0 {
+		return res, nil
+	}
+	var index int64
+	state := make(map[string][]byte)
+	var keys [][]byte
+	err = readRecord(open, func(set *types.StoreSet) error {
+		for _, kv := range set.KV {
+			if _, ok := state[string(kv.Key)]; !ok {
+				keys = append(keys, kv.Key)
+			}
+			state[string(kv.Key)] = kv.Value
+		}
+		if index%interval == 0 || index == res.Blocks-1 {
+			for _, r := range versioned {
+				checkState(res, r, r.hashes[index], set.Height, keys, state)
+			}
+		}
+		index++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// checkState 分批查询状态中的全部key
+func checkState(res *CheckResult, r *replayer, hash []byte, height int64, keys [][]byte, state map[string][]byte) {
+	for start := 0; start < len(keys); start += checkBatch {
+		end := start + checkBatch
+		if end > len(keys) {
+			end = len(keys)
+		}
+		for i, value := range r.get(hash, keys[start:end]) {
+			key := keys[start+i]
+			res.Gets++
+			if expect := state[string(key)]; !bytes.Equal(expect, value) {
+				res.add(&Mismatch{Engine: r.name, Height: height, Key: key, Expect: expect, Value: value})
+			}
+		}
+	}
+}
+
+// readRecord 打开记录文件, 依次处理每个区块
+func readRecord(open func() (io.ReadCloser, error), fn func(set *types.StoreSet) error) error {
+	f, err := open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	reader, err := NewReader(f)
+	if err != nil {
+		return err
+	}
+	for {
+		set, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err = fn(set); err != nil {
+			return err
+		}
+	}
+}
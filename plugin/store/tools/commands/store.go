@@ -0,0 +1,385 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/queue"
+	"github.com/33cn/chain33/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/tools"
+	"github.com/spf13/cobra"
+)
+
+// StoreCmd store tools cmd register
+func StoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store",
+		Short: "Benchmark and check store engines offline, the node must be stopped",
+		Args:  cobra.MinimumNArgs(1),
+	}
+	cmd.AddCommand(
+		RecordCmd(),
+		GenCmd(),
+		BenchCmd(),
+		CheckCmd(),
+		VerifyCmd(),
+	)
+	return cmd
+}
+
+// RecordCmd record block store sets
+func RecordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "record",
+		Short: "Record store sets of a block range from blockchain db, need blockchain.enableSaveBlockKVs",
+		Run:   record,
+	}
+	cmd.Flags().StringP("conf", "c", "chain33.toml", "node config file, locate the blockchain db")
+	addRangeFlags(cmd)
+	addFileFlag(cmd)
+	return cmd
+}
+
+func addRangeFlags(cmd *cobra.Command) {
+	cmd.Flags().Int64P("start", "s", 0, "start block height")
+	cmd.Flags().Int64P("end", "e", -1, "end block height, default the last block")
+}
+
+func addFileFlag(cmd *cobra.Command) {
+	cmd.Flags().StringP("file", "f", "", "record file")
+	cmd.MarkFlagRequired("file")
+}
+
+func record(cmd *cobra.Command, args []string) {
+	conf, _ := cmd.Flags().GetString("conf")
+	file, _ := cmd.Flags().GetString("file")
+
+	cfg, err := loadConfig(conf)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	chain, closer, err := openChain(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer closer()
+	start, end, err := blockRange(cmd, chain)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer f.Close()
+	sets, err := tools.Record(chain, start, end, f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Remove(file)
+		return
+	}
+	fmt.Printf("recorded %d blocks [%d, %d] to %s\n", sets, start, end, file)
+}
+
+// GenCmd generate random store sets
+func GenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gen",
+		Short: "Generate random store sets for benchmark",
+		Run:   gen,
+	}
+	addFileFlag(cmd)
+	cmd.Flags().Int64P("blocks", "b", 1000, "block count")
+	cmd.Flags().IntP("kvs", "k", 100, "key values per block")
+	cmd.Flags().IntP("keys", "n", 100000, "distinct keys")
+	cmd.Flags().IntP("size", "z", 128, "value size in bytes")
+	cmd.Flags().Int64("seed", 1, "random seed")
+	return cmd
+}
+
+func gen(cmd *cobra.Command, args []string) {
+	file, _ := cmd.Flags().GetString("file")
+	opt := &tools.GenOption{}
+	opt.Blocks, _ = cmd.Flags().GetInt64("blocks")
+	opt.KVsPerBlock, _ = cmd.Flags().GetInt("kvs")
+	opt.Keys, _ = cmd.Flags().GetInt("keys")
+	opt.ValueSize, _ = cmd.Flags().GetInt("size")
+	opt.Seed, _ = cmd.Flags().GetInt64("seed")
+	if opt.Keys <= 0 {
+		fmt.Fprintln(os.Stderr, "keys must be positive")
+		return
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer f.Close()
+	sets, err := tools.Generate(opt, f)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Remove(file)
+		return
+	}
+	fmt.Printf("generated %d blocks to %s\n", sets, file)
+}
+
+// BenchCmd replay record on each store engine
+func BenchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Replay record on each store engine, report throughput, disk usage and memory",
+		Run:   bench,
+	}
+	addFileFlag(cmd)
+	addEngineFlags(cmd)
+	return cmd
+}
+
+func addEngineFlags(cmd *cobra.Command) {
+	cmd.Flags().String("engines", strings.Join(tools.DefaultEngines, ","), "store engines, separated by comma")
+	cmd.Flags().StringP("dir", "d", "", "work directory, default a temp directory removed after finish")
+	cmd.Flags().String("driver", "leveldb", "db driver")
+	cmd.Flags().StringP("conf", "c", "", "node config file, use its store sub configs")
+	cmd.Flags().Bool("sync", false, "sync write")
+}
+
+// engineOptions 返回引擎参数, 未指定目录时使用临时目录, cleanup删除临时目录
+func engineOptions(cmd *cobra.Command) (*tools.Options, []string, func(), error) {
+	engines, _ := cmd.Flags().GetString("engines")
+	dir, _ := cmd.Flags().GetString("dir")
+	driver, _ := cmd.Flags().GetString("driver")
+	conf, _ := cmd.Flags().GetString("conf")
+	sync, _ := cmd.Flags().GetBool("sync")
+
+	opt := &tools.Options{Dir: dir, Driver: driver, Sync: sync}
+	if conf != "" {
+		cfg, err := loadConfig(conf)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		opt.Sub = cfg.GetSubConfig().Store
+	}
+	var names []string
+	for _, name := range strings.Split(engines, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil, nil, errors.New("no store engine")
+	}
+	cleanup := func() {}
+	if opt.Dir == "" {
+		tmp, err := ioutil.TempDir("", "store-bench")
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		opt.Dir = tmp
+		cleanup = func() { os.RemoveAll(tmp) }
+	}
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(opt.Dir, name)); err == nil {
+			cleanup()
+			return nil, nil, nil, fmt.Errorf("%s/%s exists, engines need an empty directory", opt.Dir, name)
+		}
+	}
+	return opt, names, cleanup, nil
+}
+
+func bench(cmd *cobra.Command, args []string) {
+	file, _ := cmd.Flags().GetString("file")
+	opt, names, cleanup, err := engineOptions(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer cleanup()
+	fmt.Printf("%-12s %8s %10s %12s %12s %12s %12s %12s\n", "engine", "blocks", "kvs", "blocks/s", "kvs/s", "disk", "alloc", "heap")
+	for _, name := range names {
+		f, err := os.Open(file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		res, err := tools.Bench(opt, name, f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, name, err)
+			continue
+		}
+		fmt.Printf("%-12s %8d %10d %12.1f %12.1f %12s %12s %12s\n", name, res.Blocks, res.KVs, res.BlocksPerSec(), res.KVsPerSec(),
+			byteSize(uint64(res.DiskUsage)), byteSize(res.Alloc), byteSize(res.HeapInuse))
+	}
+}
+
+func byteSize(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// CheckCmd cross check store engines
+func CheckCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Replay record on store engines, check get of the full key set at every state hash returns the recorded state",
+		Run:   check,
+	}
+	addFileFlag(cmd)
+	addEngineFlags(cmd)
+	cmd.Flags().Int("max", 20, "max mismatches to print")
+	cmd.Flags().Int64("interval", 1, "check the full key set every interval state hashes, the last one is always checked")
+	return cmd
+}
+
+func check(cmd *cobra.Command, args []string) {
+	file, _ := cmd.Flags().GetString("file")
+	limit, _ := cmd.Flags().GetInt("max")
+	interval, _ := cmd.Flags().GetInt64("interval")
+	opt, names, cleanup, err := engineOptions(cmd)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer cleanup()
+	open := func() (io.ReadCloser, error) { return os.Open(file) }
+	res, err := tools.Check(opt, names, open, limit, interval)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	for _, m := range res.Mismatches {
+		fmt.Printf("height %d engine %s key %s: got %s, record %s\n", m.Height, m.Engine, string(m.Key),
+			common.ToHex(m.Value), common.ToHex(m.Expect))
+	}
+	fmt.Printf("checked %d blocks, %d gets on %s, %d mismatches\n", res.Blocks, res.Gets, strings.Join(names, ","), res.Total)
+}
+
+// VerifyCmd verify data directory against block headers
+func VerifyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify state hashes of the node store against block headers",
+		Run:   verify,
+	}
+	cmd.Flags().StringP("conf", "c", "chain33.toml", "node config file, locate the blockchain db and store")
+	addRangeFlags(cmd)
+	cmd.Flags().Int("max", 20, "max failed heights to print")
+	return cmd
+}
+
+func verify(cmd *cobra.Command, args []string) {
+	conf, _ := cmd.Flags().GetString("conf")
+	limit, _ := cmd.Flags().GetInt("max")
+
+	cfg, err := loadConfig(conf)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	chain, closer, err := openChain(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer closer()
+	start, end, err := blockRange(cmd, chain)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	st, driver, err := openStore(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	defer st.Close()
+	v, ok := st.(tools.Verifier)
+	if !ok {
+		fmt.Fprintln(os.Stderr, tools.ErrNotSupported, driver)
+		return
+	}
+	res, err := tools.Verify(chain, v, start, end, limit, func(height int64) {
+		if height%10000 == 0 {
+			fmt.Printf("verified to height %d\n", height)
+		}
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+	for height := start; height <= end && len(res.Failed) > 0; height++ {
+		if err, ok := res.Failed[height]; ok {
+			fmt.Printf("height %d: %v\n", height, err)
+			delete(res.Failed, height)
+		}
+	}
+	fmt.Printf("%s store [%d, %d]: verified %d, pruned %d, failed %d\n", driver, start, end, res.Verified, res.Pruned, res.Total)
+}
+
+func loadConfig(conf string) (*types.Chain33Config, error) {
+	if _, err := os.Stat(conf); err != nil {
+		return nil, err
+	}
+	return types.NewChain33Config(types.ReadFile(conf)), nil
+}
+
+// openChain 按节点配置打开区块数据库
+func openChain(cfg *types.Chain33Config) (*tools.Chain, func(), error) {
+	mcfg := cfg.GetModuleConfig().BlockChain
+	if mcfg == nil {
+		return nil, nil, errors.New("blockchain config not found")
+	}
+	db := dbm.NewDB("blockchain", mcfg.Driver, mcfg.DbPath, mcfg.DbCache)
+	return tools.NewChain(db), db.Close, nil
+}
+
+// blockRange 检查高度范围, end为负数时到最新区块
+func blockRange(cmd *cobra.Command, chain *tools.Chain) (int64, int64, error) {
+	start, _ := cmd.Flags().GetInt64("start")
+	end, _ := cmd.Flags().GetInt64("end")
+	height, err := chain.Height()
+	if err != nil {
+		return 0, 0, err
+	}
+	if end < 0 || end > height {
+		end = height
+	}
+	if start < 0 || start > end {
+		return 0, 0, fmt.Errorf("bad block range [%d, %d], last block %d", start, end, height)
+	}
+	return start, end, nil
+}
+
+// openStore 按节点配置打开store, 不注册到queue
+func openStore(cfg *types.Chain33Config) (queue.Module, string, error) {
+	mcfg := cfg.GetModuleConfig().Store
+	if mcfg == nil {
+		return nil, "", errors.New("store config not found")
+	}
+	return store.New(cfg), mcfg.Name, nil
+}
@@ -0,0 +1,204 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package tools store引擎的离线工具: 记录区块的StoreSet, 在各引擎上回放做性能对比和一致性检查,
+// 以及检查已有数据目录中的状态hash和区块头一致
+//
+// 记录文件格式:
+//
+//	magic(8) | { setLen(4) | set(types.StoreSet) }* | 0(4)
+package tools
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+
+	log "github.com/33cn/chain33/common/log/log15"
+	"github.com/33cn/chain33/types"
+)
+
+var tlog = log.New("module", "store.tools")
+
+// 单个StoreSet的上限, 防止损坏的文件导致分配过大的内存
+const maxSetSize = 256 * 1024 * 1024
+
+var magic = []byte("C33STREC")
+
+var (
+	ErrBadMagic      = errors.New("ErrRecordBadMagic")
+	ErrSetTooLarge   = errors.New("ErrRecordSetTooLarge")
+	ErrNotSupported  = errors.New("ErrStoreToolNotSupported")
+	ErrStateNotFound = errors.New("ErrStoreStateNotFound")
+	ErrStateHash     = errors.New("ErrStoreStateHash")
+	ErrNoBlockKVs    = errors.New("ErrNoBlockKVs")
+)
+
+// Writer 写入记录文件
+type Writer struct {
+	w    *bufio.Writer
+	sets int64
+}
+
+// NewWriter 写入magic并返回Writer
+func NewWriter(w io.Writer) (*Writer, error) {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(magic); err != nil {
+		return nil, err
+	}
+	return &Writer{w: bw}, nil
+}
+
+// Add 写入一个区块的StoreSet
+func (rw *Writer) Add(set *types.StoreSet) error {
+	data := types.Encode(set)
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], uint32(len(data)))
+	if _, err := rw.w.Write(buf[:]); err != nil {
+		return err
+	}
+	if _, err := rw.w.Write(data); err != nil {
+		return err
+	}
+	rw.sets++
+	return nil
+}
+
+// Close 写入文件尾
+func (rw *Writer) Close() error {
+	var buf [4]byte
+	if _, err := rw.w.Write(buf[:]); err != nil {
+		return err
+	}
+	return rw.w.Flush()
+}
+
+// Reader 按顺序读取记录文件
+type Reader struct {
+	r    *bufio.Reader
+	done bool
+}
+
+// NewReader 读取并检查magic
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	buf := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, buf); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(buf, magic) {
+		return nil, ErrBadMagic
+	}
+	return &Reader{r: br}, nil
+}
+
+// Next 返回下一个StoreSet, 读完时返回 io.EOF
+func (rr *Reader) Next() (*types.StoreSet, error) {
+	if rr.done {
+		return nil, io.EOF
+	}
+	var buf [4]byte
+	if _, err := io.ReadFull(rr.r, buf[:]); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	size := binary.BigEndian.Uint32(buf[:])
+	if size == 0 {
+		rr.done = true
+		return nil, io.EOF
+	}
+	if size > maxSetSize {
+		return nil, ErrSetTooLarge
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(rr.r, data); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	set := &types.StoreSet{}
+	if err := types.Decode(data, set); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// 文件尾之前的EOF说明文件被截断
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// Record 从区块数据库读取[start, end]高度每个区块执行后的kv, 写入记录文件
+// 需要节点开启 blockchain.enableSaveBlockKVs
+func Record(chain *Chain, start, end int64, w io.Writer) (int64, error) {
+	rw, err := NewWriter(w)
+	if err != nil {
+		return 0, err
+	}
+	prev := zeroHash
+	if start > 0 {
+		header, err := chain.Header(start - 1)
+		if err != nil {
+			return 0, err
+		}
+		prev = header.StateHash
+	}
+	for height := start; height <= end; height++ {
+		header, err := chain.Header(height)
+		if err != nil {
+			return rw.sets, err
+		}
+		kvs, err := chain.BlockKVs(height, header.Hash)
+		if err != nil {
+			return rw.sets, err
+		}
+		if err = rw.Add(&types.StoreSet{StateHash: prev, KV: kvs.KVs, Height: height}); err != nil {
+			return rw.sets, err
+		}
+		prev = header.StateHash
+	}
+	return rw.sets, rw.Close()
+}
+
+// GenOption 生成随机记录的参数
+type GenOption struct {
+	Blocks      int64
+	KVsPerBlock int
+	// 不同key的数量, 越小覆盖写越多
+	Keys      int
+	ValueSize int
+	Seed      int64
+}
+
+// Generate 生成随机的记录, 没有节点数据时用于性能对比
+func Generate(opt *GenOption, w io.Writer) (int64, error) {
+	rw, err := NewWriter(w)
+	if err != nil {
+		return 0, err
+	}
+	rnd := rand.New(rand.NewSource(opt.Seed))
+	for height := int64(0); height < opt.Blocks; height++ {
+		// 同一个区块中key不重复, 与执行器的DelDupKey一致
+		used := make(map[int]bool)
+		set := &types.StoreSet{Height: height}
+		for i := 0; i < opt.KVsPerBlock && len(used) < opt.Keys; i++ {
+			index := rnd.Intn(opt.Keys)
+			for used[index] {
+				index = (index + 1) % opt.Keys
+			}
+			used[index] = true
+			value := make([]byte, opt.ValueSize)
+			rnd.Read(value)
+			set.KV = append(set.KV, &types.KeyValue{Key: []byte(fmt.Sprintf("mavl-bench-%012d", index)), Value: value})
+		}
+		if err = rw.Add(set); err != nil {
+			return rw.sets, err
+		}
+	}
+	return rw.sets, rw.Close()
+}
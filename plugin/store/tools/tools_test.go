@@ -0,0 +1,295 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tools_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/33cn/chain33/blockchain"
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/queue"
+	drivers "github.com/33cn/chain33/system/store"
+	"github.com/33cn/chain33/types"
+	"github.com/33cn/plugin/plugin/store/tools"
+	"github.com/stretchr/testify/require"
+
+	kvmvccdb "github.com/33cn/plugin/plugin/store/kvmvcc"
+	"github.com/33cn/plugin/plugin/store/mpt"
+
+	_ "github.com/33cn/plugin/plugin/store/kvdb"
+	_ "github.com/33cn/plugin/plugin/store/kvmvccmavl"
+)
+
+// brokenKey 在broken引擎中读到错误的值
+var brokenKey = []byte(fmt.Sprintf("mavl-bench-%012d", 7))
+
+type brokenStore struct {
+	*kvmvccdb.KVMVCCStore
+}
+
+func (b *brokenStore) Get(datas *types.StoreGet) [][]byte {
+	values := b.KVMVCCStore.Get(datas)
+	for i, key := range datas.Keys {
+		if bytes.Equal(key, brokenKey) && values[i] != nil {
+			values[i] = append([]byte("broken"), values[i]...)
+		}
+	}
+	return values
+}
+
+// forgottenKey 在forgetful引擎中丢失
+var forgottenKey = []byte("mavl-forgotten")
+
+type forgetfulStore struct {
+	*kvmvccdb.KVMVCCStore
+}
+
+func (f *forgetfulStore) Get(datas *types.StoreGet) [][]byte {
+	values := f.KVMVCCStore.Get(datas)
+	for i, key := range datas.Keys {
+		if bytes.Equal(key, forgottenKey) {
+			values[i] = nil
+		}
+	}
+	return values
+}
+
+func init() {
+	drivers.Reg("broken", func(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Module {
+		return &brokenStore{kvmvccdb.New(cfg, sub, chain33cfg).(*kvmvccdb.KVMVCCStore)}
+	})
+	drivers.Reg("forgetful", func(cfg *types.Store, sub []byte, chain33cfg *types.Chain33Config) queue.Module {
+		return &forgetfulStore{kvmvccdb.New(cfg, sub, chain33cfg).(*kvmvccdb.KVMVCCStore)}
+	})
+}
+
+func genRecord(t *testing.T, blocks int64) []byte {
+	var buf bytes.Buffer
+	sets, err := tools.Generate(&tools.GenOption{Blocks: blocks, KVsPerBlock: 20, Keys: 50, ValueSize: 32, Seed: 1}, &buf)
+	require.Nil(t, err)
+	require.Equal(t, blocks, sets)
+	return buf.Bytes()
+}
+
+func TestRecordReader(t *testing.T) {
+	data := genRecord(t, 10)
+	reader, err := tools.NewReader(bytes.NewReader(data))
+	require.Nil(t, err)
+	for height := int64(0); height < 10; height++ {
+		set, err := reader.Next()
+		require.Nil(t, err)
+		require.Equal(t, height, set.Height)
+		require.Equal(t, 20, len(set.KV))
+	}
+	_, err = reader.Next()
+	require.Equal(t, io.EOF, err)
+
+	// 截断的文件
+	reader, err = tools.NewReader(bytes.NewReader(data[:len(data)-4]))
+	require.Nil(t, err)
+	for err == nil {
+		_, err = reader.Next()
+	}
+	require.Equal(t, io.ErrUnexpectedEOF, err)
+
+	_, err = tools.NewReader(bytes.NewReader([]byte("C33SNAP\x00")))
+	require.Equal(t, tools.ErrBadMagic, err)
+}
+
+func TestBench(t *testing.T) {
+	data := genRecord(t, 20)
+	dir, err := ioutil.TempDir("", "store-bench")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	opt := &tools.Options{Dir: dir, Driver: "leveldb"}
+	for _, name := range tools.DefaultEngines {
+		res, err := tools.Bench(opt, name, bytes.NewReader(data))
+		require.Nil(t, err, name)
+		require.Equal(t, int64(20), res.Blocks)
+		require.Equal(t, int64(400), res.KVs)
+		require.True(t, res.DiskUsage > 0, name)
+		require.True(t, res.Alloc > 0, name)
+	}
+	_, err = tools.Bench(opt, "unknown", bytes.NewReader(data))
+	require.Equal(t, types.ErrNotFound, err)
+}
+
+func TestCheck(t *testing.T) {
+	data := genRecord(t, 30)
+	open := func() (io.ReadCloser, error) { return ioutil.NopCloser(bytes.NewReader(data)), nil }
+
+	dir, err := ioutil.TempDir("", "store-check")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	res, err := tools.Check(&tools.Options{Dir: filepath.Join(dir, "ok"), Driver: "leveldb"}, tools.DefaultEngines, open, 10, 1)
+	require.Nil(t, err)
+	require.Equal(t, int64(30), res.Blocks)
+	require.Equal(t, int64(0), res.Total)
+
+	// 最新状态和历史状态上的错误都能发现
+	res, err = tools.Check(&tools.Options{Dir: filepath.Join(dir, "broken"), Driver: "leveldb"}, []string{"mpt", "broken"}, open, 1, 1)
+	require.Nil(t, err)
+	require.True(t, res.Total > 1)
+	require.Equal(t, 1, len(res.Mismatches))
+	m := res.Mismatches[0]
+	require.Equal(t, "broken", m.Engine)
+	require.Equal(t, brokenKey, m.Key)
+	require.Equal(t, append([]byte("broken"), m.Expect...), m.Value)
+
+	// 只在第一个区块写入的key, 之后每个状态都要检查
+	var buf bytes.Buffer
+	rw, err := tools.NewWriter(&buf)
+	require.Nil(t, err)
+	for height := int64(0); height < 5; height++ {
+		kvs := []*types.KeyValue{{Key: []byte("mavl-touched"), Value: []byte(fmt.Sprint(height))}}
+		if height == 0 {
+			kvs = append(kvs, &types.KeyValue{Key: forgottenKey, Value: []byte("value")})
+		}
+		require.Nil(t, rw.Add(&types.StoreSet{KV: kvs, Height: height}))
+	}
+	require.Nil(t, rw.Close())
+	data = buf.Bytes()
+	for _, c := range []struct {
+		interval int64
+		heights  []int64
+	}{{1, []int64{0, 0, 1, 2, 3, 4}}, {2, []int64{0, 0, 2, 4}}, {3, []int64{0, 0, 3, 4}}} {
+		res, err = tools.Check(&tools.Options{Dir: filepath.Join(dir, fmt.Sprint("forgetful", c.interval)), Driver: "leveldb"}, []string{"forgetful"}, open, 10, c.interval)
+		require.Nil(t, err)
+		var heights []int64
+		for _, m := range res.Mismatches {
+			require.Equal(t, forgottenKey, m.Key)
+			require.Equal(t, []byte("value"), m.Expect)
+			heights = append(heights, m.Height)
+		}
+		require.Equal(t, c.heights, heights, "interval %d", c.interval)
+	}
+}
+
+// testChain 在区块数据库中写入区块头和区块kv, 状态hash由store计算
+func testChain(t *testing.T, db dbm.DB, st tools.Engine, data []byte) *tools.Chain {
+	reader, err := tools.NewReader(bytes.NewReader(data))
+	require.Nil(t, err)
+	kvdb := dbm.NewKVDB(db)
+	headers := blockchain.NewHeaderTable(kvdb)
+	blockKVs := blockchain.NewBlockKVTable(kvdb)
+	prev := make([]byte, 32)
+	for {
+		set, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		set.StateHash = prev
+		hash, err := st.MemSet(set, true)
+		require.Nil(t, err)
+		_, err = st.Commit(&types.ReqHash{Hash: hash})
+		require.Nil(t, err)
+		header := &types.Header{Height: set.Height, StateHash: hash, Hash: common.Sha256(hash)}
+		require.Nil(t, headers.Replace(header))
+		require.Nil(t, blockKVs.Replace(&types.BlockKVs{KVs: set.KV, Hash: header.Hash, Height: header.Height}))
+		require.Nil(t, db.Set([]byte(fmt.Sprintf("Height:%v", header.Height)), header.Hash))
+		prev = hash
+	}
+	for _, table := range []interface {
+		Save() ([]*types.KeyValue, error)
+	}{headers, blockKVs} {
+		kvs, err := table.Save()
+		require.Nil(t, err)
+		for _, kv := range kvs {
+			require.Nil(t, db.Set(kv.Key, kv.Value))
+		}
+	}
+	return tools.NewChain(db)
+}
+
+func TestRecordVerify(t *testing.T) {
+	data := genRecord(t, 10)
+	dir, err := ioutil.TempDir("", "store-verify")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	storeCfg := &types.Store{Name: "kvmvcc", Driver: "leveldb", DbPath: filepath.Join(dir, "kvmvcc"), DbCache: 16}
+	st := kvmvccdb.New(storeCfg, nil, nil).(*kvmvccdb.KVMVCCStore)
+	defer st.Close()
+	db := dbm.NewDB("blockchain", "leveldb", filepath.Join(dir, "blockchain"), 16)
+	defer db.Close()
+	chain := testChain(t, db, st, data)
+
+	// 从区块数据库记录的kv和原始记录一致
+	var buf bytes.Buffer
+	sets, err := tools.Record(chain, 3, 6, &buf)
+	require.Nil(t, err)
+	require.Equal(t, int64(4), sets)
+	origin, err := tools.NewReader(bytes.NewReader(data))
+	require.Nil(t, err)
+	recorded, err := tools.NewReader(&buf)
+	require.Nil(t, err)
+	for height := int64(0); height <= 6; height++ {
+		set, err := origin.Next()
+		require.Nil(t, err)
+		if height < 3 {
+			continue
+		}
+		got, err := recorded.Next()
+		require.Nil(t, err)
+		require.Equal(t, set.KV, got.KV)
+		header, err := chain.Header(height - 1)
+		require.Nil(t, err)
+		require.Equal(t, header.StateHash, got.StateHash)
+	}
+
+	res, err := tools.Verify(chain, st, 0, 9, 10, nil)
+	require.Nil(t, err)
+	require.Equal(t, int64(10), res.Verified)
+	require.Equal(t, int64(0), res.Total)
+
+	// 重新计算的状态hash和区块头不一致
+	set, err := origin.Next()
+	require.Nil(t, err)
+	key, err := dbm.GetKey(set.KV[0].Key, 7)
+	require.Nil(t, err)
+	require.Nil(t, st.GetDB().Set(key, []byte("tampered")))
+	res, err = tools.Verify(chain, st, 0, 9, 10, nil)
+	require.Nil(t, err)
+	require.Equal(t, int64(9), res.Verified)
+	require.Equal(t, map[int64]error{7: tools.ErrStateHash}, res.Failed)
+
+	_, err = tools.Record(chain, 0, 10, &buf)
+	require.Equal(t, types.ErrHeightNotExist, err)
+}
+
+func TestVerifyMpt(t *testing.T) {
+	data := genRecord(t, 10)
+	dir, err := ioutil.TempDir("", "store-verify")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	storeCfg := &types.Store{Name: "mpt", Driver: "leveldb", DbPath: filepath.Join(dir, "mpt"), DbCache: 16}
+	st := mpt.New(storeCfg, nil, nil).(*mpt.Store)
+	defer st.Close()
+	db := dbm.NewDB("blockchain", "leveldb", filepath.Join(dir, "blockchain"), 16)
+	defer db.Close()
+	chain := testChain(t, db, st, data)
+
+	res, err := tools.Verify(chain, st, 0, 9, 10, nil)
+	require.Nil(t, err)
+	require.Equal(t, int64(10), res.Verified)
+
+	// 删除高度索引视为已裁剪, 区块头不一致时报错
+	require.Nil(t, st.GetDB().Delete([]byte(fmt.Sprintf("mpt-height-root:%020d", 2))))
+	require.Nil(t, st.GetDB().Set([]byte(fmt.Sprintf("mpt-height-root:%020d", 5)), make([]byte, 32)))
+	res, err = tools.Verify(chain, st, 0, 9, 10, nil)
+	require.Nil(t, err)
+	require.Equal(t, int64(8), res.Verified)
+	require.Equal(t, int64(1), res.Pruned)
+	require.Equal(t, map[int64]error{5: tools.ErrStateHash}, res.Failed)
+}
@@ -0,0 +1,114 @@
+// Copyright Fuzamei Corp. 2018 All Rights Reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tools
+
+import (
+	"bytes"
+
+	"github.com/33cn/chain33/common"
+	dbm "github.com/33cn/chain33/common/db"
+	"github.com/33cn/chain33/types"
+)
+
+// Verifier 由store插件实现, 检查高度height的状态数据和区块头中的状态hash一致
+// prevHash 是上一个区块的状态hash, 用于重新计算状态hash
+// 状态已经被裁剪时返回 ErrStateNotFound
+type Verifier interface {
+	VerifyState(height int64, prevHash, stateHash []byte) error
+}
+
+// VerifyResult 检查数据目录的结果
+type VerifyResult struct {
+	Verified int64
+	// 已经裁剪的高度
+	Pruned int64
+	// 最多保留limit个错误高度
+	Failed map[int64]error
+	Total  int64
+}
+
+// Verify 检查[start, end]每个区块头中的状态hash, progress 每检查一个区块调用一次, 可以为空
+func Verify(chain *Chain, v Verifier, start, end int64, limit int, progress func(height int64)) (*VerifyResult, error) {
+	res := &VerifyResult{Failed: make(map[int64]error)}
+	prev := zeroHash
+	if start > 0 {
+		header, err := chain.Header(start - 1)
+		if err != nil {
+			return nil, err
+		}
+		prev = header.StateHash
+	}
+	for height := start; height <= end; height++ {
+		header, err := chain.Header(height)
+		if err != nil {
+			return res, err
+		}
+		switch err = v.VerifyState(height, prev, header.StateHash); err {
+		case nil:
+			res.Verified++
+		case ErrStateNotFound:
+			res.Pruned++
+		default:
+			res.Total++
+			if len(res.Failed) < limit {
+				res.Failed[height] = err
+			}
+			tlog.Error("Verify", "height", height, "stateHash", common.ToHex(header.StateHash), "err", err)
+		}
+		prev = header.StateHash
+		if progress != nil {
+			progress(height)
+		}
+	}
+	return res, nil
+}
+
+// 只有SimpleMVCC记录了每个版本修改的key
+type versionKeyLister interface {
+	GetDelKVList(version int64) ([]*types.KeyValue, error)
+}
+
+// VerifyMVCC 检查mvcc中版本height对应的hash, recompute时用这个版本修改的kv重新计算kvmvcc的状态hash
+// 快照导入的版本没有记录修改的key, 只检查版本对应的hash
+func VerifyMVCC(db dbm.DB, mvcc dbm.MVCC, height int64, prevHash, stateHash []byte, recompute bool) error {
+	hash, err := mvcc.GetVersionHash(height)
+	if err == types.ErrNotFound {
+		return ErrStateNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, stateHash) {
+		return ErrStateHash
+	}
+	lister, ok := mvcc.(versionKeyLister)
+	if !recompute || !ok {
+		return nil
+	}
+	keys, err := lister.GetDelKVList(height)
+	if err == dbm.ErrNotFoundInDb || err == types.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	set := &types.StoreSet{StateHash: prevHash, Height: height}
+	for _, kv := range keys {
+		key, err := dbm.GetKey(kv.Key, height)
+		if err != nil {
+			return err
+		}
+		// 值为空的key在提交时删除, 读不到即为空
+		value, err := db.Get(key)
+		if err != nil && err != dbm.ErrNotFoundInDb {
+			return err
+		}
+		set.KV = append(set.KV, &types.KeyValue{Key: kv.Key, Value: value})
+	}
+	if !bytes.Equal(common.Sha256(types.Encode(set)), stateHash) {
+		return ErrStateHash
+	}
+	return nil
+}